@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const caDescription = `
+This command consists of multiple subcommands related to managing the
+certificate authority (CA), which issues the certificates of the mesh.
+
+`
+
+const defaultCABundleSecretName = "osm-ca-bundle"
+
+func newCACmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ca",
+		Short: "manage the certificate authority of the mesh",
+		Long:  caDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newCARotateCmd(out))
+
+	return cmd
+}
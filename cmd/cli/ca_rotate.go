@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const caRotateDescription = `
+This command rotates the root certificate of the mesh without disrupting
+the traffic between meshed services. Only the root certificate of the tresor
+certificate manager, stored in the CA bundle secret, can be rotated.
+
+The rotation happens in three steps:
+
+   $ osm ca rotate introduce
+
+introduces a new root certificate. The proxies trust both the current and the
+new root certificates. Restart the meshed workloads before activating the new
+root certificate, so their proxies also trust it when connecting to the
+osm-controller.
+
+   $ osm ca rotate activate
+
+issues all the certificates of the mesh again with the new root certificate.
+Restart the meshed workloads again before retiring the old root certificate:
+the proxies connect to the osm-controller with the certificate of their
+bootstrap configuration, which was issued when their pod was created.
+
+   $ osm ca rotate retire
+
+retires the old root certificate, which the proxies stop trusting. The old root
+certificate is only retired once the bootstrap certificates of all the meshed
+pods were issued by the new root certificate.
+`
+
+type caRotateCmd struct {
+	out                io.Writer
+	caBundleSecretName string
+	clientSet          kubernetes.Interface
+}
+
+// caRotateStep applies a step of the root certificate rotation to the CA bundle secret, and returns the message describing the outcome.
+type caRotateStep func(r *caRotateCmd, secret *corev1.Secret) (string, error)
+
+func newCARotateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "rotate the root certificate of the mesh",
+		Long:  caRotateDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newCARotateStepCmd(out, "introduce", "introduce a new root certificate trusted by the mesh", (*caRotateCmd).introduce))
+	cmd.AddCommand(newCARotateStepCmd(out, "activate", "issue all certificates with the new root certificate", (*caRotateCmd).activate))
+	cmd.AddCommand(newCARotateStepCmd(out, "retire", "stop trusting the old root certificate", (*caRotateCmd).retire))
+
+	return cmd
+}
+
+func newCARotateStepCmd(out io.Writer, use, short string, step caRotateStep) *cobra.Command {
+	caRotate := &caRotateCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  caRotateDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig")
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster. Check kubeconfig")
+			}
+			caRotate.clientSet = clientset
+			return caRotate.run(step)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&caRotate.caBundleSecretName, "ca-bundle-secret-name", defaultCABundleSecretName, "name of the Kubernetes secret holding the CA of the mesh")
+
+	return cmd
+}
+
+// run applies the given step to the CA bundle secret; every osm-controller replica watches this secret and follows the rotation.
+func (r *caRotateCmd) run(step caRotateStep) error {
+	secrets := r.clientSet.CoreV1().Secrets(settings.Namespace())
+
+	secret, err := secrets.Get(context.Background(), r.caBundleSecretName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Errorf("Error getting CA bundle secret %s/%s: %s", settings.Namespace(), r.caBundleSecretName, err)
+	}
+
+	if _, ok := secret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]; !ok {
+		return errors.Errorf("Secret %s/%s does not hold the private key of the CA; only the root certificate of the tresor certificate manager can be rotated", settings.Namespace(), r.caBundleSecretName)
+	}
+
+	message, err := step(r, secret)
+	if err != nil {
+		return err
+	}
+
+	if _, err := secrets.Update(context.Background(), secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Errorf("Error updating CA bundle secret %s/%s: %s", settings.Namespace(), r.caBundleSecretName, err)
+	}
+
+	fmt.Fprint(r.out, message)
+	return nil
+}
+
+// introduce creates a new root certificate, which the mesh trusts along with the current one.
+func (r *caRotateCmd) introduce(secret *corev1.Secret) (string, error) {
+	if _, ok := secret.Data[constants.KubernetesOpaqueSecretNextCAKey]; ok {
+		return "", errors.New("A new root certificate was already introduced; run `osm ca rotate activate` to issue all certificates with it")
+	}
+
+	currentCA, err := certificate.DecodePEMCertificate(secret.Data[constants.KubernetesOpaqueSecretCAKey])
+	if err != nil {
+		return "", errors.Errorf("Error decoding the current root certificate: %s", err)
+	}
+
+	// The new root certificate has the same subject as the current one
+	subject := currentCA.Subject
+	newCA, err := tresor.NewCA(certificate.CommonName(subject.CommonName), constants.CertificationAuthorityRootValidityPeriod, first(subject.Country), first(subject.Locality), first(subject.Organization))
+	if err != nil {
+		return "", errors.Errorf("Error creating the new root certificate: %s", err)
+	}
+
+	trustBundle := tresor.GetTrustBundleFromSecret(secret)
+	secret.Data[constants.KubernetesOpaqueSecretNextCAKey] = newCA.GetCertificateChain()
+	secret.Data[constants.KubernetesOpaqueSecretNextRootPrivateKeyKey] = newCA.GetPrivateKey()
+	secret.Data[constants.KubernetesOpaqueSecretNextCAExpiration] = []byte(newCA.GetExpiration().Format(constants.TimeDateLayout))
+	secret.Data[constants.KubernetesOpaqueSecretTrustBundleKey] = append(append([]byte{}, trustBundle...), newCA.GetCertificateChain()...)
+
+	return fmt.Sprintf("Introduced a new root certificate expiring on %s; the mesh trusts both the current and the new root certificates\n"+
+		"Restart the meshed workloads, then run `osm ca rotate activate` to issue all certificates with the new root certificate\n", newCA.GetExpiration()), nil
+}
+
+// activate makes the new root certificate issue all the certificates of the mesh; the mesh keeps trusting the old root certificate.
+func (r *caRotateCmd) activate(secret *corev1.Secret) (string, error) {
+	nextCA, ok := secret.Data[constants.KubernetesOpaqueSecretNextCAKey]
+	if !ok {
+		return "", errors.New("No new root certificate was introduced; run `osm ca rotate introduce` first")
+	}
+
+	secret.Data[constants.KubernetesOpaqueSecretCAKey] = nextCA
+	secret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey] = secret.Data[constants.KubernetesOpaqueSecretNextRootPrivateKeyKey]
+	secret.Data[constants.KubernetesOpaqueSecretCAExpiration] = secret.Data[constants.KubernetesOpaqueSecretNextCAExpiration]
+	delete(secret.Data, constants.KubernetesOpaqueSecretNextCAKey)
+	delete(secret.Data, constants.KubernetesOpaqueSecretNextRootPrivateKeyKey)
+	delete(secret.Data, constants.KubernetesOpaqueSecretNextCAExpiration)
+
+	return "The new root certificate issues all the certificates of the mesh\n" +
+		"Restart the meshed workloads, so their proxies connect to the control plane with a certificate issued by the new root certificate,\n" +
+		"then run `osm ca rotate retire` to stop trusting the old root certificate\n", nil
+}
+
+// retire removes the old root certificate from the root certificates trusted by the mesh.
+func (r *caRotateCmd) retire(secret *corev1.Secret) (string, error) {
+	if _, ok := secret.Data[constants.KubernetesOpaqueSecretNextCAKey]; ok {
+		return "", errors.New("The new root certificate is not active yet; run `osm ca rotate activate` first")
+	}
+
+	if bytes.Equal(tresor.GetTrustBundleFromSecret(secret), secret.Data[constants.KubernetesOpaqueSecretCAKey]) {
+		return "", errors.New("The mesh only trusts its current root certificate; there is no root certificate to retire")
+	}
+
+	// The proxies connect to the control plane with their bootstrap certificate until they restart,
+	// so the old root certificate is only retired once no proxy uses a bootstrap certificate it issued
+	stalePods, err := r.getPodsWithStaleBootstrapCertificate(secret.Data[constants.KubernetesOpaqueSecretCAKey])
+	if err != nil {
+		return "", err
+	}
+	if len(stalePods) > 0 {
+		return "", errors.Errorf("The proxies of the following pods connect to the control plane with a certificate which was not issued by the new root certificate; "+
+			"restart them before retiring the old root certificate: %s", strings.Join(stalePods, ", "))
+	}
+
+	// Without a trust bundle, the current root certificate is the only one trusted by the mesh
+	delete(secret.Data, constants.KubernetesOpaqueSecretTrustBundleKey)
+
+	return "The old root certificate was retired; the mesh only trusts the new root certificate\n", nil
+}
+
+// getPodsWithStaleBootstrapCertificate returns the meshed pods, as namespace/name, whose bootstrap certificate was not issued by the given root certificate.
+func (r *caRotateCmd) getPodsWithStaleBootstrapCertificate(caPEM []byte) ([]string, error) {
+	ca, err := certificate.DecodePEMCertificate(caPEM)
+	if err != nil {
+		return nil, errors.Errorf("Error decoding the current root certificate: %s", err)
+	}
+
+	pods, err := r.clientSet.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{
+		LabelSelector: constants.EnvoyUniqueIDLabelName,
+	})
+	if err != nil {
+		return nil, errors.Errorf("Error listing the meshed pods: %s", err)
+	}
+
+	var stalePods []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if !r.isBootstrapCertificateIssuedBy(&pod, ca) {
+			stalePods = append(stalePods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	return stalePods, nil
+}
+
+// isBootstrapCertificateIssuedBy determines whether the certificate of the bootstrap configuration of the proxy of the given pod was issued by the given root certificate.
+func (r *caRotateCmd) isBootstrapCertificateIssuedBy(pod *corev1.Pod, ca *x509.Certificate) bool {
+	secretName := constants.EnvoyBootstrapConfigSecretPrefix + pod.Labels[constants.EnvoyUniqueIDLabelName]
+	secret, err := r.clientSet.CoreV1().Secrets(pod.Namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+
+	// The bootstrap secrets created before the bootstrap certificate was kept on its own do not hold it
+	cert, err := certificate.DecodePEMCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false
+	}
+	return cert.CheckSignatureFrom(ca) == nil
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Running the ca rotate command", func() {
+	var (
+		out        *bytes.Buffer
+		fakeClient *fake.Clientset
+		caRotate   *caRotateCmd
+		oldCAPEM   []byte
+	)
+
+	getSecret := func() *corev1.Secret {
+		secret, err := fakeClient.CoreV1().Secrets(settings.Namespace()).Get(context.TODO(), defaultCABundleSecretName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return secret
+	}
+
+	BeforeEach(func() {
+		ca, err := tresor.NewCA("osm-ca.openservicemesh.io", 1*time.Hour, "US", "CA", "Open Service Mesh")
+		Expect(err).NotTo(HaveOccurred())
+		oldCAPEM = ca.GetCertificateChain()
+
+		out = new(bytes.Buffer)
+		fakeClient = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      defaultCABundleSecretName,
+				Namespace: settings.Namespace(),
+			},
+			Data: map[string][]byte{
+				constants.KubernetesOpaqueSecretCAKey:             ca.GetCertificateChain(),
+				constants.KubernetesOpaqueSecretRootPrivateKeyKey: ca.GetPrivateKey(),
+				constants.KubernetesOpaqueSecretCAExpiration:      []byte(ca.GetExpiration().Format(constants.TimeDateLayout)),
+			},
+		})
+		caRotate = &caRotateCmd{
+			out:                out,
+			caBundleSecretName: defaultCABundleSecretName,
+			clientSet:          fakeClient,
+		}
+	})
+
+	It("rotates the root certificate in three steps", func() {
+		Expect(caRotate.run((*caRotateCmd).introduce)).To(Succeed())
+		secret := getSecret()
+		newCAPEM := secret.Data[constants.KubernetesOpaqueSecretNextCAKey]
+		Expect(newCAPEM).NotTo(BeEmpty())
+		Expect(newCAPEM).NotTo(Equal(oldCAPEM))
+		Expect(secret.Data[constants.KubernetesOpaqueSecretNextRootPrivateKeyKey]).NotTo(BeEmpty())
+		Expect(secret.Data[constants.KubernetesOpaqueSecretCAKey]).To(Equal(oldCAPEM))
+		Expect(secret.Data[constants.KubernetesOpaqueSecretTrustBundleKey]).To(Equal(append(append([]byte{}, oldCAPEM...), newCAPEM...)))
+		Expect(out.String()).To(ContainSubstring("Introduced a new root certificate"))
+
+		Expect(caRotate.run((*caRotateCmd).activate)).To(Succeed())
+		secret = getSecret()
+		Expect(secret.Data[constants.KubernetesOpaqueSecretCAKey]).To(Equal(newCAPEM))
+		Expect(secret.Data).NotTo(HaveKey(constants.KubernetesOpaqueSecretNextCAKey))
+		Expect(secret.Data).NotTo(HaveKey(constants.KubernetesOpaqueSecretNextRootPrivateKeyKey))
+		Expect(secret.Data).NotTo(HaveKey(constants.KubernetesOpaqueSecretNextCAExpiration))
+		Expect(secret.Data[constants.KubernetesOpaqueSecretTrustBundleKey]).To(ContainSubstring(string(oldCAPEM)))
+
+		Expect(caRotate.run((*caRotateCmd).retire)).To(Succeed())
+		secret = getSecret()
+		Expect(secret.Data).NotTo(HaveKey(constants.KubernetesOpaqueSecretTrustBundleKey))
+		Expect(secret.Data[constants.KubernetesOpaqueSecretCAKey]).To(Equal(newCAPEM))
+	})
+
+	It("does not retire the old root certificate while a proxy uses a bootstrap certificate it issued", func() {
+		addMeshedPod := func(name string, caPEM []byte, caKeyPEM []byte) {
+			ca, err := tresor.NewCertificateFromPEM(caPEM, caKeyPEM, time.Now().Add(1*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			certManager, err := tresor.NewCertManager(ca, 1*time.Hour, "org", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			cert, err := certManager.IssueCertificate(certificate.CommonName(name+".default.cluster.local"), nil, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fakeClient.CoreV1().Pods("default").Create(context.TODO(), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: name},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = fakeClient.CoreV1().Secrets("default").Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.EnvoyBootstrapConfigSecretPrefix + name,
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: cert.GetCertificateChain(),
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		oldCAKeyPEM := getSecret().Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]
+		addMeshedPod("created-before-activate", oldCAPEM, oldCAKeyPEM)
+
+		Expect(caRotate.run((*caRotateCmd).introduce)).To(Succeed())
+		Expect(caRotate.run((*caRotateCmd).activate)).To(Succeed())
+		secret := getSecret()
+		addMeshedPod("created-after-activate", secret.Data[constants.KubernetesOpaqueSecretCAKey], secret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey])
+
+		err := caRotate.run((*caRotateCmd).retire)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("default/created-before-activate"))
+		Expect(err.Error()).NotTo(ContainSubstring("default/created-after-activate"))
+		Expect(getSecret().Data).To(HaveKey(constants.KubernetesOpaqueSecretTrustBundleKey))
+
+		// Restarting the workload replaces its pod
+		Expect(fakeClient.CoreV1().Pods("default").Delete(context.TODO(), "created-before-activate", metav1.DeleteOptions{})).To(Succeed())
+		Expect(caRotate.run((*caRotateCmd).retire)).To(Succeed())
+		Expect(getSecret().Data).NotTo(HaveKey(constants.KubernetesOpaqueSecretTrustBundleKey))
+	})
+
+	It("does not introduce a second new root certificate", func() {
+		Expect(caRotate.run((*caRotateCmd).introduce)).To(Succeed())
+		Expect(caRotate.run((*caRotateCmd).introduce)).NotTo(Succeed())
+	})
+
+	It("does not retire the root certificate before the new one is active", func() {
+		Expect(caRotate.run((*caRotateCmd).retire)).NotTo(Succeed())
+		Expect(caRotate.run((*caRotateCmd).introduce)).To(Succeed())
+		Expect(caRotate.run((*caRotateCmd).retire)).NotTo(Succeed())
+	})
+
+	It("does not activate a root certificate which was not introduced", func() {
+		Expect(caRotate.run((*caRotateCmd).activate)).NotTo(Succeed())
+	})
+
+	It("does not rotate a root certificate without its private key", func() {
+		secret := getSecret()
+		delete(secret.Data, constants.KubernetesOpaqueSecretRootPrivateKeyKey)
+		_, err := fakeClient.CoreV1().Secrets(settings.Namespace()).Update(context.TODO(), secret, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(caRotate.run((*caRotateCmd).introduce)).NotTo(Succeed())
+	})
+})
@@ -84,13 +84,17 @@ type installCmd struct {
 	enableDebugServer             bool
 	enablePermissiveTrafficPolicy bool
 	enableEgress                  bool
+	enableEndpointSlices          bool
 	meshName                      string
 	meshCIDRRanges                []string
 	clientSet                     kubernetes.Interface
 
 	// This is an experimental flag, which will eventually
 	// become part of SMI Spec.
-	enableBackpressureExperimental bool
+	enableBackpressureExperimental     bool
+	enableResiliencePolicyExperimental bool
+	enableEgressPolicyExperimental     bool
+	enableMulticlusterModeExperimental bool
 
 	// Toggle to deploy/not deploy metrics (Promethus+Grafana) stack
 	enableMetricsStack bool
@@ -142,8 +146,12 @@ func newInstallCmd(config *helm.Configuration, out io.Writer) *cobra.Command {
 	f.BoolVar(&inst.enableDebugServer, "enable-debug-server", false, "Enable the debug HTTP server")
 	f.BoolVar(&inst.enablePermissiveTrafficPolicy, "enable-permissive-traffic-policy", false, "Enable permissive traffic policy mode")
 	f.BoolVar(&inst.enableEgress, "enable-egress", false, "Enable egress in the mesh")
-	f.StringSliceVar(&inst.meshCIDRRanges, "mesh-cidr", []string{}, "mesh CIDR range, accepts multiple CIDRs, required if enable-egress option is true")
+	f.BoolVar(&inst.enableEndpointSlices, "enable-endpoint-slices", true, "Discover the endpoints of the services from their EndpointSlices instead of their Endpoints, when the cluster serves EndpointSlices")
+	f.StringSliceVar(&inst.meshCIDRRanges, "mesh-cidr", []string{}, "mesh CIDR range, accepts multiple CIDRs, overrides the mesh CIDR ranges discovered from the cluster when enable-egress or enable-egress-policy-experimental option is true")
 	f.BoolVar(&inst.enableBackpressureExperimental, "enable-backpressure-experimental", false, "Enable experimental backpressure feature")
+	f.BoolVar(&inst.enableResiliencePolicyExperimental, "enable-resilience-policy-experimental", false, "Enable experimental resilience policy feature")
+	f.BoolVar(&inst.enableEgressPolicyExperimental, "enable-egress-policy-experimental", false, "Enable experimental egress policy feature, which replaces enable-egress")
+	f.BoolVar(&inst.enableMulticlusterModeExperimental, "enable-multicluster-mode-experimental", false, "Enable experimental multicluster mode")
 	f.BoolVar(&inst.enableMetricsStack, "enable-metrics-stack", true, "Enable metrics (Prometheus and Grafana) deployment")
 	f.StringVar(&inst.meshName, "mesh-name", defaultMeshName, "name for the new control plane instance")
 	f.BoolVar(&inst.deployJaeger, "deploy-jaeger", true, "Deploy Jaeger in the namespace of the OSM controller")
@@ -182,8 +190,8 @@ func (i *installCmd) run(config *helm.Configuration) error {
 		}
 	}
 
-	// Validate CIDR ranges if egress is enabled
-	if i.enableEgress {
+	// Validate CIDR ranges if egress is enabled and they are not discovered from the cluster
+	if (i.enableEgress || i.enableEgressPolicyExperimental) && len(i.meshCIDRRanges) > 0 {
 		if err := validateCIDRs(i.meshCIDRRanges); err != nil {
 			return errors.Errorf("Invalid mesh-cidr-ranges: %q, error: %v. Valid mesh CIDR ranges must be specified with egress enabled.", i.meshCIDRRanges, err)
 		}
@@ -252,9 +260,13 @@ func (i *installCmd) resolveValues() (map[string]interface{}, error) {
 		fmt.Sprintf("OpenServiceMesh.enableDebugServer=%t", i.enableDebugServer),
 		fmt.Sprintf("OpenServiceMesh.enablePermissiveTrafficPolicy=%t", i.enablePermissiveTrafficPolicy),
 		fmt.Sprintf("OpenServiceMesh.enableBackpressureExperimental=%t", i.enableBackpressureExperimental),
+		fmt.Sprintf("OpenServiceMesh.enableResiliencePolicyExperimental=%t", i.enableResiliencePolicyExperimental),
+		fmt.Sprintf("OpenServiceMesh.enableEgressPolicyExperimental=%t", i.enableEgressPolicyExperimental),
+		fmt.Sprintf("OpenServiceMesh.enableMulticlusterModeExperimental=%t", i.enableMulticlusterModeExperimental),
 		fmt.Sprintf("OpenServiceMesh.enableMetricsStack=%t", i.enableMetricsStack),
 		fmt.Sprintf("OpenServiceMesh.meshName=%s", i.meshName),
 		fmt.Sprintf("OpenServiceMesh.enableEgress=%t", i.enableEgress),
+		fmt.Sprintf("OpenServiceMesh.enableEndpointSlices=%t", i.enableEndpointSlices),
 		fmt.Sprintf("OpenServiceMesh.meshCIDRRanges=%s", strings.Join(i.meshCIDRRanges, " ")),
 		fmt.Sprintf("OpenServiceMesh.deployJaeger=%t", i.deployJaeger),
 	}
@@ -134,13 +134,17 @@ var _ = Describe("Running the install command", func() {
 							"retention": map[string]interface{}{
 								"time": "5d",
 							}},
-						"enableDebugServer":              false,
-						"enablePermissiveTrafficPolicy":  false,
-						"enableBackpressureExperimental": false,
-						"enableEgress":                   true,
-						"meshCIDRRanges":                 testMeshCIDR,
-						"enableMetricsStack":             true,
-						"deployJaeger":                   false,
+						"enableDebugServer":                  false,
+						"enablePermissiveTrafficPolicy":      false,
+						"enableBackpressureExperimental":     false,
+						"enableResiliencePolicyExperimental": false,
+						"enableEgressPolicyExperimental":     false,
+						"enableMulticlusterModeExperimental": false,
+						"enableEndpointSlices":               false,
+						"enableEgress":                       true,
+						"meshCIDRRanges":                     testMeshCIDR,
+						"enableMetricsStack":                 true,
+						"deployJaeger":                       false,
 					}}))
 			})
 
@@ -249,13 +253,17 @@ var _ = Describe("Running the install command", func() {
 							"retention": map[string]interface{}{
 								"time": "5d",
 							}},
-						"enableDebugServer":              false,
-						"enablePermissiveTrafficPolicy":  false,
-						"enableBackpressureExperimental": false,
-						"enableEgress":                   true,
-						"meshCIDRRanges":                 testMeshCIDR,
-						"enableMetricsStack":             true,
-						"deployJaeger":                   false,
+						"enableDebugServer":                  false,
+						"enablePermissiveTrafficPolicy":      false,
+						"enableBackpressureExperimental":     false,
+						"enableResiliencePolicyExperimental": false,
+						"enableEgressPolicyExperimental":     false,
+						"enableMulticlusterModeExperimental": false,
+						"enableEndpointSlices":               false,
+						"enableEgress":                       true,
+						"meshCIDRRanges":                     testMeshCIDR,
+						"enableMetricsStack":                 true,
+						"deployJaeger":                       false,
 					}}))
 			})
 
@@ -372,13 +380,17 @@ var _ = Describe("Running the install command", func() {
 								"time": "5d",
 							},
 						},
-						"enableDebugServer":              false,
-						"enablePermissiveTrafficPolicy":  false,
-						"enableBackpressureExperimental": false,
-						"enableEgress":                   true,
-						"meshCIDRRanges":                 testMeshCIDR,
-						"enableMetricsStack":             true,
-						"deployJaeger":                   false,
+						"enableDebugServer":                  false,
+						"enablePermissiveTrafficPolicy":      false,
+						"enableBackpressureExperimental":     false,
+						"enableResiliencePolicyExperimental": false,
+						"enableEgressPolicyExperimental":     false,
+						"enableMulticlusterModeExperimental": false,
+						"enableEndpointSlices":               false,
+						"enableEgress":                       true,
+						"meshCIDRRanges":                     testMeshCIDR,
+						"enableMetricsStack":                 true,
+						"deployJaeger":                       false,
 					}}))
 			})
 
@@ -537,13 +549,17 @@ var _ = Describe("Running the install command", func() {
 								"time": "5d",
 							},
 						},
-						"enableDebugServer":              false,
-						"enablePermissiveTrafficPolicy":  false,
-						"enableBackpressureExperimental": false,
-						"enableEgress":                   true,
-						"meshCIDRRanges":                 testMeshCIDR,
-						"enableMetricsStack":             true,
-						"deployJaeger":                   false,
+						"enableDebugServer":                  false,
+						"enablePermissiveTrafficPolicy":      false,
+						"enableBackpressureExperimental":     false,
+						"enableResiliencePolicyExperimental": false,
+						"enableEgressPolicyExperimental":     false,
+						"enableMulticlusterModeExperimental": false,
+						"enableEndpointSlices":               false,
+						"enableEgress":                       true,
+						"meshCIDRRanges":                     testMeshCIDR,
+						"enableMetricsStack":                 true,
+						"deployJaeger":                       false,
 					}}))
 			})
 
@@ -806,13 +822,17 @@ var _ = Describe("Resolving values for install command with vault parameters", f
 						"time": "5d",
 					},
 				},
-				"enableDebugServer":              false,
-				"enablePermissiveTrafficPolicy":  false,
-				"enableBackpressureExperimental": false,
-				"enableEgress":                   true,
-				"meshCIDRRanges":                 testMeshCIDR,
-				"enableMetricsStack":             true,
-				"deployJaeger":                   false,
+				"enableDebugServer":                  false,
+				"enablePermissiveTrafficPolicy":      false,
+				"enableBackpressureExperimental":     false,
+				"enableResiliencePolicyExperimental": false,
+				"enableEgressPolicyExperimental":     false,
+				"enableMulticlusterModeExperimental": false,
+				"enableEndpointSlices":               false,
+				"enableEgress":                       true,
+				"meshCIDRRanges":                     testMeshCIDR,
+				"enableMetricsStack":                 true,
+				"deployJaeger":                       false,
 			}}))
 	})
 })
@@ -884,13 +904,17 @@ var _ = Describe("Resolving values for install command with cert-manager paramet
 						"time": "5d",
 					},
 				},
-				"enableDebugServer":              false,
-				"enablePermissiveTrafficPolicy":  false,
-				"enableBackpressureExperimental": false,
-				"enableEgress":                   true,
-				"meshCIDRRanges":                 testMeshCIDR,
-				"enableMetricsStack":             true,
-				"deployJaeger":                   false,
+				"enableDebugServer":                  false,
+				"enablePermissiveTrafficPolicy":      false,
+				"enableBackpressureExperimental":     false,
+				"enableResiliencePolicyExperimental": false,
+				"enableEgressPolicyExperimental":     false,
+				"enableMulticlusterModeExperimental": false,
+				"enableEndpointSlices":               false,
+				"enableEgress":                       true,
+				"meshCIDRRanges":                     testMeshCIDR,
+				"enableMetricsStack":                 true,
+				"deployJaeger":                       false,
 			}}))
 	})
 })
@@ -37,10 +37,12 @@ func newRootCmd(config *action.Configuration, in io.Reader, out io.Writer, args
 	// Add subcommands here
 	cmd.AddCommand(
 		newMeshCmd(config, in, out),
+		newCACmd(out),
 		newEnvCmd(out),
 		newInstallCmd(config, out),
 		newDashboardCmd(config, out),
 		newNamespaceCmd(out),
+		newProxyCmd(out),
 		newVersionCmd(out),
 	)
 
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const proxyDescription = `
+This command consists of multiple subcommands related to managing the Envoy
+proxies of the mesh.
+
+`
+
+func newProxyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proxy",
+		Short: "manage the proxies of the mesh",
+		Long:  proxyDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newProxyRotateCertCmd(out))
+
+	return cmd
+}
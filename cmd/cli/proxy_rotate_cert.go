@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const proxyRotateCertDescription = `
+This command forces the rotation of the certificates of the Envoy proxies of a
+pod, a service or a service account, without waiting for the certificates to
+expire. The proxies receive their new certificates right away.
+
+   $ osm proxy rotate-cert bookstore-5f678c4cc5-9wchj --pod-namespace bookstore
+   $ osm proxy rotate-cert --service bookstore/bookstore
+   $ osm proxy rotate-cert --service-account bookstore/bookstore
+
+With --revoke, the identity of the selected proxies is revoked as well: their
+connections to the osm-controller are closed, and they can no longer connect.
+Revoke the identity of a compromised pod, then delete the pod; the pod
+replacing it gets a new identity.
+
+The certificates are rotated by every osm-controller replica, which must run
+with its debug server enabled (--enable-debug-server).
+`
+
+const (
+	proxyRotateCertPath = "debug/certs/rotate"
+	defaultPodNamespace = "default"
+)
+
+type proxyRotateCertCmd struct {
+	out            io.Writer
+	pod            string
+	podNamespace   string
+	service        string
+	serviceAccount string
+	revoke         bool
+	clientSet      kubernetes.Interface
+	post           debugServerPoster
+}
+
+// debugServerPoster sends a POST request with the given query to the debug server of the given osm-controller pod, and returns the response.
+type debugServerPoster func(clientSet kubernetes.Interface, pod *corev1.Pod, path string, query map[string]string) ([]byte, error)
+
+func newProxyRotateCertCmd(out io.Writer) *cobra.Command {
+	rotateCert := &proxyRotateCertCmd{
+		out:  out,
+		post: postToDebugServer,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-cert [POD]",
+		Short: "rotate the certificates of proxies",
+		Long:  proxyRotateCertDescription,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				rotateCert.pod = args[0]
+			}
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig")
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster. Check kubeconfig")
+			}
+			rotateCert.clientSet = clientset
+			return rotateCert.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&rotateCert.podNamespace, "pod-namespace", defaultPodNamespace, "namespace of the pod")
+	f.StringVar(&rotateCert.service, "service", "", "rotate the certificates of the proxies of this service, given as <namespace>/<name>")
+	f.StringVar(&rotateCert.serviceAccount, "service-account", "", "rotate the certificates of the proxies of this service account, given as <namespace>/<name>")
+	f.BoolVar(&rotateCert.revoke, "revoke", false, "revoke the identity of the proxies, which can no longer connect to the osm-controller")
+
+	return cmd
+}
+
+func (r *proxyRotateCertCmd) run() error {
+	query, err := r.getQuery()
+	if err != nil {
+		return err
+	}
+
+	controllers, err := r.clientSet.CoreV1().Pods(settings.Namespace()).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{"app": constants.OSMControllerName}).String(),
+	})
+	if err != nil {
+		return errors.Errorf("Error listing %s pods in namespace %s: %s", constants.OSMControllerName, settings.Namespace(), err)
+	}
+	if len(controllers.Items) == 0 {
+		return errors.Errorf("No %s pod found in namespace %s", constants.OSMControllerName, settings.Namespace())
+	}
+
+	// Every osm-controller replica serves its own proxies, and keeps its own certificates
+	for idx := range controllers.Items {
+		controller := &controllers.Items[idx]
+		response, err := r.post(r.clientSet, controller, proxyRotateCertPath, query)
+		if err != nil {
+			return errors.Errorf("Error rotating certificates through %s pod %s: %s\nThe debug server of the %s must be enabled with --enable-debug-server",
+				constants.OSMControllerName, controller.Name, err, constants.OSMControllerName)
+		}
+		fmt.Fprintf(r.out, "[%s]\n%s", controller.Name, response)
+	}
+
+	return nil
+}
+
+// getQuery returns the query selecting the proxies whose certificates are rotated, and whether they are revoked.
+func (r *proxyRotateCertCmd) getQuery() (map[string]string, error) {
+	query := make(map[string]string)
+
+	selectors := 0
+	if r.pod != "" {
+		selectors++
+	}
+	if r.service != "" {
+		selectors++
+		query["service"] = r.service
+	}
+	if r.serviceAccount != "" {
+		selectors++
+		query["serviceaccount"] = r.serviceAccount
+	}
+	if selectors != 1 {
+		return nil, errors.New("Exactly one of a pod, --service or --service-account must be given")
+	}
+
+	if r.pod != "" {
+		pod, err := r.clientSet.CoreV1().Pods(r.podNamespace).Get(context.Background(), r.pod, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Errorf("Error getting pod %s/%s: %s", r.podNamespace, r.pod, err)
+		}
+
+		proxyUUID, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]
+		if !ok {
+			return nil, errors.Errorf("Pod %s/%s does not have an Envoy proxy; label %s is missing", r.podNamespace, r.pod, constants.EnvoyUniqueIDLabelName)
+		}
+
+		serviceAccount := pod.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		query["proxy"] = catalog.NewCertCommonNameWithProxyID(proxyUUID, serviceAccount, pod.Namespace).String()
+	}
+
+	if r.revoke {
+		query["revoke"] = "true"
+	}
+
+	return query, nil
+}
+
+// postToDebugServer sends a POST request to the debug server of the given osm-controller pod through the Kubernetes API server.
+func postToDebugServer(clientSet kubernetes.Interface, pod *corev1.Pod, path string, query map[string]string) ([]byte, error) {
+	request := clientSet.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		SubResource("proxy").
+		Name(fmt.Sprintf("%s:%d", pod.Name, constants.MetricsServerPort)).
+		Suffix(path)
+	for key, value := range query {
+		request = request.Param(key, value)
+	}
+	return request.DoRaw(context.Background())
+}
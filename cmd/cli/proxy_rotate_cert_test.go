@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Running the proxy rotate-cert command", func() {
+	var (
+		out        *bytes.Buffer
+		rotateCert *proxyRotateCertCmd
+		posted     map[string]map[string]string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		posted = make(map[string]map[string]string)
+		fakeClient := fake.NewSimpleClientset(
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "osm-controller-1",
+					Namespace: settings.Namespace(),
+					Labels:    map[string]string{"app": constants.OSMControllerName},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "osm-controller-2",
+					Namespace: settings.Namespace(),
+					Labels:    map[string]string{"app": constants.OSMControllerName},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bookstore-1",
+					Namespace: "bookstore",
+					Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "6a1b2c3d"},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "bookstore",
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unmeshed-1",
+					Namespace: "bookstore",
+				},
+			},
+		)
+		rotateCert = &proxyRotateCertCmd{
+			out:          out,
+			podNamespace: defaultPodNamespace,
+			clientSet:    fakeClient,
+			post: func(_ kubernetes.Interface, pod *corev1.Pod, path string, query map[string]string) ([]byte, error) {
+				Expect(path).To(Equal(proxyRotateCertPath))
+				posted[pod.Name] = query
+				return []byte("Rotated certificate CN=bookstore.bookstore.svc.cluster.local\n"), nil
+			},
+		}
+	})
+
+	It("revokes the identity of a pod through every osm-controller", func() {
+		rotateCert.pod = "bookstore-1"
+		rotateCert.podNamespace = "bookstore"
+		rotateCert.revoke = true
+
+		Expect(rotateCert.run()).To(Succeed())
+		expectedQuery := map[string]string{"proxy": "6a1b2c3d.bookstore.bookstore", "revoke": "true"}
+		Expect(posted).To(Equal(map[string]map[string]string{"osm-controller-1": expectedQuery, "osm-controller-2": expectedQuery}))
+		Expect(out.String()).To(ContainSubstring("[osm-controller-2]\nRotated certificate CN=bookstore.bookstore.svc.cluster.local\n"))
+	})
+
+	It("rotates the certificates of a service", func() {
+		rotateCert.service = "bookstore/bookstore"
+
+		Expect(rotateCert.run()).To(Succeed())
+		Expect(posted["osm-controller-1"]).To(Equal(map[string]string{"service": "bookstore/bookstore"}))
+	})
+
+	It("requires exactly one of a pod, a service or a service account", func() {
+		Expect(rotateCert.run()).NotTo(Succeed())
+
+		rotateCert.service = "bookstore/bookstore"
+		rotateCert.serviceAccount = "bookstore/bookstore"
+		Expect(rotateCert.run()).NotTo(Succeed())
+		Expect(posted).To(BeEmpty())
+	})
+
+	It("fails for pods without an Envoy proxy", func() {
+		rotateCert.pod = "unmeshed-1"
+		rotateCert.podNamespace = "bookstore"
+
+		err := rotateCert.run()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(constants.EnvoyUniqueIDLabelName))
+	})
+
+	It("reports that the debug server must be enabled when the request fails", func() {
+		rotateCert.service = "bookstore/bookstore"
+		rotateCert.post = func(_ kubernetes.Interface, _ *corev1.Pod, _ string, _ map[string]string) ([]byte, error) {
+			return nil, errors.New("the server could not find the requested resource")
+		}
+
+		err := rotateCert.run()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("--enable-debug-server"))
+	})
+})
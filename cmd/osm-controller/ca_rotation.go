@@ -0,0 +1,62 @@
+package main
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+)
+
+// watchCABundleSecret keeps the root certificate of the given certificate manager, and the root certificates trusted by the mesh,
+// in sync with the CA bundle secret, which `osm ca rotate` updates to rotate the root certificate without disrupting the mesh.
+// The given callback is invoked every time the certificate manager is updated.
+func watchCABundleSecret(kubeClient kubernetes.Interface, certManager *tresor.CertManager, namespace, secretName string, onCAUpdated func(), stop <-chan struct{}) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", secretName).String()
+		}),
+	)
+
+	informer := informerFactory.Core().V1().Secrets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if updateCAFromSecret(certManager, obj) {
+				onCAUpdated()
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if updateCAFromSecret(certManager, obj) {
+				onCAUpdated()
+			}
+		},
+	})
+
+	go informer.Run(stop)
+}
+
+// updateCAFromSecret updates the root certificate of the given certificate manager, and the root certificates trusted by the mesh,
+// with the ones held by the given CA bundle secret. It returns whether the certificate manager was updated.
+func updateCAFromSecret(certManager *tresor.CertManager, obj interface{}) bool {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		log.Error().Msgf("Unexpected object %T in the CA bundle secret informer", obj)
+		return false
+	}
+
+	ca, err := tresor.GetCAFromSecret(secret)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error loading the CA from Kubernetes secret %s/%s", secret.Namespace, secret.Name)
+		return false
+	}
+	if ca == nil {
+		return false
+	}
+
+	certManager.UpdateCA(ca, tresor.GetTrustBundleFromSecret(secret))
+	return true
+}
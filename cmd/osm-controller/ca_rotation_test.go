@@ -0,0 +1,75 @@
+package main
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Test rotation of the root certificate", func() {
+	newCASecret := func(ca certificate.Certificater, trustBundle []byte) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "osm-ca-bundle",
+				Namespace: "osm-system",
+			},
+			Data: map[string][]byte{
+				constants.KubernetesOpaqueSecretCAKey:             ca.GetCertificateChain(),
+				constants.KubernetesOpaqueSecretRootPrivateKeyKey: ca.GetPrivateKey(),
+				constants.KubernetesOpaqueSecretCAExpiration:      []byte(ca.GetExpiration().Format(constants.TimeDateLayout)),
+			},
+		}
+		if trustBundle != nil {
+			secret.Data[constants.KubernetesOpaqueSecretTrustBundleKey] = trustBundle
+		}
+		return secret
+	}
+
+	Context("Testing updateCAFromSecret", func() {
+		It("updates the root certificate and the trust bundle of the certificate manager", func() {
+			oldCA, err := tresor.NewCA("Test CA", 1*time.Hour, "US", "CA", "Open Service Mesh")
+			Expect(err).ToNot(HaveOccurred())
+			newCA, err := tresor.NewCA("Test CA", 1*time.Hour, "US", "CA", "Open Service Mesh")
+			Expect(err).ToNot(HaveOccurred())
+
+			certManager, err := tresor.NewCertManager(oldCA, 1*time.Hour, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			go func() {
+				for range certManager.GetAnnouncementsChannel() {
+				}
+			}()
+
+			trustBundle := append(append([]byte{}, oldCA.GetCertificateChain()...), newCA.GetCertificateChain()...)
+			Expect(updateCAFromSecret(certManager, newCASecret(newCA, trustBundle))).To(BeTrue())
+
+			root, err := certManager.GetRootCertificate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(root.GetCertificateChain()).To(Equal(newCA.GetCertificateChain()))
+			actual, err := certManager.GetTrustBundle()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(trustBundle))
+
+			// Once the old root certificate is retired, the CA is the only trusted root certificate
+			Expect(updateCAFromSecret(certManager, newCASecret(newCA, nil))).To(BeTrue())
+			actual, err = certManager.GetTrustBundle()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(newCA.GetCertificateChain()))
+		})
+
+		It("ignores a secret without a CA", func() {
+			ca, err := tresor.NewCA("Test CA", 1*time.Hour, "US", "CA", "Open Service Mesh")
+			Expect(err).ToNot(HaveOccurred())
+			certManager, err := tresor.NewCertManager(ca, 1*time.Hour, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(updateCAFromSecret(certManager, &corev1.Secret{})).To(BeFalse())
+		})
+	})
+})
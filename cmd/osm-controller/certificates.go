@@ -8,6 +8,8 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmversionedclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -16,6 +18,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate/providers/certmanager"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
+	"github.com/openservicemesh/osm/pkg/certificate/store"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/debugger"
 )
@@ -44,7 +47,7 @@ const (
 )
 
 // Functions we can call to create a Certificate Manager for each kind of supported certificate issuer
-var certManagers = map[certificateManagerKind]func(kubeClient kubernetes.Interface, kubeConfig *rest.Config, enableDebugServer bool) (certificate.Manager, debugger.CertificateManagerDebugger, error){
+var certManagers = map[certificateManagerKind]func(kubeClient kubernetes.Interface, kubeConfig *rest.Config, enableDebugServer bool, revocationStore certificate.RevocationStore) (certificate.Manager, debugger.CertificateManagerDebugger, error){
 	tresorKind:      getTresorOSMCertificateManager,
 	keyVaultKind:    getAzureKeyVaultOSMCertificateManager,
 	vaultKind:       getHashiVaultOSMCertificateManager,
@@ -60,7 +63,7 @@ func getPossibleCertManagers() []string {
 	return possible
 }
 
-func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, _ *rest.Config, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, _ *rest.Config, enableDebug bool, revocationStore certificate.RevocationStore) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	var err error
 	var rootCert certificate.Certificater
 
@@ -85,9 +88,18 @@ func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, _ *rest.Con
 		if rootCert.GetPrivateKey() == nil {
 			return nil, nil, errors.Errorf("Root cert does not have a private key")
 		}
+
+		if caBundleSecretName != "" {
+			rootCert = storeOrLoadCA(kubeClient, rootCert, osmNamespace, caBundleSecretName)
+		}
 	}
 
-	certManager, err := tresor.NewCertManager(rootCert, getServiceCertValidityPeriod(), rootCertOrganization)
+	var certStore certificate.Store
+	if persistCertificates {
+		certStore = store.NewSecretStore(kubeClient, osmNamespace)
+	}
+
+	certManager, err := tresor.NewCertManager(rootCert, getServiceCertValidityPeriod(), rootCertOrganization, certStore, revocationStore)
 	if err != nil {
 		return nil, nil, errors.Errorf("Failed to instantiate Azure Key Vault as a Certificate Manager")
 	}
@@ -118,50 +130,62 @@ func getCertFromKubernetes(kubeClient kubernetes.Interface, namespace, secretNam
 		return nil
 	}
 
-	pemCert, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretCAKey]
-	if !ok {
-		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", osmNamespace, secretName, constants.KubernetesOpaqueSecretCAKey)
-		return nil
+	rootCert, err := tresor.GetCAFromSecret(rootCertSecret)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to create new Certificate Authority with cert issuer %s", *osmCertificateManagerKind)
 	}
+	return rootCert
+}
 
-	pemKey, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]
-	if !ok {
-		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", osmNamespace, secretName, constants.KubernetesOpaqueSecretRootPrivateKeyKey)
-		return nil
+// storeOrLoadCA stores the given CA in a new Kubernetes secret, unless the secret already exists.
+// When several osm-controller replicas start at the same time, the first one to store its CA wins and the others
+// load that CA from the secret, so the certificates issued by all replicas are trusted by the same root.
+func storeOrLoadCA(kubeClient kubernetes.Interface, ca certificate.Certificater, namespace, secretName string) certificate.Certificater {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			constants.KubernetesOpaqueSecretCAKey:             ca.GetCertificateChain(),
+			constants.KubernetesOpaqueSecretCAExpiration:      []byte(ca.GetExpiration().Format(constants.TimeDateLayout)),
+			constants.KubernetesOpaqueSecretRootPrivateKeyKey: ca.GetPrivateKey(),
+		},
 	}
 
-	expirationBytes, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretCAExpiration]
-	if !ok {
-		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", osmNamespace, secretName, constants.KubernetesOpaqueSecretCAExpiration)
-		return nil
+	_, err := kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if err == nil {
+		log.Info().Msgf("Stored CA in Kubernetes secret %s/%s", namespace, secretName)
+		return ca
 	}
 
-	expiration, err := time.Parse(constants.TimeDateLayout, string(expirationBytes))
-	if err != nil {
-		log.Error().Err(err).Msgf("Error parsing CA expiration %q from Kubernetes rootCertSecret %q from namespace %q", string(expirationBytes), secretName, osmNamespace)
+	if !apierrors.IsAlreadyExists(err) {
+		log.Error().Err(err).Msgf("Error storing CA in Kubernetes secret %s/%s", namespace, secretName)
+		return ca
 	}
 
-	rootCert, err := tresor.NewCertificateFromPEM(pemCert, pemKey, expiration)
-	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to create new Certificate Authority with cert issuer %s", *osmCertificateManagerKind)
+	if storedCA := getCertFromKubernetes(kubeClient, namespace, secretName); storedCA != nil {
+		log.Info().Msgf("Using the CA stored in Kubernetes secret %s/%s by another osm-controller", namespace, secretName)
+		return storedCA
 	}
-	return rootCert
+
+	return ca
 }
 
-func getAzureKeyVaultOSMCertificateManager(_ kubernetes.Interface, _ *rest.Config, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getAzureKeyVaultOSMCertificateManager(_ kubernetes.Interface, _ *rest.Config, enableDebug bool, _ certificate.RevocationStore) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	// TODO(draychev): implement: https://github.com/openservicemesh/osm/issues/577
 	log.Fatal().Msg("Azure Key Vault certificate manager is not implemented")
 	return nil, nil, nil
 }
 
-func getHashiVaultOSMCertificateManager(_ kubernetes.Interface, _ *rest.Config, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getHashiVaultOSMCertificateManager(_ kubernetes.Interface, _ *rest.Config, enableDebug bool, revocationStore certificate.RevocationStore) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	if _, ok := map[string]interface{}{"http": nil, "https": nil}[*vaultProtocol]; !ok {
 		return nil, nil, errors.Errorf("Value %s is not a valid Hashi Vault protocol", *vaultProtocol)
 	}
 
 	// A Vault address would have the following shape: "http://vault.default.svc.cluster.local:8200"
 	vaultAddr := fmt.Sprintf("%s://%s:%d", *vaultProtocol, *vaultHost, *vaultPort)
-	vaultCertManager, err := vault.NewCertManager(vaultAddr, *vaultToken, getServiceCertValidityPeriod(), *vaultRole)
+	vaultCertManager, err := vault.NewCertManager(vaultAddr, *vaultToken, getServiceCertValidityPeriod(), *vaultRole, revocationStore)
 	if err != nil {
 		return nil, nil, errors.Errorf("Error instantiating Hashicorp Vault as a Certificate Manager: %+v", err)
 	}
@@ -169,7 +193,7 @@ func getHashiVaultOSMCertificateManager(_ kubernetes.Interface, _ *rest.Config,
 	return vaultCertManager, vaultCertManager, nil
 }
 
-func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config, enableDebug bool, revocationStore certificate.RevocationStore) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	rootCertSecret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.TODO(), caBundleSecretName, metav1.GetOptions{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to get cert-manager CA secret %s/%s: %s", osmNamespace, caBundleSecretName, err)
@@ -194,7 +218,7 @@ func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeCo
 		Name:  *certmanagerIssuerName,
 		Kind:  *certmanagerIssuerKind,
 		Group: *certmanagerIssuerGroup,
-	})
+	}, revocationStore)
 	if err != nil {
 		return nil, nil, errors.Errorf("Error instantiating Jetstack cert-manager as a Certificate Manager: %+v", err)
 	}
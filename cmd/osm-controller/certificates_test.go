@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -147,4 +148,36 @@ var _ = Describe("Test CMD tools", func() {
 			Expect(*actual).To(Equal(*expected))
 		})
 	})
+
+	Context("Testing storeOrLoadCA", func() {
+		newCA := func() certificate.Certificater {
+			expiration, err := time.Parse(constants.TimeDateLayout, "2020-05-07T14:25:18.677Z")
+			Expect(err).ToNot(HaveOccurred())
+			ca, err := tresor.NewCertificateFromPEM(pem.Certificate(uuid.New().String()), pem.PrivateKey(uuid.New().String()), expiration)
+			Expect(err).ToNot(HaveOccurred())
+			return ca
+		}
+
+		It("stores the CA when no CA was stored yet", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			ns := uuid.New().String()
+			secretName := uuid.New().String()
+			ca := newCA()
+
+			actual := storeOrLoadCA(kubeClient, ca, ns, secretName)
+			Expect(actual).To(Equal(ca))
+			Expect(getCertFromKubernetes(kubeClient, ns, secretName)).To(Equal(ca))
+		})
+
+		It("loads the CA stored by another replica", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			ns := uuid.New().String()
+			secretName := uuid.New().String()
+			storedCA := newCA()
+			Expect(storeOrLoadCA(kubeClient, storedCA, ns, secretName)).To(Equal(storedCA))
+
+			actual := storeOrLoadCA(kubeClient, newCA(), ns, secretName)
+			Expect(actual).To(Equal(storedCA))
+		})
+	})
 })
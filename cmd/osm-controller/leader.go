@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"math"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// leaderElectionLeaseName is the name of the Lease the osm-controller replicas compete for
+	leaderElectionLeaseName = "osm-controller-leader"
+
+	// leaseDuration is how long the replicas which are not leading wait before attempting to acquire the leadership
+	leaseDuration = 15 * time.Second
+
+	// renewDeadline is how long the leader keeps trying to renew the leadership before giving it up
+	renewDeadline = 10 * time.Second
+
+	// retryPeriod is how long the replicas wait between attempts to acquire or renew the leadership
+	retryPeriod = 2 * time.Second
+
+	// initialDutyRetryDelay and maxDutyRetryDelay bound the exponential backoff between the attempts of a failing leader duty
+	initialDutyRetryDelay = 1 * time.Second
+	maxDutyRetryDelay     = 1 * time.Minute
+)
+
+// runLeaderElection campaigns for the leadership of the osm-controller replicas until the given context is canceled.
+// The leader runs the given duties, which must return once the context they are given is canceled: when the leadership is lost.
+// A replica which loses the leadership campaigns for it again.
+func runLeaderElection(ctx context.Context, kubeClient kubernetes.Interface, namespace string, leaderDuties func(ctx context.Context)) {
+	identity := getLeaderElectionIdentity()
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaderElectionLeaseName,
+			Namespace: namespace,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            leaderElectionLeaseName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					log.Info().Msgf("osm-controller replica %s is the leader", identity)
+					leaderDuties(ctx)
+				},
+				OnStoppedLeading: func() {
+					log.Info().Msgf("osm-controller replica %s is no longer the leader", identity)
+				},
+				OnNewLeader: func(leader string) {
+					if leader != identity {
+						log.Info().Msgf("osm-controller replica %s is the leader", leader)
+					}
+				},
+			},
+		})
+	}
+}
+
+// retryWithBackoff performs the given leader duty until it succeeds, or the given context is canceled: when the leadership is lost.
+// A transient error does not bring down the leader, and the attempts are spaced out by an exponential backoff.
+func retryWithBackoff(ctx context.Context, duty string, perform func() error) error {
+	backoff := wait.Backoff{
+		Duration: initialDutyRetryDelay,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      maxDutyRetryDelay,
+	}
+	for {
+		err := perform()
+		if err == nil {
+			return nil
+		}
+
+		delay := backoff.Step()
+		log.Error().Err(err).Msgf("Error %s; retrying in %s", duty, delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// getLeaderElectionIdentity returns the identity of this replica: the name of its pod.
+func getLeaderElectionIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting hostname; using a random leader election identity")
+		return uuid.New().String()
+	}
+	return hostname
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test leader election", func() {
+	Context("Testing runLeaderElection", func() {
+		It("runs the leader duties until the leadership is given up", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			namespace := "-osm-namespace-"
+
+			started := make(chan struct{})
+			stopped := make(chan struct{})
+			leaderDuties := func(ctx context.Context) {
+				close(started)
+				<-ctx.Done()
+				close(stopped)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go runLeaderElection(ctx, kubeClient, namespace, leaderDuties)
+
+			Eventually(started, 5*time.Second).Should(BeClosed())
+			lease, err := kubeClient.CoordinationV1().Leases(namespace).Get(context.TODO(), leaderElectionLeaseName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*lease.Spec.HolderIdentity).To(Equal(getLeaderElectionIdentity()))
+
+			cancel()
+			Eventually(stopped, 5*time.Second).Should(BeClosed())
+		})
+	})
+
+	Context("Testing retryWithBackoff", func() {
+		It("retries a failing leader duty until it succeeds", func() {
+			attempts := 0
+			err := retryWithBackoff(context.Background(), "testing", func() error {
+				attempts++
+				if attempts < 2 {
+					return errors.New("transient error")
+				}
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(attempts).To(Equal(2))
+		})
+
+		It("gives up once the leadership is lost", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			attempts := 0
+			err := retryWithBackoff(ctx, "testing", func() error {
+				attempts++
+				return errors.New("persistent error")
+			})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(attempts).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/endpoint/providers/remote"
+)
+
+const (
+	// remoteClusterKubeconfigKey is the key of the remote cluster secrets holding the kubeconfig of the remote cluster
+	remoteClusterKubeconfigKey = "kubeconfig"
+
+	// remoteClusterOSMNamespaceKey is the key of the remote cluster secrets holding the namespace of the OSM controller
+	// of the remote cluster; the namespace of the local OSM controller when not set
+	remoteClusterOSMNamespaceKey = "osmNamespace"
+)
+
+// getRemoteClusterProviders returns an endpoints provider for each remote cluster of the multicluster mesh.
+// The remote clusters are given by the secrets of the OSM namespace labeled with openservicemesh.io/multicluster-remote: <mesh name>,
+// which hold the kubeconfig giving access to the services exported by the remote cluster.
+func getRemoteClusterProviders(kubeClient kubernetes.Interface, cfg configurator.Configurator, stop chan struct{}) []endpoint.Provider {
+	secrets, err := kubeClient.CoreV1().Secrets(osmNamespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{constants.MulticlusterRemoteClusterLabel: meshName}).String(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing the remote cluster secrets in namespace %s", osmNamespace)
+		return nil
+	}
+
+	var providers []endpoint.Provider
+	for _, secret := range secrets.Items {
+		remoteKubeConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[remoteClusterKubeconfigKey])
+		if err != nil {
+			log.Error().Err(err).Msgf("Error parsing the kubeconfig of remote cluster secret %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+		remoteKubeClient, err := kubernetes.NewForConfig(remoteKubeConfig)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error creating the client of remote cluster secret %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+
+		remoteOSMNamespace := string(secret.Data[remoteClusterOSMNamespaceKey])
+		if remoteOSMNamespace == "" {
+			remoteOSMNamespace = osmNamespace
+		}
+
+		providerIdent := fmt.Sprintf("%s-%s", constants.RemoteProviderNamePrefix, secret.Name)
+		provider, err := remote.NewProvider(remoteKubeClient, remoteOSMNamespace, stop, providerIdent, cfg)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error creating the endpoints provider of remote cluster secret %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+		log.Info().Msgf("Discovering the services exported by the remote cluster of secret %s/%s", secret.Namespace, secret.Name)
+		providers = append(providers, provider)
+	}
+	return providers
+}
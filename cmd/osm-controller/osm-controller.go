@@ -4,18 +4,23 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/certificate/rotor"
+	"github.com/openservicemesh/osm/pkg/certificate/store"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/debugger"
@@ -23,11 +28,13 @@ import (
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
 	"github.com/openservicemesh/osm/pkg/envoy/ads"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/injector"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/multicluster"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/signals"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -40,6 +47,12 @@ const (
 	defaultServiceCertValidityMinutes = 60 // 1 hour
 	caBundleSecretNameCLIParam        = "ca-bundle-secret-name"
 	xdsServerCertificateCommonName    = "ads"
+
+	// checkCertificateExpirationInterval is the interval at which the certificates are checked for expiration and rotated
+	checkCertificateExpirationInterval = 5 * time.Second
+
+	// revocationsConfigMapName is the name of the ConfigMap, in the namespace of OSM, holding the Common Names of the revoked certificates
+	revocationsConfigMapName = "osm-revocations"
 )
 
 var (
@@ -53,6 +66,9 @@ var (
 	caBundleSecretName         string
 	enableDebugServer          bool
 	osmConfigMapName           string
+	enableLeaderElection       bool
+	persistCertificates        bool
+	enableEndpointSlices       bool
 
 	injectorConfig injector.Config
 
@@ -94,6 +110,9 @@ func init() {
 	flags.StringVar(&caBundleSecretName, caBundleSecretNameCLIParam, "", "Name of the Kubernetes Secret for the OSM CA bundle")
 	flags.BoolVar(&enableDebugServer, "enable-debug-server", false, "Enable OSM debug HTTP server")
 	flags.StringVar(&osmConfigMapName, "osm-configmap-name", "osm-config", "Name of the OSM ConfigMap")
+	flags.BoolVar(&persistCertificates, "persist-certificates", false, "Persist the certificates issued by tresor in Kubernetes secrets, so they survive restarts and are shared by the osm-controller replicas")
+	flags.BoolVar(&enableEndpointSlices, "enable-endpoint-slices", true, "Discover the endpoints of the services from their EndpointSlices instead of their Endpoints, when the cluster serves EndpointSlices")
+	flags.BoolVar(&enableLeaderElection, "enable-leader-election", false, "Elect a leader among the osm-controller replicas to configure the MutatingWebhookConfiguration, export the CA bundle, and rotate and release the persisted certificates")
 
 	// sidecar injector options
 	flags.BoolVar(&injectorConfig.DefaultInjection, "default-injection", true, "Enable sidecar injection by default")
@@ -103,6 +122,9 @@ func init() {
 
 	// feature flags
 	flags.BoolVar(&optionalFeatures.Backpressure, "enable-backpressure-experimental", false, "Enable experimental backpressure feature")
+	flags.BoolVar(&optionalFeatures.ResiliencePolicy, "enable-resilience-policy-experimental", false, "Enable experimental resilience policy feature")
+	flags.BoolVar(&optionalFeatures.EgressPolicy, "enable-egress-policy-experimental", false, "Enable experimental egress policy feature")
+	flags.BoolVar(&optionalFeatures.MulticlusterMode, "enable-multicluster-mode-experimental", false, "Enable experimental multicluster mode")
 }
 
 func main() {
@@ -149,69 +171,119 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to create new mesh spec client")
 	}
 
+	// Revocations are persisted in a ConfigMap watched by every replica, so a revoked proxy cannot connect to any replica, even after a restart
+	revocationStore, err := store.NewConfigMapRevocationStore(kubeClient, osmNamespace, revocationsConfigMapName, stop)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to load the revoked certificates from ConfigMap %s/%s", osmNamespace, revocationsConfigMapName)
+	}
+
 	// Get the Certificate Manager based on the CLI argument passed to this module.
-	certManager, certDebugger, err := certManagers[certificateManagerKind(*osmCertificateManagerKind)](kubeClient, kubeConfig, enableDebugServer)
+	certManager, certDebugger, err := certManagers[certificateManagerKind(*osmCertificateManagerKind)](kubeClient, kubeConfig, enableDebugServer, revocationStore)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Failed to get certificate manager based on CLI argument")
 	}
 
 	log.Info().Msgf("Service certificates will be valid for %+v", getServiceCertValidityPeriod())
 
-	if caBundleSecretName == "" {
-		log.Info().Msgf("CA bundle will not be exported to a k8s secret (no --%s provided)", caBundleSecretNameCLIParam)
+	dynamicClient := dynamic.NewForConfigOrDie(kubeConfig)
+
+	// The EndpointSlices of a large service are lighter to watch than its Endpoints, rewritten in full on every change,
+	// and the conditions of their endpoints tell the serving endpoints of the terminating pods
+	var provider endpoint.Provider
+	useEndpointSlices := enableEndpointSlices && kube.ServesEndpointSlices(kubeClient)
+	if enableEndpointSlices && !useEndpointSlices {
+		log.Info().Msg("The cluster does not serve EndpointSlices; discovering the endpoints of the services from their Endpoints")
+	}
+	if useEndpointSlices {
+		provider, err = kube.NewEndpointSliceProvider(kubeClient, dynamicClient, namespaceController, stop, constants.KubeProviderName, cfg)
 	} else {
-		if err := createOrUpdateCABundleKubernetesSecret(kubeClient, certManager, osmNamespace, caBundleSecretName); err != nil {
-			log.Error().Err(err).Msgf("Error exporting CA bundle into Kubernetes secret with name %s", caBundleSecretName)
-		}
+		provider, err = kube.NewProvider(kubeClient, namespaceController, stop, constants.KubeProviderName, cfg)
 	}
-
-	provider, err := kube.NewProvider(kubeClient, namespaceController, stop, constants.KubeProviderName, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Failed to get endpoint provider")
 	}
 
 	endpointsProviders := []endpoint.Provider{provider}
 
+	// The services exported by the remote clusters of a multicluster mesh are discovered by a provider per remote cluster
+	if featureflags.IsMulticlusterModeEnabled() {
+		endpointsProviders = append(endpointsProviders, getRemoteClusterProviders(kubeClient, cfg, stop)...)
+	}
+
 	// TODO (#88): Add Azure Endpoint provider to list of providers when supported
 
-	ingressClient, err := ingress.NewIngressClient(kubeClient, namespaceController, stop, cfg)
+	ingressClient, err := ingress.NewIngressClient(kubeClient, dynamicClient, namespaceController, stop, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize ingress client")
 	}
 
+	gatewayClient, err := gateway.NewGatewayClient(kubeClient, dynamicClient, namespaceController, stop)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize gateway client")
+	}
+
 	meshCatalog := catalog.NewMeshCatalog(
 		namespaceController,
 		kubeClient,
 		meshSpec,
 		certManager,
 		ingressClient,
+		gatewayClient,
 		stop,
 		cfg,
 		endpointsProviders...)
 
+	// The root certificate of tresor is rotated by updating the CA bundle secret, and the leader then configures the webhook
+	// with the new root certificates trusted by the mesh
+	caBundleUpdated := make(chan struct{}, 1)
+	tresorCertManager, isTresor := certManager.(*tresor.CertManager)
+	if isTresor && caBundleSecretName != "" {
+		watchCABundleSecret(kubeClient, tresorCertManager, osmNamespace, caBundleSecretName, func() {
+			select {
+			case caBundleUpdated <- struct{}{}:
+			default:
+				// The leader has yet to handle the previous update
+			}
+		}, stop)
+	}
+
 	// Create the sidecar-injector webhook
-	if err := injector.NewWebhook(injectorConfig, kubeClient, certManager, meshCatalog, namespaceController, meshName, osmNamespace, webhookName, stop, cfg); err != nil {
+	if err := injector.NewWebhook(injectorConfig, kubeClient, certManager, meshCatalog, namespaceController, osmNamespace, stop, cfg); err != nil {
 		log.Fatal().Err(err).Msg("Error creating mutating webhook")
 	}
 
-	xdsServer := ads.NewADSServer(meshCatalog, enableDebugServer, osmNamespace, cfg)
+	// The certificates persisted in the shared store are only rotated and released by the leader; the other replicas load them from the store.
+	// Without a shared store, every replica rotates and releases the certificates of its own certificate manager.
+	sharedCertificateStore := isTresor && persistCertificates
+	if sharedCertificateStore {
+		store.WatchSecrets(kubeClient, osmNamespace, tresorCertManager.LoadCertificate, tresorCertManager.UnloadCertificate, stop)
+	} else {
+		rotor.New(certManager).Start(checkCertificateExpirationInterval, stop)
+		injector.ReleaseCertificatesOfDeletedPods(kubeClient, certManager, stop)
+	}
 
-	// TODO(draychev): we need to pass this hard-coded string is a CLI argument (https://github.com/openservicemesh/osm/issues/542)
-	validityPeriod := constants.XDSCertificateValidityPeriod
-	adsCert, err := certManager.IssueCertificate(xdsServerCertificateCommonName, &validityPeriod)
-	if err != nil {
-		log.Fatal().Err(err)
+	// Only one replica at a time configures the webhook, exports the CA bundle, and rotates and releases the shared certificates
+	leaderDuties := func(ctx context.Context) {
+		runLeaderDuties(ctx, kubeClient, certManager, sharedCertificateStore, caBundleUpdated, meshCatalog, cfg)
+	}
+	if enableLeaderElection {
+		go runLeaderElection(ctx, kubeClient, osmNamespace, leaderDuties)
+	} else {
+		go leaderDuties(ctx)
 	}
 
-	grpcServer, lis := utils.NewGrpc(xdsServerType, *port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
+	// TODO(draychev): figure out the NS and POD
+	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
+
+	xdsServer := ads.NewADSServer(meshCatalog, enableDebugServer, osmNamespace, cfg, metricsStore)
+
+	// TODO(draychev): we need to pass this hard-coded string is a CLI argument (https://github.com/openservicemesh/osm/issues/542)
+	grpcServer, lis := utils.NewGrpc(xdsServerType, *port, certManager, xdsServerCertificateCommonName, constants.XDSCertificateValidityPeriod)
 	xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, xdsServer)
 
 	go utils.GrpcServe(ctx, grpcServer, lis, cancel, xdsServerType)
 
 	// initialize the http server and start it
-	// TODO(draychev): figure out the NS and POD
-	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
-
 	// Expose /debug endpoints and data only if the enableDebugServer flag is enabled
 	var debugServer debugger.DebugServer
 	if enableDebugServer {
@@ -226,6 +298,50 @@ func main() {
 	log.Info().Msg("Goodbye!")
 }
 
+// runLeaderDuties performs the duties of the osm-controller replica which leads; the webhook is configured, the CA bundle is exported,
+// and until the context is canceled, the certificates of the shared certificate store are rotated and released, in multicluster mode services
+// are exported to the remote clusters, and the webhook is configured again every time the CA bundle is updated.
+func runLeaderDuties(ctx context.Context, kubeClient clientset.Interface, certManager certificate.Manager, sharedCertificateStore bool, caBundleUpdated <-chan struct{}, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator) {
+	if caBundleSecretName == "" {
+		log.Info().Msgf("CA bundle will not be exported to a k8s secret (no --%s provided)", caBundleSecretNameCLIParam)
+	} else {
+		_ = retryWithBackoff(ctx, "exporting the CA bundle into Kubernetes secret "+caBundleSecretName, func() error {
+			return createOrUpdateCABundleKubernetesSecret(kubeClient, certManager, osmNamespace, caBundleSecretName)
+		})
+	}
+
+	configureWebhook := func() error {
+		return retryWithBackoff(ctx, "configuring the mutating webhook", func() error {
+			return injector.PatchMutatingWebhookConfiguration(certManager, meshName, osmNamespace, webhookName, kubeClient)
+		})
+	}
+	if err := configureWebhook(); err != nil {
+		// The leadership was lost
+		return
+	}
+
+	if sharedCertificateStore {
+		rotor.New(certManager).Start(checkCertificateExpirationInterval, ctx.Done())
+		injector.ReleaseCertificatesOfDeletedPods(kubeClient, certManager, ctx.Done())
+	}
+
+	if featureflags.IsMulticlusterModeEnabled() {
+		multicluster.NewExporter(kubeClient, meshCatalog, cfg, osmNamespace).Start(ctx.Done())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-caBundleUpdated:
+			log.Info().Msg("The CA bundle was updated; configuring the mutating webhook with the new root certificates trusted by the mesh")
+			if err := configureWebhook(); err != nil {
+				return
+			}
+		}
+	}
+}
+
 func parseFlags() error {
 	if err := flags.Parse(os.Args); err != nil {
 		return err
@@ -286,6 +402,12 @@ func saveOrUpdateSecretToKubernetes(kubeClient clientset.Interface, ca certifica
 		return getErr
 	}
 
+	// A CA stored along with its private key is owned by tresor, and is only replaced by `osm ca rotate`
+	if _, ok := existingSecret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]; ok && privKey == nil {
+		log.Info().Msgf("CA bundle Kubernetes secret %s in namespace %s already holds the CA", caBundleSecretName, namespace)
+		return nil
+	}
+
 	log.Info().Msgf("Updating existing CA bundle Kubernetes secret %s in namespace %s", caBundleSecretName, namespace)
 
 	// Override or add CA bundle to existing Secret
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -18,7 +19,7 @@ var _ = Describe("Test creation of CA bundle k8s secret", func() {
 	Context("Testing createCABundleKubernetesSecret", func() {
 		It("creates a k8s secret", func() {
 
-			cache := make(map[certificate.CommonName]certificate.Certificater)
+			cache := make(map[certificate.CacheKey]certificate.Certificater)
 			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 			secretName := "--secret--name--"
 			namespace := "--namespace--"
@@ -34,5 +35,31 @@ var _ = Describe("Test creation of CA bundle k8s secret", func() {
 			Expect(stringPEM).To(Equal(expected))
 			Expect(len(actual.Data[constants.KubernetesOpaqueSecretCAKey])).To(Equal(1915))
 		})
+
+		It("does not overwrite a CA stored along with its private key", func() {
+			cache := make(map[certificate.CacheKey]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+			secretName := "--secret--name--"
+			namespace := "--namespace--"
+			storedCA := map[string][]byte{
+				constants.KubernetesOpaqueSecretCAKey:             []byte("ca"),
+				constants.KubernetesOpaqueSecretRootPrivateKeyKey: []byte("key"),
+				constants.KubernetesOpaqueSecretCAExpiration:      []byte("expiration"),
+			}
+			k8sClient := testclient.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      secretName,
+					Namespace: namespace,
+				},
+				Data: storedCA,
+			})
+
+			err := createOrUpdateCABundleKubernetesSecret(k8sClient, certManager, namespace, secretName)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual, err := k8sClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, v1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Data).To(Equal(storedCA))
+		})
 	})
 })
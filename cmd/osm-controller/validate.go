@@ -46,5 +46,13 @@ func validateCLIParams() error {
 	if webhookName == "" {
 		return errors.Errorf("Invalid --webhook-name value: '%s'", webhookName)
 	}
+
+	if enableLeaderElection && certificateManagerKind(*osmCertificateManagerKind) == tresorKind && caBundleSecretName == "" {
+		return errors.Errorf("Please specify --%s so the osm-controller replicas share the same CA", caBundleSecretNameCLIParam)
+	}
+
+	if persistCertificates && caBundleSecretName == "" {
+		return errors.Errorf("Please specify --%s so the persisted certificates are issued by the same CA after a restart", caBundleSecretNameCLIParam)
+	}
 	return nil
 }
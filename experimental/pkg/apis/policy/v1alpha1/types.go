@@ -21,6 +21,56 @@ type BackpressureSpec struct {
 
 	// MaxConnections is the max number of connections a proxy will make to the remote service.
 	MaxConnections uint32 `json:"maxConnections,omitempty"`
+
+	// RateLimit is the local rate limit of the requests sent to the service.
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+// RateLimit configures the local rate limiting of the requests sent to a service.
+// Each proxy enforces the limits on its own, so the limits apply to each replica of a service.
+type RateLimit struct {
+	// RequestsPerSecond is the number of requests per second each proxy of the service accepts from all the sources.
+	RequestsPerSecond uint32 `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of requests accepted above RequestsPerSecond during a burst.
+	Burst uint32 `json:"burst,omitempty"`
+
+	// Sources is the list of the rate limits of the requests sent by given source services.
+	// Source rate limits are not supported yet: the policies setting them are rejected.
+	Sources []SourceRateLimit `json:"sources,omitempty"`
+
+	// Routes is the list of the rate limits of the requests matching given HTTPRouteGroup matches.
+	Routes []RouteRateLimit `json:"routes,omitempty"`
+}
+
+// SourceRateLimit is the rate limit of the requests sent by a source service
+type SourceRateLimit struct {
+	// Name is the name of the source service.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the source service, which defaults to the namespace of the policy.
+	Namespace string `json:"namespace,omitempty"`
+
+	// RequestsPerSecond is the number of requests per second each proxy of the source service may send.
+	RequestsPerSecond uint32 `json:"requestsPerSecond"`
+
+	// Burst is the number of requests accepted above RequestsPerSecond during a burst.
+	Burst uint32 `json:"burst,omitempty"`
+}
+
+// RouteRateLimit is the rate limit of the requests matching an HTTPRouteGroup match
+type RouteRateLimit struct {
+	// HTTPRouteGroup is the name of the HTTPRouteGroup, in the namespace of the policy.
+	HTTPRouteGroup string `json:"httpRouteGroup"`
+
+	// Match is the name of the match of the HTTPRouteGroup.
+	Match string `json:"match"`
+
+	// RequestsPerSecond is the number of requests per second matching the route each proxy of the service accepts.
+	RequestsPerSecond uint32 `json:"requestsPerSecond"`
+
+	// Burst is the number of requests accepted above RequestsPerSecond during a burst.
+	Burst uint32 `json:"burst,omitempty"`
 }
 
 // BackpressureList is ...
@@ -32,3 +82,169 @@ type BackpressureList struct {
 	// Items is the list of Backpressure
 	Items []Backpressure `json:"items"`
 }
+
+// ResiliencePolicy configures the timeouts, retries, circuit breaking and outlier detection
+// applied by the proxies to the requests sent to a destination service.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ResiliencePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResiliencePolicySpec `json:"spec"`
+}
+
+// ResiliencePolicySpec is the specification of a ResiliencePolicy
+type ResiliencePolicySpec struct {
+	// Destination is the name of the service the policy applies to, in the namespace of the policy.
+	Destination string `json:"destination"`
+
+	// Timeout is the timeout of the requests sent to the destination service, including all their retries.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// Retry is the retry policy of the requests sent to the destination service.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// CircuitBreaker is the set of thresholds limiting the traffic sent to the destination service.
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// OutlierDetection configures the ejection of the failing endpoints of the destination service.
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+
+	// HealthCheck configures the active health checking of the endpoints of the destination service.
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// RetryPolicy is the retry policy of the requests sent to a service
+type RetryPolicy struct {
+	// Attempts is the max number of times a failed request is retried.
+	Attempts uint32 `json:"attempts"`
+
+	// PerTryTimeout is the timeout of each attempt of a request.
+	PerTryTimeout metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// RetryOn is the list of conditions under which a request is retried, such as 5xx or connect-failure.
+	// When not set, the requests failing with a 5xx status, or a transient gRPC status for gRPC traffic, are retried.
+	RetryOn []string `json:"retryOn,omitempty"`
+}
+
+// CircuitBreaker is the set of thresholds limiting the traffic sent to a service
+type CircuitBreaker struct {
+	// MaxConnections is the max number of connections a proxy will make to the service.
+	MaxConnections uint32 `json:"maxConnections,omitempty"`
+
+	// MaxPendingRequests is the max number of requests a proxy will queue while waiting for a connection to the service.
+	MaxPendingRequests uint32 `json:"maxPendingRequests,omitempty"`
+
+	// MaxRequests is the max number of parallel requests a proxy will send to the service.
+	MaxRequests uint32 `json:"maxRequests,omitempty"`
+
+	// MaxRetries is the max number of parallel retries a proxy will send to the service.
+	MaxRetries uint32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetection configures the ejection of the failing endpoints of a service
+type OutlierDetection struct {
+	// Consecutive5xx is the number of consecutive 5xx responses after which an endpoint is ejected.
+	Consecutive5xx uint32 `json:"consecutive5xx,omitempty"`
+
+	// BaseEjectionTime is the time an endpoint is ejected for, multiplied by the number of times it was ejected.
+	BaseEjectionTime metav1.Duration `json:"baseEjectionTime,omitempty"`
+}
+
+// HealthCheck configures the active health checking of the endpoints of a service.
+// The endpoints failing the health checks do not receive traffic until they pass them again.
+type HealthCheck struct {
+	// Path is the path of the HTTP health check requests, sent to the HTTP ports of the service.
+	// When not set, or for the ports which are not HTTP, the health check only opens a TCP connection.
+	Path string `json:"path,omitempty"`
+
+	// Interval is the time between two health checks of an endpoint, 10s by default.
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout is the time to wait for a health check response, 1s by default.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed health checks after which an endpoint is unhealthy, 3 by default.
+	UnhealthyThreshold uint32 `json:"unhealthyThreshold,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful health checks after which an unhealthy endpoint is healthy again, 1 by default.
+	HealthyThreshold uint32 `json:"healthyThreshold,omitempty"`
+}
+
+// ResiliencePolicyList is a list of ResiliencePolicy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ResiliencePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of ResiliencePolicy
+	Items []ResiliencePolicy `json:"items"`
+}
+
+// Egress allowlists the destinations outside the mesh the pods running as the source service accounts may send traffic to.
+// The egress traffic of these pods that is not allowed by an Egress policy is denied.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Egress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EgressSpec `json:"spec"`
+}
+
+// EgressSpec is the specification of an Egress policy
+type EgressSpec struct {
+	// Sources is the list of the service accounts the policy applies to.
+	Sources []EgressSource `json:"sources"`
+
+	// Hosts is the list of the hostnames of the external destinations, which may start with a wildcard such as *.example.com.
+	// HTTPS traffic is matched on its SNI, and HTTP traffic on its Host header.
+	Hosts []string `json:"hosts,omitempty"`
+
+	// IPAddresses is the list of the IP addresses or CIDR ranges of the external destinations.
+	// The traffic sent to these addresses is matched on its destination address, whatever its protocol.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// Ports is the list of the ports of the external destinations, along with their protocol.
+	Ports []EgressPort `json:"ports"`
+}
+
+// EgressSource is a service account an Egress policy applies to
+type EgressSource struct {
+	// Name is the name of the service account.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the service account, which defaults to the namespace of the policy.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// EgressPort is a port of the external destinations of an Egress policy
+type EgressPort struct {
+	// Number is the port number.
+	Number uint32 `json:"number"`
+
+	// Protocol is the protocol of the traffic sent to the port: http, https or tcp.
+	Protocol string `json:"protocol"`
+}
+
+const (
+	// EgressProtocolHTTP is the protocol of plaintext HTTP egress traffic, matched on its Host header
+	EgressProtocolHTTP = "http"
+
+	// EgressProtocolHTTPS is the protocol of TLS encrypted egress traffic, matched on its SNI
+	EgressProtocolHTTPS = "https"
+
+	// EgressProtocolTCP is the protocol of TCP egress traffic, matched on its destination address
+	EgressProtocolTCP = "tcp"
+)
+
+// EgressList is a list of Egress policies
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EgressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of Egress policies
+	Items []Egress `json:"items"`
+}
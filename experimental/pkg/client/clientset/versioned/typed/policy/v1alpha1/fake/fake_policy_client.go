@@ -32,6 +32,14 @@ func (c *FakePolicyV1alpha1) Backpressures(namespace string) v1alpha1.Backpressu
 	return &FakeBackpressures{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) Egresses(namespace string) v1alpha1.EgressInterface {
+	return &FakeEgresses{c, namespace}
+}
+
+func (c *FakePolicyV1alpha1) ResiliencePolicies(namespace string) v1alpha1.ResiliencePolicyInterface {
+	return &FakeResiliencePolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakePolicyV1alpha1) RESTClient() rest.Interface {
@@ -19,3 +19,7 @@ limitations under the License.
 package v1alpha1
 
 type BackpressureExpansion interface{}
+
+type EgressExpansion interface{}
+
+type ResiliencePolicyExpansion interface{}
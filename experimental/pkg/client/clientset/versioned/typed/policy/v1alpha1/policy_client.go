@@ -27,6 +27,8 @@ import (
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	BackpressuresGetter
+	EgressesGetter
+	ResiliencePoliciesGetter
 }
 
 // PolicyV1alpha1Client is used to interact with features provided by the policy.openservicemesh.io group.
@@ -38,6 +40,14 @@ func (c *PolicyV1alpha1Client) Backpressures(namespace string) BackpressureInter
 	return newBackpressures(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) Egresses(namespace string) EgressInterface {
+	return newEgresses(c, namespace)
+}
+
+func (c *PolicyV1alpha1Client) ResiliencePolicies(namespace string) ResiliencePolicyInterface {
+	return newResiliencePolicies(c, namespace)
+}
+
 // NewForConfig creates a new PolicyV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*PolicyV1alpha1Client, error) {
 	config := *c
@@ -26,6 +26,10 @@ import (
 type Interface interface {
 	// Backpressures returns a BackpressureInformer.
 	Backpressures() BackpressureInformer
+	// Egresses returns a EgressInformer.
+	Egresses() EgressInformer
+	// ResiliencePolicies returns a ResiliencePolicyInformer.
+	ResiliencePolicies() ResiliencePolicyInformer
 }
 
 type version struct {
@@ -43,3 +47,13 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Backpressures() BackpressureInformer {
 	return &backpressureInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// Egresses returns a EgressInformer.
+func (v *version) Egresses() EgressInformer {
+	return &egressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ResiliencePolicies returns a ResiliencePolicyInformer.
+func (v *version) ResiliencePolicies() ResiliencePolicyInformer {
+	return &resiliencePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -25,3 +25,19 @@ type BackpressureListerExpansion interface{}
 // BackpressureNamespaceListerExpansion allows custom methods to be added to
 // BackpressureNamespaceLister.
 type BackpressureNamespaceListerExpansion interface{}
+
+// EgressListerExpansion allows custom methods to be added to
+// EgressLister.
+type EgressListerExpansion interface{}
+
+// EgressNamespaceListerExpansion allows custom methods to be added to
+// EgressNamespaceLister.
+type EgressNamespaceListerExpansion interface{}
+
+// ResiliencePolicyListerExpansion allows custom methods to be added to
+// ResiliencePolicyLister.
+type ResiliencePolicyListerExpansion interface{}
+
+// ResiliencePolicyNamespaceListerExpansion allows custom methods to be added to
+// ResiliencePolicyNamespaceLister.
+type ResiliencePolicyNamespaceListerExpansion interface{}
@@ -9,43 +9,60 @@ PROXY_INBOUND_PORT=${PROXY_INBOUND_PORT:-15003}
 PROXY_UID=${PROXY_UID:-1337}
 SSH_PORT=${SSH_PORT:-22}
 
-# Create a new chain for redirecting outbound traffic to PROXY_PORT
-iptables -t nat -N PROXY_REDIRECT
-iptables -t nat -A PROXY_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_PORT}"
+# Program the rules redirecting the traffic to the Proxy with the given iptables command,
+# iptables for the IPv4 traffic and ip6tables for the IPv6 traffic, the given loopback range being the one of the IP family
+program_rules() {
+  local IPTABLES=$1
+  local LOOPBACK=$2
 
-# Traffic to the Proxy Admin port flows to the Proxy -- not redirected
-iptables -t nat -A PROXY_REDIRECT -p tcp --dport "${PROXY_ADMIN_PORT}" -j ACCEPT
+  # Create a new chain for redirecting outbound traffic to PROXY_PORT
+  "${IPTABLES}" -t nat -N PROXY_REDIRECT
+  "${IPTABLES}" -t nat -A PROXY_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_PORT}"
 
+  # Traffic to the Proxy Admin port flows to the Proxy -- not redirected
+  "${IPTABLES}" -t nat -A PROXY_REDIRECT -p tcp --dport "${PROXY_ADMIN_PORT}" -j ACCEPT
 
-# Create a new chain for redirecting inbound traffic to PROXY_INBOUND_PORT
-iptables -t nat -N PROXY_IN_REDIRECT
-iptables -t nat -A PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_INBOUND_PORT}"
 
-# Create a new chain to redirect inbound traffic to Envoy
-iptables -t nat -N PROXY_INBOUND
-iptables -t nat -A PREROUTING -p tcp -j PROXY_INBOUND
+  # Create a new chain for redirecting inbound traffic to PROXY_INBOUND_PORT
+  "${IPTABLES}" -t nat -N PROXY_IN_REDIRECT
+  "${IPTABLES}" -t nat -A PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_INBOUND_PORT}"
 
-# Skip inbound SSH redirection
-iptables -t nat -A PROXY_INBOUND -p tcp --dport "${SSH_PORT}" -j RETURN
-# Skip inbound stats query redirection
-iptables -t nat -A PROXY_INBOUND -p tcp --dport "${PROXY_STATS_PORT}" -j RETURN
-# Redirect remaining inbound traffic to PROXY_INBOUND_PORT
-iptables -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT
+  # Create a new chain to redirect inbound traffic to Envoy
+  "${IPTABLES}" -t nat -N PROXY_INBOUND
+  "${IPTABLES}" -t nat -A PREROUTING -p tcp -j PROXY_INBOUND
 
+  # Skip inbound SSH redirection
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp --dport "${SSH_PORT}" -j RETURN
+  # Skip inbound stats query redirection
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp --dport "${PROXY_STATS_PORT}" -j RETURN
+  # Redirect remaining inbound traffic to PROXY_INBOUND_PORT
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT
 
-# Create a new chain to redirect outbound traffic to Envoy
-iptables -t nat -N PROXY_OUTPUT
 
-# For all TCP traffic, jump to PROXY_OUTPUT chain from OUTPUT chain
-iptables -t nat -A OUTPUT -p tcp -j PROXY_OUTPUT
+  # Create a new chain to redirect outbound traffic to Envoy
+  "${IPTABLES}" -t nat -N PROXY_OUTPUT
 
-# TODO(shashank): Redirect app back calls to itself using PROXY_UID
+  # For all TCP traffic, jump to PROXY_OUTPUT chain from OUTPUT chain
+  "${IPTABLES}" -t nat -A OUTPUT -p tcp -j PROXY_OUTPUT
 
-# Don't redirect Envoy traffic back to itself for non-loopback traffic
-iptables -t nat -A PROXY_OUTPUT -m owner --uid-owner "${PROXY_UID}" -j RETURN
+  # TODO(shashank): Redirect app back calls to itself using PROXY_UID
 
-# Skip localhost traffic
-iptables -t nat -A PROXY_OUTPUT -d 127.0.0.1/32 -j RETURN
+  # Don't redirect Envoy traffic back to itself for non-loopback traffic
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -m owner --uid-owner "${PROXY_UID}" -j RETURN
 
-# Redirect remaining outbound traffic to Envoy
-iptables -t nat -A PROXY_OUTPUT -j PROXY_REDIRECT
+  # Skip localhost traffic
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -d "${LOOPBACK}" -j RETURN
+
+  # Redirect remaining outbound traffic to Envoy
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -j PROXY_REDIRECT
+}
+
+program_rules iptables 127.0.0.1/32
+
+# The IPv6 traffic of the dual-stack and IPv6 pods is redirected as well,
+# unless IPv6 is disabled on the node, in which case the pods have no IPv6 traffic
+if ip6tables -t nat -L >/dev/null 2>&1; then
+  program_rules ip6tables ::1/128
+else
+  echo "IPv6 is not available, skipping the ip6tables rules"
+fi
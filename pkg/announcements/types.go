@@ -0,0 +1,211 @@
+// Package announcements provides the types of the announcements the OSM components make
+// when the resources they observe change, so the consumers of the announcements can
+// determine what the changes affect.
+package announcements
+
+// Kind is the kind of an announcement: what changed and how
+type Kind string
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+const (
+	// ServiceAdded is the kind of announcement emitted when a Kubernetes service is added
+	ServiceAdded Kind = "service-added"
+
+	// ServiceUpdated is the kind of announcement emitted when a Kubernetes service is updated
+	ServiceUpdated Kind = "service-updated"
+
+	// ServiceDeleted is the kind of announcement emitted when a Kubernetes service is deleted
+	ServiceDeleted Kind = "service-deleted"
+
+	// EndpointsAdded is the kind of announcement emitted when Kubernetes endpoints are added
+	EndpointsAdded Kind = "endpoints-added"
+
+	// EndpointsUpdated is the kind of announcement emitted when Kubernetes endpoints are updated
+	EndpointsUpdated Kind = "endpoints-updated"
+
+	// EndpointsDeleted is the kind of announcement emitted when Kubernetes endpoints are deleted
+	EndpointsDeleted Kind = "endpoints-deleted"
+
+	// EndpointSliceAdded is the kind of announcement emitted when a Kubernetes endpoint slice is added
+	EndpointSliceAdded Kind = "endpointslice-added"
+
+	// EndpointSliceUpdated is the kind of announcement emitted when a Kubernetes endpoint slice is updated
+	EndpointSliceUpdated Kind = "endpointslice-updated"
+
+	// EndpointSliceDeleted is the kind of announcement emitted when a Kubernetes endpoint slice is deleted
+	EndpointSliceDeleted Kind = "endpointslice-deleted"
+
+	// DeploymentAdded is the kind of announcement emitted when a Kubernetes deployment is added
+	DeploymentAdded Kind = "deployment-added"
+
+	// DeploymentUpdated is the kind of announcement emitted when a Kubernetes deployment is updated
+	DeploymentUpdated Kind = "deployment-updated"
+
+	// DeploymentDeleted is the kind of announcement emitted when a Kubernetes deployment is deleted
+	DeploymentDeleted Kind = "deployment-deleted"
+
+	// NamespaceAdded is the kind of announcement emitted when a monitored namespace is added
+	NamespaceAdded Kind = "namespace-added"
+
+	// NamespaceUpdated is the kind of announcement emitted when a monitored namespace is updated
+	NamespaceUpdated Kind = "namespace-updated"
+
+	// NamespaceDeleted is the kind of announcement emitted when a monitored namespace is deleted
+	NamespaceDeleted Kind = "namespace-deleted"
+
+	// IngressAdded is the kind of announcement emitted when a Kubernetes ingress is added
+	IngressAdded Kind = "ingress-added"
+
+	// IngressUpdated is the kind of announcement emitted when a Kubernetes ingress is updated
+	IngressUpdated Kind = "ingress-updated"
+
+	// IngressDeleted is the kind of announcement emitted when a Kubernetes ingress is deleted
+	IngressDeleted Kind = "ingress-deleted"
+
+	// IngressClassAdded is the kind of announcement emitted when a Kubernetes ingress class is added
+	IngressClassAdded Kind = "ingress-class-added"
+
+	// IngressClassUpdated is the kind of announcement emitted when a Kubernetes ingress class is updated
+	IngressClassUpdated Kind = "ingress-class-updated"
+
+	// IngressClassDeleted is the kind of announcement emitted when a Kubernetes ingress class is deleted
+	IngressClassDeleted Kind = "ingress-class-deleted"
+
+	// GatewayHTTPRouteAdded is the kind of announcement emitted when a Gateway API HTTPRoute is added
+	GatewayHTTPRouteAdded Kind = "gateway-http-route-added"
+
+	// GatewayHTTPRouteUpdated is the kind of announcement emitted when a Gateway API HTTPRoute is updated
+	GatewayHTTPRouteUpdated Kind = "gateway-http-route-updated"
+
+	// GatewayHTTPRouteDeleted is the kind of announcement emitted when a Gateway API HTTPRoute is deleted
+	GatewayHTTPRouteDeleted Kind = "gateway-http-route-deleted"
+
+	// GatewayTLSRouteAdded is the kind of announcement emitted when a Gateway API TLSRoute is added
+	GatewayTLSRouteAdded Kind = "gateway-tls-route-added"
+
+	// GatewayTLSRouteUpdated is the kind of announcement emitted when a Gateway API TLSRoute is updated
+	GatewayTLSRouteUpdated Kind = "gateway-tls-route-updated"
+
+	// GatewayTLSRouteDeleted is the kind of announcement emitted when a Gateway API TLSRoute is deleted
+	GatewayTLSRouteDeleted Kind = "gateway-tls-route-deleted"
+
+	// TrafficSplitAdded is the kind of announcement emitted when an SMI TrafficSplit is added
+	TrafficSplitAdded Kind = "traffic-split-added"
+
+	// TrafficSplitUpdated is the kind of announcement emitted when an SMI TrafficSplit is updated
+	TrafficSplitUpdated Kind = "traffic-split-updated"
+
+	// TrafficSplitDeleted is the kind of announcement emitted when an SMI TrafficSplit is deleted
+	TrafficSplitDeleted Kind = "traffic-split-deleted"
+
+	// RouteGroupAdded is the kind of announcement emitted when an SMI HTTPRouteGroup is added
+	RouteGroupAdded Kind = "route-group-added"
+
+	// RouteGroupUpdated is the kind of announcement emitted when an SMI HTTPRouteGroup is updated
+	RouteGroupUpdated Kind = "route-group-updated"
+
+	// RouteGroupDeleted is the kind of announcement emitted when an SMI HTTPRouteGroup is deleted
+	RouteGroupDeleted Kind = "route-group-deleted"
+
+	// TCPRouteAdded is the kind of announcement emitted when an SMI TCPRoute is added
+	TCPRouteAdded Kind = "tcp-route-added"
+
+	// TCPRouteUpdated is the kind of announcement emitted when an SMI TCPRoute is updated
+	TCPRouteUpdated Kind = "tcp-route-updated"
+
+	// TCPRouteDeleted is the kind of announcement emitted when an SMI TCPRoute is deleted
+	TCPRouteDeleted Kind = "tcp-route-deleted"
+
+	// TrafficTargetAdded is the kind of announcement emitted when an SMI TrafficTarget is added
+	TrafficTargetAdded Kind = "traffic-target-added"
+
+	// TrafficTargetUpdated is the kind of announcement emitted when an SMI TrafficTarget is updated
+	TrafficTargetUpdated Kind = "traffic-target-updated"
+
+	// TrafficTargetDeleted is the kind of announcement emitted when an SMI TrafficTarget is deleted
+	TrafficTargetDeleted Kind = "traffic-target-deleted"
+
+	// BackpressureAdded is the kind of announcement emitted when a Backpressure policy is added
+	BackpressureAdded Kind = "backpressure-added"
+
+	// BackpressureUpdated is the kind of announcement emitted when a Backpressure policy is updated
+	BackpressureUpdated Kind = "backpressure-updated"
+
+	// BackpressureDeleted is the kind of announcement emitted when a Backpressure policy is deleted
+	BackpressureDeleted Kind = "backpressure-deleted"
+
+	// ResiliencePolicyAdded is the kind of announcement emitted when a ResiliencePolicy is added
+	ResiliencePolicyAdded Kind = "resilience-policy-added"
+
+	// ResiliencePolicyUpdated is the kind of announcement emitted when a ResiliencePolicy is updated
+	ResiliencePolicyUpdated Kind = "resilience-policy-updated"
+
+	// ResiliencePolicyDeleted is the kind of announcement emitted when a ResiliencePolicy is deleted
+	ResiliencePolicyDeleted Kind = "resilience-policy-deleted"
+
+	// EgressAdded is the kind of announcement emitted when an Egress policy is added
+	EgressAdded Kind = "egress-added"
+
+	// EgressUpdated is the kind of announcement emitted when an Egress policy is updated
+	EgressUpdated Kind = "egress-updated"
+
+	// EgressDeleted is the kind of announcement emitted when an Egress policy is deleted
+	EgressDeleted Kind = "egress-deleted"
+
+	// AzureResourceAdded is the kind of announcement emitted when an AzureResource is added
+	AzureResourceAdded Kind = "azure-resource-added"
+
+	// AzureResourceUpdated is the kind of announcement emitted when an AzureResource is updated
+	AzureResourceUpdated Kind = "azure-resource-updated"
+
+	// AzureResourceDeleted is the kind of announcement emitted when an AzureResource is deleted
+	AzureResourceDeleted Kind = "azure-resource-deleted"
+
+	// ConfigMapAdded is the kind of announcement emitted when the OSM ConfigMap is added
+	ConfigMapAdded Kind = "configmap-added"
+
+	// ConfigMapChanged is the kind of announcement emitted when the OSM ConfigMap is changed
+	ConfigMapChanged Kind = "configmap-changed"
+
+	// ConfigMapDeleted is the kind of announcement emitted when the OSM ConfigMap is deleted
+	ConfigMapDeleted Kind = "configmap-deleted"
+
+	// MeshCIDRRangesChanged is the kind of announcement emitted when the mesh CIDR ranges discovered from the cluster change
+	MeshCIDRRangesChanged Kind = "mesh-cidr-ranges-changed"
+
+	// CertificateRotated is the kind of announcement emitted when a certificate is rotated
+	CertificateRotated Kind = "certificate-rotated"
+
+	// CertificateRevoked is the kind of announcement emitted when a certificate is revoked
+	CertificateRevoked Kind = "certificate-revoked"
+
+	// TrustBundleChanged is the kind of announcement emitted when the root certificates trusted by the mesh change
+	TrustBundleChanged Kind = "trust-bundle-changed"
+
+	// MulticlusterExportAdded is the kind of announcement emitted when the services exported by a remote cluster are discovered
+	MulticlusterExportAdded Kind = "multicluster-export-added"
+
+	// MulticlusterExportUpdated is the kind of announcement emitted when the services exported by a remote cluster change
+	MulticlusterExportUpdated Kind = "multicluster-export-updated"
+
+	// MulticlusterExportDeleted is the kind of announcement emitted when a remote cluster no longer exports services
+	MulticlusterExportDeleted Kind = "multicluster-export-deleted"
+)
+
+// Announcement is a message from one of the OSM components announcing a change to the resources it observes.
+type Announcement struct {
+	// Type is the kind of change announced
+	Type Kind
+
+	// Namespace is the namespace of the object which changed; empty for cluster scoped objects
+	Namespace string
+
+	// Name is the name of the object which changed
+	Name string
+
+	// Object is the object which changed; for updates this is the new object
+	Object interface{}
+}
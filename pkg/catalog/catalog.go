@@ -1,27 +1,27 @@
 package catalog
 
 import (
-	"time"
-
 	set "github.com/deckarep/golang-set"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/smi"
 )
 
 // NewMeshCatalog creates a new service catalog
-func NewMeshCatalog(namespaceController namespace.Controller, kubeClient kubernetes.Interface, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
+func NewMeshCatalog(namespaceController namespace.Controller, kubeClient kubernetes.Interface, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, gatewayMonitor gateway.Monitor, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
 	log.Info().Msg("Create a new Service MeshCatalog.")
 	sc := MeshCatalog{
 		endpointsProviders: endpointsProviders,
 		meshSpec:           meshSpec,
 		certManager:        certManager,
 		ingressMonitor:     ingressMonitor,
+		gatewayMonitor:     gatewayMonitor,
 		configurator:       cfg,
 
 		expectedProxies:      make(map[certificate.CommonName]expectedProxy),
@@ -41,7 +41,10 @@ func NewMeshCatalog(namespaceController namespace.Controller, kubeClient kuberne
 		sc.announcementChannels.Add(announcementChannel)
 	}
 
-	go sc.repeater()
+	changes := make(chan meshChange, maxPendingChanges)
+	go sc.receiveAnnouncements(changes)
+	go sc.dispatcher(changes, stop)
+
 	return &sc
 }
 
@@ -51,25 +54,18 @@ func (mc *MeshCatalog) GetSMISpec() smi.MeshSpec {
 }
 
 func (mc *MeshCatalog) getAnnouncementChannels() []announcementChannel {
-	ticking := make(chan interface{})
 	announcementChannels := []announcementChannel{
 		{"MeshSpec", mc.meshSpec.GetAnnouncementsChannel()},
 		{"CertManager", mc.certManager.GetAnnouncementsChannel()},
 		{"IngressMonitor", mc.ingressMonitor.GetAnnouncementsChannel()},
-		{"Ticker", ticking},
+		{"GatewayMonitor", mc.gatewayMonitor.GetAnnouncementsChannel()},
 		{"Namespace", mc.namespaceController.GetAnnouncementsChannel()},
+		{"OSMConfigMap", mc.configurator.GetAnnouncementsChannel()},
 	}
 	for _, ep := range mc.endpointsProviders {
 		annCh := announcementChannel{ep.GetID(), ep.GetAnnouncementsChannel()}
 		announcementChannels = append(announcementChannels, annCh)
 	}
 
-	// TODO(draychev): Ticker Announcement channel should be made optional
-	// with osm-config configurable interval
-	// See Github Issue: https://github.com/openservicemesh/osm/issues/1501
-	go func() {
-		ticker := time.NewTicker(updateAtLeastEvery)
-		ticking <- ticker.C
-	}()
 	return announcementChannels
 }
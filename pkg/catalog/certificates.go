@@ -6,18 +6,26 @@ import (
 )
 
 // GetCertificateForService returns the certificate the given proxy uses for mTLS to the XDS server.
-func (mc *MeshCatalog) GetCertificateForService(meshService service.MeshService) (certificate.Certificater, error) {
+// The certificate carries the SPIFFE ID of the given service account, which the peers of the proxy validate.
+func (mc *MeshCatalog) GetCertificateForService(meshService service.MeshService, serviceAccount service.K8sServiceAccount) (certificate.Certificater, error) {
 	cn := meshService.GetCommonName()
+	spiffeID := mc.GetSPIFFEID(serviceAccount)
 
-	cert, err := mc.certManager.GetCertificate(cn)
+	// The certificate manager returns the certificate it already issued, as long as it carries the same SPIFFE ID
+	cert, err := mc.certManager.IssueCertificate(cn, nil, spiffeID)
 	if err != nil {
-		// Certificate was not found in CertManager's cache, issue one
-		newCert, err := mc.certManager.IssueCertificate(cn, nil)
-		if err != nil {
-			log.Error().Err(err).Msgf("Error issuing a new certificate for service:%s, CN: %s", meshService, cn)
-			return nil, err
-		}
-		return newCert, nil
+		log.Error().Err(err).Msgf("Error issuing a new certificate for service:%s, CN: %s, SPIFFE ID: %s", meshService, cn, spiffeID)
+		return nil, err
 	}
 	return cert, nil
 }
+
+// GetSPIFFEID returns the SPIFFE ID of the workloads running as the given service account, in the trust domain of the mesh.
+func (mc *MeshCatalog) GetSPIFFEID(serviceAccount service.K8sServiceAccount) certificate.SPIFFEID {
+	return certificate.NewSPIFFEID(mc.configurator.GetTrustDomain(), serviceAccount.Namespace, serviceAccount.Name)
+}
+
+// GetTrustBundle returns the PEM encoded root certificates trusted by the mesh.
+func (mc *MeshCatalog) GetTrustBundle() ([]byte, error) {
+	return mc.certManager.GetTrustBundle()
+}
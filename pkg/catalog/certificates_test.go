@@ -10,6 +10,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Test certificate tooling", func() {
@@ -21,7 +22,7 @@ var _ = Describe("Test certificate tooling", func() {
 
 	Context("Testing DecodePEMCertificate along with GetCommonName and IssueCertificate", func() {
 		It("issues a PEM certificate with the correct CN", func() {
-			cert, err := mc.GetCertificateForService(namespacedService)
+			cert, err := mc.GetCertificateForService(namespacedService, tests.BookstoreServiceAccount)
 			Expect(err).ToNot(HaveOccurred())
 
 			actual := cert.GetCertificateChain()
@@ -45,13 +46,36 @@ var _ = Describe("Test certificate tooling", func() {
 			Name:      "service-name-here",
 		}
 		It("issues a PEM certificate with the correct CN", func() {
-			cert, err := mc.GetCertificateForService(namespacedService)
+			cert, err := mc.GetCertificateForService(namespacedService, tests.BookstoreServiceAccount)
 			Expect(err).ToNot(HaveOccurred())
 
-			cachedCert, err := mc.GetCertificateForService(namespacedService)
+			cachedCert, err := mc.GetCertificateForService(namespacedService, tests.BookstoreServiceAccount)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(cert).To(Equal(cachedCert))
 		})
 	})
+
+	Context("Testing the SPIFFE ID of the certificates issued by GetCertificateForService", func() {
+		It("issues a certificate carrying the SPIFFE ID of the given service account", func() {
+			cert, err := mc.GetCertificateForService(tests.BookstoreService, tests.BookstoreServiceAccount)
+			Expect(err).ToNot(HaveOccurred())
+
+			x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(x509Cert.URIs).To(HaveLen(1))
+			Expect(x509Cert.URIs[0].String()).To(Equal("spiffe://cluster.local/ns/default/sa/bookstore"))
+			Expect(certificate.GetSPIFFEID(cert)).To(Equal(mc.GetSPIFFEID(tests.BookstoreServiceAccount)))
+		})
+
+		It("issues a new certificate when the SPIFFE ID changes", func() {
+			cert, err := mc.GetCertificateForService(tests.BookstoreService, tests.BookstoreServiceAccount)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherCert, err := mc.GetCertificateForService(tests.BookstoreService, tests.BookbuyerServiceAccount)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherCert).ToNot(Equal(cert))
+			Expect(certificate.GetSPIFFEID(otherCert)).To(Equal(mc.GetSPIFFEID(tests.BookbuyerServiceAccount)))
+		})
+	})
 })
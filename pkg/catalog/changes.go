@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// getAffectedServices returns the services affected by an announcement.
+// allServices is true when the announcement may affect any service, in which case all proxies must be updated.
+func getAffectedServices(announcement announcements.Announcement) (services []service.MeshService, allServices bool) {
+	switch announcement.Type {
+	case announcements.EndpointsAdded, announcements.EndpointsUpdated, announcements.EndpointsDeleted,
+		announcements.ServiceUpdated:
+		// Endpoints are named after the service they belong to
+		return []service.MeshService{{Namespace: announcement.Namespace, Name: announcement.Name}}, false
+
+	case announcements.EndpointSliceAdded, announcements.EndpointSliceUpdated, announcements.EndpointSliceDeleted:
+		// Endpoint slices are labeled with the name of the service they belong to, in any API version
+		endpointSlice, err := meta.Accessor(announcement.Object)
+		if err != nil || endpointSlice.GetLabels()[discoveryv1beta1.LabelServiceName] == "" {
+			return nil, true
+		}
+		return []service.MeshService{{Namespace: endpointSlice.GetNamespace(), Name: endpointSlice.GetLabels()[discoveryv1beta1.LabelServiceName]}}, false
+
+	case announcements.TrafficSplitAdded, announcements.TrafficSplitUpdated, announcements.TrafficSplitDeleted:
+		trafficSplit, ok := announcement.Object.(*split.TrafficSplit)
+		if !ok {
+			return nil, true
+		}
+		services = append(services, service.MeshService{Namespace: trafficSplit.Namespace, Name: trafficSplit.Spec.Service})
+		for _, backend := range trafficSplit.Spec.Backends {
+			services = append(services, service.NewMeshService(trafficSplit.Namespace, backend.Service))
+		}
+		return services, false
+
+	default:
+		// Services which appear or disappear change which services the proxies may communicate with;
+		// traffic targets, routes, namespaces, the OSM ConfigMap, certificate rotations etc. may affect any service
+		return nil, true
+	}
+}
@@ -0,0 +1,221 @@
+package catalog
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// updateAtLeastEvery is the interval at which all proxies are updated, even when nothing changed
+	updateAtLeastEvery = 1 * time.Minute
+
+	// maxPendingChanges is the number of changes which can be queued while the dispatcher is busy notifying proxies
+	maxPendingChanges = 1024
+)
+
+// GetConfigGeneration returns the current generation of the catalog's configuration, which changes whenever the catalog changes.
+func (mc *MeshCatalog) GetConfigGeneration() uint64 {
+	return atomic.LoadUint64(&mc.configGeneration)
+}
+
+// receiveAnnouncements converts the announcements from SMI, Secrets, Endpoints providers etc. to mesh changes.
+func (mc *MeshCatalog) receiveAnnouncements(changes chan<- meshChange) {
+	cases, caseNames := mc.getCases()
+	for {
+		chosenIdx, message, ok := reflect.Select(cases)
+		if !ok {
+			// The channel was closed - stop selecting on it
+			log.Info().Msgf("[dispatcher] Announcement channel from %s closed", caseNames[chosenIdx])
+			cases[chosenIdx].Chan = reflect.ValueOf(nil)
+			continue
+		}
+
+		announcement, ok := message.Interface().(announcements.Announcement)
+		if !ok {
+			log.Error().Msgf("[dispatcher] Received unexpected message %T from %s", message.Interface(), caseNames[chosenIdx])
+			continue
+		}
+
+		log.Info().Msgf("[dispatcher] Received announcement %s for %s/%s from %s", announcement.Type, announcement.Namespace, announcement.Name, caseNames[chosenIdx])
+		atomic.AddUint64(&mc.configGeneration, 1)
+
+		services, allServices := getAffectedServices(announcement)
+		changes <- meshChange{
+			source:      caseNames[chosenIdx],
+			services:    services,
+			allServices: allServices,
+		}
+	}
+}
+
+// dispatcher coalesces the changes received into proxy updates.
+// Proxies are updated once no change was received for the configured minimum delay (trailing edge),
+// and no later than the configured maximum delay after the first change of a burst of changes.
+// Only the proxies affected by the changes are notified.
+func (mc *MeshCatalog) dispatcher(changes <-chan meshChange, stop <-chan struct{}) {
+	ticker := time.NewTicker(updateAtLeastEvery)
+	defer ticker.Stop()
+
+	var pending *pendingUpdate
+	var timer *time.Timer
+	var timerC <-chan time.Time
+
+	for {
+		select {
+		case <-stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case change := <-changes:
+			if pending == nil {
+				pending = newPendingUpdate()
+			}
+			pending.add(change)
+
+			// Wait for the minimum delay after this change, but no longer than the maximum delay since the first change
+			delay := mc.configurator.GetProxyUpdateMinDelay()
+			if untilDeadline := time.Until(pending.firstChangeAt.Add(mc.configurator.GetProxyUpdateMaxDelay())); untilDeadline < delay {
+				delay = untilDeadline
+			}
+			if delay < 0 {
+				delay = 0
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(delay)
+			timerC = timer.C
+
+		case <-timerC:
+			mc.notifyProxies(pending.toProxyUpdate())
+			pending = nil
+			timer = nil
+			timerC = nil
+
+		case <-ticker.C:
+			if pending != nil {
+				// An update is about to be sent anyway
+				continue
+			}
+			mc.notifyProxies(ProxyUpdate{Sources: []string{"Ticker"}})
+		}
+	}
+}
+
+// notifyProxies sends the update to the connected proxies affected by it.
+func (mc *MeshCatalog) notifyProxies(update ProxyUpdate) {
+	var affectedServices map[service.MeshService]interface{}
+	if len(update.Services) > 0 {
+		affectedServices = make(map[service.MeshService]interface{})
+		for _, svc := range update.Services {
+			affectedServices[svc] = nil
+		}
+	}
+
+	mc.connectedProxiesLock.Lock()
+	var proxies []*envoy.Proxy
+	for _, connectedEnvoy := range mc.connectedProxies {
+		proxies = append(proxies, connectedEnvoy.proxy)
+	}
+	mc.connectedProxiesLock.Unlock()
+
+	log.Info().Msgf("[dispatcher] Update from %v affecting services %v", update.Sources, update.Services)
+	for _, proxy := range proxies {
+		if affectedServices != nil && !mc.isProxyAffected(proxy, affectedServices) {
+			continue
+		}
+		log.Debug().Msgf("[dispatcher] Notify envoy %s", proxy.GetCommonName())
+		select {
+		// send the update if possible - do not block; when the channel is full an update is already pending
+		case proxy.GetAnnouncementsChannel() <- update:
+		default:
+		}
+	}
+}
+
+// isProxyAffected determines whether the config of the given proxy may depend on any of the given services:
+// the proxy fronts one of the services, or one of the services is allowed to communicate with it.
+// The multicluster gateway proxies the traffic to all the exported services, so it is affected by any change.
+func (mc *MeshCatalog) isProxyAffected(proxy *envoy.Proxy, affectedServices map[service.MeshService]interface{}) bool {
+	svcList, err := mc.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with CN=%q; notifying it", proxy.GetCommonName())
+		return true
+	}
+
+	for _, proxyService := range svcList {
+		if mc.IsMulticlusterGateway(proxyService) {
+			return true
+		}
+
+		if _, ok := affectedServices[proxyService]; ok {
+			return true
+		}
+
+		outbound, err := mc.ListAllowedOutboundServices(proxyService)
+		if err != nil {
+			return true
+		}
+		inbound, err := mc.ListAllowedInboundServices(proxyService)
+		if err != nil {
+			return true
+		}
+		for _, svc := range append(outbound, inbound...) {
+			if _, ok := affectedServices[svc]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (mc *MeshCatalog) getCases() ([]reflect.SelectCase, []string) {
+	var caseNames []string
+	var cases []reflect.SelectCase
+	for _, channelInterface := range mc.announcementChannels.ToSlice() {
+		annCh := channelInterface.(announcementChannel)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(annCh.channel)})
+		caseNames = append(caseNames, annCh.announcer)
+	}
+	return cases, caseNames
+}
+
+func newPendingUpdate() *pendingUpdate {
+	return &pendingUpdate{
+		firstChangeAt: time.Now(),
+		sources:       make(map[string]interface{}),
+		services:      make(map[service.MeshService]interface{}),
+	}
+}
+
+func (p *pendingUpdate) add(change meshChange) {
+	p.sources[change.source] = nil
+	if change.allServices {
+		p.allServices = true
+	}
+	for _, svc := range change.services {
+		p.services[svc] = nil
+	}
+}
+
+func (p *pendingUpdate) toProxyUpdate() ProxyUpdate {
+	var update ProxyUpdate
+	for source := range p.sources {
+		update.Sources = append(update.Sources, source)
+	}
+	if !p.allServices {
+		for svc := range p.services {
+			update.Services = append(update.Services, svc)
+		}
+	}
+	return update
+}
@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test catalog dispatcher", func() {
+	newCatalog := func(minDelay, maxDelay time.Duration) *MeshCatalog {
+		return &MeshCatalog{
+			configurator: configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				ProxyUpdateMinDelay: minDelay,
+				ProxyUpdateMaxDelay: maxDelay,
+			}),
+			connectedProxies:    make(map[certificate.CommonName]connectedProxy),
+			disconnectedProxies: make(map[certificate.CommonName]disconnectedProxy),
+		}
+	}
+
+	Context("Test dispatcher()", func() {
+		It("coalesces a burst of changes into a single proxy update", func() {
+			mc := newCatalog(100*time.Millisecond, 5*time.Second)
+			proxy := envoy.NewProxy(certificate.CommonName("proxy.sa.ns"), nil)
+			mc.RegisterProxy(proxy)
+
+			changes := make(chan meshChange)
+			stop := make(chan struct{})
+			defer close(stop)
+			go mc.dispatcher(changes, stop)
+
+			changes <- meshChange{source: "Endpoints", allServices: true}
+			changes <- meshChange{source: "Services", allServices: true}
+			changes <- meshChange{source: "Endpoints", allServices: true}
+
+			var update interface{}
+			Eventually(proxy.GetAnnouncementsChannel(), 2*time.Second).Should(Receive(&update))
+			Expect(update.(ProxyUpdate).Sources).To(ConsistOf("Endpoints", "Services"))
+			Expect(update.(ProxyUpdate).Services).To(BeEmpty())
+			Consistently(proxy.GetAnnouncementsChannel(), 300*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("updates the proxies no later than the maximum delay during a continuous burst of changes", func() {
+			mc := newCatalog(200*time.Millisecond, 300*time.Millisecond)
+			proxy := envoy.NewProxy(certificate.CommonName("proxy.sa.ns"), nil)
+			mc.RegisterProxy(proxy)
+
+			changes := make(chan meshChange)
+			stop := make(chan struct{})
+			defer close(stop)
+			go mc.dispatcher(changes, stop)
+
+			start := time.Now()
+			received := false
+			for time.Since(start) < 1*time.Second && !received {
+				changes <- meshChange{source: "Endpoints", allServices: true}
+				select {
+				case <-proxy.GetAnnouncementsChannel():
+					received = true
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+			Expect(received).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 1*time.Second))
+		})
+	})
+
+	Context("Test notifyProxies()", func() {
+		It("notifies the proxies without queueing duplicate updates", func() {
+			mc := newCatalog(0, 0)
+			affected := envoy.NewProxy(certificate.CommonName("affected.sa.ns"), nil)
+			mc.RegisterProxy(affected)
+
+			// Updates affecting all services notify every proxy
+			mc.notifyProxies(ProxyUpdate{Sources: []string{"Ticker"}})
+			Expect(affected.GetAnnouncementsChannel()).To(Receive())
+
+			// A pending update is not duplicated
+			mc.notifyProxies(ProxyUpdate{Sources: []string{"Ticker"}})
+			mc.notifyProxies(ProxyUpdate{Sources: []string{"Ticker"}})
+			Expect(affected.GetAnnouncementsChannel()).To(Receive())
+			Expect(affected.GetAnnouncementsChannel()).ToNot(Receive())
+		})
+	})
+
+	Context("Test getAffectedServices()", func() {
+		It("returns the service of the endpoints which changed", func() {
+			services, allServices := getAffectedServices(announcements.Announcement{
+				Type:      announcements.EndpointsUpdated,
+				Namespace: tests.Namespace,
+				Name:      tests.BookstoreServiceName,
+			})
+			Expect(allServices).To(BeFalse())
+			Expect(services).To(Equal([]service.MeshService{tests.BookstoreService}))
+		})
+
+		It("returns the service of the endpoint slice which changed", func() {
+			endpointSlice := &unstructured.Unstructured{}
+			endpointSlice.SetAPIVersion("discovery.k8s.io/v1")
+			endpointSlice.SetKind("EndpointSlice")
+			endpointSlice.SetNamespace(tests.Namespace)
+			endpointSlice.SetName(tests.BookstoreServiceName + "-abcde")
+			endpointSlice.SetLabels(map[string]string{discoveryv1beta1.LabelServiceName: tests.BookstoreServiceName})
+
+			services, allServices := getAffectedServices(announcements.Announcement{
+				Type:      announcements.EndpointSliceUpdated,
+				Namespace: endpointSlice.GetNamespace(),
+				Name:      endpointSlice.GetName(),
+				Object:    endpointSlice,
+			})
+			Expect(allServices).To(BeFalse())
+			Expect(services).To(Equal([]service.MeshService{tests.BookstoreService}))
+		})
+
+		It("returns all services when a service is added", func() {
+			_, allServices := getAffectedServices(announcements.Announcement{
+				Type:      announcements.ServiceAdded,
+				Namespace: tests.Namespace,
+				Name:      tests.BookstoreServiceName,
+				Object:    tests.NewServiceFixture(tests.BookstoreServiceName, tests.Namespace, nil),
+			})
+			Expect(allServices).To(BeTrue())
+		})
+
+		It("returns the root service and the backends of a traffic split", func() {
+			services, allServices := getAffectedServices(announcements.Announcement{
+				Type:      announcements.TrafficSplitUpdated,
+				Namespace: tests.TrafficSplit.Namespace,
+				Name:      tests.TrafficSplit.Name,
+				Object:    &tests.TrafficSplit,
+			})
+			Expect(allServices).To(BeFalse())
+			Expect(services).To(ContainElement(service.MeshService{Namespace: tests.Namespace, Name: tests.TrafficSplit.Spec.Service}))
+			Expect(services).To(HaveLen(len(tests.TrafficSplit.Spec.Backends) + 1))
+		})
+
+		It("returns all services for announcements not tied to specific services", func() {
+			_, allServices := getAffectedServices(announcements.Announcement{Type: announcements.CertificateRotated})
+			Expect(allServices).To(BeTrue())
+			_, allServices = getAffectedServices(announcements.Announcement{Type: announcements.TrafficTargetDeleted})
+			Expect(allServices).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"net"
+	"sort"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// ListEgressTrafficPolicies returns the egress traffic policies, built from Egress policies, for the given service account.
+// There is one policy per destination port, merging the destinations allowed on this port by all the Egress policies.
+func (mc *MeshCatalog) ListEgressTrafficPolicies(serviceAccount service.K8sServiceAccount) []trafficpolicy.EgressTrafficPolicy {
+	type destinations struct {
+		httpHosts map[string]interface{}
+		tlsHosts  map[string]interface{}
+		ipRanges  map[string]interface{}
+	}
+	destinationsPerPort := make(map[uint32]*destinations)
+
+	for _, egress := range mc.meshSpec.ListEgressPolicies(serviceAccount) {
+		var ipRanges []string
+		for _, ipAddress := range egress.Spec.IPAddresses {
+			ipRanges = append(ipRanges, getIPRange(ipAddress))
+		}
+
+		for _, port := range egress.Spec.Ports {
+			dst, found := destinationsPerPort[port.Number]
+			if !found {
+				dst = &destinations{
+					httpHosts: make(map[string]interface{}),
+					tlsHosts:  make(map[string]interface{}),
+					ipRanges:  make(map[string]interface{}),
+				}
+				destinationsPerPort[port.Number] = dst
+			}
+
+			// The IP addresses are matched on the destination address of the traffic, whatever its protocol
+			for _, ipRange := range ipRanges {
+				dst.ipRanges[ipRange] = nil
+			}
+
+			switch port.Protocol {
+			case osmPolicy.EgressProtocolHTTP:
+				for _, host := range egress.Spec.Hosts {
+					dst.httpHosts[host] = nil
+				}
+			case osmPolicy.EgressProtocolHTTPS:
+				for _, host := range egress.Spec.Hosts {
+					dst.tlsHosts[host] = nil
+				}
+			}
+		}
+	}
+
+	var policies []trafficpolicy.EgressTrafficPolicy
+	for port, dst := range destinationsPerPort {
+		policies = append(policies, trafficpolicy.EgressTrafficPolicy{
+			Port:      port,
+			HTTPHosts: sortedKeys(dst.httpHosts),
+			TLSHosts:  sortedKeys(dst.tlsHosts),
+			IPRanges:  sortedKeys(dst.ipRanges),
+		})
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Port < policies[j].Port })
+
+	log.Debug().Msgf("Constructed egress traffic policies for service account %s: %+v", serviceAccount, policies)
+	return policies
+}
+
+// getIPRange returns the CIDR range of the given IP address or CIDR range, validated by the SMI client
+func getIPRange(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ipAddress
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
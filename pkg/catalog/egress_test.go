@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Egress traffic policies", func() {
+	mc := newFakeMeshCatalog()
+
+	Context("Test ListEgressTrafficPolicies", func() {
+		It("lists the egress traffic policies of the source service account, one per port", func() {
+			actual := mc.ListEgressTrafficPolicies(tests.BookbuyerServiceAccount)
+
+			expected := []trafficpolicy.EgressTrafficPolicy{
+				{
+					Port:      80,
+					HTTPHosts: []string{"*.github.com", "httpbin.org"},
+					IPRanges:  []string{"10.10.0.0/16"},
+				},
+				{
+					Port:     443,
+					TLSHosts: []string{"*.github.com", "httpbin.org"},
+					IPRanges: []string{"10.10.0.0/16"},
+				},
+			}
+			Expect(actual).To(Equal(expected))
+		})
+
+		It("does not list egress traffic policies for the service accounts without Egress policy", func() {
+			Expect(mc.ListEgressTrafficPolicies(tests.BookstoreServiceAccount)).To(BeEmpty())
+		})
+	})
+
+	Context("Test getIPRange", func() {
+		It("returns the CIDR range of an IP address", func() {
+			Expect(getIPRange("10.0.0.1")).To(Equal("10.0.0.1/32"))
+			Expect(getIPRange("2001:db8::1")).To(Equal("2001:db8::1/128"))
+			Expect(getIPRange("10.10.0.0/16")).To(Equal("10.10.0.0/16"))
+		})
+	})
+})
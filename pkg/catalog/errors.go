@@ -11,4 +11,6 @@ var (
 	errNamespaceDoesNotMatchCertificate      = errors.New("namespace does not match certificate")
 	errServiceNotFoundForAnyProvider         = errors.New("no service found for service account with any of the mesh supported providers")
 	errNoTrafficSpecFoundForTrafficPolicy    = errors.New("no traffic spec found for the traffic policy")
+	errInvalidCertificateSubject             = errors.New("exactly one of proxy, service or service account must be selected")
+	errServiceNotFound                       = errors.New("service not found")
 )
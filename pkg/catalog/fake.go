@@ -7,11 +7,13 @@ import (
 	"github.com/onsi/ginkgo"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -28,7 +30,7 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface) *MeshCatalog {
 	mockCtrl = gomock.NewController(ginkgo.GinkgoT())
 	mockNsController = namespace.NewMockController(mockCtrl)
 	meshSpec := smi.NewFakeMeshSpecClient()
-	cache := make(map[certificate.CommonName]certificate.Certificater)
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
 	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 	ingressMonitor := ingress.NewFakeIngressMonitor()
 	stop := make(<-chan struct{})
@@ -40,7 +42,7 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface) *MeshCatalog {
 	osmConfigMapName := "-test-osm-config-map-"
 	cfg := configurator.NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
 
-	testChan := make(chan interface{})
+	testChan := make(chan announcements.Announcement)
 
 	mockNsController.EXPECT().IsMonitoredNamespace(tests.BookstoreService.Namespace).Return(true).AnyTimes()
 	mockNsController.EXPECT().IsMonitoredNamespace(tests.BookbuyerService.Namespace).Return(true).AnyTimes()
@@ -48,5 +50,5 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface) *MeshCatalog {
 	mockNsController.EXPECT().GetAnnouncementsChannel().Return(testChan).AnyTimes()
 
 	return NewMeshCatalog(mockNsController, kubeClient, meshSpec, certManager,
-		ingressMonitor, stop, cfg, endpointProviders...)
+		ingressMonitor, gateway.NewFakeGatewayMonitor(), stop, cfg, endpointProviders...)
 }
@@ -0,0 +1,206 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gateway"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetGatewayHTTPRoutesPerHost returns the routes per host of the Gateway API HTTPRoute resources
+// for the requests routed by the gateways to the given port of the service.
+func (mc *MeshCatalog) GetGatewayHTTPRoutesPerHost(svc service.MeshService, port service.ServicePort) (map[string][]trafficpolicy.GatewayHTTPRoute, error) {
+	routesPerHost := make(map[string][]trafficpolicy.GatewayHTTPRoute)
+	httpRoutes, err := mc.gatewayMonitor.GetHTTPRoutes(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get Gateway API HTTPRoute resources with backend %s", svc)
+		return routesPerHost, err
+	}
+
+	for _, httpRoute := range httpRoutes {
+		hosts := httpRoute.Spec.Hostnames
+		if len(hosts) == 0 {
+			hosts = []string{constants.WildcardHTTPMethod}
+		}
+
+		for ruleIdx, rule := range httpRoute.Spec.Rules {
+			backendRef := getHTTPBackendRef(rule, httpRoute.Namespace, svc, port)
+			if backendRef == nil || backendRef.GetWeight() == 0 {
+				// The gateways route none of the requests matched by the rule to this port of the service
+				continue
+			}
+
+			// The filters of the backend apply to the requests routed to the backend, in addition to the filters of the rule
+			filters := append(append([]gateway.HTTPRouteFilter{}, rule.Filters...), backendRef.Filters...)
+			requestHeaders, responseHeaders := getHeaderModifiers(filters)
+
+			matches := rule.Matches
+			if len(matches) == 0 {
+				// A rule without matches matches all the requests
+				matches = []gateway.HTTPRouteMatch{{}}
+			}
+			for matchIdx, match := range matches {
+				route := trafficpolicy.GatewayHTTPRoute{
+					Name:            fmt.Sprintf("%s/%s/%d/%d", httpRoute.Namespace, httpRoute.Name, ruleIdx, matchIdx),
+					HTTPRoute:       getGatewayHTTPRouteMatch(match),
+					RequestHeaders:  requestHeaders,
+					ResponseHeaders: responseHeaders,
+				}
+				for _, host := range hosts {
+					routesPerHost[host] = append(routesPerHost[host], route)
+				}
+			}
+		}
+	}
+
+	log.Trace().Msgf("Created Gateway API routes per host for service %s port %d: %+v", svc, port.Port, routesPerHost)
+
+	return routesPerHost, nil
+}
+
+// ListGatewayTLSHostnames returns the SNI hostnames of the Gateway API TLSRoute resources for the TLS connections
+// routed by the gateways to the given port of the service. The wildcard hostname * matches any SNI hostname.
+func (mc *MeshCatalog) ListGatewayTLSHostnames(svc service.MeshService, port service.ServicePort) ([]string, error) {
+	tlsRoutes, err := mc.gatewayMonitor.GetTLSRoutes(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get Gateway API TLSRoute resources with backend %s", svc)
+		return nil, err
+	}
+
+	hostnames := make(map[string]interface{})
+	for _, tlsRoute := range tlsRoutes {
+		if !isTLSRouteBackend(tlsRoute, svc, port) {
+			continue
+		}
+		if len(tlsRoute.Spec.Hostnames) == 0 {
+			hostnames[constants.WildcardHTTPMethod] = nil
+		}
+		for _, hostname := range tlsRoute.Spec.Hostnames {
+			hostnames[hostname] = nil
+		}
+	}
+
+	var tlsHostnames []string
+	for hostname := range hostnames {
+		tlsHostnames = append(tlsHostnames, hostname)
+	}
+	sort.Strings(tlsHostnames)
+	return tlsHostnames, nil
+}
+
+// getHTTPBackendRef returns the backend of the rule corresponding to the given port of the service, nil if none
+func getHTTPBackendRef(rule gateway.HTTPRouteRule, routeNamespace string, svc service.MeshService, port service.ServicePort) *gateway.HTTPBackendRef {
+	for i := range rule.BackendRefs {
+		if rule.BackendRefs[i].IsServicePortBackend(routeNamespace, svc, port) {
+			return &rule.BackendRefs[i]
+		}
+	}
+	return nil
+}
+
+// isTLSRouteBackend returns true if the TLSRoute routes connections to the given port of the service
+func isTLSRouteBackend(tlsRoute *gateway.TLSRoute, svc service.MeshService, port service.ServicePort) bool {
+	for _, rule := range tlsRoute.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			if backendRef.IsServicePortBackend(tlsRoute.Namespace, svc, port) && backendRef.GetWeight() != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getGatewayHTTPRouteMatch returns the route matching the requests matched by the HTTPRoute match
+func getGatewayHTTPRouteMatch(match gateway.HTTPRouteMatch) trafficpolicy.HTTPRoute {
+	route := trafficpolicy.HTTPRoute{
+		PathRegex: constants.RegexMatchAll,
+		Methods:   []string{constants.WildcardHTTPMethod},
+	}
+
+	if match.Path != nil && match.Path.Value != nil {
+		pathType := gateway.PathMatchPathPrefix
+		if match.Path.Type != nil {
+			pathType = *match.Path.Type
+		}
+		switch pathType {
+		case gateway.PathMatchExact:
+			route.PathRegex = getExactPathRegex(*match.Path.Value)
+		case gateway.PathMatchRegularExpression:
+			route.PathRegex = *match.Path.Value
+		default:
+			route.PathRegex = getPrefixPathRegex(*match.Path.Value)
+		}
+	}
+
+	if match.Method != nil {
+		route.Methods = []string{*match.Method}
+	}
+
+	for _, header := range match.Headers {
+		if route.Headers == nil {
+			route.Headers = make(map[string]string)
+		}
+		route.Headers[header.Name] = getValueRegex(header.Type, header.Value)
+	}
+
+	for _, queryParam := range match.QueryParams {
+		if route.QueryParams == nil {
+			route.QueryParams = make(map[string]string)
+		}
+		route.QueryParams[queryParam.Name] = getValueRegex(queryParam.Type, queryParam.Value)
+	}
+
+	return route
+}
+
+// getValueRegex returns the regex matching a header or query parameter value; values are matched exactly by default
+func getValueRegex(matchType *string, value string) string {
+	if matchType != nil && *matchType == gateway.HeaderMatchRegularExpression {
+		return value
+	}
+	return regexp.QuoteMeta(value)
+}
+
+// getHeaderModifiers returns the modifications of the request and response headers made by the given filters
+func getHeaderModifiers(filters []gateway.HTTPRouteFilter) (*trafficpolicy.HeaderModifier, *trafficpolicy.HeaderModifier) {
+	var requestHeaders, responseHeaders *trafficpolicy.HeaderModifier
+	for _, filter := range filters {
+		switch filter.Type {
+		case gateway.FilterRequestHeaderModifier:
+			requestHeaders = addHeaderModifications(requestHeaders, filter.RequestHeaderModifier)
+		case gateway.FilterResponseHeaderModifier:
+			responseHeaders = addHeaderModifications(responseHeaders, filter.ResponseHeaderModifier)
+		default:
+			log.Debug().Msgf("Gateway API HTTPRoute filter %s is not applied by the backend proxies", filter.Type)
+		}
+	}
+	return requestHeaders, responseHeaders
+}
+
+// addHeaderModifications adds the modifications of the given header filter to the given header modifier
+func addHeaderModifications(headerModifier *trafficpolicy.HeaderModifier, filter *gateway.HTTPHeaderFilter) *trafficpolicy.HeaderModifier {
+	if filter == nil {
+		return headerModifier
+	}
+	if headerModifier == nil {
+		headerModifier = &trafficpolicy.HeaderModifier{}
+	}
+	for _, header := range filter.Set {
+		if headerModifier.Set == nil {
+			headerModifier.Set = make(map[string]string)
+		}
+		headerModifier.Set[header.Name] = header.Value
+	}
+	for _, header := range filter.Add {
+		if headerModifier.Add == nil {
+			headerModifier.Add = make(map[string]string)
+		}
+		headerModifier.Add[header.Name] = header.Value
+	}
+	headerModifier.Remove = append(headerModifier.Remove, filter.Remove...)
+	return headerModifier
+}
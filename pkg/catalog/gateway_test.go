@@ -0,0 +1,174 @@
+package catalog
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gateway"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Test Gateway API routes", func() {
+	svc := tests.BookstoreService
+	servicePort := service.ServicePort{Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+
+	stringPtr := func(s string) *string { return &s }
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	newBackendRef := func(name string, port int32, weight *int32) gateway.HTTPBackendRef {
+		return gateway.HTTPBackendRef{BackendRef: gateway.BackendRef{Name: name, Port: int32Ptr(port), Weight: weight}}
+	}
+
+	Context("Test GetGatewayHTTPRoutesPerHost()", func() {
+		It("returns the routes of the rules routing to the service port", func() {
+			gatewayMonitor := gateway.NewFakeGatewayMonitor()
+			gatewayMonitor.FakeHTTPRoutes = []*gateway.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: svc.Namespace, Name: "bookstore"},
+					Spec: gateway.HTTPRouteSpec{
+						Hostnames: []string{"bookstore.example.com"},
+						Rules: []gateway.HTTPRouteRule{
+							{
+								Matches: []gateway.HTTPRouteMatch{
+									{
+										Path:   &gateway.HTTPPathMatch{Type: stringPtr(gateway.PathMatchExact), Value: stringPtr("/books")},
+										Method: stringPtr("GET"),
+									},
+								},
+								Filters: []gateway.HTTPRouteFilter{
+									{
+										Type:                  gateway.FilterRequestHeaderModifier,
+										RequestHeaderModifier: &gateway.HTTPHeaderFilter{Set: []gateway.HTTPHeader{{Name: "x-rule", Value: "books"}}},
+									},
+								},
+								BackendRefs: []gateway.HTTPBackendRef{newBackendRef(svc.Name, 80, nil)},
+							},
+							{
+								// The rule routes to another port of the service
+								BackendRefs: []gateway.HTTPBackendRef{newBackendRef(svc.Name, 9090, nil)},
+							},
+							{
+								// The rule routes no requests to the service
+								BackendRefs: []gateway.HTTPBackendRef{newBackendRef(svc.Name, 80, int32Ptr(0)), newBackendRef("bookstore-v2", 80, nil)},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: svc.Namespace, Name: "catch-all"},
+					Spec: gateway.HTTPRouteSpec{
+						Rules: []gateway.HTTPRouteRule{
+							{
+								BackendRefs: []gateway.HTTPBackendRef{newBackendRef(svc.Name, 80, nil)},
+							},
+						},
+					},
+				},
+			}
+			mc := MeshCatalog{gatewayMonitor: gatewayMonitor}
+
+			routesPerHost, err := mc.GetGatewayHTTPRoutesPerHost(svc, servicePort)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(routesPerHost).To(Equal(map[string][]trafficpolicy.GatewayHTTPRoute{
+				"bookstore.example.com": {
+					{
+						Name: svc.Namespace + "/bookstore/0/0",
+						HTTPRoute: trafficpolicy.HTTPRoute{
+							PathRegex: "/books",
+							Methods:   []string{"GET"},
+						},
+						RequestHeaders: &trafficpolicy.HeaderModifier{Set: map[string]string{"x-rule": "books"}},
+					},
+				},
+				constants.WildcardHTTPMethod: {
+					{
+						Name: svc.Namespace + "/catch-all/0/0",
+						HTTPRoute: trafficpolicy.HTTPRoute{
+							PathRegex: constants.RegexMatchAll,
+							Methods:   []string{constants.WildcardHTTPMethod},
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("Test ListGatewayTLSHostnames()", func() {
+		It("returns the hostnames of the TLSRoute resources routing to the service port", func() {
+			gatewayMonitor := gateway.NewFakeGatewayMonitor()
+			gatewayMonitor.FakeTLSRoutes = []*gateway.TLSRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: svc.Namespace, Name: "bookstore"},
+					Spec: gateway.TLSRouteSpec{
+						Hostnames: []string{"b.example.com", "a.example.com"},
+						Rules:     []gateway.TLSRouteRule{{BackendRefs: []gateway.BackendRef{{Name: svc.Name, Port: int32Ptr(80)}}}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: svc.Namespace, Name: "other-port"},
+					Spec: gateway.TLSRouteSpec{
+						Hostnames: []string{"c.example.com"},
+						Rules:     []gateway.TLSRouteRule{{BackendRefs: []gateway.BackendRef{{Name: svc.Name, Port: int32Ptr(443)}}}},
+					},
+				},
+			}
+			mc := MeshCatalog{gatewayMonitor: gatewayMonitor}
+
+			hostnames, err := mc.ListGatewayTLSHostnames(svc, servicePort)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hostnames).To(Equal([]string{"a.example.com", "b.example.com"}))
+		})
+	})
+
+	Context("Test getGatewayHTTPRouteMatch()", func() {
+		It("matches the paths by prefix by default", func() {
+			route := getGatewayHTTPRouteMatch(gateway.HTTPRouteMatch{Path: &gateway.HTTPPathMatch{Value: stringPtr("/books")}})
+			Expect(regexp.MustCompile("^" + route.PathRegex + "$").MatchString("/books/bought")).To(BeTrue())
+			Expect(regexp.MustCompile("^" + route.PathRegex + "$").MatchString("/booksbought")).To(BeFalse())
+		})
+
+		It("matches the headers and query parameters exactly by default", func() {
+			route := getGatewayHTTPRouteMatch(gateway.HTTPRouteMatch{
+				Headers: []gateway.HTTPHeaderMatch{
+					{Name: "x-version", Value: "v1.0"},
+					{Name: "x-user", Value: "user-[0-9]+", Type: stringPtr(gateway.HeaderMatchRegularExpression)},
+				},
+				QueryParams: []gateway.HTTPQueryParamMatch{{Name: "page", Value: "1"}},
+			})
+			Expect(route.PathRegex).To(Equal(constants.RegexMatchAll))
+			Expect(route.Headers).To(Equal(map[string]string{"x-version": `v1\.0`, "x-user": "user-[0-9]+"}))
+			Expect(route.QueryParams).To(Equal(map[string]string{"page": "1"}))
+		})
+	})
+
+	Context("Test getHeaderModifiers()", func() {
+		It("combines the header modifications of the filters", func() {
+			requestHeaders, responseHeaders := getHeaderModifiers([]gateway.HTTPRouteFilter{
+				{
+					Type:                  gateway.FilterRequestHeaderModifier,
+					RequestHeaderModifier: &gateway.HTTPHeaderFilter{Add: []gateway.HTTPHeader{{Name: "x-a", Value: "a"}}, Remove: []string{"x-b"}},
+				},
+				{
+					Type:                  gateway.FilterRequestHeaderModifier,
+					RequestHeaderModifier: &gateway.HTTPHeaderFilter{Set: []gateway.HTTPHeader{{Name: "x-c", Value: "c"}}},
+				},
+				{
+					Type: "RequestRedirect",
+				},
+			})
+			Expect(requestHeaders).To(Equal(&trafficpolicy.HeaderModifier{
+				Set:    map[string]string{"x-c": "c"},
+				Add:    map[string]string{"x-a": "a"},
+				Remove: []string{"x-b"},
+			}))
+			Expect(responseHeaders).To(BeNil())
+		})
+	})
+})
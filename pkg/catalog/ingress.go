@@ -1,13 +1,20 @@
 package catalog
 
 import (
+	"regexp"
+	"strings"
+
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-// GetIngressRoutesPerHost returns routes per host as defined in observed ingress k8s resources.
-func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map[string][]trafficpolicy.HTTPRoute, error) {
+// GetIngressRoutesPerHost returns routes per host as defined in observed ingress k8s resources
+// for the ingress backends corresponding to the given port of the service.
+func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService, port service.ServicePort) (map[string][]trafficpolicy.HTTPRoute, error) {
 	domainRoutesMap := make(map[string][]trafficpolicy.HTTPRoute)
 	ingresses, err := mc.ingressMonitor.GetIngressResources(service)
 	if err != nil {
@@ -24,29 +31,83 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 	}
 
 	for _, ingress := range ingresses {
-		if ingress.Spec.Backend != nil && ingress.Spec.Backend.ServiceName == service.Name {
+		if ingress.Spec.Backend != nil && isIngressBackend(*ingress.Spec.Backend, service, port) {
 			domainRoutesMap[constants.WildcardHTTPMethod] = []trafficpolicy.HTTPRoute{defaultRoute}
 		}
 
 		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
 			domain := rule.Host
 			if domain == "" {
 				domain = constants.WildcardHTTPMethod
 			}
 			for _, ingressPath := range rule.HTTP.Paths {
-				if ingressPath.Backend.ServiceName != service.Name {
+				if !isIngressBackend(ingressPath.Backend, service, port) {
 					continue
 				}
 				routePolicy := defaultRoute
-				if routePolicy.PathRegex != "" {
-					routePolicy.PathRegex = ingressPath.Path
-				}
+				routePolicy.PathRegex = getIngressPathRegex(ingressPath)
 				domainRoutesMap[domain] = append(domainRoutesMap[domain], routePolicy)
 			}
 		}
 	}
 
-	log.Trace().Msgf("Created routes per host for service %s: %+v", service, domainRoutesMap)
+	log.Trace().Msgf("Created routes per host for service %s port %d: %+v", service, port.Port, domainRoutesMap)
 
 	return domainRoutesMap, nil
 }
+
+// isIngressBackend returns true if the ingress backend corresponds to the given port of the service.
+// The port of the backend is either the number or the name of the service port.
+func isIngressBackend(backend networkingV1beta1.IngressBackend, svc service.MeshService, port service.ServicePort) bool {
+	if backend.ServiceName != svc.Name {
+		return false
+	}
+	switch backend.ServicePort.Type {
+	case intstr.Int:
+		// A backend without a port corresponds to every port of the service
+		return backend.ServicePort.IntVal == 0 || uint32(backend.ServicePort.IntVal) == port.Port
+	case intstr.String:
+		return backend.ServicePort.StrVal == "" || backend.ServicePort.StrVal == port.Name
+	}
+	return false
+}
+
+// getIngressPathRegex returns the regex matching the request paths of the ingress path according to its path type.
+// The path of an ingress path without a path type, or with the ImplementationSpecific path type, is a regex.
+func getIngressPathRegex(ingressPath networkingV1beta1.HTTPIngressPath) string {
+	if ingressPath.Path == "" {
+		return constants.RegexMatchAll
+	}
+	if ingressPath.PathType == nil {
+		return ingressPath.Path
+	}
+
+	switch *ingressPath.PathType {
+	case networkingV1beta1.PathTypeExact:
+		return getExactPathRegex(ingressPath.Path)
+
+	case networkingV1beta1.PathTypePrefix:
+		return getPrefixPathRegex(ingressPath.Path)
+
+	default:
+		return ingressPath.Path
+	}
+}
+
+// getExactPathRegex returns the regex matching the given path exactly
+func getExactPathRegex(path string) string {
+	return regexp.QuoteMeta(path)
+}
+
+// getPrefixPathRegex returns the regex matching the request paths with the given prefix element by element:
+// the prefix /foo matches /foo and /foo/bar, but not /foobar
+func getPrefixPathRegex(prefix string) string {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix == "" {
+		return constants.RegexMatchAll
+	}
+	return regexp.QuoteMeta(prefix) + "(/.*)?"
+}
@@ -2,23 +2,26 @@ package catalog
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
-	extensionsV1beta "k8s.io/api/extensions/v1beta1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -48,7 +51,7 @@ func newFakeMeshCatalog() *MeshCatalog {
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockNsController = namespace.NewMockController(mockCtrl)
 	meshSpec := smi.NewFakeMeshSpecClient()
-	cache := make(map[certificate.CommonName]certificate.Certificater)
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
 	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 	ingressMonitor := ingress.NewFakeIngressMonitor()
 	ingressMonitor.FakeIngresses = getFakeIngresses()
@@ -74,7 +77,7 @@ func newFakeMeshCatalog() *MeshCatalog {
 	osmConfigMapName := "-test-osm-config-map-"
 	cfg := configurator.NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
 
-	testChan := make(chan interface{})
+	testChan := make(chan announcements.Announcement)
 	monitoredNamespace := []string{
 		tests.BookstoreService.Namespace,
 		tests.BookbuyerService.Namespace,
@@ -88,11 +91,11 @@ func newFakeMeshCatalog() *MeshCatalog {
 	mockNsController.EXPECT().ListMonitoredNamespaces().Return(monitoredNamespace, nil).AnyTimes()
 
 	return NewMeshCatalog(mockNsController, kubeClient, meshSpec, certManager,
-		ingressMonitor, stop, cfg, endpointProviders...)
+		ingressMonitor, gateway.NewFakeGatewayMonitor(), stop, cfg, endpointProviders...)
 }
 
-func getFakeIngresses() []*extensionsV1beta.Ingress {
-	return []*extensionsV1beta.Ingress{
+func getFakeIngresses() []*networkingV1beta1.Ingress {
+	return []*networkingV1beta1.Ingress{
 		{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "ingress-1",
@@ -101,23 +104,23 @@ func getFakeIngresses() []*extensionsV1beta.Ingress {
 					constants.OSMKubeResourceMonitorAnnotation: "enabled",
 				},
 			},
-			Spec: extensionsV1beta.IngressSpec{
-				Backend: &extensionsV1beta.IngressBackend{
+			Spec: networkingV1beta1.IngressSpec{
+				Backend: &networkingV1beta1.IngressBackend{
 					ServiceName: fakeIngressService,
 					ServicePort: intstr.IntOrString{
 						Type:   intstr.Int,
 						IntVal: fakeIngressPort,
 					},
 				},
-				Rules: []extensionsV1beta.IngressRule{
+				Rules: []networkingV1beta1.IngressRule{
 					{
 						Host: "fake1.com",
-						IngressRuleValue: extensionsV1beta.IngressRuleValue{
-							HTTP: &extensionsV1beta.HTTPIngressRuleValue{
-								Paths: []extensionsV1beta.HTTPIngressPath{
+						IngressRuleValue: networkingV1beta1.IngressRuleValue{
+							HTTP: &networkingV1beta1.HTTPIngressRuleValue{
+								Paths: []networkingV1beta1.HTTPIngressPath{
 									{
 										Path: "/fake1-path1",
-										Backend: extensionsV1beta.IngressBackend{
+										Backend: networkingV1beta1.IngressBackend{
 											ServiceName: fakeIngressService,
 											ServicePort: intstr.IntOrString{
 												Type:   intstr.Int,
@@ -127,7 +130,7 @@ func getFakeIngresses() []*extensionsV1beta.Ingress {
 									},
 									{
 										Path: "/fake1-path2",
-										Backend: extensionsV1beta.IngressBackend{
+										Backend: networkingV1beta1.IngressBackend{
 											ServiceName: fakeIngressService,
 											ServicePort: intstr.IntOrString{
 												Type:   intstr.Int,
@@ -151,16 +154,16 @@ func getFakeIngresses() []*extensionsV1beta.Ingress {
 					constants.OSMKubeResourceMonitorAnnotation: "enabled",
 				},
 			},
-			Spec: extensionsV1beta.IngressSpec{
-				Rules: []extensionsV1beta.IngressRule{
+			Spec: networkingV1beta1.IngressSpec{
+				Rules: []networkingV1beta1.IngressRule{
 					{
 						Host: "fake2.com",
-						IngressRuleValue: extensionsV1beta.IngressRuleValue{
-							HTTP: &extensionsV1beta.HTTPIngressRuleValue{
-								Paths: []extensionsV1beta.HTTPIngressPath{
+						IngressRuleValue: networkingV1beta1.IngressRuleValue{
+							HTTP: &networkingV1beta1.HTTPIngressRuleValue{
+								Paths: []networkingV1beta1.HTTPIngressPath{
 									{
 										Path: "/fake2-path1",
-										Backend: extensionsV1beta.IngressBackend{
+										Backend: networkingV1beta1.IngressBackend{
 											ServiceName: fakeIngressService,
 											ServicePort: intstr.IntOrString{
 												Type:   intstr.Int,
@@ -195,7 +198,7 @@ var _ = Describe("Test ingress route policies", func() {
 				Namespace: fakeIngressNamespace,
 				Name:      fakeIngressService,
 			}
-			domainRoutesMap, _ := mc.GetIngressRoutesPerHost(fakeService)
+			domainRoutesMap, _ := mc.GetIngressRoutesPerHost(fakeService, service.ServicePort{Port: uint32(fakeIngressPort), Name: "http"})
 
 			for domain, routePolicies := range domainRoutesMap {
 				// The number of route policies per domain is the product of the number of rules and paths per rule
@@ -217,5 +220,62 @@ var _ = Describe("Test ingress route policies", func() {
 			}
 		})
 
+		It("Does not return the routes of the ingress backends corresponding to another port of the service", func() {
+			fakeService := service.MeshService{
+				Namespace: fakeIngressNamespace,
+				Name:      fakeIngressService,
+			}
+			domainRoutesMap, err := mc.GetIngressRoutesPerHost(fakeService, service.ServicePort{Port: 8080, Name: "http-alt"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(domainRoutesMap).To(BeEmpty())
+		})
+	})
+
+	Context("Testing isIngressBackend", func() {
+		svc := service.MeshService{Namespace: fakeIngressNamespace, Name: fakeIngressService}
+		port := service.ServicePort{Port: 80, Name: "http"}
+
+		It("Matches the backends by service name and port number or name", func() {
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: fakeIngressService, ServicePort: intstr.FromInt(80)}, svc, port)).To(BeTrue())
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: fakeIngressService, ServicePort: intstr.FromString("http")}, svc, port)).To(BeTrue())
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: fakeIngressService}, svc, port)).To(BeTrue())
+		})
+
+		It("Does not match the backends of another service or port", func() {
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: "other", ServicePort: intstr.FromInt(80)}, svc, port)).To(BeFalse())
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: fakeIngressService, ServicePort: intstr.FromInt(8080)}, svc, port)).To(BeFalse())
+			Expect(isIngressBackend(networkingV1beta1.IngressBackend{ServiceName: fakeIngressService, ServicePort: intstr.FromString("grpc")}, svc, port)).To(BeFalse())
+		})
+	})
+
+	Context("Testing getIngressPathRegex", func() {
+		exact := networkingV1beta1.PathTypeExact
+		prefix := networkingV1beta1.PathTypePrefix
+		implementationSpecific := networkingV1beta1.PathTypeImplementationSpecific
+
+		It("Matches the exact path", func() {
+			regex := getIngressPathRegex(networkingV1beta1.HTTPIngressPath{Path: "/books.json", PathType: &exact})
+			Expect(regex).To(Equal(`/books\.json`))
+			Expect(regexp.MustCompile("^" + regex + "$").MatchString("/books.json")).To(BeTrue())
+			Expect(regexp.MustCompile("^" + regex + "$").MatchString("/booksXjson")).To(BeFalse())
+		})
+
+		It("Matches the path prefix element by element", func() {
+			regex := getIngressPathRegex(networkingV1beta1.HTTPIngressPath{Path: "/books/", PathType: &prefix})
+			Expect(regex).To(Equal("/books(/.*)?"))
+			matcher := regexp.MustCompile("^" + regex + "$")
+			Expect(matcher.MatchString("/books")).To(BeTrue())
+			Expect(matcher.MatchString("/books/")).To(BeTrue())
+			Expect(matcher.MatchString("/books/bought")).To(BeTrue())
+			Expect(matcher.MatchString("/booksbought")).To(BeFalse())
+
+			Expect(getIngressPathRegex(networkingV1beta1.HTTPIngressPath{Path: "/", PathType: &prefix})).To(Equal(constants.RegexMatchAll))
+		})
+
+		It("Treats the other paths as regexes", func() {
+			Expect(getIngressPathRegex(networkingV1beta1.HTTPIngressPath{Path: "/books/.*"})).To(Equal("/books/.*"))
+			Expect(getIngressPathRegex(networkingV1beta1.HTTPIngressPath{Path: "/books/.*", PathType: &implementationSpecific})).To(Equal("/books/.*"))
+			Expect(getIngressPathRegex(networkingV1beta1.HTTPIngressPath{})).To(Equal(constants.RegexMatchAll))
+		})
 	})
 })
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+// ListExportedServices returns the services of the local cluster exported to the remote clusters of the multicluster mesh,
+// which are annotated with openservicemesh.io/multicluster-export: "true".
+func (mc *MeshCatalog) ListExportedServices() []service.MeshService {
+	if !featureflags.IsMulticlusterModeEnabled() {
+		return nil
+	}
+
+	var exportedServices []service.MeshService
+	for _, svc := range mc.meshSpec.ListServices() {
+		if exported, err := strconv.ParseBool(svc.Annotations[constants.MulticlusterExportAnnotation]); err != nil || !exported {
+			continue
+		}
+		exportedServices = append(exportedServices, utils.K8sSvcToMeshSvc(svc))
+	}
+
+	// The order is stable, so the config generated from the list does not change while the exported services do not change
+	sort.Slice(exportedServices, func(i, j int) bool {
+		return exportedServices[i].String() < exportedServices[j].String()
+	})
+	return exportedServices
+}
+
+// IsMulticlusterGateway returns true if the given service is the service of the gateway receiving the traffic from remote clusters
+func (mc *MeshCatalog) IsMulticlusterGateway(svc service.MeshService) bool {
+	return featureflags.IsMulticlusterModeEnabled() && !svc.IsRemote() && svc.String() == mc.configurator.GetMulticlusterGateway()
+}
+
+// listRemoteServicePorts returns the ports of a service exported by a remote cluster, from the provider discovering its services
+func (mc *MeshCatalog) listRemoteServicePorts(meshService service.MeshService) ([]service.ServicePort, error) {
+	for _, provider := range mc.endpointsProviders {
+		portsProvider, ok := provider.(endpoint.ServicePortsProvider)
+		if !ok {
+			continue
+		}
+		if ports, found := portsProvider.ListServicePorts(meshService); found {
+			return ports, nil
+		}
+	}
+	log.Error().Msgf("Error fetching ports of remote service %s", meshService)
+	return nil, errServiceNotFound
+}
+
+// getRemoteServiceHostnames returns the hostnames of a service exported by a remote cluster: the hostnames the service has
+// in its own cluster, with the ID of the cluster in place of the cluster domain. Ex. bookstore.default.svc.cluster-2:80
+func (mc *MeshCatalog) getRemoteServiceHostnames(meshService service.MeshService) ([]string, error) {
+	ports, err := mc.listRemoteServicePorts(meshService)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := fmt.Sprintf("%s.%s.svc.%s", meshService.Name, meshService.Namespace, meshService.Cluster)
+	hostnames := []string{serviceName}
+	for _, port := range ports {
+		hostnames = append(hostnames, fmt.Sprintf("%s:%d", serviceName, port.Port))
+	}
+	return hostnames, nil
+}
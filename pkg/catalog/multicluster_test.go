@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// fakeExportingMeshSpec lists the bookstore service exported to the remote clusters, and the bookbuyer service
+type fakeExportingMeshSpec struct {
+	smi.MeshSpec
+}
+
+func (fakeExportingMeshSpec) ListServices() []*corev1.Service {
+	bookstore := tests.NewServiceFixture(tests.BookstoreService.Name, tests.BookstoreService.Namespace, nil)
+	bookstore.Annotations = map[string]string{constants.MulticlusterExportAnnotation: "true"}
+	bookbuyer := tests.NewServiceFixture(tests.BookbuyerService.Name, tests.BookbuyerService.Namespace, nil)
+	return []*corev1.Service{bookstore, bookbuyer}
+}
+
+// fakeRemoteProvider discovers the bookstore service exported by cluster-2
+type fakeRemoteProvider struct {
+	endpoint.Provider
+}
+
+func (fakeRemoteProvider) ListServicePorts(svc service.MeshService) ([]service.ServicePort, bool) {
+	if svc != remoteBookstore {
+		return nil, false
+	}
+	return []service.ServicePort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}}, true
+}
+
+var remoteBookstore = service.MeshService{Namespace: tests.BookstoreService.Namespace, Name: tests.BookstoreService.Name, Cluster: "cluster-2"}
+
+var _ = Describe("Multicluster", func() {
+	mc := &MeshCatalog{
+		meshSpec:           fakeExportingMeshSpec{},
+		endpointsProviders: []endpoint.Provider{kube.NewFakeProvider(), fakeRemoteProvider{}},
+		configurator: configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+			ClusterID:           "cluster-1",
+			MulticlusterGateway: "osm-system/osm-multicluster-gateway",
+		}),
+	}
+	gateway := service.MeshService{Namespace: "osm-system", Name: "osm-multicluster-gateway"}
+
+	Context("when the multicluster mode is enabled", func() {
+		BeforeEach(func() {
+			featureflags.Features.MulticlusterMode = true
+		})
+
+		AfterEach(func() {
+			featureflags.Features.MulticlusterMode = false
+		})
+
+		It("lists the services annotated for export", func() {
+			Expect(mc.ListExportedServices()).To(Equal([]service.MeshService{tests.BookstoreService}))
+		})
+
+		It("recognizes the service of the multicluster gateway", func() {
+			Expect(mc.IsMulticlusterGateway(gateway)).To(BeTrue())
+			Expect(mc.IsMulticlusterGateway(tests.BookstoreService)).To(BeFalse())
+			Expect(mc.IsMulticlusterGateway(service.MeshService{Namespace: gateway.Namespace, Name: gateway.Name, Cluster: "cluster-2"})).To(BeFalse())
+		})
+	})
+
+	Context("when the multicluster mode is disabled", func() {
+		It("does not export services", func() {
+			Expect(mc.ListExportedServices()).To(BeEmpty())
+		})
+
+		It("does not configure a multicluster gateway", func() {
+			Expect(mc.IsMulticlusterGateway(gateway)).To(BeFalse())
+		})
+	})
+
+	Context("Test services exported by remote clusters", func() {
+		It("lists the ports of a remote service from the provider of its cluster", func() {
+			ports, err := mc.ListServicePorts(remoteBookstore)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(Equal([]service.ServicePort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}}))
+		})
+
+		It("returns an error for a remote service no provider discovers", func() {
+			_, err := mc.ListServicePorts(service.MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-3"})
+			Expect(err).To(Equal(errServiceNotFound))
+		})
+
+		It("returns the hostnames of a remote service qualified by the ID of its cluster", func() {
+			hostnames, err := mc.getRemoteServiceHostnames(remoteBookstore)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hostnames).To(Equal([]string{
+				"bookstore.default.svc.cluster-2",
+				"bookstore.default.svc.cluster-2:80",
+			}))
+		})
+	})
+})
@@ -0,0 +1,171 @@
+package catalog
+
+import (
+	"sort"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// RotateCertificates forces the rotation of the certificates of the Envoy proxies selected by the given subject:
+// the certificates of the services of these proxies are issued again, and pushed to the proxies over SDS.
+// When revoke is true, the XDS certificates of the selected proxies are revoked first: their ADS streams are closed,
+// and they can no longer connect to the control plane. It returns the CNs of the rotated and of the revoked certificates.
+func (mc *MeshCatalog) RotateCertificates(subject CertificateSubject, revoke bool) (rotated []certificate.CommonName, revoked []certificate.CommonName, err error) {
+	proxyCNs, err := mc.selectProxies(subject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Revoke the XDS certificates before rotating the service certificates, so the revoked proxies do not receive the new ones
+	if revoke {
+		for _, cn := range proxyCNs {
+			if err := mc.certManager.RevokeCertificate(cn); err != nil {
+				log.Error().Err(err).Msgf("Error revoking certificate of Envoy with CN=%s", cn)
+				return rotated, revoked, err
+			}
+			revoked = append(revoked, cn)
+		}
+	}
+
+	issued, err := mc.certManager.ListCertificates()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing issued certificates")
+		return rotated, revoked, err
+	}
+
+	for _, svc := range mc.getSubjectServices(subject, proxyCNs) {
+		cn := svc.GetCommonName()
+		// Only the issued certificates are rotated, one per SPIFFE ID requested by the proxies of the service
+		var spiffeIDs []certificate.SPIFFEID
+		for _, cert := range issued {
+			if cert.GetCommonName() == cn {
+				spiffeIDs = append(spiffeIDs, certificate.GetSPIFFEID(cert))
+			}
+		}
+		if len(spiffeIDs) == 0 {
+			log.Trace().Msgf("Skipping the rotation of the certificate of service %s, which was not issued", svc)
+			continue
+		}
+		for _, spiffeID := range spiffeIDs {
+			if _, err := mc.certManager.RotateCertificate(cn, spiffeID); err != nil {
+				log.Error().Err(err).Msgf("Error rotating certificate of service %s with SPIFFE ID %s", svc, spiffeID)
+				return rotated, revoked, err
+			}
+		}
+		rotated = append(rotated, cn)
+	}
+
+	return rotated, revoked, nil
+}
+
+// IsProxyRevoked determines whether the XDS certificate of the Envoy proxy with the given CN was revoked.
+func (mc *MeshCatalog) IsProxyRevoked(cn certificate.CommonName) bool {
+	return mc.certManager.IsRevoked(cn)
+}
+
+// selectProxies returns the CNs of the Envoy proxies selected by the given subject.
+func (mc *MeshCatalog) selectProxies(subject CertificateSubject) ([]certificate.CommonName, error) {
+	selectors := 0
+	if subject.ProxyCommonName != "" {
+		selectors++
+	}
+	if subject.Service != nil {
+		selectors++
+	}
+	if subject.ServiceAccount != nil {
+		selectors++
+	}
+	if selectors != 1 {
+		return nil, errInvalidCertificateSubject
+	}
+
+	if subject.ProxyCommonName != "" {
+		if _, err := getCertificateCommonNameMeta(subject.ProxyCommonName); err != nil {
+			return nil, err
+		}
+		return []certificate.CommonName{subject.ProxyCommonName}, nil
+	}
+
+	var connected []certificate.CommonName
+	mc.connectedProxiesLock.Lock()
+	for cn := range mc.connectedProxies {
+		connected = append(connected, cn)
+	}
+	mc.connectedProxiesLock.Unlock()
+
+	var proxyCNs []certificate.CommonName
+	for _, cn := range connected {
+		if mc.isProxySelected(cn, subject) {
+			proxyCNs = append(proxyCNs, cn)
+		}
+	}
+
+	sort.Slice(proxyCNs, func(i, j int) bool {
+		return proxyCNs[i] < proxyCNs[j]
+	})
+	return proxyCNs, nil
+}
+
+// isProxySelected determines whether the Envoy proxy with the given CN belongs to the service or the service account of the given subject.
+func (mc *MeshCatalog) isProxySelected(cn certificate.CommonName, subject CertificateSubject) bool {
+	if subject.ServiceAccount != nil {
+		cnMeta, err := getCertificateCommonNameMeta(cn)
+		if err != nil {
+			return false
+		}
+		return cnMeta.ServiceAccount == subject.ServiceAccount.Name && cnMeta.Namespace == subject.ServiceAccount.Namespace
+	}
+
+	services, err := mc.GetServicesFromEnvoyCertificate(cn)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up services for Envoy with CN=%s", cn)
+		return false
+	}
+	for _, svc := range services {
+		if svc.Equals(*subject.Service) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSubjectServices returns the services whose certificates are rotated for the given subject, sorted by name:
+// the selected service or the services of the selected service account, and the services of the selected proxies.
+func (mc *MeshCatalog) getSubjectServices(subject CertificateSubject, proxyCNs []certificate.CommonName) []service.MeshService {
+	services := make(map[service.MeshService]interface{})
+
+	if subject.Service != nil {
+		services[*subject.Service] = nil
+	}
+
+	if subject.ServiceAccount != nil {
+		accountServices, err := mc.GetServicesForServiceAccount(*subject.ServiceAccount)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error looking up services for service account %s", subject.ServiceAccount)
+		}
+		for _, svc := range accountServices {
+			services[svc] = nil
+		}
+	}
+
+	for _, cn := range proxyCNs {
+		proxyServices, err := mc.GetServicesFromEnvoyCertificate(cn)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error looking up services for Envoy with CN=%s", cn)
+			continue
+		}
+		for _, svc := range proxyServices {
+			services[svc] = nil
+		}
+	}
+
+	var sorted []service.MeshService
+	for svc := range services {
+		sorted = append(sorted, svc)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+	return sorted
+}
@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"github.com/google/uuid"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test certificate rotation and revocation", func() {
+	mc := NewFakeMeshCatalog(testclient.NewSimpleClientset())
+
+	bookstoreProxyCN := NewCertCommonNameWithProxyID(uuid.New().String(), tests.BookstoreServiceAccountName, tests.Namespace)
+	bookbuyerProxyCN := NewCertCommonNameWithProxyID(uuid.New().String(), tests.BookbuyerServiceAccountName, tests.Namespace)
+	mc.RegisterProxy(envoy.NewProxy(bookstoreProxyCN, nil))
+	mc.RegisterProxy(envoy.NewProxy(bookbuyerProxyCN, nil))
+
+	Context("Testing RotateCertificates()", func() {
+		It("rotates the certificates of a service account without revoking its proxies", func() {
+			// Only the certificates which were issued are rotated
+			_, err := mc.GetCertificateForService(tests.BookstoreService, tests.BookstoreServiceAccount)
+			Expect(err).ToNot(HaveOccurred())
+
+			rotated, revoked, err := mc.RotateCertificates(CertificateSubject{ServiceAccount: &tests.BookstoreServiceAccount}, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(ContainElement(tests.BookstoreService.GetCommonName()))
+			Expect(revoked).To(BeEmpty())
+			Expect(mc.IsProxyRevoked(bookstoreProxyCN)).To(BeFalse())
+
+			// The rotated certificate keeps its SPIFFE ID
+			cert, err := mc.certManager.GetCertificate(tests.BookstoreService.GetCommonName(), mc.GetSPIFFEID(tests.BookstoreServiceAccount))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate.GetSPIFFEID(cert)).To(Equal(mc.GetSPIFFEID(tests.BookstoreServiceAccount)))
+		})
+
+		It("revokes the proxies of a service account", func() {
+			rotated, revoked, err := mc.RotateCertificates(CertificateSubject{ServiceAccount: &tests.BookstoreServiceAccount}, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(ContainElement(tests.BookstoreService.GetCommonName()))
+			Expect(revoked).To(Equal([]certificate.CommonName{bookstoreProxyCN}))
+
+			Expect(mc.IsProxyRevoked(bookstoreProxyCN)).To(BeTrue())
+			Expect(mc.IsProxyRevoked(bookbuyerProxyCN)).To(BeFalse())
+		})
+
+		It("requires exactly one subject", func() {
+			_, _, err := mc.RotateCertificates(CertificateSubject{}, false)
+			Expect(err).To(Equal(errInvalidCertificateSubject))
+
+			_, _, err = mc.RotateCertificates(CertificateSubject{ProxyCommonName: bookbuyerProxyCN, ServiceAccount: &tests.BookbuyerServiceAccount}, false)
+			Expect(err).To(Equal(errInvalidCertificateSubject))
+		})
+
+		It("rejects invalid proxy certificate CNs", func() {
+			_, _, err := mc.RotateCertificates(CertificateSubject{ProxyCommonName: "invalid"}, true)
+			Expect(err).To(Equal(errInvalidCertificateCN))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package catalog
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -19,6 +20,9 @@ import (
 const (
 	//HTTPTraffic specifies HTTP Traffic Policy
 	HTTPTraffic = "HTTPRouteGroup"
+
+	// TCPTraffic specifies TCP Traffic Policy
+	TCPTraffic = "TCPRoute"
 )
 
 // ListTrafficPolicies returns all the traffic policies for a given service that Envoy proxy should be aware of.
@@ -57,24 +61,38 @@ func (mc *MeshCatalog) getAllowedDirectionalServices(svc service.MeshService, di
 		return nil, err
 	}
 
+	allTCPTrafficPolicies, err := mc.ListTCPTrafficPolicies(svc)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed listing TCP traffic policies")
+		return nil, err
+	}
+
 	allowedServicesSet := mapset.NewSet()
 
-	for _, policy := range allTrafficPolicies {
+	addAllowedService := func(source, destination service.MeshService) {
 		if directn == inbound {
 			// we are looking for services that can connect to the given service
-			if policy.Destination.Equals(svc) {
-				allowedServicesSet.Add(policy.Source)
+			if destination.Equals(svc) {
+				allowedServicesSet.Add(source)
 			}
 		}
 
 		if directn == outbound {
 			// we are looking for services the given svc can connect to
-			if policy.Source.Equals(svc) {
-				allowedServicesSet.Add(policy.Destination)
+			if source.Equals(svc) {
+				allowedServicesSet.Add(destination)
 			}
 		}
 	}
 
+	for _, policy := range allTrafficPolicies {
+		addAllowedService(policy.Source, policy.Destination)
+	}
+
+	for _, policy := range allTCPTrafficPolicies {
+		addAllowedService(policy.Source, policy.Destination)
+	}
+
 	// Convert the set of interfaces to a list of namespaced services
 	var allowedServices []service.MeshService
 	for svc := range allowedServicesSet.Iter() {
@@ -101,6 +119,71 @@ func (mc *MeshCatalog) ListAllowedOutboundServices(sourceService service.MeshSer
 	return mc.getAllowedDirectionalServices(sourceService, outbound)
 }
 
+// This function returns the list of service accounts SMI TrafficTargets authorize the given service account to connect with.
+// This is a bimodal function:
+//   - it could list service accounts that are allowed to connect to the given service account (inbound)
+//   - it could list service accounts that the given service account can connect to (outbound)
+func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcAccount service.K8sServiceAccount, directn direction) []service.K8sServiceAccount {
+	allowedServiceAccountsSet := mapset.NewSet()
+
+	for _, trafficTarget := range mc.meshSpec.ListTrafficTargets() {
+		if len(trafficTarget.Spec.Rules) == 0 {
+			continue
+		}
+
+		destination := service.K8sServiceAccount{
+			Namespace: trafficTarget.Spec.Destination.Namespace,
+			Name:      trafficTarget.Spec.Destination.Name,
+		}
+
+		for _, trafficSource := range trafficTarget.Spec.Sources {
+			source := service.K8sServiceAccount{
+				Namespace: trafficSource.Namespace,
+				Name:      trafficSource.Name,
+			}
+
+			// we are looking for service accounts that can connect to the given service account
+			if directn == inbound && destination == svcAccount {
+				allowedServiceAccountsSet.Add(source)
+			}
+
+			// we are looking for service accounts the given service account can connect to
+			if directn == outbound && source == svcAccount {
+				allowedServiceAccountsSet.Add(destination)
+			}
+		}
+	}
+
+	var allowedServiceAccounts []service.K8sServiceAccount
+	for svcAccount := range allowedServiceAccountsSet.Iter() {
+		allowedServiceAccounts = append(allowedServiceAccounts, svcAccount.(service.K8sServiceAccount))
+	}
+
+	// The order is stable, so the config generated from the list does not change while the policies do not change
+	sort.Slice(allowedServiceAccounts, func(i, j int) bool {
+		return allowedServiceAccounts[i].String() < allowedServiceAccounts[j].String()
+	})
+
+	msg := map[direction]string{
+		inbound:  "Allowed inbound service accounts for destination service account %q: %+v",
+		outbound: "Allowed outbound service accounts from source service account %q: %+v",
+	}[directn]
+
+	log.Trace().Msgf(msg, svcAccount, allowedServiceAccounts)
+
+	return allowedServiceAccounts
+}
+
+// ListAllowedInboundServiceAccounts lists the service accounts SMI TrafficTargets allow to connect to the given service account.
+func (mc *MeshCatalog) ListAllowedInboundServiceAccounts(destinationServiceAccount service.K8sServiceAccount) []service.K8sServiceAccount {
+	return mc.getAllowedDirectionalServiceAccounts(destinationServiceAccount, inbound)
+}
+
+// ListAllowedOutboundServiceAccounts lists the service accounts SMI TrafficTargets allow the given service account to connect to.
+func (mc *MeshCatalog) ListAllowedOutboundServiceAccounts(sourceServiceAccount service.K8sServiceAccount) []service.K8sServiceAccount {
+	return mc.getAllowedDirectionalServiceAccounts(sourceServiceAccount, outbound)
+}
+
 //GetWeightedClusterForService returns the weighted cluster for a given service
 func (mc *MeshCatalog) GetWeightedClusterForService(svc service.MeshService) (service.WeightedCluster, error) {
 	log.Trace().Msgf("Finding weighted cluster for service %s", svc)
@@ -164,6 +247,17 @@ func (mc *MeshCatalog) GetHostnamesForService(meshService service.MeshService) (
 		}
 	}
 
+	if meshService.IsRemote() {
+		// This service exported by a remote cluster is not a backend for a traffic split policy.
+		// The hostnames for this service are qualified by the ID of its cluster.
+		hostnames, err := mc.getRemoteServiceHostnames(meshService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting service hostnames for remote MeshService %s", meshService)
+			return "", err
+		}
+		return hostnamesTostr(hostnames), nil
+	}
+
 	// This service is not a backend for a traffic split policy.
 	// The hostnames for this service are the Kubernetes service DNS names.
 	hostnames, err := mc.getServiceHostnames(meshService)
@@ -259,6 +353,10 @@ func getTrafficPolicyPerRoute(mc *MeshCatalog, routePolicies map[trafficpolicy.T
 
 			for _, trafficTarget := range trafficTargetPermutations {
 				for _, trafficTargetSpecs := range trafficTargets.Spec.Rules {
+					if trafficTargetSpecs.Kind == TCPTraffic {
+						// TCP traffic policies are built by ListTCPTrafficPolicies
+						continue
+					}
 					if trafficTargetSpecs.Kind != HTTPTraffic {
 						log.Error().Msgf("TrafficTarget %s/%s has Spec Kind %s which isn't supported for now; Skipping...", trafficTargets.Namespace, trafficTargets.Name, trafficTargetSpecs.Kind)
 						continue
@@ -3,9 +3,25 @@ package catalog
 import (
 	"strings"
 
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
+// ListServicePorts returns the ports of the given service, along with the application protocol each port carries.
+// The ports of the services exported by remote clusters are discovered by the providers of these clusters.
+func (mc *MeshCatalog) ListServicePorts(meshService service.MeshService) ([]service.ServicePort, error) {
+	if meshService.IsRemote() {
+		return mc.listRemoteServicePorts(meshService)
+	}
+
+	svc := mc.meshSpec.GetService(meshService)
+	if svc == nil {
+		log.Error().Msgf("Error fetching service %s", meshService)
+		return nil, errServiceNotFound
+	}
+	return kubernetes.GetServicePorts(svc), nil
+}
+
 // GetServicesForServiceAccount returns a list of services corresponding to a service account
 func (mc *MeshCatalog) GetServicesForServiceAccount(sa service.K8sServiceAccount) ([]service.MeshService, error) {
 	var services []service.MeshService
@@ -0,0 +1,150 @@
+package catalog
+
+import (
+	mapset "github.com/deckarep/golang-set"
+	target "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+// ListTCPTrafficPolicies returns the TCP traffic policies, built from SMI TrafficTargets referencing TCPRoutes, for the given service.
+// There is one policy per source, destination and TCP port of the destination service.
+func (mc *MeshCatalog) ListTCPTrafficPolicies(meshService service.MeshService) ([]trafficpolicy.TCPTrafficTarget, error) {
+	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+		// Build TCP traffic policies from service discovery for allow-all policy
+		return mc.buildAllowAllTCPTrafficPolicies(meshService), nil
+	}
+
+	tcpRoutes := mapset.NewSet()
+	for _, tcpRoute := range mc.meshSpec.ListTCPTrafficSpecs() {
+		tcpRoutes.Add(mc.getTrafficSpecName(TCPTraffic, tcpRoute.Namespace, tcpRoute.Name))
+	}
+
+	var trafficPolicies []trafficpolicy.TCPTrafficTarget
+	for _, trafficTarget := range mc.meshSpec.ListTrafficTargets() {
+		if !mc.hasTCPRouteRule(trafficTarget, tcpRoutes) {
+			continue
+		}
+
+		dstServiceAccount := service.K8sServiceAccount{
+			Namespace: trafficTarget.Spec.Destination.Namespace,
+			Name:      trafficTarget.Spec.Destination.Name,
+		}
+		dstServices, err := mc.GetServicesForServiceAccount(dstServiceAccount)
+		if err != nil {
+			log.Error().Err(err).Msgf("TrafficTarget %s/%s could not get destination services for service account %s", trafficTarget.Namespace, trafficTarget.Name, dstServiceAccount)
+			return nil, err
+		}
+
+		for _, trafficSource := range trafficTarget.Spec.Sources {
+			srcServiceAccount := service.K8sServiceAccount{
+				Namespace: trafficSource.Namespace,
+				Name:      trafficSource.Name,
+			}
+			srcServices, err := mc.GetServicesForServiceAccount(srcServiceAccount)
+			if err != nil {
+				log.Error().Err(err).Msgf("TrafficTarget %s/%s could not get source services for service account %s", trafficTarget.Namespace, trafficTarget.Name, srcServiceAccount)
+				return nil, err
+			}
+
+			for _, dstService := range dstServices {
+				ports := mc.getTCPServicePorts(dstService, trafficTarget.Spec.Destination.Port)
+				for _, srcService := range srcServices {
+					// only the policies corresponding to the given service are relevant
+					if !srcService.Equals(meshService) && !dstService.Equals(meshService) {
+						continue
+					}
+					for _, port := range ports {
+						trafficPolicies = append(trafficPolicies, trafficpolicy.TCPTrafficTarget{
+							Name:        utils.GetTrafficTargetName(trafficTarget.Name, srcService, dstService),
+							Destination: dstService,
+							Source:      srcService,
+							Port:        port.Port,
+							TargetPort:  port.TargetPort,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	log.Debug().Msgf("Constructed TCP traffic policies for service %s: %+v", meshService, trafficPolicies)
+	return trafficPolicies, nil
+}
+
+// buildAllowAllTCPTrafficPolicies returns the TCP traffic policies allowing the traffic between the given service and
+// the other services of the mesh, on the ports of the destination services whose application protocol is TCP.
+// The traffic to the other ports is HTTP traffic, allowed by the allow-all HTTP traffic policies.
+func (mc *MeshCatalog) buildAllowAllTCPTrafficPolicies(meshService service.MeshService) []trafficpolicy.TCPTrafficTarget {
+	var meshServices []service.MeshService
+	for _, svc := range mc.meshSpec.ListServices() {
+		meshServices = append(meshServices, utils.K8sSvcToMeshSvc(svc))
+	}
+
+	var trafficPolicies []trafficpolicy.TCPTrafficTarget
+	for _, dstService := range meshServices {
+		ports, err := mc.ListServicePorts(dstService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of service %s", dstService)
+			continue
+		}
+		for _, srcService := range meshServices {
+			if srcService.Equals(dstService) {
+				continue
+			}
+			// only the policies corresponding to the given service are relevant
+			if !srcService.Equals(meshService) && !dstService.Equals(meshService) {
+				continue
+			}
+			for _, port := range ports {
+				if port.IsHTTP() {
+					continue
+				}
+				trafficPolicies = append(trafficPolicies, trafficpolicy.TCPTrafficTarget{
+					Name:        utils.GetTrafficTargetName("", srcService, dstService),
+					Destination: dstService,
+					Source:      srcService,
+					Port:        port.Port,
+					TargetPort:  port.TargetPort,
+				})
+			}
+		}
+	}
+	return trafficPolicies
+}
+
+// hasTCPRouteRule returns true when one of the rules of the given TrafficTarget references one of the given TCPRoutes.
+func (mc *MeshCatalog) hasTCPRouteRule(trafficTarget *target.TrafficTarget, tcpRoutes mapset.Set) bool {
+	for _, rule := range trafficTarget.Spec.Rules {
+		if rule.Kind != TCPTraffic {
+			continue
+		}
+		specKey := mc.getTrafficSpecName(TCPTraffic, trafficTarget.Namespace, rule.Name)
+		if !tcpRoutes.Contains(specKey) {
+			log.Error().Msgf("TrafficTarget %s/%s could not find a TrafficSpec %s; Skipping...", trafficTarget.Namespace, trafficTarget.Name, specKey)
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// getTCPServicePorts returns the ports of the given service.
+// When the TrafficTarget restricts the destination to a port, only the service port targeting this port of the pods is returned.
+func (mc *MeshCatalog) getTCPServicePorts(meshService service.MeshService, targetPort *int) []service.ServicePort {
+	servicePorts, err := mc.ListServicePorts(meshService)
+	if err != nil {
+		return nil
+	}
+
+	var ports []service.ServicePort
+	for _, port := range servicePorts {
+		if targetPort != nil && port.TargetPort != uint32(*targetPort) {
+			continue
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
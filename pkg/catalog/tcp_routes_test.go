@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+var _ = Describe("TCP traffic policies", func() {
+	mc := newFakeMeshCatalog()
+
+	Context("Test ListTCPTrafficPolicies", func() {
+		It("lists the TCP traffic policies of the destination service", func() {
+			actual, err := mc.ListTCPTrafficPolicies(tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := []trafficpolicy.TCPTrafficTarget{
+				{
+					Name:        utils.GetTrafficTargetName(tests.TCPTrafficTargetName, tests.BookbuyerService, tests.BookstoreService),
+					Destination: tests.BookstoreService,
+					Source:      tests.BookbuyerService,
+					Port:        tests.ServicePort,
+					TargetPort:  tests.ServicePort,
+				},
+			}
+			Expect(actual).To(Equal(expected))
+		})
+
+		It("lists the TCP traffic policies of the source service", func() {
+			actual, err := mc.ListTCPTrafficPolicies(tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(actual)).To(Equal(2))
+			for _, policy := range actual {
+				Expect(policy.Source).To(Equal(tests.BookbuyerService))
+				Expect(policy.Destination).To(BeElementOf(tests.BookstoreService, tests.BookstoreApexService))
+			}
+		})
+
+		It("does not list TCP traffic policies for services without TCP traffic", func() {
+			actual, err := mc.ListTCPTrafficPolicies(service.MeshService{Namespace: tests.Namespace, Name: "unknown"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(BeEmpty())
+		})
+
+		It("lists the services allowed TCP traffic as allowed services", func() {
+			outboundServices, err := mc.ListAllowedOutboundServices(tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(outboundServices).To(ConsistOf(tests.BookstoreService, tests.BookstoreApexService))
+		})
+	})
+
+	Context("Test buildAllowAllTCPTrafficPolicies", func() {
+		It("does not build TCP traffic policies for the HTTP ports of the services", func() {
+			Expect(mc.buildAllowAllTCPTrafficPolicies(tests.BookstoreService)).To(BeEmpty())
+		})
+	})
+
+	Context("Test getTCPServicePorts", func() {
+		It("returns the TCP ports of the service", func() {
+			ports := mc.getTCPServicePorts(tests.BookstoreService, nil)
+			Expect(len(ports)).To(Equal(1))
+			Expect(ports[0].Port).To(Equal(uint32(tests.ServicePort)))
+		})
+
+		It("only returns the port targeting the port of the TrafficTarget", func() {
+			otherPort := tests.ServicePort + 1
+			Expect(mc.getTCPServicePorts(tests.BookstoreService, &otherPort)).To(BeEmpty())
+		})
+	})
+})
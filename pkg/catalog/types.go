@@ -11,10 +11,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
@@ -33,6 +35,7 @@ type MeshCatalog struct {
 	meshSpec           smi.MeshSpec
 	certManager        certificate.Manager
 	ingressMonitor     ingress.Monitor
+	gatewayMonitor     gateway.Monitor
 	configurator       configurator.Configurator
 
 	expectedProxies     map[certificate.CommonName]expectedProxy
@@ -46,6 +49,10 @@ type MeshCatalog struct {
 
 	announcementChannels mapset.Set
 
+	// configGeneration is incremented on every announcement received from the providers the catalog relies on.
+	// Config generated from the catalog for a given generation remains valid until the generation changes.
+	configGeneration uint64
+
 	// Current assumption is that OSM is working with a single Kubernetes cluster.
 	// This here is the client to that cluster.
 	kubeClient kubernetes.Interface
@@ -61,21 +68,43 @@ type MeshCataloger interface {
 	// ListTrafficPolicies returns all the traffic policies for a given service that Envoy proxy should be aware of.
 	ListTrafficPolicies(service.MeshService) ([]trafficpolicy.TrafficTarget, error)
 
+	// ListTCPTrafficPolicies returns the TCP traffic policies, built from SMI TrafficTargets referencing TCPRoutes, for the given service.
+	ListTCPTrafficPolicies(service.MeshService) ([]trafficpolicy.TCPTrafficTarget, error)
+
+	// ListEgressTrafficPolicies returns the egress traffic policies, built from Egress policies, for the given service account.
+	ListEgressTrafficPolicies(service.K8sServiceAccount) []trafficpolicy.EgressTrafficPolicy
+
 	// ListAllowedInboundServices lists the inbound services allowed to connect to the given service.
 	ListAllowedInboundServices(service.MeshService) ([]service.MeshService, error)
 
 	// ListAllowedOutboundServices lists the services the given service is allowed outbound connections to.
 	ListAllowedOutboundServices(service.MeshService) ([]service.MeshService, error)
 
+	// ListAllowedInboundServiceAccounts lists the service accounts SMI TrafficTargets allow to connect to the given service account.
+	ListAllowedInboundServiceAccounts(service.K8sServiceAccount) []service.K8sServiceAccount
+
+	// ListAllowedOutboundServiceAccounts lists the service accounts SMI TrafficTargets allow the given service account to connect to.
+	ListAllowedOutboundServiceAccounts(service.K8sServiceAccount) []service.K8sServiceAccount
+
 	// ListSMIPolicies lists SMI policies.
 	ListSMIPolicies() ([]*split.TrafficSplit, []service.WeightedService, []service.K8sServiceAccount, []*spec.HTTPRouteGroup, []*target.TrafficTarget, []*corev1.Service)
 
+	// ListServicePorts returns the ports of the given service, along with the application protocol each port carries.
+	ListServicePorts(service.MeshService) ([]service.ServicePort, error)
+
 	// ListEndpointsForService returns the list of provider endpoints corresponding to a service
 	ListEndpointsForService(service.MeshService) ([]endpoint.Endpoint, error)
 
-	// GetCertificateForService returns the SSL Certificate for the given service.
+	// GetCertificateForService returns the SSL Certificate for the given service, carrying the SPIFFE ID of the given service account.
 	// This certificate will be used for service-to-service mTLS.
-	GetCertificateForService(service.MeshService) (certificate.Certificater, error)
+	GetCertificateForService(service.MeshService, service.K8sServiceAccount) (certificate.Certificater, error)
+
+	// GetSPIFFEID returns the SPIFFE ID of the workloads running as the given service account.
+	GetSPIFFEID(service.K8sServiceAccount) certificate.SPIFFEID
+
+	// GetTrustBundle returns the PEM encoded root certificates trusted by the mesh.
+	// This trust bundle is used to validate the certificates presented by peers during service-to-service mTLS.
+	GetTrustBundle() ([]byte, error)
 
 	// ExpectProxy catalogs the fact that a certificate was issued for an Envoy proxy and this is expected to connect to XDS.
 	ExpectProxy(certificate.CommonName)
@@ -83,12 +112,24 @@ type MeshCataloger interface {
 	// GetServicesFromEnvoyCertificate returns a list of services the given Envoy is a member of based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
 	GetServicesFromEnvoyCertificate(certificate.CommonName) ([]service.MeshService, error)
 
+	// GetServiceAccountFromEnvoyCertificate returns the service account of the given Envoy based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
+	GetServiceAccountFromEnvoyCertificate(certificate.CommonName) (service.K8sServiceAccount, error)
+
+	// GetLocalityFromEnvoyCertificate returns the locality of the node the given Envoy runs on based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
+	GetLocalityFromEnvoyCertificate(certificate.CommonName) (endpoint.Locality, error)
+
+	// GetIPFamiliesFromEnvoyCertificate returns the IP families of the addresses of the pod the given Envoy runs in, the family of its primary address first, based on the certificate provided.
+	GetIPFamiliesFromEnvoyCertificate(certificate.CommonName) ([]corev1.IPFamily, error)
+
 	// RegisterProxy registers a newly connected proxy with the service mesh catalog.
 	RegisterProxy(*envoy.Proxy)
 
 	// UnregisterProxy unregisters an existing proxy from the service mesh catalog
 	UnregisterProxy(*envoy.Proxy)
 
+	// IsProxyRevoked determines whether the XDS certificate of the Envoy proxy with the given CN was revoked.
+	IsProxyRevoked(certificate.CommonName) bool
+
 	// GetServicesForServiceAccount returns a list of services corresponding to a service account
 	GetServicesForServiceAccount(service.K8sServiceAccount) ([]service.MeshService, error)
 
@@ -99,16 +140,72 @@ type MeshCataloger interface {
 	//GetWeightedClusterForService returns the weighted cluster for a service
 	GetWeightedClusterForService(service service.MeshService) (service.WeightedCluster, error)
 
-	// GetIngressRoutesPerHost returns the HTTP routes per host associated with an ingress service
-	GetIngressRoutesPerHost(service.MeshService) (map[string][]trafficpolicy.HTTPRoute, error)
+	// GetIngressRoutesPerHost returns the HTTP routes per host associated with the given port of an ingress service
+	GetIngressRoutesPerHost(service.MeshService, service.ServicePort) (map[string][]trafficpolicy.HTTPRoute, error)
+
+	// GetGatewayHTTPRoutesPerHost returns the routes per host of the Gateway API HTTPRoute resources for the given port of a backend service
+	GetGatewayHTTPRoutesPerHost(service.MeshService, service.ServicePort) (map[string][]trafficpolicy.GatewayHTTPRoute, error)
+
+	// ListGatewayTLSHostnames returns the SNI hostnames of the Gateway API TLSRoute resources for the given port of a backend service
+	ListGatewayTLSHostnames(service.MeshService, service.ServicePort) ([]string, error)
+
+	// ListExportedServices returns the services of the local cluster exported to the remote clusters of the multicluster mesh
+	ListExportedServices() []service.MeshService
+
+	// IsMulticlusterGateway returns true if the given service is the service of the gateway receiving the traffic from remote clusters
+	IsMulticlusterGateway(service.MeshService) bool
 
 	// ListMonitoredNamespaces lists namespaces monitored by the control plane
 	ListMonitoredNamespaces() []string
+
+	// GetConfigGeneration returns the current generation of the catalog's configuration, which changes whenever the catalog changes
+	GetConfigGeneration() uint64
+}
+
+// CertificateSubject selects the Envoy proxies whose certificates are rotated or revoked; exactly one of its fields is set.
+type CertificateSubject struct {
+	// ProxyCommonName selects the Envoy proxy with the given XDS certificate CN
+	ProxyCommonName certificate.CommonName
+
+	// Service selects the connected Envoy proxies of the given service
+	Service *service.MeshService
+
+	// ServiceAccount selects the connected Envoy proxies of the pods running as the given service account
+	ServiceAccount *service.K8sServiceAccount
+}
+
+// ProxyUpdate is the announcement sent to the proxies affected by the changes the catalog observed.
+type ProxyUpdate struct {
+	// Sources are the subsystems which announced the changes
+	Sources []string
+
+	// Services are the services affected by the changes; empty when the changes may affect any service
+	Services []service.MeshService
+}
+
+// meshChange is a change announced by one of the providers the catalog relies on
+type meshChange struct {
+	// source is the subsystem which announced the change
+	source string
+
+	// services are the services affected by the change
+	services []service.MeshService
+
+	// allServices is true when the change may affect any service
+	allServices bool
+}
+
+// pendingUpdate accumulates the changes received since the proxies were last updated
+type pendingUpdate struct {
+	firstChangeAt time.Time
+	sources       map[string]interface{}
+	services      map[service.MeshService]interface{}
+	allServices   bool
 }
 
 type announcementChannel struct {
 	announcer string
-	channel   <-chan interface{}
+	channel   <-chan announcements.Announcement
 }
 
 type expectedProxy struct {
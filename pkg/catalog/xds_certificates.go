@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"net"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
@@ -52,6 +55,70 @@ func (mc *MeshCatalog) GetServicesFromEnvoyCertificate(cn certificate.CommonName
 	return serviceList, nil
 }
 
+// GetServiceAccountFromEnvoyCertificate returns the service account of the given Envoy based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
+func (mc *MeshCatalog) GetServiceAccountFromEnvoyCertificate(cn certificate.CommonName) (service.K8sServiceAccount, error) {
+	cnMeta, err := getCertificateCommonNameMeta(cn)
+	if err != nil {
+		return service.K8sServiceAccount{}, err
+	}
+	return service.K8sServiceAccount{
+		Namespace: cnMeta.Namespace,
+		Name:      cnMeta.ServiceAccount,
+	}, nil
+}
+
+// GetLocalityFromEnvoyCertificate returns the locality of the node the given Envoy runs on based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
+func (mc *MeshCatalog) GetLocalityFromEnvoyCertificate(cn certificate.CommonName) (endpoint.Locality, error) {
+	pod, err := GetPodFromCertificate(cn, mc.kubeClient)
+	if err != nil {
+		return endpoint.Locality{}, err
+	}
+	if pod.Spec.NodeName == "" {
+		return endpoint.Locality{}, nil
+	}
+
+	node, err := mc.kubeClient.CoreV1().Nodes().Get(context.Background(), pod.Spec.NodeName, v12.GetOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting node %s of pod %s/%s", pod.Spec.NodeName, pod.Namespace, pod.Name)
+		return endpoint.Locality{}, err
+	}
+	return k8s.GetNodeLocality(node), nil
+}
+
+// GetIPFamiliesFromEnvoyCertificate returns the IP families of the addresses of the pod the given Envoy runs in, the family of its primary address first, based on the certificate provided.
+// A dual-stack pod has an IPv4 and an IPv6 address, a pod has at most one address of each family. The pods without an address yet are assumed to be IPv4 pods.
+func (mc *MeshCatalog) GetIPFamiliesFromEnvoyCertificate(cn certificate.CommonName) ([]v1.IPFamily, error) {
+	pod, err := GetPodFromCertificate(cn, mc.kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	podIPs := pod.Status.PodIPs
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		// The clusters before Kubernetes 1.16 only report the primary address of the pods
+		podIPs = []v1.PodIP{{IP: pod.Status.PodIP}}
+	}
+
+	var ipFamilies []v1.IPFamily
+	for _, podIP := range podIPs {
+		ip := net.ParseIP(podIP.IP)
+		if ip == nil {
+			log.Error().Msgf("Error parsing IP address %s of pod %s/%s", podIP.IP, pod.Namespace, pod.Name)
+			continue
+		}
+		ipFamily := v1.IPv6Protocol
+		if ip.To4() != nil {
+			ipFamily = v1.IPv4Protocol
+		}
+		ipFamilies = append(ipFamilies, ipFamily)
+	}
+
+	if len(ipFamilies) == 0 {
+		return []v1.IPFamily{v1.IPv4Protocol}, nil
+	}
+	return ipFamilies, nil
+}
+
 // filterTrafficSplitServices takes a list of services and removes from it the ones
 // that have been split via an SMI TrafficSplit.
 func (mc *MeshCatalog) filterTrafficSplitServices(services []v1.Service) []v1.Service {
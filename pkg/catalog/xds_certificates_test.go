@@ -14,6 +14,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -67,6 +68,88 @@ var _ = Describe("Test XDS certificate tooling", func() {
 		})
 	})
 
+	Context("Test GetLocalityFromEnvoyCertificate()", func() {
+		It("returns the locality of the node of the pod", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := NewFakeMeshCatalog(kubeClient)
+
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					v1.LabelZoneRegionStable:        "us-east-1",
+					v1.LabelZoneFailureDomainStable: "us-east-1a",
+				},
+			}}
+			_, err := kubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			pod := tests.NewPodTestFixtureWithOptions(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName)
+			pod.Spec.NodeName = node.Name
+			_, err = kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			locality, err := mc.GetLocalityFromEnvoyCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locality).To(Equal(endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"}))
+		})
+
+		It("returns an empty locality for a pod not scheduled yet", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := NewFakeMeshCatalog(kubeClient)
+
+			pod := tests.NewPodTestFixtureWithOptions(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName)
+			_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			locality, err := mc.GetLocalityFromEnvoyCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locality.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Context("Test GetIPFamiliesFromEnvoyCertificate()", func() {
+		It("returns the IP families of the addresses of a dual-stack pod, the family of its primary address first", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := NewFakeMeshCatalog(kubeClient)
+
+			pod := tests.NewPodTestFixtureWithOptions(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName)
+			pod.Status.PodIPs = []v1.PodIP{{IP: "fd00:10:244::5"}, {IP: "10.244.0.5"}}
+			_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			ipFamilies, err := mc.GetIPFamiliesFromEnvoyCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipFamilies).To(Equal([]v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}))
+		})
+
+		It("returns the IP family of the primary address of a pod of a cluster not reporting all the addresses", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := NewFakeMeshCatalog(kubeClient)
+
+			pod := tests.NewPodTestFixtureWithOptions(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName)
+			pod.Status.PodIP = "fd00:10:244::5"
+			_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			ipFamilies, err := mc.GetIPFamiliesFromEnvoyCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipFamilies).To(Equal([]v1.IPFamily{v1.IPv6Protocol}))
+		})
+
+		It("returns IPv4 for a pod without an address yet", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := NewFakeMeshCatalog(kubeClient)
+
+			pod := tests.NewPodTestFixtureWithOptions(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName)
+			_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			ipFamilies, err := mc.GetIPFamiliesFromEnvoyCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipFamilies).To(Equal([]v1.IPFamily{v1.IPv4Protocol}))
+		})
+	})
+
 	Context("Test getServiceFromCertificate()", func() {
 		It("works as expected", func() {
 
@@ -15,21 +15,27 @@ import (
 	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/rotor"
 )
 
 // IssueCertificate implements certificate.Manager and returns a newly issued certificate.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	start := time.Now()
 
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to issue certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
 	// Attempt to grab certificate from cache.
-	if cert := cm.getFromCache(cn); cert != nil {
+	if cert := cm.getFromCache(certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}); cert != nil {
 		return cert, nil
 	}
 
 	// Cache miss/needs rotation so issue new certificate.
-	cert, err := cm.issue(cn, validityPeriod)
+	cert, err := cm.issue(cn, validityPeriod, spiffeID)
 	if err != nil {
 		return nil, err
 	}
@@ -39,21 +45,24 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPerio
 	return cert, nil
 }
 
-// GetCertificate returns a certificate given its Common Name (CN)
-func (cm *CertManager) GetCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
-	if cert := cm.getFromCache(cn); cert != nil {
+// GetCertificate returns the certificate issued for the given Common Name (CN) and SPIFFE ID
+func (cm *CertManager) GetCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
+	if cm.revoked.IsRevoked(cn) {
+		return nil, errCertificateRevoked
+	}
+	if cert := cm.getFromCache(certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}); cert != nil {
 		return cert, nil
 	}
 	return nil, fmt.Errorf("failed to find certificate with CN=%s", cn)
 }
 
-func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certificater {
+func (cm *CertManager) getFromCache(key certificate.CacheKey) certificate.Certificater {
 	cm.cacheLock.RLock()
 	defer cm.cacheLock.RUnlock()
-	if cert, exists := cm.cache[cn]; exists {
-		log.Trace().Msgf("Certificate found in cache CN=%s", cn)
+	if cert, exists := cm.cache[key]; exists {
+		log.Trace().Msgf("Certificate found in cache CN=%s", key)
 		if rotor.ShouldRotate(cert) {
-			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", cn)
+			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", key)
 			return nil
 		}
 		return cert
@@ -61,23 +70,29 @@ func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certi
 	return nil
 }
 
-// RotateCertificate implements certificate.Manager and rotates an existing
-// certificate. When a certificate is successfully created, garbage collect
-// old CertificateRequests.
-func (cm *CertManager) RotateCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+// RotateCertificate implements certificate.Manager and rotates the existing
+// certificate with the given CN and SPIFFE ID. When a certificate is
+// successfully created, garbage collect old CertificateRequests.
+func (cm *CertManager) RotateCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	log.Info().Msgf("Rotating certificate for CN=%s", cn)
 
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to rotate certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
 	start := time.Now()
 
-	cert, err := cm.issue(cn, &cm.validityPeriod)
+	cert, err := cm.issue(cn, &cm.validityPeriod, spiffeID)
 	if err != nil {
 		return cert, err
 	}
 
-	cm.cacheLock.Lock()
-	cm.cache[cn] = cert
-	cm.cacheLock.Unlock()
-	cm.announcements <- nil
+	cm.announcements <- announcements.Announcement{
+		Type:   announcements.CertificateRotated,
+		Name:   cn.String(),
+		Object: cert,
+	}
 
 	log.Info().Msgf("Rotating certificate CN=%s took %+v", cn, time.Since(start))
 
@@ -89,6 +104,58 @@ func (cm *CertManager) GetRootCertificate() (certificate.Certificater, error) {
 	return cm.ca, nil
 }
 
+// GetTrustBundle implements certificate.Manager and returns the PEM encoded root certificate, which is the only one trusted by the mesh.
+func (cm *CertManager) GetTrustBundle() ([]byte, error) {
+	return cm.ca.GetCertificateChain(), nil
+}
+
+// RevokeCertificate implements certificate.Manager and revokes the
+// certificates with the given CN: the certificates are removed from the cache,
+// whatever their SPIFFE ID, and no certificate is issued for this CN anymore.
+func (cm *CertManager) RevokeCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Revoking certificate for CN=%s", cn)
+
+	if err := cm.revoked.Revoke(cn); err != nil {
+		return err
+	}
+
+	cm.forget(cn)
+
+	cm.announcements <- announcements.Announcement{
+		Type: announcements.CertificateRevoked,
+		Name: cn.String(),
+	}
+
+	return nil
+}
+
+// ReleaseCertificate implements certificate.Manager and forgets the
+// certificates with the given CN, which are no longer used: the certificates
+// are removed from the cache, whatever their SPIFFE ID, without being revoked.
+func (cm *CertManager) ReleaseCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Releasing certificate for CN=%s", cn)
+	cm.forget(cn)
+	return nil
+}
+
+// forget removes the certificates with the given CN from the cache, whatever
+// their SPIFFE ID.
+func (cm *CertManager) forget(cn certificate.CommonName) {
+	cm.cacheLock.Lock()
+	for key := range cm.cache {
+		if key.CommonName == cn {
+			delete(cm.cache, key)
+		}
+	}
+	cm.cacheLock.Unlock()
+}
+
+// IsRevoked implements certificate.Manager and determines whether the
+// certificate with the given CN was revoked.
+func (cm *CertManager) IsRevoked(cn certificate.CommonName) bool {
+	return cm.revoked.IsRevoked(cn)
+}
+
 // ListCertificates lists all certificates issued
 func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
 	var certs []certificate.Certificater
@@ -102,7 +169,7 @@ func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
 
 // GetAnnouncementsChannel returns a channel, which is used to announce when
 // changes have been made to the issued certificates.
-func (cm *CertManager) GetAnnouncementsChannel() <-chan interface{} {
+func (cm *CertManager) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return cm.announcements
 }
 
@@ -129,7 +196,7 @@ func (cm *CertManager) certificaterFromCertificateRequest(cr *cmapi.CertificateR
 
 // issue will request a new signed certificate from the configured cert-manager
 // issuer.
-func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
+func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	var duration *metav1.Duration
 	if validityPeriod != nil {
 		duration = &metav1.Duration{
@@ -158,6 +225,7 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 			CommonName: cn.String(),
 		},
 		DNSNames: []string{cn.String()},
+		URIs:     spiffeID.URIs(),
 	}
 
 	csrDER, err := x509.CreateCertificateRequest(rand.Reader, csr, certPrivKey)
@@ -213,19 +281,21 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 
 	cm.cacheLock.Lock()
 	defer cm.cacheLock.Unlock()
-	cm.cache[cert.GetCommonName()] = cert
+	cm.cache[certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}] = cert
 
 	return cert, nil
 }
 
 // NewCertManager will construct a new certificate.Certificater implemented
-// using Jetstack's cert-manager,
+// using Jetstack's cert-manager. When a revocation store is given, the
+// revocations are persisted in it, and the revocations it holds are honored.
 func NewCertManager(
 	ca certificate.Certificater,
 	client cmversionedclient.Interface,
 	namespace string,
 	validityPeriod time.Duration,
 	issuerRef cmmeta.ObjectReference,
+	revocationStore certificate.RevocationStore,
 ) (*CertManager, error) {
 	informerFactory := cminformers.NewSharedInformerFactory(client, time.Second*30)
 	crLister := informerFactory.Certmanager().V1beta1().CertificateRequests().Lister().CertificateRequests(namespace)
@@ -235,8 +305,9 @@ func NewCertManager(
 
 	cm := &CertManager{
 		ca:             ca,
-		cache:          make(map[certificate.CommonName]certificate.Certificater),
-		announcements:  make(chan interface{}),
+		cache:          make(map[certificate.CacheKey]certificate.Certificater),
+		revoked:        certificate.NewRevocationList(revocationStore),
+		announcements:  make(chan announcements.Announcement),
 		namespace:      namespace,
 		client:         client.CertmanagerV1beta1().CertificateRequests(namespace),
 		issuerRef:      issuerRef,
@@ -244,8 +315,5 @@ func NewCertManager(
 		validityPeriod: validityPeriod,
 	}
 
-	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(cm).Start(checkCertificateExpirationInterval)
-
 	return cm, nil
 }
@@ -96,14 +96,14 @@ var _ = Describe("Test cert-manager Certificate Manager", func() {
 			}
 		})
 
-		cm, newCertError := NewCertManager(rootCertificator, fakeClient, "osm-system", validity, cmmeta.ObjectReference{Name: "osm-ca"})
+		cm, newCertError := NewCertManager(rootCertificator, fakeClient, "osm-system", validity, cmmeta.ObjectReference{Name: "osm-ca"}, nil)
 		It("should get an issued certificate from the cache", func() {
 			Expect(newCertError).ToNot(HaveOccurred())
-			cert, issueCertificateError := cm.IssueCertificate(cn, &validity)
+			cert, issueCertificateError := cm.IssueCertificate(cn, &validity, "")
 			Expect(issueCertificateError).ToNot(HaveOccurred())
 			Expect(cert.GetCommonName()).To(Equal(cn))
 
-			cachedCert, getCertificateError := cm.GetCertificate(cn)
+			cachedCert, getCertificateError := cm.GetCertificate(cn, "")
 			Expect(getCertificateError).ToNot(HaveOccurred())
 			Expect(cachedCert).To(Equal(cert))
 		})
@@ -19,8 +19,8 @@ var _ = Describe("Test cert-manager Debugger", func() {
 			expiration: time.Now(),
 			commonName: "foo.bar.co.uk",
 		}
-		cache := map[certificate.CommonName]certificate.Certificater{
-			"foo": cert,
+		cache := map[certificate.CacheKey]certificate.Certificater{
+			{CommonName: "foo"}: cert,
 		}
 		cm := CertManager{
 			cache: cache,
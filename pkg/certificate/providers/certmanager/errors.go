@@ -0,0 +1,7 @@
+package certmanager
+
+import (
+	"errors"
+)
+
+var errCertificateRevoked = errors.New("certificate revoked")
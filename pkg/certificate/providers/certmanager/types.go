@@ -8,6 +8,7 @@ import (
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1beta1"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1beta1"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -16,10 +17,6 @@ import (
 const (
 	// How many bits to use for the RSA key
 	rsaBits = 4096
-
-	// checkCertificateExpirationInterval is the interval to check wether a
-	// certificate is close to expiration and needs renewal.
-	checkCertificateExpirationInterval = 5 * time.Second
 )
 
 var (
@@ -37,12 +34,16 @@ type CertManager struct {
 
 	// cache holds a local cache of issued certificates as
 	// certificate.Certificaters
-	cache     map[certificate.CommonName]certificate.Certificater
+	cache     map[certificate.CacheKey]certificate.Certificater
 	cacheLock sync.RWMutex
 
+	// revoked holds the Common Names of the revoked certificates, which are
+	// not issued anymore.
+	revoked *certificate.RevocationList
+
 	// The channel announcing to the rest of the system when a certificate has
 	// changed.
-	announcements chan interface{}
+	announcements chan announcements.Announcement
 
 	certificatesOrganization string
 
@@ -3,6 +3,7 @@ package keyvault
 import (
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -13,5 +14,5 @@ var (
 type client struct {
 	client        *keyvault.BaseClient
 	vaultURL      string
-	announcements chan interface{}
+	announcements chan announcements.Announcement
 }
@@ -3,12 +3,8 @@ package tresor
 import (
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
-	"github.com/openservicemesh/osm/pkg/certificate/rotor"
-)
-
-const (
-	checkCertificateExpirationInterval = 5 * time.Second
 )
 
 // GetCommonName implements certificate.Certificater and returns the CN of the cert.
@@ -69,32 +65,46 @@ func LoadCA(certFilePEM string, keyFilePEM string) (*Certificate, error) {
 	return &rootCertificate, nil
 }
 
-// NewCertManager creates a new CertManager with the passed CA and CA Private Key
-func NewCertManager(ca certificate.Certificater, validityPeriod time.Duration, certificatesOrganization string) (*CertManager, error) {
+// NewCertManager creates a new CertManager with the passed CA and CA Private Key.
+// When a certificate store is given, the certificates issued are persisted in it, and the certificates it holds are loaded.
+// When a revocation store is given, the revocations are persisted in it, and the revocations it holds are honored.
+func NewCertManager(ca certificate.Certificater, validityPeriod time.Duration, certificatesOrganization string, certStore certificate.Store, revocationStore certificate.RevocationStore) (*CertManager, error) {
 	if ca == nil {
 		return nil, errNoIssuingCA
 	}
 
-	cache := make(map[certificate.CommonName]certificate.Certificater)
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
 
 	certManager := CertManager{
 		// The root certificate signing all newly issued certificates
 		ca: ca,
 
+		// Until the root certificate is rotated, the mesh only trusts the root certificate signing the certificates
+		trustBundle: ca.GetCertificateChain(),
+
 		// Newly issued certificates will be valid for this duration
 		validityPeriod: validityPeriod,
 
 		// Channel used to inform other components of cert changes (rotation etc.)
-		announcements: make(chan interface{}),
+		announcements: make(chan announcements.Announcement),
 
 		// Certificate cache
 		cache: &cache,
 
+		// Certificate persistence
+		store: certStore,
+
+		// Revoked certificates, which are not issued anymore
+		revoked: certificate.NewRevocationList(revocationStore),
+
 		certificatesOrganization: certificatesOrganization,
 	}
 
-	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(&certManager).Start(checkCertificateExpirationInterval)
+	if certStore != nil {
+		if err := certManager.loadStoredCertificates(); err != nil {
+			return nil, err
+		}
+	}
 
 	return &certManager, nil
 }
@@ -1,6 +1,7 @@
 package tresor
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -9,16 +10,18 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/rotor"
 )
 
-func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
+func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	if validityPeriod == nil {
 		validityPeriod = &cm.validityPeriod
 	}
 
-	if cm.ca == nil {
+	ca := cm.getCA()
+	if ca == nil {
 		log.Error().Msgf("Invalid CA provided for issuance of certificate with CN=%s", cn)
 		return nil, errNoIssuingCA
 	}
@@ -39,6 +42,7 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 		SerialNumber: serialNumber,
 
 		DNSNames: []string{string(cn)},
+		URIs:     spiffeID.URIs(),
 
 		Subject: pkix.Name{
 			CommonName:   string(cn),
@@ -52,12 +56,12 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 		BasicConstraintsValid: true,
 	}
 
-	x509Root, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
+	x509Root, err := certificate.DecodePEMCertificate(ca.GetCertificateChain())
 	if err != nil {
 		log.Error().Err(err).Msg("Error decoding Root Certificate's PEM")
 	}
 
-	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
+	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(ca.GetPrivateKey())
 	if err != nil {
 		log.Error().Err(err).Msg("Error decoding Root Certificate's Private Key PEM ")
 	}
@@ -84,22 +88,22 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 		commonName: cn,
 		certChain:  certPEM,
 		privateKey: privKeyPEM,
-		issuingCA:  cm.ca,
+		issuingCA:  ca,
 		expiration: template.NotAfter,
 	}
 
-	log.Info().Msgf("Created new certificate for CN=%s; validity=%+v; expires on %+v; serial: %x", cn, validityPeriod, template.NotAfter, template.SerialNumber)
+	log.Info().Msgf("Created new certificate for CN=%s; SPIFFE ID=%q; validity=%+v; expires on %+v; serial: %x", cn, spiffeID, validityPeriod, template.NotAfter, template.SerialNumber)
 
 	return cert, nil
 }
 
-func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certificater {
+func (cm *CertManager) getFromCache(key certificate.CacheKey) certificate.Certificater {
 	cm.cacheLock.Lock()
 	defer cm.cacheLock.Unlock()
-	if cert, exists := (*cm.cache)[cn]; exists {
-		log.Trace().Msgf("Certificate found in cache CN=%s", cn)
+	if cert, exists := (*cm.cache)[key]; exists {
+		log.Trace().Msgf("Certificate found in cache CN=%s", key)
 		if rotor.ShouldRotate(cert) {
-			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", cn)
+			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", key)
 			return nil
 		}
 		return cert
@@ -107,57 +111,243 @@ func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certi
 	return nil
 }
 
+// getFromStore returns the certificate with the given key from the certificate store, when it is usable,
+// and caches it. The certificate may have been issued before a restart, or by another replica.
+func (cm *CertManager) getFromStore(key certificate.CacheKey) certificate.Certificater {
+	if cm.store == nil {
+		return nil
+	}
+
+	cert, err := cm.store.Get(key)
+	if err != nil || cert == nil || !cm.isUsable(cert) {
+		return nil
+	}
+
+	log.Trace().Msgf("Certificate found in store CN=%s", key)
+	cm.cacheLock.Lock()
+	(*cm.cache)[key] = cert
+	cm.cacheLock.Unlock()
+	return cert
+}
+
+// isUsable determines whether a stored certificate was issued by the CA of this certificate manager and does not need to be rotated.
+func (cm *CertManager) isUsable(cert certificate.Certificater) bool {
+	return bytes.Equal(cert.GetIssuingCA(), cm.getCA().GetCertificateChain()) && !rotor.ShouldRotate(cert)
+}
+
+// storeCertificate persists the given certificate when the certificate manager has a certificate store.
+func (cm *CertManager) storeCertificate(cert certificate.Certificater) {
+	if cm.store == nil {
+		return
+	}
+	if err := cm.store.Put(cert); err != nil {
+		log.Error().Err(err).Msgf("Error storing certificate CN=%s", cert.GetCommonName())
+	}
+}
+
+// loadStoredCertificates caches the usable certificates of the certificate store, and removes the expired ones from the store.
+func (cm *CertManager) loadStoredCertificates() error {
+	certs, err := cm.store.List()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing stored certificates")
+		return err
+	}
+
+	cm.cacheLock.Lock()
+	defer cm.cacheLock.Unlock()
+	for _, cert := range certs {
+		if time.Now().After(cert.GetExpiration()) {
+			log.Trace().Msgf("Removing expired certificate CN=%s from the store", cert.GetCommonName())
+			if err := cm.store.Delete(certificate.GetCacheKey(cert)); err != nil {
+				log.Error().Err(err).Msgf("Error removing expired certificate CN=%s from the store", cert.GetCommonName())
+			}
+			continue
+		}
+		if !bytes.Equal(cert.GetIssuingCA(), cm.getCA().GetCertificateChain()) {
+			log.Trace().Msgf("Stored certificate CN=%s was issued by another CA; it will be issued again", cert.GetCommonName())
+			continue
+		}
+		(*cm.cache)[certificate.GetCacheKey(cert)] = cert
+	}
+
+	log.Info().Msgf("Loaded %d certificates from the store", len(*cm.cache))
+	return nil
+}
+
 // IssueCertificate implements certificate.Manager and returns a newly issued certificate.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
+// Certificates are cached and stored per CN and SPIFFE ID, so the service accounts of a service each get their own certificate.
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	start := time.Now()
 
-	if cert := cm.getFromCache(cn); cert != nil {
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to issue certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
+	key := certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}
+	if cert := cm.getFromCache(key); cert != nil {
 		return cert, nil
 	}
 
-	cert, err := cm.issue(cn, validityPeriod)
+	if cert := cm.getFromStore(key); cert != nil {
+		return cert, nil
+	}
+
+	cert, err := cm.issue(cn, validityPeriod, spiffeID)
 	if err != nil {
 		return cert, err
 	}
 
 	cm.cacheLock.Lock()
-	(*cm.cache)[cn] = cert
+	(*cm.cache)[key] = cert
 	cm.cacheLock.Unlock()
+	cm.storeCertificate(cert)
 
 	log.Info().Msgf("It took %+v to issue certificate with CN=%s", time.Since(start), cn)
 
 	return cert, nil
 }
 
-// GetCertificate returns a certificate given its Common Name (CN)
-func (cm *CertManager) GetCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
-	if cert := cm.getFromCache(cn); cert != nil {
+// GetCertificate returns the certificate issued for the given Common Name (CN) and SPIFFE ID
+func (cm *CertManager) GetCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
+	if cm.revoked.IsRevoked(cn) {
+		return nil, errCertificateRevoked
+	}
+	key := certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}
+	if cert := cm.getFromCache(key); cert != nil {
+		return cert, nil
+	}
+	if cert := cm.getFromStore(key); cert != nil {
 		return cert, nil
 	}
 	return nil, errCertNotFound
 }
 
-// RotateCertificate implements certificate.Manager and rotates an existing certificate.
-func (cm *CertManager) RotateCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+// RotateCertificate implements certificate.Manager and rotates the existing certificate with the given CN and SPIFFE ID.
+func (cm *CertManager) RotateCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	log.Info().Msgf("Rotating certificate for CN=%s", cn)
 
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to rotate certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
 	start := time.Now()
 
-	cert, err := cm.issue(cn, &cm.validityPeriod)
+	cert, err := cm.issue(cn, &cm.validityPeriod, spiffeID)
 	if err != nil {
 		return cert, err
 	}
 
 	cm.cacheLock.Lock()
-	(*cm.cache)[cn] = cert
+	(*cm.cache)[certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}] = cert
 	cm.cacheLock.Unlock()
-	cm.announcements <- nil
+	cm.storeCertificate(cert)
+	cm.announcements <- announcements.Announcement{
+		Type:   announcements.CertificateRotated,
+		Name:   cn.String(),
+		Object: cert,
+	}
 
 	log.Info().Msgf("Rotating certificate CN=%s took %+v", cn, time.Since(start))
 
 	return cert, nil
 }
 
+// RevokeCertificate implements certificate.Manager and revokes the certificates with the given CN:
+// the certificates are removed from the cache and from the store, whatever their SPIFFE ID, and no certificate is issued for this CN anymore.
+func (cm *CertManager) RevokeCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Revoking certificate for CN=%s", cn)
+
+	if err := cm.revoked.Revoke(cn); err != nil {
+		return err
+	}
+
+	if err := cm.forget(cn); err != nil {
+		return err
+	}
+
+	cm.announcements <- announcements.Announcement{
+		Type: announcements.CertificateRevoked,
+		Name: cn.String(),
+	}
+
+	return nil
+}
+
+// ReleaseCertificate implements certificate.Manager and forgets the certificates with the given CN, which are no longer used:
+// the certificates are removed from the cache and from the store, whatever their SPIFFE ID, without being revoked.
+func (cm *CertManager) ReleaseCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Releasing certificate for CN=%s", cn)
+	return cm.forget(cn)
+}
+
+// forget removes the certificates with the given CN from the cache and from the store, whatever their SPIFFE ID.
+// The cache holds all the stored certificates, which are loaded from the store, so only the cached certificates are removed from the store.
+func (cm *CertManager) forget(cn certificate.CommonName) error {
+	var keys []certificate.CacheKey
+	cm.cacheLock.Lock()
+	for key := range *cm.cache {
+		if key.CommonName == cn {
+			keys = append(keys, key)
+			delete(*cm.cache, key)
+		}
+	}
+	cm.cacheLock.Unlock()
+
+	if cm.store == nil {
+		return nil
+	}
+	for _, key := range keys {
+		if err := cm.store.Delete(key); err != nil {
+			log.Error().Err(err).Msgf("Error removing certificate CN=%s from the store", cn)
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCertificate caches the given certificate, stored by another osm-controller replica, in place of the cached certificate with the same CN and SPIFFE ID.
+// This is how the replicas pick up the certificates rotated by the replica which leads.
+func (cm *CertManager) LoadCertificate(cert certificate.Certificater) {
+	if cm.revoked.IsRevoked(cert.GetCommonName()) || !bytes.Equal(cert.GetIssuingCA(), cm.getCA().GetCertificateChain()) {
+		return
+	}
+
+	key := certificate.GetCacheKey(cert)
+	cm.cacheLock.Lock()
+	cached, replaced := (*cm.cache)[key]
+	if replaced && bytes.Equal(cached.GetCertificateChain(), cert.GetCertificateChain()) {
+		cm.cacheLock.Unlock()
+		return
+	}
+	(*cm.cache)[key] = cert
+	cm.cacheLock.Unlock()
+
+	if !replaced {
+		return
+	}
+
+	log.Trace().Msgf("Loaded certificate CN=%s rotated by another replica", key)
+	cm.announcements <- announcements.Announcement{
+		Type:   announcements.CertificateRotated,
+		Name:   cert.GetCommonName().String(),
+		Object: cert,
+	}
+}
+
+// UnloadCertificate removes the certificate with the given key, which another osm-controller replica removed from the store, from the cache.
+func (cm *CertManager) UnloadCertificate(key certificate.CacheKey) {
+	cm.cacheLock.Lock()
+	delete(*cm.cache, key)
+	cm.cacheLock.Unlock()
+}
+
+// IsRevoked implements certificate.Manager and determines whether the certificate with the given CN was revoked.
+func (cm *CertManager) IsRevoked(cn certificate.CommonName) bool {
+	return cm.revoked.IsRevoked(cn)
+}
+
 // ListCertificates lists all certificates issued
 func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
 	var certs []certificate.Certificater
@@ -171,10 +361,54 @@ func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
 
 // GetRootCertificate returns the root certificate.
 func (cm *CertManager) GetRootCertificate() (certificate.Certificater, error) {
-	return cm.ca, nil
+	return cm.getCA(), nil
+}
+
+// GetTrustBundle implements certificate.Manager and returns the PEM encoded root certificates trusted by the mesh.
+func (cm *CertManager) GetTrustBundle() ([]byte, error) {
+	cm.caLock.RLock()
+	defer cm.caLock.RUnlock()
+	return cm.trustBundle, nil
+}
+
+// UpdateCA replaces the root certificate signing the newly issued certificates, and the root certificates trusted by the mesh.
+// When the signing root certificate changes, all the certificates issued so far are issued again by the new root certificate.
+func (cm *CertManager) UpdateCA(ca certificate.Certificater, trustBundle []byte) {
+	cm.caLock.Lock()
+	caChanged := !bytes.Equal(cm.ca.GetCertificateChain(), ca.GetCertificateChain())
+	trustBundleChanged := !bytes.Equal(cm.trustBundle, trustBundle)
+	cm.ca = ca
+	cm.trustBundle = trustBundle
+	cm.caLock.Unlock()
+
+	if trustBundleChanged {
+		log.Info().Msg("The root certificates trusted by the mesh changed")
+		cm.announcements <- announcements.Announcement{
+			Type:   announcements.TrustBundleChanged,
+			Object: trustBundle,
+		}
+	}
+
+	if !caChanged {
+		return
+	}
+
+	log.Info().Msgf("The root certificate changed; issuing all certificates again with the new root certificate expiring on %+v", ca.GetExpiration())
+	certs, _ := cm.ListCertificates()
+	for _, cert := range certs {
+		if _, err := cm.RotateCertificate(cert.GetCommonName(), certificate.GetSPIFFEID(cert)); err != nil {
+			log.Error().Err(err).Msgf("Error issuing certificate CN=%s with the new root certificate", cert.GetCommonName())
+		}
+	}
+}
+
+func (cm *CertManager) getCA() certificate.Certificater {
+	cm.caLock.RLock()
+	defer cm.caLock.RUnlock()
+	return cm.ca
 }
 
 // GetAnnouncementsChannel implements certificate.Manager and returns the channel on which the certificate manager announces changes made to certificates.
-func (cm *CertManager) GetAnnouncementsChannel() <-chan interface{} {
+func (cm *CertManager) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return cm.announcements
 }
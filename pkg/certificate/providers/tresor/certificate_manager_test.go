@@ -3,7 +3,9 @@ package tresor
 import (
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/store"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -29,10 +31,10 @@ var _ = Describe("Test Certificate Manager", func() {
 			expected := "-----BEGIN CERTIFICATE-----\nMIIElzCCA3+gAwIBAgIRAOsakgIV4y"
 			Expect(string(rootCert.GetCertificateChain()[:len(expected)])).To(Equal(expected))
 
-			m, newCertError := NewCertManager(rootCert, validity, "org")
+			m, newCertError := NewCertManager(rootCert, validity, "org", nil, nil)
 			Expect(newCertError).ToNot(HaveOccurred())
 
-			cert, err := m.IssueCertificate(serviceFQDN, nil)
+			cert, err := m.IssueCertificate(serviceFQDN, nil, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(cert.GetCommonName()).To(Equal(certificate.CommonName(serviceFQDN)))
 
@@ -67,10 +69,10 @@ var _ = Describe("Test Certificate Manager", func() {
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Error loading CA from files %s and %s", rootCertPem, rootKeyPem)
 		}
-		m, newCertError := NewCertManager(rootCert, validity, "org")
+		m, newCertError := NewCertManager(rootCert, validity, "org", nil, nil)
 		It("should issue a certificate", func() {
 			Expect(newCertError).ToNot(HaveOccurred())
-			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil)
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, nil, "")
 			Expect(issueCertificateError).ToNot(HaveOccurred())
 			Expect(cert.GetCommonName()).To(Equal(certificate.CommonName(serviceFQDN)))
 
@@ -105,16 +107,325 @@ var _ = Describe("Test Certificate Manager", func() {
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Error loading CA from files %s and %s", rootCertPem, rootKeyPem)
 		}
-		m, newCertError := NewCertManager(rootCert, validity, "org")
+		m, newCertError := NewCertManager(rootCert, validity, "org", nil, nil)
 		It("should get an issued certificate from the cache", func() {
 			Expect(newCertError).ToNot(HaveOccurred())
-			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, &validity)
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, &validity, "")
 			Expect(issueCertificateError).ToNot(HaveOccurred())
 			Expect(cert.GetCommonName()).To(Equal(certificate.CommonName(serviceFQDN)))
 
-			cachedCert, getCertificateError := m.GetCertificate(serviceFQDN)
+			cachedCert, getCertificateError := m.GetCertificate(serviceFQDN, "")
 			Expect(getCertificateError).ToNot(HaveOccurred())
 			Expect(cachedCert).To(Equal(cert))
 		})
 	})
+
+	Context("Test sharing certificates through a certificate store", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+		rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error creating CA")
+		}
+
+		It("should load the certificates issued by a previous certificate manager", func() {
+			certStore := store.NewMemoryStore()
+			m, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			restarted, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			certs, err := restarted.ListCertificates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certs).To(HaveLen(1))
+
+			reloadedCert, err := restarted.GetCertificate(serviceFQDN, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reloadedCert.GetCertificateChain()).To(Equal(cert.GetCertificateChain()))
+
+			reissuedCert, err := restarted.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reissuedCert.GetCertificateChain()).To(Equal(cert.GetCertificateChain()))
+		})
+
+		It("should not load the certificates issued by another CA", func() {
+			certStore := store.NewMemoryStore()
+			m, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			otherCA, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			other, err := NewCertManager(otherCA, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			certs, err := other.ListCertificates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certs).To(BeEmpty())
+
+			reissuedCert, err := other.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reissuedCert.GetCertificateChain()).ToNot(Equal(cert.GetCertificateChain()))
+		})
+	})
+
+	Context("Test rotating the root certificate", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+
+		It("should trust both root certificates and issue all certificates again with the new one", func() {
+			oldCA, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			newCA, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+
+			m, err := NewCertManager(oldCA, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			oldCert, err := m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			var kinds []announcements.Kind
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for a := range m.GetAnnouncementsChannel() {
+					kinds = append(kinds, a.Type)
+				}
+			}()
+
+			trustBundle := append(append([]byte{}, oldCA.GetCertificateChain()...), newCA.GetCertificateChain()...)
+
+			// Introduce the new root certificate
+			m.UpdateCA(oldCA, trustBundle)
+			actualTrustBundle, err := m.GetTrustBundle()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualTrustBundle).To(Equal(trustBundle))
+			cert, err := m.GetCertificate(serviceFQDN, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cert.GetCertificateChain()).To(Equal(oldCert.GetCertificateChain()))
+
+			// Activate the new root certificate
+			m.UpdateCA(newCA, trustBundle)
+			root, err := m.GetRootCertificate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(root).To(Equal(newCA))
+			cert, err = m.GetCertificate(serviceFQDN, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cert.GetIssuingCA()).To(Equal(newCA.GetCertificateChain()))
+
+			// Retire the old root certificate
+			m.UpdateCA(newCA, newCA.GetCertificateChain())
+			actualTrustBundle, err = m.GetTrustBundle()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualTrustBundle).To(Equal(newCA.GetCertificateChain()))
+
+			close(m.announcements)
+			<-done
+			Expect(kinds).To(Equal([]announcements.Kind{announcements.TrustBundleChanged, announcements.CertificateRotated, announcements.TrustBundleChanged}))
+		})
+	})
+
+	Context("Test revoking a certificate", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+
+		It("should forget the certificate and refuse to issue it again", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			certStore := store.NewMemoryStore()
+			m, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			var kinds []announcements.Kind
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for a := range m.GetAnnouncementsChannel() {
+					kinds = append(kinds, a.Type)
+				}
+			}()
+
+			Expect(m.RevokeCertificate(serviceFQDN)).To(Succeed())
+			Expect(m.IsRevoked(serviceFQDN)).To(BeTrue())
+
+			_, err = m.GetCertificate(serviceFQDN, "")
+			Expect(err).To(Equal(errCertificateRevoked))
+			_, err = m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).To(Equal(errCertificateRevoked))
+			_, err = m.RotateCertificate(serviceFQDN, "")
+			Expect(err).To(Equal(errCertificateRevoked))
+
+			storedCert, err := certStore.Get(certificate.CacheKey{CommonName: serviceFQDN})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(storedCert).To(BeNil())
+
+			close(m.announcements)
+			<-done
+			Expect(kinds).To(Equal([]announcements.Kind{announcements.CertificateRevoked}))
+		})
+	})
+
+	Context("Test releasing a certificate", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+
+		It("should forget the certificate and issue it again", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			certStore := store.NewMemoryStore()
+			m, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(m.ReleaseCertificate(serviceFQDN)).To(Succeed())
+			Expect(m.IsRevoked(serviceFQDN)).To(BeFalse())
+
+			_, err = m.GetCertificate(serviceFQDN, "")
+			Expect(err).To(Equal(errCertNotFound))
+			storedCert, err := certStore.Get(certificate.CacheKey{CommonName: serviceFQDN})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(storedCert).To(BeNil())
+
+			_, err = m.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Test loading the certificates rotated by another replica", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+
+		It("should replace the cached certificate with the one rotated by the leader", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			leader, err := NewCertManager(rootCert, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			replica, err := NewCertManager(rootCert, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			cert, err := replica.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			go func() {
+				<-leader.GetAnnouncementsChannel()
+			}()
+			rotatedCert, err := leader.RotateCertificate(serviceFQDN, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotatedCert.GetCertificateChain()).ToNot(Equal(cert.GetCertificateChain()))
+
+			announced := make(chan announcements.Announcement, 1)
+			go func() {
+				announced <- <-replica.GetAnnouncementsChannel()
+			}()
+			replica.LoadCertificate(rotatedCert)
+			Expect((<-announced).Type).To(Equal(announcements.CertificateRotated))
+			loadedCert, err := replica.GetCertificate(serviceFQDN, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loadedCert.GetCertificateChain()).To(Equal(rotatedCert.GetCertificateChain()))
+
+			// Loading the same certificate again changes nothing, and announces nothing
+			replica.LoadCertificate(rotatedCert)
+
+			replica.UnloadCertificate(certificate.GetCacheKey(rotatedCert))
+			_, err = replica.GetCertificate(serviceFQDN, "")
+			Expect(err).To(Equal(errCertNotFound))
+		})
+
+		It("should not load the certificates issued by another CA", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			otherCA, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			m, err := NewCertManager(rootCert, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			other, err := NewCertManager(otherCA, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherCert, err := other.IssueCertificate(serviceFQDN, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			m.LoadCertificate(otherCert)
+			_, err = m.GetCertificate(serviceFQDN, "")
+			Expect(err).To(Equal(errCertNotFound))
+		})
+	})
+
+	Context("Test issuing a certificate with a SPIFFE ID", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+		spiffeID := certificate.NewSPIFFEID("cluster.local", "default", "bookstore")
+
+		It("should carry the SPIFFE ID as a URI SAN, and keep it when the certificate is rotated", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			m, err := NewCertManager(rootCert, validity, "org", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			cert, err := m.IssueCertificate(serviceFQDN, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(x509Cert.URIs).To(HaveLen(1))
+			Expect(x509Cert.URIs[0].String()).To(Equal("spiffe://cluster.local/ns/default/sa/bookstore"))
+
+			cachedCert, err := m.IssueCertificate(serviceFQDN, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cachedCert).To(Equal(cert))
+
+			go func() {
+				<-m.GetAnnouncementsChannel()
+			}()
+			rotatedCert, err := m.RotateCertificate(serviceFQDN, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotatedCert.GetCertificateChain()).ToNot(Equal(cert.GetCertificateChain()))
+			Expect(certificate.GetSPIFFEID(rotatedCert)).To(Equal(spiffeID))
+		})
+
+		It("should keep a certificate per SPIFFE ID for the service accounts of the same service", func() {
+			rootCert, err := NewCA(cn, validity, "US", "CA", "Open Service Mesh Tresor")
+			Expect(err).ToNot(HaveOccurred())
+			certStore := store.NewMemoryStore()
+			m, err := NewCertManager(rootCert, validity, "org", certStore, nil)
+			Expect(err).ToNot(HaveOccurred())
+			otherSPIFFEID := certificate.NewSPIFFEID("cluster.local", "default", "bookstore-v2")
+
+			cert, err := m.IssueCertificate(serviceFQDN, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			otherCert, err := m.IssueCertificate(serviceFQDN, nil, otherSPIFFEID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate.GetSPIFFEID(otherCert)).To(Equal(otherSPIFFEID))
+
+			// Issuing the certificate of one service account does not replace the certificate of the other one
+			cachedCert, err := m.IssueCertificate(serviceFQDN, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cachedCert).To(Equal(cert))
+			cachedOtherCert, err := m.IssueCertificate(serviceFQDN, nil, otherSPIFFEID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cachedOtherCert).To(Equal(otherCert))
+
+			certs, err := m.ListCertificates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certs).To(HaveLen(2))
+			storedCerts, err := certStore.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(storedCerts).To(HaveLen(2))
+
+			// Revoking the CN revokes the certificates of both service accounts
+			go func() {
+				<-m.GetAnnouncementsChannel()
+			}()
+			Expect(m.RevokeCertificate(serviceFQDN)).To(Succeed())
+			certs, err = m.ListCertificates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certs).To(BeEmpty())
+			storedCerts, err = certStore.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(storedCerts).To(BeEmpty())
+		})
+	})
 })
@@ -19,8 +19,8 @@ var _ = Describe("Test Tresor Debugger", func() {
 			commonName: "foo.bar.co.uk",
 		}
 		cert.issuingCA = cert
-		cache := map[certificate.CommonName]certificate.Certificater{
-			"foo": cert,
+		cache := map[certificate.CacheKey]certificate.Certificater{
+			{CommonName: "foo"}: cert,
 		}
 		cm := CertManager{
 			cache: &cache,
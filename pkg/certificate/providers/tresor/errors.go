@@ -9,3 +9,4 @@ var errGeneratingSerialNumber = errors.New("generate serial number")
 var errGeneratingPrivateKey = errors.New("generate private")
 var errNoIssuingCA = errors.New("no issuing CA")
 var errCertNotFound = errors.New("certificate not found")
+var errCertificateRevoked = errors.New("certificate revoked")
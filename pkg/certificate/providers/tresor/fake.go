@@ -3,11 +3,12 @@ package tresor
 import (
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
 // NewFakeCertManager creates a fake CertManager used for testing.
-func NewFakeCertManager(cache *map[certificate.CommonName]certificate.Certificater, validityPeriod time.Duration) *CertManager {
+func NewFakeCertManager(cache *map[certificate.CacheKey]certificate.Certificater, validityPeriod time.Duration) *CertManager {
 	rootCertCountry := "US"
 	rootCertLocality := "CA"
 	rootCertOrganization := "Open Service Mesh Tresor"
@@ -18,8 +19,10 @@ func NewFakeCertManager(cache *map[certificate.CommonName]certificate.Certificat
 
 	return &CertManager{
 		ca:             ca.(*Certificate),
+		trustBundle:    ca.GetCertificateChain(),
 		validityPeriod: validityPeriod,
-		announcements:  make(chan interface{}),
+		announcements:  make(chan announcements.Announcement),
 		cache:          cache,
+		revoked:        certificate.NewRevocationList(nil),
 	}
 }
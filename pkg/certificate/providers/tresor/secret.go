@@ -0,0 +1,49 @@
+package tresor
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// GetCAFromSecret returns the CA held by the given CA bundle secret, or nil when the secret does not hold a CA along with its private key.
+func GetCAFromSecret(rootCertSecret *corev1.Secret) (certificate.Certificater, error) {
+	namespace, secretName := rootCertSecret.Namespace, rootCertSecret.Name
+
+	pemCert, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretCAKey]
+	if !ok {
+		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", namespace, secretName, constants.KubernetesOpaqueSecretCAKey)
+		return nil, nil
+	}
+
+	pemKey, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]
+	if !ok {
+		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", namespace, secretName, constants.KubernetesOpaqueSecretRootPrivateKeyKey)
+		return nil, nil
+	}
+
+	expirationBytes, ok := rootCertSecret.Data[constants.KubernetesOpaqueSecretCAExpiration]
+	if !ok {
+		log.Error().Msgf("Opaque k8s secret %s/%s does not have required field %q", namespace, secretName, constants.KubernetesOpaqueSecretCAExpiration)
+		return nil, nil
+	}
+
+	expiration, err := time.Parse(constants.TimeDateLayout, string(expirationBytes))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing CA expiration %q from Kubernetes rootCertSecret %q from namespace %q", string(expirationBytes), secretName, namespace)
+	}
+
+	return NewCertificateFromPEM(pemCert, pemKey, expiration)
+}
+
+// GetTrustBundleFromSecret returns the root certificates trusted by the mesh held by the given CA bundle secret.
+// Until the root certificate is rotated for the first time, the secret only holds the CA, which is the only trusted root certificate.
+func GetTrustBundleFromSecret(secret *corev1.Secret) []byte {
+	if trustBundle := secret.Data[constants.KubernetesOpaqueSecretTrustBundleKey]; len(trustBundle) > 0 {
+		return trustBundle
+	}
+	return secret.Data[constants.KubernetesOpaqueSecretCAKey]
+}
@@ -0,0 +1,66 @@
+package tresor
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Test loading the CA bundle secret", func() {
+	ca, err := NewCA("Tresor CA for Testing", 1*time.Hour, "US", "CA", "Open Service Mesh Tresor")
+	It("should create a new CA", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("Test GetCAFromSecret()", func() {
+		It("loads the CA along with its private key", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey:             ca.GetCertificateChain(),
+					constants.KubernetesOpaqueSecretRootPrivateKeyKey: ca.GetPrivateKey(),
+					constants.KubernetesOpaqueSecretCAExpiration:      []byte(ca.GetExpiration().Format(constants.TimeDateLayout)),
+				},
+			}
+			actual, err := GetCAFromSecret(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.GetCertificateChain()).To(Equal(ca.GetCertificateChain()))
+			Expect(actual.GetPrivateKey()).To(Equal(ca.GetPrivateKey()))
+		})
+
+		It("returns nil when the secret does not hold the private key of the CA", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey: ca.GetCertificateChain(),
+				},
+			}
+			actual, err := GetCAFromSecret(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(BeNil())
+		})
+	})
+
+	Context("Test GetTrustBundleFromSecret()", func() {
+		It("returns the trust bundle held by the secret", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey:          []byte("ca"),
+					constants.KubernetesOpaqueSecretTrustBundleKey: []byte("ca next-ca"),
+				},
+			}
+			Expect(GetTrustBundleFromSecret(secret)).To(Equal([]byte("ca next-ca")))
+		})
+
+		It("returns the CA when the secret does not hold a trust bundle", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey: []byte("ca"),
+				},
+			}
+			Expect(GetTrustBundleFromSecret(secret)).To(Equal([]byte("ca")))
+		})
+	})
+})
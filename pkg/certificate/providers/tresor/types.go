@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -34,13 +35,24 @@ type CertManager struct {
 	// The Certificate Authority root certificate to be used by this certificate manager
 	ca certificate.Certificater
 
+	// The PEM encoded root certificates trusted by the mesh; while the root certificate is being rotated,
+	// it holds root certificates other than the one signing the newly issued certificates.
+	trustBundle []byte
+	caLock      sync.RWMutex
+
 	// The channel announcing to the rest of the system when a certificate has changed
-	announcements chan interface{}
+	announcements chan announcements.Announcement
 
 	// Cache for all the certificates issued
-	cache     *map[certificate.CommonName]certificate.Certificater
+	cache     *map[certificate.CacheKey]certificate.Certificater
 	cacheLock sync.Mutex
 
+	// Store persisting the certificates issued; nil when the certificates are not persisted
+	store certificate.Store
+
+	// The Common Names of the revoked certificates, which are not issued anymore
+	revoked *certificate.RevocationList
+
 	certificatesOrganization string
 }
 
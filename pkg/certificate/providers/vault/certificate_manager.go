@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/vault/api"
 	"github.com/pkg/errors"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/rotor"
@@ -19,17 +20,17 @@ const (
 	certificateField = "certificate"
 	privateKeyField  = "private_key"
 	issuingCAField   = "issuing_ca"
-
-	checkCertificateExpirationInterval = 5 * time.Second
 )
 
 // NewCertManager implements certificate.Manager and wraps a Hashi Vault with methods to allow easy certificate issuance.
-func NewCertManager(vaultAddr, token string, validityPeriod time.Duration, vaultRole string) (*CertManager, error) {
-	cache := make(map[certificate.CommonName]certificate.Certificater)
+// When a revocation store is given, the revocations are persisted in it, and the revocations it holds are honored.
+func NewCertManager(vaultAddr, token string, validityPeriod time.Duration, vaultRole string, revocationStore certificate.RevocationStore) (*CertManager, error) {
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
 	c := &CertManager{
 		validityPeriod: validityPeriod,
-		announcements:  make(chan interface{}),
+		announcements:  make(chan announcements.Announcement),
 		cache:          &cache,
+		revoked:        certificate.NewRevocationList(revocationStore),
 		vaultRole:      vaultRole,
 	}
 	config := api.DefaultConfig()
@@ -44,7 +45,7 @@ func NewCertManager(vaultAddr, token string, validityPeriod time.Duration, vault
 
 	c.client.SetToken(token)
 
-	someCert, err := c.issue("localhost", nil)
+	someCert, err := c.issue("localhost", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -56,14 +57,11 @@ func NewCertManager(vaultAddr, token string, validityPeriod time.Duration, vault
 		issuingCA:  someCert.GetIssuingCA(),
 	}
 
-	// Instantiating a new certificate rotation mechanism will start a goroutine for certificate rotation.
-	rotor.New(c).Start(checkCertificateExpirationInterval)
-
 	return c, nil
 }
 
-func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
-	secret, err := cm.client.Logical().Write(getIssueURL(cm.vaultRole), getIssuanceData(cn, cm.validityPeriod))
+func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
+	secret, err := cm.client.Logical().Write(getIssueURL(cm.vaultRole), getIssuanceData(cn, cm.validityPeriod, spiffeID))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error issuing new certificate for CN=%s", cn)
 		return nil, err
@@ -76,13 +74,13 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod *time.Dur
 	return newCert(cn, secret, time.Now().Add(*validityPeriod)), nil
 }
 
-func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certificater {
+func (cm *CertManager) getFromCache(key certificate.CacheKey) certificate.Certificater {
 	cm.cacheLock.Lock()
 	defer cm.cacheLock.Unlock()
-	if cert, exists := (*cm.cache)[cn]; exists {
-		log.Trace().Msgf("Certificate found in cache CN=%s", cn)
+	if cert, exists := (*cm.cache)[key]; exists {
+		log.Trace().Msgf("Certificate found in cache CN=%s", key)
 		if rotor.ShouldRotate(cert) {
-			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", cn)
+			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", key)
 			return nil
 		}
 		return cert
@@ -91,22 +89,28 @@ func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certi
 }
 
 // IssueCertificate issues a certificate by leveraging the Hashi Vault CertManager.
-func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration) (certificate.Certificater, error) {
+func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPeriod *time.Duration, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	log.Info().Msgf("Issuing new certificate for CN=%s", cn)
 
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to issue certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
 	start := time.Now()
 
-	if cert := cm.getFromCache(cn); cert != nil {
+	key := certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}
+	if cert := cm.getFromCache(key); cert != nil {
 		return cert, nil
 	}
 
-	cert, err := cm.issue(cn, validityPeriod)
+	cert, err := cm.issue(cn, validityPeriod, spiffeID)
 	if err != nil {
 		return cert, err
 	}
 
 	cm.cacheLock.Lock()
-	(*cm.cache)[cn] = cert
+	(*cm.cache)[key] = cert
 	cm.cacheLock.Unlock()
 
 	log.Info().Msgf("Issuing new certificate for CN=%s took %+v", cn, time.Since(start))
@@ -125,9 +129,12 @@ func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
 	return certs, nil
 }
 
-// GetCertificate returns a certificate given its Common Name (CN)
-func (cm *CertManager) GetCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
-	if cert := cm.getFromCache(cn); cert != nil {
+// GetCertificate returns the certificate issued for the given Common Name (CN) and SPIFFE ID
+func (cm *CertManager) GetCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
+	if cm.revoked.IsRevoked(cn) {
+		return nil, errCertificateRevoked
+	}
+	if cert := cm.getFromCache(certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}); cert != nil {
 		return cert, nil
 	}
 	return nil, errCertNotFound
@@ -138,32 +145,89 @@ func (cm *CertManager) GetRootCertificate() (certificate.Certificater, error) {
 	return cm.ca, nil
 }
 
+// GetTrustBundle implements certificate.Manager and returns the PEM encoded root certificate, which is the only one trusted by the mesh.
+func (cm *CertManager) GetTrustBundle() ([]byte, error) {
+	return cm.ca.GetCertificateChain(), nil
+}
+
 // GetAnnouncementsChannel returns a channel used by the Hashi Vault instance to signal when a certificate has been changed.
-func (cm *CertManager) GetAnnouncementsChannel() <-chan interface{} {
+func (cm *CertManager) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return cm.announcements
 }
 
-// RotateCertificate implements certificate.Manager and rotates an existing certificate.
-func (cm *CertManager) RotateCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+// RotateCertificate implements certificate.Manager and rotates the existing certificate with the given CN and SPIFFE ID.
+func (cm *CertManager) RotateCertificate(cn certificate.CommonName, spiffeID certificate.SPIFFEID) (certificate.Certificater, error) {
 	log.Info().Msgf("Rotating certificate for CN=%s", cn)
 
+	if cm.revoked.IsRevoked(cn) {
+		log.Error().Msgf("Refusing to rotate certificate with CN=%s, which was revoked", cn)
+		return nil, errCertificateRevoked
+	}
+
 	start := time.Now()
 
-	cert, err := cm.issue(cn, &cm.validityPeriod)
+	cert, err := cm.issue(cn, &cm.validityPeriod, spiffeID)
 	if err != nil {
 		return cert, err
 	}
 
 	cm.cacheLock.Lock()
-	(*cm.cache)[cn] = cert
+	(*cm.cache)[certificate.CacheKey{CommonName: cn, SPIFFEID: spiffeID}] = cert
 	cm.cacheLock.Unlock()
-	cm.announcements <- nil
+	cm.announcements <- announcements.Announcement{
+		Type:   announcements.CertificateRotated,
+		Name:   cn.String(),
+		Object: cert,
+	}
 
 	log.Info().Msgf("Rotating certificate CN=%s took %+v", cn, time.Since(start))
 
 	return cert, nil
 }
 
+// RevokeCertificate implements certificate.Manager and revokes the certificates with the given CN:
+// the certificates are removed from the cache, whatever their SPIFFE ID, and no certificate is issued for this CN anymore.
+func (cm *CertManager) RevokeCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Revoking certificate for CN=%s", cn)
+
+	if err := cm.revoked.Revoke(cn); err != nil {
+		return err
+	}
+
+	cm.forget(cn)
+
+	cm.announcements <- announcements.Announcement{
+		Type: announcements.CertificateRevoked,
+		Name: cn.String(),
+	}
+
+	return nil
+}
+
+// ReleaseCertificate implements certificate.Manager and forgets the certificates with the given CN, which are no longer used:
+// the certificates are removed from the cache, whatever their SPIFFE ID, without being revoked.
+func (cm *CertManager) ReleaseCertificate(cn certificate.CommonName) error {
+	log.Info().Msgf("Releasing certificate for CN=%s", cn)
+	cm.forget(cn)
+	return nil
+}
+
+// forget removes the certificates with the given CN from the cache, whatever their SPIFFE ID.
+func (cm *CertManager) forget(cn certificate.CommonName) {
+	cm.cacheLock.Lock()
+	for key := range *cm.cache {
+		if key.CommonName == cn {
+			delete(*cm.cache, key)
+		}
+	}
+	cm.cacheLock.Unlock()
+}
+
+// IsRevoked implements certificate.Manager and determines whether the certificate with the given CN was revoked.
+func (cm *CertManager) IsRevoked(cn certificate.CommonName) bool {
+	return cm.revoked.IsRevoked(cn)
+}
+
 // Certificate implements certificate.Certificater
 type Certificate struct {
 	// The commonName of the certificate
@@ -49,7 +49,7 @@ var _ = Describe("Test client helpers", func() {
 			vaultToken := "bar"
 			validityPeriod := 1 * time.Second
 			vaultRole := "baz"
-			_, err := NewCertManager(vaultAddr, vaultToken, validityPeriod, vaultRole)
+			_, err := NewCertManager(vaultAddr, vaultToken, validityPeriod, vaultRole, nil)
 			Expect(err).To(HaveOccurred())
 			vaultError := err.(*url.Error)
 			expected := `unsupported protocol scheme "foo"`
@@ -88,26 +88,27 @@ var _ = Describe("Test client helpers", func() {
 
 	Context("Test Hashi Vault functions", func() {
 		cm := CertManager{
-			cache: &map[certificate.CommonName]certificate.Certificater{
-				expiredCertCN: expiredCert,
-				validCertCN:   validCert,
+			cache: &map[certificate.CacheKey]certificate.Certificater{
+				{CommonName: expiredCertCN}: expiredCert,
+				{CommonName: validCertCN}:   validCert,
 			},
-			ca: rootCert,
+			ca:      rootCert,
+			revoked: certificate.NewRevocationList(nil),
 		}
 
 		It("gets certs from cache", func() {
 			// This cert does not exist - returns nil
-			Expect(cm.getFromCache("nothing")).To(BeNil())
+			Expect(cm.getFromCache(certificate.CacheKey{CommonName: "nothing"})).To(BeNil())
 
 			// This cert has expired -- returns nil
-			Expect(cm.getFromCache(expiredCertCN)).To(BeNil())
+			Expect(cm.getFromCache(certificate.CacheKey{CommonName: expiredCertCN})).To(BeNil())
 
-			actual := cm.getFromCache(validCertCN)
+			actual := cm.getFromCache(certificate.CacheKey{CommonName: validCertCN})
 			Expect(actual).To(Equal(validCert))
 		})
 
 		It("creates certificates", func() {
-			actual, err := cm.GetCertificate(validCertCN)
+			actual, err := cm.GetCertificate(validCertCN, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actual).To(Equal(validCert))
 		})
@@ -119,7 +120,7 @@ var _ = Describe("Test client helpers", func() {
 		})
 
 		It("implements Certificater correctly", func() {
-			actual, err := cm.GetCertificate(validCertCN)
+			actual, err := cm.GetCertificate(validCertCN, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actual.GetCommonName()).To(Equal(validCertCN))
 			Expect(actual.GetCertificateChain()).To(Equal([]byte(validCert.certChain)))
@@ -5,3 +5,4 @@ import (
 )
 
 var errCertNotFound = errors.New("certificate not found")
+var errCertificateRevoked = errors.New("certificate revoked")
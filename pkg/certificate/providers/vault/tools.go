@@ -19,9 +19,14 @@ func getRoleConfigURL(vaultRole string) string {
 	return fmt.Sprintf("pki/roles/%s", vaultRole)
 }
 
-func getIssuanceData(cn certificate.CommonName, validityPeriod time.Duration) map[string]interface{} {
-	return map[string]interface{}{
+func getIssuanceData(cn certificate.CommonName, validityPeriod time.Duration, spiffeID certificate.SPIFFEID) map[string]interface{} {
+	data := map[string]interface{}{
 		"common_name": cn.String(),
 		"ttl":         getDurationInMinutes(validityPeriod),
 	}
+	// The Vault role must allow the SPIFFE IDs with allowed_uri_sans
+	if spiffeID != "" {
+		data["uri_sans"] = spiffeID.String()
+	}
+	return data
 }
@@ -40,12 +40,23 @@ var _ = Describe("Test tools", func() {
 	Context("Test cert issuance data for request", func() {
 		It("creates a map w/ correct fields", func() {
 			cn := certificate.CommonName("blah.foo.com")
-			actual := getIssuanceData(cn, 8123*time.Minute)
+			actual := getIssuanceData(cn, 8123*time.Minute, "")
 			expected := map[string]interface{}{
 				"common_name": "blah.foo.com",
 				"ttl":         "135h",
 			}
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("requests the SPIFFE ID as a URI SAN", func() {
+			cn := certificate.CommonName("blah.foo.com")
+			actual := getIssuanceData(cn, 8123*time.Minute, "spiffe://cluster.local/ns/foo/sa/blah")
+			expected := map[string]interface{}{
+				"common_name": "blah.foo.com",
+				"ttl":         "135h",
+				"uri_sans":    "spiffe://cluster.local/ns/foo/sa/blah",
+			}
+			Expect(actual).To(Equal(expected))
+		})
 	})
 })
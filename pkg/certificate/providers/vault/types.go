@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/vault/api"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
@@ -18,12 +19,15 @@ type CertManager struct {
 	ca certificate.Certificater
 
 	// The channel announcing to the rest of the system when a certificate has changed
-	announcements chan interface{}
+	announcements chan announcements.Announcement
 
 	// Cache for all the certificates issued
-	cache     *map[certificate.CommonName]certificate.Certificater
+	cache     *map[certificate.CacheKey]certificate.Certificater
 	cacheLock sync.Mutex
 
+	// The Common Names of the revoked certificates, which are not issued anymore
+	revoked *certificate.RevocationList
+
 	// Hashicorp Vault client
 	client *api.Client
 
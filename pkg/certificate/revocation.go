@@ -0,0 +1,41 @@
+package certificate
+
+import (
+	"time"
+)
+
+// NewRevocationList creates a new, empty list of revoked certificates.
+// When the given revocation store is not nil, the revocations are persisted in the store,
+// and the revocations persisted by the other osm-controller replicas, or before a restart, are honored.
+func NewRevocationList(store RevocationStore) *RevocationList {
+	return &RevocationList{
+		revoked: make(map[CommonName]time.Time),
+		store:   store,
+	}
+}
+
+// Revoke adds the given Common Name (CN) to the list of revoked certificates.
+func (rl *RevocationList) Revoke(cn CommonName) error {
+	rl.lock.Lock()
+	if _, ok := rl.revoked[cn]; !ok {
+		rl.revoked[cn] = time.Now()
+	}
+	rl.lock.Unlock()
+
+	if rl.store == nil {
+		return nil
+	}
+	if err := rl.store.Revoke(cn); err != nil {
+		log.Error().Err(err).Msgf("Error persisting the revocation of certificate with CN=%s", cn)
+		return err
+	}
+	return nil
+}
+
+// IsRevoked determines whether the certificate with the given Common Name (CN) was revoked.
+func (rl *RevocationList) IsRevoked(cn CommonName) bool {
+	rl.lock.RLock()
+	_, ok := rl.revoked[cn]
+	rl.lock.RUnlock()
+	return ok || (rl.store != nil && rl.store.IsRevoked(cn))
+}
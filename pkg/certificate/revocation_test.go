@@ -0,0 +1,44 @@
+package certificate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeRevocationStore implements RevocationStore and keeps the revocations in memory
+type fakeRevocationStore map[CommonName]interface{}
+
+func (s fakeRevocationStore) Revoke(cn CommonName) error {
+	s[cn] = nil
+	return nil
+}
+
+func (s fakeRevocationStore) IsRevoked(cn CommonName) bool {
+	_, ok := s[cn]
+	return ok
+}
+
+var _ = Describe("Test the revocation list", func() {
+	Context("Test revoking a certificate", func() {
+		It("lists only the revoked certificates", func() {
+			rl := NewRevocationList(nil)
+			Expect(rl.IsRevoked("a.b.c")).To(BeFalse())
+
+			Expect(rl.Revoke("a.b.c")).To(Succeed())
+			Expect(rl.IsRevoked("a.b.c")).To(BeTrue())
+			Expect(rl.IsRevoked("d.e.f")).To(BeFalse())
+		})
+
+		It("persists the revocations and honors the persisted ones", func() {
+			store := fakeRevocationStore{}
+			rl := NewRevocationList(store)
+			Expect(rl.Revoke("a.b.c")).To(Succeed())
+			Expect(store.IsRevoked("a.b.c")).To(BeTrue())
+
+			// The revocations of another replica, or from before a restart, are honored
+			restarted := NewRevocationList(store)
+			Expect(restarted.IsRevoked("a.b.c")).To(BeTrue())
+			Expect(restarted.IsRevoked("d.e.f")).To(BeFalse())
+		})
+	})
+})
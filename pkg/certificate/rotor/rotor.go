@@ -25,15 +25,20 @@ func New(certManager certificate.Manager) *CertRotor {
 	}
 }
 
-// Start starts a new facility for automatic certificate rotation.
-func (r CertRotor) Start(checkInterval time.Duration) {
+// Start starts a new facility for automatic certificate rotation, which runs until the stop channel is closed.
+func (r CertRotor) Start(checkInterval time.Duration, stop <-chan struct{}) {
 	// iterate over the list of certificates
 	// when a cert needs to be rotated - call RotateCertificate()
 	ticker := time.NewTicker(checkInterval)
 	go func() {
+		defer ticker.Stop()
 		for {
 			r.checkAndRotate()
-			<-ticker.C
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
 		}
 	}()
 }
@@ -56,7 +61,7 @@ func (r *CertRotor) checkAndRotate() {
 
 		if shouldRotate {
 			// Remove the certificate from the cache of the certificate manager
-			newCert, err := r.certManager.RotateCertificate(cert.GetCommonName())
+			newCert, err := r.certManager.RotateCertificate(cert.GetCommonName(), certificate.GetSPIFFEID(cert))
 			if err != nil {
 				log.Error().Err(err).Msgf("Error rotating cert CN=%s", cert.GetCommonName())
 				continue
@@ -0,0 +1,48 @@
+package certificate
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const spiffeScheme = "spiffe"
+
+// NewSPIFFEID returns the SPIFFE ID of the workloads running as the given service account in the given trust domain.
+func NewSPIFFEID(trustDomain, namespace, serviceAccount string) SPIFFEID {
+	return SPIFFEID(fmt.Sprintf("%sns/%s/sa/%s", GetSPIFFETrustDomainPrefix(trustDomain), namespace, serviceAccount))
+}
+
+// GetSPIFFETrustDomainPrefix returns the prefix shared by the SPIFFE IDs of all the workloads of the given trust domain.
+func GetSPIFFETrustDomainPrefix(trustDomain string) string {
+	return fmt.Sprintf("%s://%s/", spiffeScheme, trustDomain)
+}
+
+// URIs returns the URI SANs of a certificate carrying the given SPIFFE ID, or nil when the SPIFFE ID is empty or invalid.
+func (id SPIFFEID) URIs() []*url.URL {
+	if id == "" {
+		return nil
+	}
+	uri, err := url.Parse(id.String())
+	if err != nil || uri.Scheme != spiffeScheme {
+		log.Error().Msgf("Invalid SPIFFE ID %q", id)
+		return nil
+	}
+	return []*url.URL{uri}
+}
+
+// GetSPIFFEID returns the SPIFFE ID the given certificate carries as a URI SAN, or an empty SPIFFE ID when it carries none.
+func GetSPIFFEID(cert Certificater) SPIFFEID {
+	if cert == nil {
+		return ""
+	}
+	x509Cert, err := DecodePEMCertificate(cert.GetCertificateChain())
+	if err != nil {
+		return ""
+	}
+	for _, uri := range x509Cert.URIs {
+		if uri.Scheme == spiffeScheme {
+			return SPIFFEID(uri.String())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package certificate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test SPIFFE IDs", func() {
+	Context("Test NewSPIFFEID()", func() {
+		It("creates the SPIFFE ID of a service account", func() {
+			spiffeID := NewSPIFFEID("cluster.local", "default", "bookstore")
+			Expect(spiffeID).To(Equal(SPIFFEID("spiffe://cluster.local/ns/default/sa/bookstore")))
+			Expect(spiffeID.URIs()).To(HaveLen(1))
+			Expect(spiffeID.URIs()[0].String()).To(Equal(spiffeID.String()))
+			Expect(spiffeID.String()).To(HavePrefix(GetSPIFFETrustDomainPrefix("cluster.local")))
+		})
+
+		It("has no URI SAN when the SPIFFE ID is empty or invalid", func() {
+			Expect(SPIFFEID("").URIs()).To(BeNil())
+			Expect(SPIFFEID("https://cluster.local/ns/default/sa/bookstore").URIs()).To(BeNil())
+		})
+	})
+
+	Context("Test GetSPIFFEID()", func() {
+		It("returns an empty SPIFFE ID for a certificate without one", func() {
+			Expect(GetSPIFFEID(nil)).To(Equal(SPIFFEID("")))
+		})
+	})
+})
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+// GetCommonName implements certificate.Certificater and returns the CN of the cert.
+func (c storedCertificate) GetCommonName() certificate.CommonName {
+	return c.commonName
+}
+
+// GetCertificateChain implements certificate.Certificater and returns the certificate chain.
+func (c storedCertificate) GetCertificateChain() []byte {
+	return c.certChain
+}
+
+// GetPrivateKey implements certificate.Certificater and returns the private key.
+func (c storedCertificate) GetPrivateKey() []byte {
+	return c.privateKey
+}
+
+// GetIssuingCA implements certificate.Certificater and returns the root certificate for the given cert.
+func (c storedCertificate) GetIssuingCA() []byte {
+	return c.issuingCA
+}
+
+// GetExpiration implements certificate.Certificater and returns the time the given certificate expires.
+func (c storedCertificate) GetExpiration() time.Time {
+	return c.expiration
+}
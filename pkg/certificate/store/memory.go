@@ -0,0 +1,46 @@
+package store
+
+import (
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+// NewMemoryStore creates a new certificate store, which keeps the certificates in memory.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		certificates: make(map[certificate.CacheKey]certificate.Certificater),
+	}
+}
+
+// Get implements certificate.Store and returns the certificate with the given key, or nil when no such certificate is stored.
+func (s *MemoryStore) Get(key certificate.CacheKey) (certificate.Certificater, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.certificates[key], nil
+}
+
+// Put implements certificate.Store and stores the given certificate.
+func (s *MemoryStore) Put(cert certificate.Certificater) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.certificates[certificate.GetCacheKey(cert)] = cert
+	return nil
+}
+
+// Delete implements certificate.Store and removes the certificate with the given key from the store.
+func (s *MemoryStore) Delete(key certificate.CacheKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.certificates, key)
+	return nil
+}
+
+// List implements certificate.Store and lists all stored certificates.
+func (s *MemoryStore) List() ([]certificate.Certificater, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var certs []certificate.Certificater
+	for _, cert := range s.certificates {
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
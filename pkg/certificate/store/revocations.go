@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// NewConfigMapRevocationStore creates a new revocation store, which keeps the Common Names of the revoked certificates in the Kubernetes
+// ConfigMap with the given name and namespace. Every process using the same ConfigMap watches it, so the revocations survive restarts
+// and are shared by the osm-controller replicas.
+func NewConfigMapRevocationStore(kubeClient kubernetes.Interface, namespace, name string, stop <-chan struct{}) (*ConfigMapRevocationStore, error) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(namespace))
+	informer := informerFactory.Core().V1().ConfigMaps().Informer()
+	s := &ConfigMapRevocationStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		name:       name,
+		cache:      informer.GetStore(),
+	}
+
+	go informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		return nil, errSyncingRevocations
+	}
+
+	log.Info().Msgf("Revocations are persisted in ConfigMap %s/%s", namespace, name)
+	return s, nil
+}
+
+// Revoke implements certificate.RevocationStore and adds the given Common Name to the ConfigMap, along with the time of the revocation.
+func (s *ConfigMapRevocationStore) Revoke(cn certificate.CommonName) error {
+	revokedAt := time.Now().UTC().Format(constants.TimeDateLayout)
+	configMaps := s.kubeClient.CoreV1().ConfigMaps(s.namespace)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(context.Background(), s.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.name,
+					Namespace: s.namespace,
+				},
+				Data: map[string]string{
+					string(cn): revokedAt,
+				},
+			}
+			_, err = configMaps.Create(context.Background(), configMap, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica created the ConfigMap in the meantime; update it instead
+				return apierrors.NewConflict(corev1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := configMap.Data[string(cn)]; ok {
+			return nil
+		}
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[string(cn)] = revokedAt
+		_, err = configMaps.Update(context.Background(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error persisting the revocation of certificate with CN=%s in ConfigMap %s/%s", cn, s.namespace, s.name)
+		return err
+	}
+	return nil
+}
+
+// IsRevoked implements certificate.RevocationStore and determines whether the given Common Name is in the ConfigMap.
+func (s *ConfigMapRevocationStore) IsRevoked(cn certificate.CommonName) bool {
+	item, exists, err := s.cache.GetByKey(fmt.Sprintf("%s/%s", s.namespace, s.name))
+	if err != nil || !exists {
+		return false
+	}
+	configMap, ok := item.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+	_, revoked := configMap.Data[string(cn)]
+	return revoked
+}
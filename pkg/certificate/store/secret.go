@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// NewSecretStore creates a new certificate store, which keeps every certificate in a Kubernetes secret in the given namespace.
+// The certificates stored survive restarts, and are shared by all the processes using the same namespace.
+func NewSecretStore(kubeClient kubernetes.Interface, namespace string) *SecretStore {
+	return &SecretStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+	}
+}
+
+// Get implements certificate.Store and returns the certificate with the given key, or nil when no such certificate is stored.
+func (s *SecretStore) Get(key certificate.CacheKey) (certificate.Certificater, error) {
+	secret, err := s.kubeClient.CoreV1().Secrets(s.namespace).Get(context.Background(), getSecretName(key), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the secret holding the certificate with CN=%s", key)
+		return nil, err
+	}
+	return certificateFromSecret(secret)
+}
+
+// Put implements certificate.Store and stores the given certificate in a Kubernetes secret.
+func (s *SecretStore) Put(cert certificate.Certificater) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getSecretName(certificate.GetCacheKey(cert)),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				certificateLabel: "true",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			commonNameKey:                                []byte(cert.GetCommonName()),
+			corev1.TLSCertKey:                            cert.GetCertificateChain(),
+			corev1.TLSPrivateKeyKey:                      cert.GetPrivateKey(),
+			constants.KubernetesOpaqueSecretCAKey:        cert.GetIssuingCA(),
+			constants.KubernetesOpaqueSecretCAExpiration: []byte(cert.GetExpiration().UTC().Format(constants.TimeDateLayout)),
+		},
+	}
+
+	_, err := s.kubeClient.CoreV1().Secrets(s.namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = s.kubeClient.CoreV1().Secrets(s.namespace).Update(context.Background(), secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Error storing the certificate with CN=%s in secret %s/%s", cert.GetCommonName(), s.namespace, secret.Name)
+		return err
+	}
+	return nil
+}
+
+// Delete implements certificate.Store and deletes the Kubernetes secret holding the certificate with the given key.
+func (s *SecretStore) Delete(key certificate.CacheKey) error {
+	err := s.kubeClient.CoreV1().Secrets(s.namespace).Delete(context.Background(), getSecretName(key), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error().Err(err).Msgf("Error deleting the secret holding the certificate with CN=%s", key)
+		return err
+	}
+	return nil
+}
+
+// List implements certificate.Store and lists the certificates held by Kubernetes secrets.
+func (s *SecretStore) List() ([]certificate.Certificater, error) {
+	secrets, err := s.kubeClient.CoreV1().Secrets(s.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", certificateLabel),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing the secrets holding certificates in namespace %s", s.namespace)
+		return nil, err
+	}
+
+	var certs []certificate.Certificater
+	for idx := range secrets.Items {
+		cert, err := certificateFromSecret(&secrets.Items[idx])
+		if err != nil {
+			log.Error().Err(err).Msgf("Skipping secret %s/%s", s.namespace, secrets.Items[idx].Name)
+			continue
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// WatchSecrets watches the Kubernetes secrets holding certificates in the given namespace, whichever process stored them.
+// onPut is called with every certificate stored or replaced, and onDelete with the key of every certificate removed from the store.
+func WatchSecrets(kubeClient kubernetes.Interface, namespace string, onPut func(certificate.Certificater), onDelete func(certificate.CacheKey), stop <-chan struct{}) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = fmt.Sprintf("%s=true", certificateLabel)
+		}),
+	)
+
+	informer := informerFactory.Core().V1().Secrets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cert := certificateFromObject(obj); cert != nil {
+				onPut(cert)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if cert := certificateFromObject(obj); cert != nil {
+				onPut(cert)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			// The informer missed the deletion; the tombstone holds the last known state of the secret
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if cert := certificateFromObject(obj); cert != nil {
+				onDelete(certificate.GetCacheKey(cert))
+			}
+		},
+	})
+
+	go informer.Run(stop)
+}
+
+// certificateFromObject returns the certificate held by the given Kubernetes secret, or nil when it holds none.
+func certificateFromObject(obj interface{}) certificate.Certificater {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+	cert, err := certificateFromSecret(secret)
+	if err != nil {
+		log.Error().Err(err).Msgf("Skipping secret %s/%s", secret.Namespace, secret.Name)
+		return nil
+	}
+	return cert
+}
+
+// getSecretName returns the name of the Kubernetes secret holding the certificate with the given key.
+// Common Names and SPIFFE IDs are not necessarily valid Kubernetes object names, hence the hash.
+// The certificates without SPIFFE ID keep the name derived from their Common Name only.
+func getSecretName(key certificate.CacheKey) string {
+	name := string(key.CommonName)
+	if key.SPIFFEID != "" {
+		name = fmt.Sprintf("%s|%s", key.CommonName, key.SPIFFEID)
+	}
+	return fmt.Sprintf("%s%x", secretNamePrefix, sha256.Sum256([]byte(name)))
+}
+
+func certificateFromSecret(secret *corev1.Secret) (certificate.Certificater, error) {
+	for _, key := range []string{commonNameKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, constants.KubernetesOpaqueSecretCAKey, constants.KubernetesOpaqueSecretCAExpiration} {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, errors.Errorf("Secret %s/%s does not have required field %q", secret.Namespace, secret.Name, key)
+		}
+	}
+
+	expiration, err := time.Parse(constants.TimeDateLayout, string(secret.Data[constants.KubernetesOpaqueSecretCAExpiration]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing the expiration of the certificate in secret %s/%s", secret.Namespace, secret.Name)
+	}
+
+	return storedCertificate{
+		commonName: certificate.CommonName(secret.Data[commonNameKey]),
+		certChain:  secret.Data[corev1.TLSCertKey],
+		privateKey: secret.Data[corev1.TLSPrivateKeyKey],
+		issuingCA:  secret.Data[constants.KubernetesOpaqueSecretCAKey],
+		expiration: expiration,
+	}, nil
+}
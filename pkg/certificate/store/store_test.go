@@ -0,0 +1,155 @@
+package store
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+var _ = Describe("Test certificate stores", func() {
+	expiration := time.Now().Add(1 * time.Hour).UTC().Truncate(time.Second)
+	newCert := func(cn string, chain string) certificate.Certificater {
+		return storedCertificate{
+			commonName: certificate.CommonName(cn),
+			certChain:  []byte(chain),
+			privateKey: []byte("key"),
+			issuingCA:  []byte("ca"),
+			expiration: expiration,
+		}
+	}
+
+	stores := map[string]func() certificate.Store{
+		"MemoryStore": func() certificate.Store { return NewMemoryStore() },
+		"SecretStore": func() certificate.Store { return NewSecretStore(testclient.NewSimpleClientset(), "osm-system") },
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+
+		Context(name, func() {
+			It("returns nil for a certificate which was never stored", func() {
+				s := newStore()
+				cert, err := s.Get(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert).To(BeNil())
+			})
+
+			It("stores, overwrites, lists and deletes certificates", func() {
+				s := newStore()
+				Expect(s.Put(newCert("foo.bar.cluster.local", "chain-1"))).To(Succeed())
+
+				cert, err := s.Get(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.GetCommonName()).To(Equal(certificate.CommonName("foo.bar.cluster.local")))
+				Expect(cert.GetCertificateChain()).To(Equal([]byte("chain-1")))
+				Expect(cert.GetPrivateKey()).To(Equal([]byte("key")))
+				Expect(cert.GetIssuingCA()).To(Equal([]byte("ca")))
+				Expect(cert.GetExpiration()).To(BeTemporally("==", expiration))
+
+				Expect(s.Put(newCert("foo.bar.cluster.local", "chain-2"))).To(Succeed())
+				cert, err = s.Get(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.GetCertificateChain()).To(Equal([]byte("chain-2")))
+
+				Expect(s.Put(newCert("baz.bar.cluster.local", "chain-3"))).To(Succeed())
+				certs, err := s.List()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs).To(HaveLen(2))
+
+				Expect(s.Delete(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})).To(Succeed())
+				cert, err = s.Get(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert).To(BeNil())
+
+				// Deleting a certificate which is not stored is not an error
+				Expect(s.Delete(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})).To(Succeed())
+
+				certs, err = s.List()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs).To(HaveLen(1))
+				Expect(certs[0].GetCommonName()).To(Equal(certificate.CommonName("baz.bar.cluster.local")))
+			})
+		})
+	}
+
+	Context("WatchSecrets", func() {
+		It("calls the handlers when certificates are stored and deleted", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			s := NewSecretStore(kubeClient, "osm-system")
+			put := make(chan certificate.Certificater, 2)
+			deleted := make(chan certificate.CacheKey, 1)
+			stop := make(chan struct{})
+			defer close(stop)
+			WatchSecrets(kubeClient, "osm-system", func(cert certificate.Certificater) { put <- cert }, func(key certificate.CacheKey) { deleted <- key }, stop)
+
+			Expect(s.Put(newCert("foo.bar.cluster.local", "chain-1"))).To(Succeed())
+			var cert certificate.Certificater
+			Eventually(put, 2*time.Second).Should(Receive(&cert))
+			Expect(cert.GetCertificateChain()).To(Equal([]byte("chain-1")))
+
+			Expect(s.Put(newCert("foo.bar.cluster.local", "chain-2"))).To(Succeed())
+			Eventually(put, 2*time.Second).Should(Receive(&cert))
+			Expect(cert.GetCertificateChain()).To(Equal([]byte("chain-2")))
+
+			Expect(s.Delete(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})).To(Succeed())
+			Eventually(deleted, 2*time.Second).Should(Receive(Equal(certificate.CacheKey{CommonName: "foo.bar.cluster.local"})))
+		})
+	})
+
+	Context("ConfigMapRevocationStore", func() {
+		It("shares the revocations among the stores using the same ConfigMap", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			kubeClient := testclient.NewSimpleClientset()
+
+			replica, err := NewConfigMapRevocationStore(kubeClient, "osm-system", "osm-revocations", stop)
+			Expect(err).ToNot(HaveOccurred())
+			otherReplica, err := NewConfigMapRevocationStore(kubeClient, "osm-system", "osm-revocations", stop)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replica.IsRevoked("a.b.c")).To(BeFalse())
+
+			Expect(replica.Revoke("a.b.c")).To(Succeed())
+			Expect(otherReplica.Revoke("d.e.f")).To(Succeed())
+			// Revoking a certificate twice is not an error
+			Expect(otherReplica.Revoke("a.b.c")).To(Succeed())
+
+			for _, s := range []*ConfigMapRevocationStore{replica, otherReplica} {
+				s := s
+				Eventually(func() bool { return s.IsRevoked("a.b.c") }).Should(BeTrue())
+				Eventually(func() bool { return s.IsRevoked("d.e.f") }).Should(BeTrue())
+				Expect(s.IsRevoked("g.h.i")).To(BeFalse())
+			}
+
+			// The revocations survive a restart
+			restarted, err := NewConfigMapRevocationStore(kubeClient, "osm-system", "osm-revocations", stop)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted.IsRevoked("a.b.c")).To(BeTrue())
+			Expect(restarted.IsRevoked("d.e.f")).To(BeTrue())
+		})
+	})
+
+	Context("getSecretName", func() {
+		It("returns a valid Kubernetes object name", func() {
+			name := getSecretName(certificate.CacheKey{CommonName: "bookstore.bookstore-ns.svc.cluster.local"})
+			Expect(name).To(HavePrefix(secretNamePrefix))
+			Expect(len(name)).To(BeNumerically("<=", 253))
+			Expect(name).To(MatchRegexp(`^[a-z0-9-]+$`))
+		})
+
+		It("returns a different name per SPIFFE ID of the same Common Name", func() {
+			cn := certificate.CommonName("bookstore.bookstore-ns.svc.cluster.local")
+			v1 := certificate.NewSPIFFEID("cluster.local", "bookstore-ns", "bookstore-v1")
+			v2 := certificate.NewSPIFFEID("cluster.local", "bookstore-ns", "bookstore-v2")
+			names := map[string]interface{}{
+				getSecretName(certificate.CacheKey{CommonName: cn}):               nil,
+				getSecretName(certificate.CacheKey{CommonName: cn, SPIFFEID: v1}): nil,
+				getSecretName(certificate.CacheKey{CommonName: cn, SPIFFEID: v2}): nil,
+			}
+			Expect(names).To(HaveLen(3))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+// Package store implements the persistence of the certificates issued and revoked by a certificate manager.
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// secretNamePrefix is the prefix of the names of the Kubernetes secrets holding certificates
+	secretNamePrefix = "osm-cert-"
+
+	// certificateLabel is the label applied to the Kubernetes secrets holding certificates
+	certificateLabel = "openservicemesh.io/certificate"
+
+	// commonNameKey is the key which holds the Common Name of the certificate in a Kubernetes secret
+	commonNameKey = "common_name"
+)
+
+var (
+	log = logger.New("certificate/store")
+
+	errSyncingRevocations = errors.New("Failed initial cache sync for the revocations ConfigMap informer")
+)
+
+// MemoryStore implements certificate.Store and keeps the certificates in memory
+type MemoryStore struct {
+	certificates map[certificate.CacheKey]certificate.Certificater
+	lock         sync.RWMutex
+}
+
+// SecretStore implements certificate.Store and keeps every certificate in a Kubernetes secret
+type SecretStore struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+// ConfigMapRevocationStore implements certificate.RevocationStore and keeps the revocations in a Kubernetes ConfigMap
+type ConfigMapRevocationStore struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	name       string
+	cache      cache.Store
+}
+
+// storedCertificate implements certificate.Certificater for the certificates loaded from a store
+type storedCertificate struct {
+	commonName certificate.CommonName
+	certChain  []byte
+	privateKey []byte
+	issuingCA  []byte
+	expiration time.Time
+}
@@ -1,8 +1,11 @@
 package certificate
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -25,6 +28,37 @@ func (cn CommonName) String() string {
 	return string(cn)
 }
 
+// SPIFFEID is the SPIFFE identity of a workload, of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>.
+// Certificates carry it as a URI Subject Alternative Name (SAN).
+type SPIFFEID string
+
+func (id SPIFFEID) String() string {
+	return string(id)
+}
+
+// CacheKey identifies a certificate issued by a certificate manager in its cache and its store, by its Common Name and its SPIFFE ID.
+// The proxies of a service running as different service accounts are issued certificates with the CN of the service,
+// but with the SPIFFE ID of their own service account.
+type CacheKey struct {
+	CommonName CommonName
+	SPIFFEID   SPIFFEID
+}
+
+func (k CacheKey) String() string {
+	if k.SPIFFEID == "" {
+		return k.CommonName.String()
+	}
+	return fmt.Sprintf("%s (%s)", k.CommonName, k.SPIFFEID)
+}
+
+// GetCacheKey returns the key of the given certificate in the cache and the store of a certificate manager.
+func GetCacheKey(cert Certificater) CacheKey {
+	return CacheKey{
+		CommonName: cert.GetCommonName(),
+		SPIFFEID:   GetSPIFFEID(cert),
+	}
+}
+
 // Certificater is the interface declaring methods each Certificate object must have.
 type Certificater interface {
 
@@ -46,23 +80,72 @@ type Certificater interface {
 
 // Manager is the interface declaring the methods for the Certificate Manager.
 type Manager interface {
-	// IssueCertificate issues a new certificate.
-	IssueCertificate(CommonName, *time.Duration) (Certificater, error)
+	// IssueCertificate issues a new certificate. When the given SPIFFE ID is not empty, the certificate carries it as a URI SAN.
+	IssueCertificate(CommonName, *time.Duration, SPIFFEID) (Certificater, error)
 
-	// GetCertificate returns a certificate given its Common Name (CN)
-	GetCertificate(CommonName) (Certificater, error)
+	// GetCertificate returns the certificate issued for the given Common Name (CN) and SPIFFE ID
+	GetCertificate(CommonName, SPIFFEID) (Certificater, error)
 
-	// RotateCertificate rotates an existing certificate.
-	RotateCertificate(CommonName) (Certificater, error)
+	// RotateCertificate rotates the existing certificate issued for the given Common Name (CN) and SPIFFE ID.
+	RotateCertificate(CommonName, SPIFFEID) (Certificater, error)
 
 	// GetRootCertificate returns the root certificate in PEM format and its expiration.
 	GetRootCertificate() (Certificater, error)
 
+	// GetTrustBundle returns the PEM encoded root certificates trusted by the mesh. While the root certificate is being rotated,
+	// the trust bundle holds both the old and the new root certificates.
+	GetTrustBundle() ([]byte, error)
+
 	// ListCertificates lists all certificates issued
 	ListCertificates() ([]Certificater, error)
 
+	// RevokeCertificate revokes the certificates with the given Common Name (CN), whatever their SPIFFE ID:
+	// the certificates are forgotten, and no certificate is issued for this CN anymore.
+	RevokeCertificate(CommonName) error
+
+	// ReleaseCertificate forgets the certificates with the given Common Name (CN), whatever their SPIFFE ID, once they are no longer used:
+	// unlike revoked certificates, a certificate may be issued for this CN again.
+	ReleaseCertificate(CommonName) error
+
+	// IsRevoked determines whether the certificate with the given Common Name (CN) was revoked.
+	IsRevoked(CommonName) bool
+
 	// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the issued certificates.
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
+}
+
+// Store is the interface declaring the methods for the persistence of the certificates issued by a Certificate Manager.
+type Store interface {
+	// Get returns the certificate with the given key, or nil when no such certificate is stored.
+	Get(CacheKey) (Certificater, error)
+
+	// Put stores the given certificate, replacing the stored certificate with the same Common Name (CN) and SPIFFE ID.
+	Put(Certificater) error
+
+	// Delete removes the certificate with the given key from the store.
+	Delete(CacheKey) error
+
+	// List lists all stored certificates.
+	List() ([]Certificater, error)
+}
+
+// RevocationStore is the interface declaring the methods for the persistence of the revoked certificates,
+// so revocations survive restarts and are shared by the osm-controller replicas.
+type RevocationStore interface {
+	// Revoke persists the revocation of the certificates with the given Common Name (CN).
+	Revoke(CommonName) error
+
+	// IsRevoked determines whether the certificates with the given Common Name (CN) were revoked.
+	IsRevoked(CommonName) bool
+}
+
+// RevocationList keeps track of the Common Names (CN) of the revoked certificates, and when they were revoked.
+type RevocationList struct {
+	revoked map[CommonName]time.Time
+	lock    sync.RWMutex
+
+	// store persists the revocations, when it is not nil
+	store RevocationStore
 }
 
 var (
@@ -0,0 +1,251 @@
+package configurator
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+const (
+	kubeSystemNamespace = "kube-system"
+
+	// kubeProxyConfigMapName is the name of the ConfigMap holding the KubeProxyConfiguration, whose clusterCIDR is the pod CIDR
+	kubeProxyConfigMapName = "kube-proxy"
+	kubeProxyConfigKey     = "config.conf"
+
+	// kubeadmConfigMapName is the name of the ConfigMap holding the kubeadm ClusterConfiguration, whose networking lists the pod and service CIDRs
+	kubeadmConfigMapName           = "kubeadm-config"
+	kubeadmClusterConfigurationKey = "ClusterConfiguration"
+)
+
+// kubeProxyConfiguration is the subset of the KubeProxyConfiguration used to discover the mesh CIDR ranges
+type kubeProxyConfiguration struct {
+	ClusterCIDR string `yaml:"clusterCIDR"`
+}
+
+// kubeadmClusterConfiguration is the subset of the kubeadm ClusterConfiguration used to discover the mesh CIDR ranges
+type kubeadmClusterConfiguration struct {
+	Networking struct {
+		PodSubnet     string `yaml:"podSubnet"`
+		ServiceSubnet string `yaml:"serviceSubnet"`
+	} `yaml:"networking"`
+}
+
+// meshCIDRDiscovery discovers the pod and service CIDR ranges of the cluster, and announces when they change
+type meshCIDRDiscovery struct {
+	nodeInformer      cache.SharedIndexInformer
+	serviceInformer   cache.SharedIndexInformer
+	configMapInformer cache.SharedIndexInformer
+	announcements     chan announcements.Announcement
+	cacheSynced       chan interface{}
+
+	cidrRanges     []string
+	cidrRangesLock sync.RWMutex
+}
+
+func newMeshCIDRDiscovery(kubeClient kubernetes.Interface, announcements chan announcements.Announcement) *meshCIDRDiscovery {
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
+	kubeSystemInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(kubeSystemNamespace))
+
+	d := &meshCIDRDiscovery{
+		nodeInformer:      informerFactory.Core().V1().Nodes().Informer(),
+		serviceInformer:   informerFactory.Core().V1().Services().Informer(),
+		configMapInformer: kubeSystemInformerFactory.Core().V1().ConfigMaps().Informer(),
+		announcements:     announcements,
+		cacheSynced:       make(chan interface{}),
+	}
+
+	// Nodes are updated on every heartbeat, so the CIDR ranges are only rediscovered when the fields they are derived from change
+	d.nodeInformer.AddEventHandler(d.getEventHandlers(func(oldObj, newObj interface{}) bool {
+		oldNode, newNode := oldObj.(*v1.Node), newObj.(*v1.Node)
+		return oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR || !reflect.DeepEqual(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs)
+	}))
+	d.serviceInformer.AddEventHandler(d.getEventHandlers(func(oldObj, newObj interface{}) bool {
+		return !reflect.DeepEqual(k8s.GetServiceClusterIPs(oldObj.(*v1.Service)), k8s.GetServiceClusterIPs(newObj.(*v1.Service)))
+	}))
+	d.configMapInformer.AddEventHandler(d.getEventHandlers(func(oldObj, newObj interface{}) bool {
+		return !reflect.DeepEqual(oldObj.(*v1.ConfigMap).Data, newObj.(*v1.ConfigMap).Data)
+	}))
+
+	return d
+}
+
+func (d *meshCIDRDiscovery) getEventHandlers(hasChanged func(oldObj, newObj interface{}) bool) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { d.updateIfSynced() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if hasChanged(oldObj, newObj) {
+				d.updateIfSynced()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { d.updateIfSynced() },
+	}
+}
+
+// updateIfSynced rediscovers the mesh CIDR ranges once the caches of the informers synced:
+// the objects of their initial list are all handled at once by run
+func (d *meshCIDRDiscovery) updateIfSynced() {
+	select {
+	case <-d.cacheSynced:
+		d.update()
+	default:
+	}
+}
+
+func (d *meshCIDRDiscovery) run(stop <-chan struct{}) {
+	go d.nodeInformer.Run(stop)
+	go d.serviceInformer.Run(stop)
+	go d.configMapInformer.Run(stop)
+	log.Info().Msg("[ConfigMap Client] Waiting for mesh CIDR discovery informers' cache to sync")
+	if !cache.WaitForCacheSync(stop, d.nodeInformer.HasSynced, d.serviceInformer.HasSynced, d.configMapInformer.HasSynced) {
+		log.Error().Msg("Failed initial cache sync for mesh CIDR discovery informers")
+		return
+	}
+
+	// Closing the cacheSynced channel lets the event handlers rediscover the mesh CIDR ranges,
+	// the changes made since the caches synced are included in the following update.
+	close(d.cacheSynced)
+	d.update()
+}
+
+// update rediscovers the mesh CIDR ranges, and announces them when they changed
+func (d *meshCIDRDiscovery) update() {
+	var nodes []*v1.Node
+	for _, obj := range d.nodeInformer.GetStore().List() {
+		nodes = append(nodes, obj.(*v1.Node))
+	}
+	var services []*v1.Service
+	for _, obj := range d.serviceInformer.GetStore().List() {
+		services = append(services, obj.(*v1.Service))
+	}
+	var configMaps []*v1.ConfigMap
+	for _, obj := range d.configMapInformer.GetStore().List() {
+		configMaps = append(configMaps, obj.(*v1.ConfigMap))
+	}
+	cidrRanges := discoverMeshCIDRRanges(nodes, services, configMaps)
+
+	d.cidrRangesLock.Lock()
+	changed := !reflect.DeepEqual(d.cidrRanges, cidrRanges)
+	d.cidrRanges = cidrRanges
+	d.cidrRangesLock.Unlock()
+
+	if !changed {
+		return
+	}
+	log.Info().Msgf("Discovered mesh CIDR ranges %v", cidrRanges)
+	if d.announcements != nil {
+		d.announcements <- announcements.Announcement{
+			Type: announcements.MeshCIDRRangesChanged,
+		}
+	}
+}
+
+func (d *meshCIDRDiscovery) getCIDRRanges() []string {
+	d.cidrRangesLock.RLock()
+	defer d.cidrRangesLock.RUnlock()
+	return d.cidrRanges
+}
+
+// discoverMeshCIDRRanges returns the pod and service CIDR ranges of the cluster:
+// - the pod CIDRs are the pod CIDRs of the nodes, the cluster CIDR of kube-proxy and the pod subnet of kubeadm
+// - the service CIDR is the service subnet of kubeadm or, when unknown, the smallest range including the cluster IPs of the services
+func discoverMeshCIDRRanges(nodes []*v1.Node, services []*v1.Service, configMaps []*v1.ConfigMap) []string {
+	cidrSet := make(map[string]interface{})
+	addCIDRs := func(commaSeparatedCIDRs string) {
+		for _, cidr := range strings.Split(commaSeparatedCIDRs, ",") {
+			if _, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr)); err == nil {
+				cidrSet[ipNet.String()] = nil
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		addCIDRs(node.Spec.PodCIDR)
+		addCIDRs(strings.Join(node.Spec.PodCIDRs, ","))
+	}
+
+	serviceSubnet := ""
+	for _, configMap := range configMaps {
+		switch configMap.Name {
+		case kubeProxyConfigMapName:
+			var config kubeProxyConfiguration
+			if err := yaml.Unmarshal([]byte(configMap.Data[kubeProxyConfigKey]), &config); err != nil {
+				log.Error().Err(err).Msgf("Error parsing the KubeProxyConfiguration of ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+				continue
+			}
+			addCIDRs(config.ClusterCIDR)
+		case kubeadmConfigMapName:
+			var config kubeadmClusterConfiguration
+			if err := yaml.Unmarshal([]byte(configMap.Data[kubeadmClusterConfigurationKey]), &config); err != nil {
+				log.Error().Err(err).Msgf("Error parsing the ClusterConfiguration of ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+				continue
+			}
+			addCIDRs(config.Networking.PodSubnet)
+			serviceSubnet = config.Networking.ServiceSubnet
+		}
+	}
+
+	if serviceSubnet != "" {
+		addCIDRs(serviceSubnet)
+	} else {
+		var clusterIPs []net.IP
+		for _, svc := range services {
+			clusterIPs = append(clusterIPs, k8s.GetServiceClusterIPs(svc)...)
+		}
+		for _, cidr := range getCoveringCIDRs(clusterIPs) {
+			cidrSet[cidr] = nil
+		}
+	}
+
+	var cidrs []string
+	for cidr := range cidrSet {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	return cidrs
+}
+
+// getCoveringCIDRs returns the smallest IPv4 and IPv6 CIDR ranges including the given IP addresses
+func getCoveringCIDRs(ips []net.IP) []string {
+	ipsPerFamily := make(map[int][]net.IP)
+	for _, ip := range ips {
+		if ipv4 := ip.To4(); ipv4 != nil {
+			ipsPerFamily[net.IPv4len] = append(ipsPerFamily[net.IPv4len], ipv4)
+		} else {
+			ipsPerFamily[net.IPv6len] = append(ipsPerFamily[net.IPv6len], ip.To16())
+		}
+	}
+
+	var cidrs []string
+	for ipLen, familyIPs := range ipsPerFamily {
+		prefixLen := ipLen * 8
+		for _, ip := range familyIPs[1:] {
+			prefixLen = commonPrefixLen(familyIPs[0], ip, prefixLen)
+		}
+		ipNet := net.IPNet{IP: familyIPs[0].Mask(net.CIDRMask(prefixLen, ipLen*8)), Mask: net.CIDRMask(prefixLen, ipLen*8)}
+		cidrs = append(cidrs, ipNet.String())
+	}
+	return cidrs
+}
+
+// commonPrefixLen returns the number of leading bits the given IP addresses of the same family have in common, up to maxLen
+func commonPrefixLen(a, b net.IP, maxLen int) int {
+	for i := 0; i < maxLen; i++ {
+		mask := byte(0x80) >> uint(i%8)
+		if a[i/8]&mask != b[i/8]&mask {
+			return i
+		}
+	}
+	return maxLen
+}
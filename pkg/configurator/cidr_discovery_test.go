@@ -0,0 +1,119 @@
+package configurator
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+)
+
+var _ = Describe("Test mesh CIDR discovery", func() {
+	newNode := func(podCIDRs ...string) *v1.Node {
+		return &v1.Node{Spec: v1.NodeSpec{PodCIDR: podCIDRs[0], PodCIDRs: podCIDRs}}
+	}
+	newService := func(clusterIP string) *v1.Service {
+		return &v1.Service{Spec: v1.ServiceSpec{ClusterIP: clusterIP}}
+	}
+	newConfigMap := func(name, key, value string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: kubeSystemNamespace, Name: name},
+			Data:       map[string]string{key: value},
+		}
+	}
+
+	Context("Test discoverMeshCIDRRanges", func() {
+		It("discovers the pod CIDRs of the nodes and the range of the cluster IPs", func() {
+			cidrs := discoverMeshCIDRRanges(
+				[]*v1.Node{newNode("10.244.0.0/24"), newNode("10.244.1.0/24", "fd00:10:244:1::/64")},
+				[]*v1.Service{newService("10.96.0.1"), newService("10.96.12.7"), newService(v1.ClusterIPNone)},
+				nil)
+			Expect(cidrs).To(Equal([]string{"10.244.0.0/24", "10.244.1.0/24", "10.96.0.0/20", "fd00:10:244:1::/64"}))
+		})
+
+		It("discovers the cluster CIDR of kube-proxy and the subnets of kubeadm", func() {
+			cidrs := discoverMeshCIDRRanges(
+				nil,
+				[]*v1.Service{newService("10.96.0.1")},
+				[]*v1.ConfigMap{
+					newConfigMap(kubeProxyConfigMapName, kubeProxyConfigKey, "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nclusterCIDR: 10.244.0.0/16\n"),
+					newConfigMap(kubeadmConfigMapName, kubeadmClusterConfigurationKey, "networking:\n  podSubnet: 10.244.0.0/16\n  serviceSubnet: 10.96.0.0/12\n"),
+				})
+			Expect(cidrs).To(Equal([]string{"10.244.0.0/16", "10.96.0.0/12"}))
+		})
+
+		It("ignores the unrelated and invalid configuration", func() {
+			cidrs := discoverMeshCIDRRanges(
+				nil,
+				nil,
+				[]*v1.ConfigMap{
+					newConfigMap("coredns", "Corefile", ".:53 {}"),
+					newConfigMap(kubeProxyConfigMapName, kubeProxyConfigKey, "clusterCIDR: not-a-cidr"),
+				})
+			Expect(cidrs).To(BeEmpty())
+		})
+	})
+
+	Context("Test getCoveringCIDRs", func() {
+		It("returns the smallest range including the IP addresses", func() {
+			Expect(getCoveringCIDRs([]net.IP{net.ParseIP("10.0.0.1")})).To(Equal([]string{"10.0.0.1/32"}))
+			Expect(getCoveringCIDRs([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.255.1")})).To(Equal([]string{"10.0.0.0/16"}))
+			Expect(getCoveringCIDRs([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("192.168.0.1")})).To(Equal([]string{"0.0.0.0/0"}))
+		})
+	})
+
+	Context("Test meshCIDRDiscovery.run", func() {
+		It("announces the mesh CIDR ranges discovered from the initial list once", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			for _, podCIDR := range []string{"10.244.0.0/24", "10.244.1.0/24", "10.244.2.0/24"} {
+				node := newNode(podCIDR)
+				node.Name = podCIDR
+				_, err := kubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+			announcementsChannel := make(chan announcements.Announcement, 10)
+			d := newMeshCIDRDiscovery(kubeClient, announcementsChannel)
+			stop := make(chan struct{})
+			defer close(stop)
+
+			d.run(stop)
+
+			Expect(d.getCIDRRanges()).To(Equal([]string{"10.244.0.0/24", "10.244.1.0/24", "10.244.2.0/24"}))
+			Expect(announcementsChannel).To(HaveLen(1))
+		})
+	})
+
+	Context("Test GetEffectiveMeshCIDRRanges", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("returns the discovered mesh CIDR ranges unless the OSM ConfigMap overrides them", func() {
+			_, err := kubeClient.CoreV1().Nodes().Create(context.TODO(), newNode("10.244.0.0/24"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			announcement := <-cfg.GetAnnouncementsChannel()
+			Expect(announcement.Type).To(Equal(announcements.MeshCIDRRangesChanged))
+			Expect(cfg.GetEffectiveMeshCIDRRanges()).To(Equal([]string{"10.244.0.0/24"}))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{meshCIDRRangesKey: "10.0.0.0/8"},
+			}
+			_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+			Expect(cfg.GetEffectiveMeshCIDRRanges()).To(Equal([]string{"10.0.0.0/8"}))
+		})
+	})
+})
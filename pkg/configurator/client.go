@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
 
@@ -19,12 +20,20 @@ const (
 	prometheusScrapingKey          = "prometheus_scraping"
 	meshCIDRRangesKey              = "mesh_cidr_ranges"
 	useHTTPSIngressKey             = "use_https_ingress"
+	ingressClassKey                = "ingress_class"
 	tracingEnableKey               = "tracing_enable"
 	tracingAddressKey              = "tracing_address"
 	tracingPortKey                 = "tracing_port"
 	tracingEndpointKey             = "tracing_endpoint"
 	defaultInMeshCIDR              = ""
 	envoyLogLevel                  = "envoy_log_level"
+	useDeltaXDSKey                 = "use_delta_xds"
+	proxyUpdateMinDelayKey         = "proxy_update_min_delay"
+	proxyUpdateMaxDelayKey         = "proxy_update_max_delay"
+	trustDomainKey                 = "trust_domain"
+	grpcStatsKey                   = "grpc_stats"
+	clusterIDKey                   = "cluster_id"
+	multiclusterGatewayKey         = "multicluster_gateway"
 )
 
 // NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
@@ -39,10 +48,11 @@ func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmN
 		informer:         informer,
 		cache:            informer.GetStore(),
 		cacheSynced:      make(chan interface{}),
-		announcements:    make(chan interface{}),
+		announcements:    make(chan announcements.Announcement),
 		osmNamespace:     osmNamespace,
 		osmConfigMapName: osmConfigMapName,
 	}
+	client.meshCIDRDiscovery = newMeshCIDRDiscovery(kubeClient, client.announcements)
 
 	// Ensure this exclusively watches only the Namespace where OSM in installed and the particular ConfigMap we need.
 	shouldObserve := func(obj interface{}) bool {
@@ -53,9 +63,14 @@ func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmN
 
 	informerName := "ConfigMap"
 	providerName := "OSMConfigMap"
-	informer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, client.announcements, shouldObserve))
+	informer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.ConfigMapAdded,
+		Update: announcements.ConfigMapChanged,
+		Delete: announcements.ConfigMapDeleted,
+	}))
 
 	client.run(stop)
+	go client.meshCIDRDiscovery.run(stop)
 
 	return &client
 }
@@ -78,6 +93,9 @@ type osmConfig struct {
 	// UseHTTPSIngress is a bool toggle enabling HTTPS protocol between ingress and backend pods
 	UseHTTPSIngress bool `yaml:"use_https_ingress"`
 
+	// IngressClass is the class of the Ingress resources whose backends are configured to accept ingress traffic
+	IngressClass string `yaml:"ingress_class"`
+
 	// TracingEnabled is a bool toggle used to enable or disable tracing
 	TracingEnable bool `yaml:"tracing_enable"`
 
@@ -95,6 +113,27 @@ type osmConfig struct {
 
 	// EnvoyLogLevel is a string that defines the log level for envoy proxies
 	EnvoyLogLevel string `yaml:"envoy_log_level"`
+
+	// UseDeltaXDS is a bool toggle making Envoy proxies use the incremental (delta) xDS protocol
+	UseDeltaXDS bool `yaml:"use_delta_xds"`
+
+	// ProxyUpdateMinDelay is the time to wait after the last change before the proxies are updated
+	ProxyUpdateMinDelay string `yaml:"proxy_update_min_delay"`
+
+	// ProxyUpdateMaxDelay is the longest time to wait before the proxies are updated while changes keep coming
+	ProxyUpdateMaxDelay string `yaml:"proxy_update_max_delay"`
+
+	// TrustDomain is the SPIFFE trust domain of the identities carried by the certificates of the mesh
+	TrustDomain string `yaml:"trust_domain"`
+
+	// GRPCStats is a bool toggle used to enable or disable the gRPC statistics of the proxies
+	GRPCStats bool `yaml:"grpc_stats"`
+
+	// ClusterID is the ID of the cluster in the multicluster mesh
+	ClusterID string `yaml:"cluster_id"`
+
+	// MulticlusterGateway is the <namespace>/<name> of the service of the gateway receiving the traffic from remote clusters
+	MulticlusterGateway string `yaml:"multicluster_gateway"`
 }
 
 func (c *Client) run(stop <-chan struct{}) {
@@ -136,9 +175,20 @@ func (c *Client) getConfigMap() *osmConfig {
 		PrometheusScraping:          getBoolValueForKey(configMap, prometheusScrapingKey),
 		MeshCIDRRanges:              getEgressCIDR(configMap),
 		UseHTTPSIngress:             getBoolValueForKey(configMap, useHTTPSIngressKey),
+		IngressClass:                getStringValueForKey(configMap, ingressClassKey),
 
 		TracingEnable: getBoolValueForKey(configMap, tracingEnableKey),
 		EnvoyLogLevel: getStringValueForKey(configMap, envoyLogLevel),
+		UseDeltaXDS:   getBoolValueForKey(configMap, useDeltaXDSKey),
+
+		ProxyUpdateMinDelay: getStringValueForKey(configMap, proxyUpdateMinDelayKey),
+		ProxyUpdateMaxDelay: getStringValueForKey(configMap, proxyUpdateMaxDelayKey),
+
+		TrustDomain: getStringValueForKey(configMap, trustDomainKey),
+		GRPCStats:   getBoolValueForKey(configMap, grpcStatsKey),
+
+		ClusterID:           getStringValueForKey(configMap, clusterIDKey),
+		MulticlusterGateway: getStringValueForKey(configMap, multiclusterGatewayKey),
 	}
 
 	if osmConfigMap.TracingEnable {
@@ -154,7 +204,7 @@ func getEgressCIDR(configMap *v1.ConfigMap) string {
 	cidr, ok := configMap.Data[meshCIDRRangesKey]
 	if !ok {
 		if getBoolValueForKey(configMap, egressKey) {
-			log.Error().Err(errMissingKeyInConfigMap).Msgf("Missing ConfigMap %s/%s key %s, required when egress is enabled; Defaulting to %+v", configMap.Namespace, configMap.Name, meshCIDRRangesKey, defaultInMeshCIDR)
+			log.Info().Msgf("Missing ConfigMap %s/%s key %s; The mesh CIDR ranges discovered from the cluster are used", configMap.Namespace, configMap.Name, meshCIDRRangesKey)
 		}
 		return defaultInMeshCIDR
 	}
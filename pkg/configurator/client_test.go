@@ -12,7 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
-	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/announcements"
 )
 
 var _ = Describe("Test OSM ConfigMap parsing", func() {
@@ -82,8 +82,11 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &cm, metav1.UpdateOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
-			event := <-cfg.GetAnnouncementsChannel()
-			log.Info().Msgf("ConfigMap Update Event:  %+v", event.(kubernetes.Event).Value.(*v1.ConfigMap).Data)
+			announcement := <-cfg.GetAnnouncementsChannel()
+			Expect(announcement.Type).To(Equal(announcements.ConfigMapChanged))
+			Expect(announcement.Namespace).To(Equal(osmNamespace))
+			Expect(announcement.Name).To(Equal(osmConfigMapName))
+			log.Info().Msgf("ConfigMap Update Event:  %+v", announcement.Object.(*v1.ConfigMap).Data)
 
 			Expect(cfg.getConfigMap().Egress).To(BeTrue())
 
@@ -107,12 +110,20 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 				"TracingEndpoint":             tracingEndpointKey,
 				"MeshCIDRRanges":              meshCIDRRangesKey,
 				"UseHTTPSIngress":             useHTTPSIngressKey,
+				"IngressClass":                ingressClassKey,
 				"EnvoyLogLevel":               envoyLogLevel,
+				"UseDeltaXDS":                 useDeltaXDSKey,
+				"ProxyUpdateMinDelay":         proxyUpdateMinDelayKey,
+				"ProxyUpdateMaxDelay":         proxyUpdateMaxDelayKey,
+				"TrustDomain":                 trustDomainKey,
+				"GRPCStats":                   grpcStatsKey,
+				"ClusterID":                   clusterIDKey,
+				"MulticlusterGateway":         multiclusterGatewayKey,
 			}
 			t := reflect.TypeOf(osmConfig{})
 
 			actualNumberOfFields := t.NumField()
-			expectedNumberOfFields := 10
+			expectedNumberOfFields := 18
 			Expect(actualNumberOfFields).To(
 				Equal(expectedNumberOfFields),
 				fmt.Sprintf("Fields have been added or removed from the osmConfig struct -- expected %d, actual %d; please correct this unit test", expectedNumberOfFields, actualNumberOfFields))
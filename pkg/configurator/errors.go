@@ -1,7 +0,0 @@
-package configurator
-
-import "github.com/pkg/errors"
-
-var (
-	errMissingKeyInConfigMap = errors.New("missing key in ConfigMap")
-)
@@ -1,6 +1,9 @@
 package configurator
 
 import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -13,6 +16,14 @@ type FakeConfigurator struct {
 	TracingEnable               bool
 	MeshCIDRRanges              []string
 	HTTPSIngress                bool
+	IngressClass                string
+	DeltaXDS                    bool
+	ProxyUpdateMinDelay         time.Duration
+	ProxyUpdateMaxDelay         time.Duration
+	TrustDomain                 string
+	GRPCStats                   bool
+	ClusterID                   string
+	MulticlusterGateway         string
 }
 
 // NewFakeConfigurator create a new fake Configurator
@@ -35,6 +46,14 @@ func NewFakeConfiguratorWithOptions(f FakeConfigurator) Configurator {
 		TracingEnable:               f.TracingEnable,
 		MeshCIDRRanges:              f.MeshCIDRRanges,
 		HTTPSIngress:                f.HTTPSIngress,
+		IngressClass:                f.IngressClass,
+		DeltaXDS:                    f.DeltaXDS,
+		ProxyUpdateMinDelay:         f.ProxyUpdateMinDelay,
+		ProxyUpdateMaxDelay:         f.ProxyUpdateMaxDelay,
+		TrustDomain:                 f.TrustDomain,
+		GRPCStats:                   f.GRPCStats,
+		ClusterID:                   f.ClusterID,
+		MulticlusterGateway:         f.MulticlusterGateway,
 	}
 }
 
@@ -73,14 +92,68 @@ func (f FakeConfigurator) GetMeshCIDRRanges() []string {
 	return f.MeshCIDRRanges
 }
 
+// GetEffectiveMeshCIDRRanges returns the mesh CIDR ranges set in the OSM ConfigMap or, when none is set, the mesh CIDR ranges discovered from the cluster
+func (f FakeConfigurator) GetEffectiveMeshCIDRRanges() []string {
+	return f.MeshCIDRRanges
+}
+
 // UseHTTPSIngress determines whether we use HTTPS for ingress to backend pods traffic
 func (f FakeConfigurator) UseHTTPSIngress() bool {
 	return f.HTTPSIngress
 }
 
+// GetIngressClass returns the class of the Ingress resources whose backends are configured to accept ingress traffic, all classes when empty
+func (f FakeConfigurator) GetIngressClass() string {
+	return f.IngressClass
+}
+
+// IsDeltaXDSEnabled determines whether Envoy proxies should use the incremental (delta) xDS protocol
+func (f FakeConfigurator) IsDeltaXDSEnabled() bool {
+	return f.DeltaXDS
+}
+
+// GetProxyUpdateMinDelay returns the time to wait after the last change before the proxies are updated
+func (f FakeConfigurator) GetProxyUpdateMinDelay() time.Duration {
+	if f.ProxyUpdateMinDelay != 0 {
+		return f.ProxyUpdateMinDelay
+	}
+	return constants.DefaultProxyUpdateMinDelay
+}
+
+// GetProxyUpdateMaxDelay returns the longest time to wait before the proxies are updated while changes keep coming
+func (f FakeConfigurator) GetProxyUpdateMaxDelay() time.Duration {
+	if f.ProxyUpdateMaxDelay != 0 {
+		return f.ProxyUpdateMaxDelay
+	}
+	return constants.DefaultProxyUpdateMaxDelay
+}
+
+// GetTrustDomain returns the SPIFFE trust domain of the identities carried by the certificates of the mesh
+func (f FakeConfigurator) GetTrustDomain() string {
+	if f.TrustDomain != "" {
+		return f.TrustDomain
+	}
+	return constants.DefaultTrustDomain
+}
+
+// IsGRPCStatsEnabled determines whether the proxies emit statistics for the gRPC traffic
+func (f FakeConfigurator) IsGRPCStatsEnabled() bool {
+	return f.GRPCStats
+}
+
+// GetClusterID returns the ID of the cluster in the multicluster mesh
+func (f FakeConfigurator) GetClusterID() string {
+	return f.ClusterID
+}
+
+// GetMulticlusterGateway returns the <namespace>/<name> of the service of the gateway receiving the traffic from remote clusters
+func (f FakeConfigurator) GetMulticlusterGateway() string {
+	return f.MulticlusterGateway
+}
+
 // GetAnnouncementsChannel returns a fake announcement channel
-func (f FakeConfigurator) GetAnnouncementsChannel() <-chan interface{} {
-	return make(chan interface{})
+func (f FakeConfigurator) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return make(chan announcements.Announcement)
 }
 
 // GetTracingHost is the host to which we send tracing spans
@@ -6,7 +6,9 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -112,11 +114,28 @@ func (c *Client) GetMeshCIDRRanges() []string {
 	return cidrs
 }
 
+// GetEffectiveMeshCIDRRanges returns the mesh CIDR ranges set in the OSM ConfigMap, which override the discovered ones,
+// or the mesh CIDR ranges discovered from the cluster when none is set
+func (c *Client) GetEffectiveMeshCIDRRanges() []string {
+	if cidrs := c.GetMeshCIDRRanges(); len(cidrs) > 0 {
+		return cidrs
+	}
+	if c.meshCIDRDiscovery == nil {
+		return nil
+	}
+	return c.meshCIDRDiscovery.getCIDRRanges()
+}
+
 // UseHTTPSIngress determines whether traffic between ingress and backend pods should use HTTPS protocol
 func (c *Client) UseHTTPSIngress() bool {
 	return c.getConfigMap().UseHTTPSIngress
 }
 
+// GetIngressClass returns the class of the Ingress resources whose backends are configured to accept ingress traffic, all classes when empty
+func (c *Client) GetIngressClass() string {
+	return c.getConfigMap().IngressClass
+}
+
 // GetEnvoyLogLevel returns the envoy log level
 func (c *Client) GetEnvoyLogLevel() string {
 	logLevel := c.getConfigMap().EnvoyLogLevel
@@ -126,7 +145,60 @@ func (c *Client) GetEnvoyLogLevel() string {
 	return constants.DefaultEnvoyLogLevel
 }
 
+// IsDeltaXDSEnabled determines whether Envoy proxies should use the incremental (delta) xDS protocol
+func (c *Client) IsDeltaXDSEnabled() bool {
+	return c.getConfigMap().UseDeltaXDS
+}
+
+// GetProxyUpdateMinDelay returns the time to wait after the last change before the proxies are updated
+func (c *Client) GetProxyUpdateMinDelay() time.Duration {
+	return c.getDurationOrDefault(c.getConfigMap().ProxyUpdateMinDelay, proxyUpdateMinDelayKey, constants.DefaultProxyUpdateMinDelay)
+}
+
+// GetProxyUpdateMaxDelay returns the longest time to wait before the proxies are updated while changes keep coming
+func (c *Client) GetProxyUpdateMaxDelay() time.Duration {
+	return c.getDurationOrDefault(c.getConfigMap().ProxyUpdateMaxDelay, proxyUpdateMaxDelayKey, constants.DefaultProxyUpdateMaxDelay)
+}
+
+// GetTrustDomain returns the SPIFFE trust domain of the identities carried by the certificates of the mesh
+func (c *Client) GetTrustDomain() string {
+	trustDomain := c.getConfigMap().TrustDomain
+	if trustDomain != "" {
+		return trustDomain
+	}
+	return constants.DefaultTrustDomain
+}
+
+// IsGRPCStatsEnabled determines whether the proxies emit statistics for the gRPC traffic
+func (c *Client) IsGRPCStatsEnabled() bool {
+	return c.getConfigMap().GRPCStats
+}
+
+// GetClusterID returns the ID of the cluster in the multicluster mesh
+func (c *Client) GetClusterID() string {
+	return c.getConfigMap().ClusterID
+}
+
+// GetMulticlusterGateway returns the <namespace>/<name> of the service of the gateway receiving the traffic from remote clusters
+func (c *Client) GetMulticlusterGateway() string {
+	return c.getConfigMap().MulticlusterGateway
+}
+
+func (c *Client) getDurationOrDefault(value, key string, defaultDuration time.Duration) time.Duration {
+	if value == "" {
+		return defaultDuration
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Error().Err(err).Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", value, key, c.getConfigMapCacheKey(), defaultDuration)
+		return defaultDuration
+	}
+
+	return duration
+}
+
 // GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap.
-func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
+func (c *Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return c.announcements
 }
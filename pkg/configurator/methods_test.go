@@ -2,6 +2,7 @@ package configurator
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,6 +10,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 var _ = Describe("Test Envoy configuration creation", func() {
@@ -384,4 +387,67 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testErrorEnvoyLogLevel))
 		})
 	})
+
+	Context("create OSM config for the proxy update delays", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly retrieves the proxy update delays", func() {
+			Expect(cfg.GetProxyUpdateMinDelay()).To(Equal(constants.DefaultProxyUpdateMinDelay))
+			Expect(cfg.GetProxyUpdateMaxDelay()).To(Equal(constants.DefaultProxyUpdateMaxDelay))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					proxyUpdateMinDelayKey: "500ms",
+					proxyUpdateMaxDelayKey: "not-a-duration",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyUpdateMinDelay()).To(Equal(500 * time.Millisecond))
+			Expect(cfg.GetProxyUpdateMaxDelay()).To(Equal(constants.DefaultProxyUpdateMaxDelay))
+		})
+	})
+
+	Context("create OSM config for the SPIFFE trust domain", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly retrieves the trust domain", func() {
+			Expect(cfg.GetTrustDomain()).To(Equal(constants.DefaultTrustDomain))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					trustDomainKey: "example.org",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetTrustDomain()).To(Equal("example.org"))
+		})
+	})
 })
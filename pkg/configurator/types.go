@@ -1,8 +1,11 @@
 package configurator
 
 import (
+	"time"
+
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -14,10 +17,12 @@ var (
 type Client struct {
 	osmNamespace     string
 	osmConfigMapName string
-	announcements    chan interface{}
+	announcements    chan announcements.Announcement
 	informer         cache.SharedIndexInformer
 	cache            cache.Store
 	cacheSynced      chan interface{}
+
+	meshCIDRDiscovery *meshCIDRDiscovery
 }
 
 // Configurator is the controller interface for K8s namespaces
@@ -52,12 +57,39 @@ type Configurator interface {
 	// GetMeshCIDRRanges returns a list of mesh CIDR ranges
 	GetMeshCIDRRanges() []string
 
+	// GetEffectiveMeshCIDRRanges returns the mesh CIDR ranges set in the OSM ConfigMap or, when none is set, the mesh CIDR ranges discovered from the cluster
+	GetEffectiveMeshCIDRRanges() []string
+
 	// UseHTTPSIngress determines whether protocol used for traffic from ingress to backend pods should be HTTPS.
 	UseHTTPSIngress() bool
 
+	// GetIngressClass returns the class of the Ingress resources whose backends are configured to accept ingress traffic, all classes when empty
+	GetIngressClass() string
+
 	// GetEnvoyLogLevel returns the envoy log level
 	GetEnvoyLogLevel() string
 
+	// IsDeltaXDSEnabled determines whether Envoy proxies should use the incremental (delta) xDS protocol
+	IsDeltaXDSEnabled() bool
+
+	// GetProxyUpdateMinDelay returns the time to wait after the last change before the proxies are updated
+	GetProxyUpdateMinDelay() time.Duration
+
+	// GetProxyUpdateMaxDelay returns the longest time to wait before the proxies are updated while changes keep coming
+	GetProxyUpdateMaxDelay() time.Duration
+
+	// GetTrustDomain returns the SPIFFE trust domain of the identities carried by the certificates of the mesh
+	GetTrustDomain() string
+
+	// IsGRPCStatsEnabled determines whether the proxies emit statistics for the gRPC traffic
+	IsGRPCStatsEnabled() bool
+
+	// GetClusterID returns the ID of the cluster in the multicluster mesh
+	GetClusterID() string
+
+	// GetMulticlusterGateway returns the <namespace>/<name> of the service of the gateway receiving the traffic from remote clusters
+	GetMulticlusterGateway() string
+
 	// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
 }
@@ -12,9 +12,15 @@ const (
 	// KubeProviderName is a string constant used for the ID string of the Kubernetes endpoints provider.
 	KubeProviderName = "Kubernetes"
 
+	// RemoteProviderNamePrefix is the prefix of the ID string of the endpoints providers of the remote clusters of a multicluster mesh.
+	RemoteProviderNamePrefix = "RemoteCluster"
+
 	// WildcardIPAddr is a string constant.
 	WildcardIPAddr = "0.0.0.0"
 
+	// WildcardIPv6Addr is the IPv6 wildcard address.
+	WildcardIPv6Addr = "::"
+
 	// EnvoyAdminPort is Envoy's admin port
 	EnvoyAdminPort = 15000
 
@@ -42,6 +48,9 @@ const (
 	// LocalhostIPAddress is the local host address.
 	LocalhostIPAddress = "127.0.0.1"
 
+	// LocalhostIPv6Address is the IPv6 local host address.
+	LocalhostIPv6Address = "::1"
+
 	// EnvoyMetricsCluster is the cluster name of the Prometheus metrics cluster
 	EnvoyMetricsCluster = "envoy-metrics-cluster"
 
@@ -60,6 +69,15 @@ const (
 	// DefaultEnvoyLogLevel is the default envoy log level if not defined in the osm configmap
 	DefaultEnvoyLogLevel = "debug"
 
+	// DefaultTrustDomain is the default SPIFFE trust domain of the identities carried by the certificates of the mesh, if not defined in the osm configmap
+	DefaultTrustDomain = "cluster.local"
+
+	// DefaultProxyUpdateMinDelay is the default time to wait after the last change before the proxies are updated, if not defined in the osm configmap
+	DefaultProxyUpdateMinDelay = 1 * time.Second
+
+	// DefaultProxyUpdateMaxDelay is the default longest time to wait before the proxies are updated while changes keep coming, if not defined in the osm configmap
+	DefaultProxyUpdateMaxDelay = 10 * time.Second
+
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
@@ -105,9 +123,26 @@ const (
 	// KubernetesOpaqueSecretCAExpiration is the key which holds the CA's expiration in a Kubernetes secret.
 	KubernetesOpaqueSecretCAExpiration = "expiration"
 
+	// KubernetesOpaqueSecretTrustBundleKey is the key which holds all the root certificates trusted by the mesh in a Kubernetes secret.
+	// When a secret does not have this key, the CA held by KubernetesOpaqueSecretCAKey is the only trusted root certificate.
+	KubernetesOpaqueSecretTrustBundleKey = "trust-bundle.crt"
+
+	// KubernetesOpaqueSecretNextCAKey is the key which holds the CA introduced by a root certificate rotation in a Kubernetes secret.
+	KubernetesOpaqueSecretNextCAKey = "next-ca.crt"
+
+	// KubernetesOpaqueSecretNextRootPrivateKeyKey is the key which holds the private key of the CA introduced by a root certificate rotation in a Kubernetes secret.
+	KubernetesOpaqueSecretNextRootPrivateKeyKey = "next-private.key"
+
+	// KubernetesOpaqueSecretNextCAExpiration is the key which holds the expiration of the CA introduced by a root certificate rotation in a Kubernetes secret.
+	KubernetesOpaqueSecretNextCAExpiration = "next-expiration"
+
 	// EnvoyUniqueIDLabelName is the label applied to pods with the unique ID of the Envoy sidecar.
 	EnvoyUniqueIDLabelName = "osm-envoy-uid"
 
+	// EnvoyBootstrapConfigSecretPrefix is the prefix of the name of the secret holding the bootstrap configuration of an Envoy sidecar,
+	// which is followed by the unique ID of the sidecar.
+	EnvoyBootstrapConfigSecretPrefix = "envoy-bootstrap-config-"
+
 	// TimeDateLayout is the layout for time.Parse used in this repo
 	TimeDateLayout = "2006-01-02T15:04:05.000Z"
 
@@ -133,4 +168,16 @@ const (
 
 	// InitContainerName is the name of the init container
 	InitContainerName = "osm-init"
+
+	// MulticlusterExportAnnotation is the annotation of the services exported to the remote clusters of a multicluster mesh
+	MulticlusterExportAnnotation = "openservicemesh.io/multicluster-export"
+
+	// MulticlusterExportConfigMapName is the name of the ConfigMap holding the services exported to the remote clusters
+	MulticlusterExportConfigMapName = "osm-multicluster-export"
+
+	// MulticlusterRemoteClusterLabel is the label of the secrets holding the kubeconfig of the remote clusters of a multicluster mesh
+	MulticlusterRemoteClusterLabel = "openservicemesh.io/multicluster-remote"
+
+	// MulticlusterGatewayPort is the port the multicluster gateway receives the traffic from remote clusters on
+	MulticlusterGatewayPort = 15443
 )
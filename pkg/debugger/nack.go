@@ -0,0 +1,20 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (ds debugServer) getXDSNACKsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nacks := ds.xdsDebugger.GetXDSNACKs()
+
+		jsonNACKs, err := json.Marshal(nacks)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling NACKs %+v", nacks)
+		}
+
+		_, _ = fmt.Fprint(w, string(jsonNACKs))
+	})
+}
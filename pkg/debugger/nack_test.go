@@ -0,0 +1,53 @@
+package debugger
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+type fakeXDSDebugger struct{}
+
+// GetXDSLog implements XDSDebugger
+func (f fakeXDSDebugger) GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time {
+	return &map[certificate.CommonName]map[envoy.TypeURI][]time.Time{}
+}
+
+// GetXDSNACKs implements XDSDebugger
+func (f fakeXDSDebugger) GetXDSNACKs() map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK {
+	return map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK{
+		"proxy-cn": {
+			envoy.TypeCDS: {
+				{
+					TypeURI: envoy.TypeCDS,
+					Version: "abc",
+					Nonce:   "123",
+					Error:   "invalid cluster",
+					Time:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Test debugger methods", func() {
+	Context("Testing getXDSNACKsHandler()", func() {
+		It("returns JSON serialized NACKs", func() {
+			ds := debugServer{
+				xdsDebugger: fakeXDSDebugger{},
+			}
+			nacksHandler := ds.getXDSNACKsHandler()
+			responseRecorder := httptest.NewRecorder()
+			nacksHandler.ServeHTTP(responseRecorder, nil)
+			actualResponseBody := responseRecorder.Body.String()
+			expectedResponseBody := `{"proxy-cn":{"type.googleapis.com/envoy.config.cluster.v3.Cluster":[{"type_uri":"type.googleapis.com/envoy.config.cluster.v3.Cluster","version":"abc","nonce":"123","error":"invalid cluster","time":"2020-01-01T00:00:00Z"}]}}`
+			Expect(actualResponseBody).To(Equal(expectedResponseBody), fmt.Sprintf("Actual value did not match expectations:\n%s", actualResponseBody))
+		})
+	})
+})
@@ -15,6 +15,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -93,6 +94,15 @@ func (f fakeMeshCatalogDebuger) ListMonitoredNamespaces() []string {
 	return []string{tests.Namespace}
 }
 
+// RotateCertificates implements MeshCatalogDebugger
+func (f fakeMeshCatalogDebuger) RotateCertificates(subject catalog.CertificateSubject, revoke bool) ([]certificate.CommonName, []certificate.CommonName, error) {
+	rotated := []certificate.CommonName{tests.BookstoreService.GetCommonName()}
+	if !revoke {
+		return rotated, nil, nil
+	}
+	return rotated, []certificate.CommonName{subject.ProxyCommonName}, nil
+}
+
 // NewFakeMeshCatalogDebugger implements and creates a new MeshCatalogDebugger
 func NewFakeMeshCatalogDebugger() MeshCatalogDebugger {
 	return fakeMeshCatalogDebuger{}
@@ -0,0 +1,94 @@
+package debugger
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	rotateProxyQueryKey          = "proxy"
+	rotateServiceQueryKey        = "service"
+	rotateServiceAccountQueryKey = "serviceaccount"
+	rotateRevokeQueryKey         = "revoke"
+)
+
+// getCertRotationHandler forces the rotation of the certificates of the Envoy proxy, the service or the service account
+// given in the query, and revokes the XDS certificates of the selected proxies when the query has revoke=true.
+func (ds debugServer) getCertRotationHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Certificates are rotated with POST requests", http.StatusMethodNotAllowed)
+			return
+		}
+
+		subject, revoke, err := parseCertRotationQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rotated, revoked, err := ds.meshCatalogDebugger.RotateCertificates(subject, revoke)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error rotating certificates for %+v", subject)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		for _, cn := range revoked {
+			_, _ = fmt.Fprintf(w, "Revoked certificate CN=%s\n", cn)
+		}
+		for _, cn := range rotated {
+			_, _ = fmt.Fprintf(w, "Rotated certificate CN=%s\n", cn)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "Error rotating certificates: %s\n", err)
+		}
+	})
+}
+
+// parseCertRotationQuery returns the subject of the certificate rotation given in the query, and whether its certificates must be revoked.
+func parseCertRotationQuery(query url.Values) (catalog.CertificateSubject, bool, error) {
+	var subject catalog.CertificateSubject
+
+	if proxy := query.Get(rotateProxyQueryKey); proxy != "" {
+		subject.ProxyCommonName = certificate.CommonName(proxy)
+	}
+
+	if svc := query.Get(rotateServiceQueryKey); svc != "" {
+		meshService, err := service.UnmarshalMeshService(svc)
+		if err != nil {
+			return subject, false, errors.Errorf("Invalid service %q, expected <namespace>/<name>", svc)
+		}
+		subject.Service = meshService
+	}
+
+	if sa := query.Get(rotateServiceAccountQueryKey); sa != "" {
+		chunks := strings.Split(sa, "/")
+		if len(chunks) != 2 || chunks[0] == "" || chunks[1] == "" {
+			return subject, false, errors.Errorf("Invalid service account %q, expected <namespace>/<name>", sa)
+		}
+		subject.ServiceAccount = &service.K8sServiceAccount{
+			Namespace: chunks[0],
+			Name:      chunks[1],
+		}
+	}
+
+	revoke := false
+	if value := query.Get(rotateRevokeQueryKey); value != "" {
+		var err error
+		if revoke, err = strconv.ParseBool(value); err != nil {
+			return subject, false, errors.Errorf("Invalid value %q for %s, expected true or false", value, rotateRevokeQueryKey)
+		}
+	}
+
+	return subject, revoke, nil
+}
@@ -0,0 +1,60 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test certificate rotation through the debug server", func() {
+	ds := debugServer{
+		meshCatalogDebugger: NewFakeMeshCatalogDebugger(),
+	}
+
+	Context("Testing getCertRotationHandler()", func() {
+		It("rotates and revokes the certificates of a proxy", func() {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/debug/certs/rotate?proxy=abc.bookstore.default&revoke=true", nil)
+			ds.getCertRotationHandler().ServeHTTP(responseRecorder, request)
+
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			Expect(responseRecorder.Body.String()).To(Equal("Revoked certificate CN=abc.bookstore.default\nRotated certificate CN=bookstore.default.svc.cluster.local\n"))
+		})
+
+		It("only accepts POST requests", func() {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/debug/certs/rotate?proxy=abc.bookstore.default", nil)
+			ds.getCertRotationHandler().ServeHTTP(responseRecorder, request)
+
+			Expect(responseRecorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("rejects invalid queries", func() {
+			for _, query := range []string{"service=bookstore", "serviceaccount=default/", "proxy=abc.bookstore.default&revoke=maybe"} {
+				responseRecorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodPost, "/debug/certs/rotate?"+query, nil)
+				ds.getCertRotationHandler().ServeHTTP(responseRecorder, request)
+
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest), query)
+			}
+		})
+	})
+
+	Context("Testing parseCertRotationQuery()", func() {
+		It("parses services and service accounts", func() {
+			subject, revoke, err := parseCertRotationQuery(map[string][]string{
+				rotateServiceQueryKey:        {"default/bookstore"},
+				rotateServiceAccountQueryKey: {"default/bookbuyer"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoke).To(BeFalse())
+			Expect(subject.Service).To(Equal(&tests.BookstoreService))
+			Expect(subject.ServiceAccount).To(Equal(&service.K8sServiceAccount{Namespace: "default", Name: "bookbuyer"}))
+		})
+	})
+})
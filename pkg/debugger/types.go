@@ -11,6 +11,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -52,12 +53,19 @@ type MeshCatalogDebugger interface {
 
 	// ListMonitoredNamespaces lists the namespaces that the control plan knows about.
 	ListMonitoredNamespaces() []string
+
+	// RotateCertificates forces the rotation of the certificates of the Envoy proxies selected by the given subject, and revokes
+	// their XDS certificates when requested. It returns the CNs of the rotated and of the revoked certificates.
+	RotateCertificates(subject catalog.CertificateSubject, revoke bool) ([]certificate.CommonName, []certificate.CommonName, error)
 }
 
 // XDSDebugger is an interface providing debugging server with methods introspecting XDS.
 type XDSDebugger interface {
 	// GetXDSLog returns a log of the XDS responses sent to Envoy proxies.
 	GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time
+
+	// GetXDSNACKs returns the most recent xDS responses rejected by each Envoy proxy.
+	GetXDSNACKs() map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK
 }
 
 // DebugServer is the interface of the Debug HTTP server.
@@ -8,6 +8,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	osm "github.com/openservicemesh/osm/pkg/apis/azureresource/v1"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 
@@ -49,7 +50,7 @@ func newClient(kubeClient kubernetes.Interface, azureResourceClient *osmClient.C
 		informers:           &informerCollection,
 		caches:              &cacheCollection,
 		cacheSynced:         make(chan interface{}),
-		announcements:       make(chan interface{}),
+		announcements:       make(chan announcements.Announcement),
 		namespaceController: namespaceController,
 	}
 
@@ -57,7 +58,11 @@ func newClient(kubeClient kubernetes.Interface, azureResourceClient *osmClient.C
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		return namespaceController.IsMonitoredNamespace(ns)
 	}
-	informerCollection.AzureResource.AddEventHandler(k8s.GetKubernetesEventHandlers("AzureResource", "Azure", client.announcements, shouldObserve))
+	informerCollection.AzureResource.AddEventHandler(k8s.GetKubernetesEventHandlers("AzureResource", "Azure", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.AzureResourceAdded,
+		Update: announcements.AzureResourceUpdated,
+		Delete: announcements.AzureResourceDeleted,
+	}))
 
 	return &client
 }
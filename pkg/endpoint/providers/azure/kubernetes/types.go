@@ -4,6 +4,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 )
@@ -29,6 +30,6 @@ type Client struct {
 	kubeClient          kubernetes.Interface
 	informers           *InformerCollection
 	providerIdent       string
-	announcements       chan interface{}
+	announcements       chan announcements.Announcement
 	namespaceController namespace.Controller
 }
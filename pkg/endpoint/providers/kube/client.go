@@ -2,20 +2,22 @@ package kube
 
 import (
 	"context"
+	"fmt"
 	"net"
-	"reflect"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
@@ -24,41 +26,70 @@ import (
 )
 
 // NewProvider implements mesh.EndpointsProvider, which creates a new Kubernetes cluster/compute provider.
+// The endpoints of the services are discovered from their Endpoints.
 func NewProvider(kubeClient kubernetes.Interface, namespaceController namespace.Controller, stop chan struct{}, providerIdent string, cfg configurator.Configurator) (endpoint.Provider, error) {
 	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
+	client := newClient(kubeClient, informerFactory, namespaceController, providerIdent)
 
+	client.informers.Endpoints = informerFactory.Core().V1().Endpoints().Informer()
+	client.caches.Endpoints = client.informers.Endpoints.GetStore()
+	client.informers.Endpoints.AddEventHandler(k8s.GetKubernetesEventHandlers("Endpoints", "Kubernetes", client.announcements, client.shouldObserve, k8s.EventTypes{
+		Add:    announcements.EndpointsAdded,
+		Update: announcements.EndpointsUpdated,
+		Delete: announcements.EndpointsDeleted,
+	}))
+
+	if err := client.run(stop); err != nil {
+		return nil, errors.Errorf("Failed to start Kubernetes EndpointProvider client: %+v", err)
+	}
+
+	return client, nil
+}
+
+// newClient returns a client watching the Deployments, Nodes and Pods with the given informer factory.
+// The informer of the resources the endpoints are discovered from is set by the constructor of the provider.
+func newClient(kubeClient kubernetes.Interface, informerFactory informers.SharedInformerFactory, namespaceController namespace.Controller, providerIdent string) *Client {
 	informerCollection := InformerCollection{
-		Endpoints:   informerFactory.Core().V1().Endpoints().Informer(),
 		Deployments: informerFactory.Apps().V1().Deployments().Informer(),
+		Nodes:       informerFactory.Core().V1().Nodes().Informer(),
+		Pods:        informerFactory.Core().V1().Pods().Informer(),
 	}
 
 	cacheCollection := CacheCollection{
-		Endpoints:   informerCollection.Endpoints.GetStore(),
 		Deployments: informerCollection.Deployments.GetStore(),
+		Nodes:       informerCollection.Nodes.GetStore(),
+		Pods:        informerCollection.Pods.GetStore(),
 	}
 
-	client := Client{
+	client := &Client{
 		providerIdent:       providerIdent,
 		kubeClient:          kubeClient,
 		informers:           &informerCollection,
 		caches:              &cacheCollection,
 		cacheSynced:         make(chan interface{}),
-		announcements:       make(chan interface{}),
+		announcements:       make(chan announcements.Announcement),
 		namespaceController: namespaceController,
 	}
 
-	shouldObserve := func(obj interface{}) bool {
-		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
-		return namespaceController.IsMonitoredNamespace(ns)
-	}
-	informerCollection.Endpoints.AddEventHandler(k8s.GetKubernetesEventHandlers("Endpoints", "Kubernetes", client.announcements, shouldObserve))
-	informerCollection.Deployments.AddEventHandler(k8s.GetKubernetesEventHandlers("Deployments", "Kubernetes", client.announcements, shouldObserve))
+	informerCollection.Deployments.AddEventHandler(k8s.GetKubernetesEventHandlers("Deployments", "Kubernetes", client.announcements, client.shouldObserve, k8s.EventTypes{
+		Add:    announcements.DeploymentAdded,
+		Update: announcements.DeploymentUpdated,
+		Delete: announcements.DeploymentDeleted,
+	}))
+	// The Nodes are only cached to look up the locality of the endpoints: their status is updated too frequently
+	// to announce their changes, while their topology labels do not change once they joined the cluster.
+	// The Pods are only cached to look up the terminating endpoints: the endpoints of a pod change when it terminates.
 
-	if err := client.run(stop); err != nil {
-		return nil, errors.Errorf("Failed to start Kubernetes EndpointProvider client: %+v", err)
-	}
+	return client
+}
 
-	return &client, nil
+// shouldObserve returns true if the given object belongs to a namespace monitored by OSM
+func (c Client) shouldObserve(obj interface{}) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return c.namespaceController.IsMonitoredNamespace(accessor.GetNamespace())
 }
 
 // GetID returns a string descriptor / identifier of the compute provider.
@@ -67,9 +98,26 @@ func (c *Client) GetID() string {
 	return c.providerIdent
 }
 
-// ListEndpointsForService retrieves the list of IP addresses for the given service
+// ListEndpointsForService retrieves the list of IP addresses for the given service, along with their health status
 func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
 	log.Info().Msgf("[%s] Getting Endpoints for service %s on Kubernetes", c.providerIdent, svc)
+	if svc.IsRemote() {
+		// The endpoints of the services exported by remote clusters are discovered by the providers of these clusters
+		return []endpoint.Endpoint{}
+	}
+	if !c.namespaceController.IsMonitoredNamespace(svc.Namespace) {
+		// Doesn't belong to namespaces we are observing
+		return []endpoint.Endpoint{}
+	}
+	if c.caches.EndpointSlices != nil {
+		return c.listEndpointsFromEndpointSlices(svc)
+	}
+	return c.listEndpointsFromEndpoints(svc)
+}
+
+// listEndpointsFromEndpoints returns the endpoints of the given service from its Endpoints, which list the addresses
+// of the pods not ready to receive traffic apart from the ready ones
+func (c Client) listEndpointsFromEndpoints(svc service.MeshService) []endpoint.Endpoint {
 	var endpoints []endpoint.Endpoint = []endpoint.Endpoint{}
 	endpointsInterface, exist, err := c.caches.Endpoints.GetByKey(svc.String())
 	if err != nil {
@@ -83,24 +131,30 @@ func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endp
 	}
 
 	kubernetesEndpoints := endpointsInterface.(*corev1.Endpoints)
-	if kubernetesEndpoints != nil {
-		if !c.namespaceController.IsMonitoredNamespace(kubernetesEndpoints.Namespace) {
-			// Doesn't belong to namespaces we are observing
-			return endpoints
+	if kubernetesEndpoints == nil {
+		return endpoints
+	}
+	for _, kubernetesEndpoint := range kubernetesEndpoints.Subsets {
+		addresses := map[bool][]corev1.EndpointAddress{
+			true:  kubernetesEndpoint.Addresses,
+			false: kubernetesEndpoint.NotReadyAddresses,
 		}
-		for _, kubernetesEndpoint := range kubernetesEndpoints.Subsets {
-			for _, address := range kubernetesEndpoint.Addresses {
+		for _, ready := range []bool{true, false} {
+			for _, address := range addresses[ready] {
+				ip := net.ParseIP(address.IP)
+				if ip == nil {
+					log.Error().Msgf("Error parsing IP address %s", address.IP)
+					continue
+				}
+				locality := c.getEndpointLocality(address.NodeName, address.IP)
+				health := c.getHealthStatus(address.TargetRef, ready)
 				for _, port := range kubernetesEndpoint.Ports {
-					ip := net.ParseIP(address.IP)
-					if ip == nil {
-						log.Error().Msgf("Error parsing IP address %s", address.IP)
-						break
-					}
-					ept := endpoint.Endpoint{
-						IP:   ip,
-						Port: endpoint.Port(port.Port),
-					}
-					endpoints = append(endpoints, ept)
+					endpoints = append(endpoints, endpoint.Endpoint{
+						IP:       ip,
+						Port:     endpoint.Port(port.Port),
+						Locality: locality,
+						Health:   health,
+					})
 				}
 			}
 		}
@@ -108,6 +162,21 @@ func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endp
 	return endpoints
 }
 
+// getHealthStatus returns the health status of an endpoint backed by the given object, with the given readiness.
+// The endpoints of the terminating pods are draining, whether they are still ready or not.
+func (c Client) getHealthStatus(targetRef *corev1.ObjectReference, ready bool) endpoint.HealthStatus {
+	if targetRef != nil && targetRef.Kind == "Pod" && c.caches.Pods != nil {
+		podInterface, exist, err := c.caches.Pods.GetByKey(fmt.Sprintf("%s/%s", targetRef.Namespace, targetRef.Name))
+		if err == nil && exist && podInterface.(*corev1.Pod).DeletionTimestamp != nil {
+			return endpoint.Draining
+		}
+	}
+	if !ready {
+		return endpoint.Unhealthy
+	}
+	return endpoint.Healthy
+}
+
 // GetServicesForServiceAccount retrieves a list of services for the given service account.
 func (c Client) GetServicesForServiceAccount(svcAccount service.K8sServiceAccount) ([]service.MeshService, error) {
 	log.Info().Msgf("[%s] Getting Services for service account %s on Kubernetes", c.providerIdent, svcAccount)
@@ -169,7 +238,7 @@ func (c Client) GetServicesForServiceAccount(svcAccount service.K8sServiceAccoun
 }
 
 // GetAnnouncementsChannel returns the announcement channel for the Kubernetes endpoints provider.
-func (c Client) GetAnnouncementsChannel() <-chan interface{} {
+func (c Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return c.announcements
 }
 
@@ -181,8 +250,11 @@ func (c *Client) run(stop <-chan struct{}) error {
 	}
 
 	sharedInformers := map[string]cache.SharedInformer{
-		"Endpoints":   c.informers.Endpoints,
-		"Deployments": c.informers.Deployments,
+		"Endpoints":      c.informers.Endpoints,
+		"EndpointSlices": c.informers.EndpointSlices,
+		"Deployments":    c.informers.Deployments,
+		"Nodes":          c.informers.Nodes,
+		"Pods":           c.informers.Pods,
 	}
 
 	var names []string
@@ -209,6 +281,19 @@ func (c *Client) run(stop <-chan struct{}) error {
 	return nil
 }
 
+// getEndpointLocality returns the locality of the node the endpoint with the given address runs on, empty when unknown
+func (c Client) getEndpointLocality(nodeName *string, address string) endpoint.Locality {
+	if nodeName == nil || c.caches.Nodes == nil {
+		return endpoint.Locality{}
+	}
+	nodeInterface, exist, err := c.caches.Nodes.GetByKey(*nodeName)
+	if err != nil || !exist {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes Node %s of endpoint %s from cache", c.providerIdent, *nodeName, address)
+		return endpoint.Locality{}
+	}
+	return k8s.GetNodeLocality(nodeInterface.(*corev1.Node))
+}
+
 // getServicesByLabels gets Kubernetes services whose selectors match the given labels
 func (c *Client) getServicesByLabels(matchLabels map[string]string, namespace string) ([]corev1.Service, error) {
 	var serviceList []corev1.Service
@@ -92,6 +92,98 @@ var _ = Describe("Test Kube Client Provider", func() {
 		})
 	})
 
+	It("should return the locality of the nodes of the endpoints", func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					corev1.LabelZoneRegionStable:        "us-east-1",
+					corev1.LabelZoneFailureDomainStable: "us-east-1a",
+				},
+			},
+		}
+		_, err := fakeClientSet.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		nodeName := node.Name
+		endp := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tests.BookstoreService.Name,
+				Namespace: tests.BookstoreService.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{IP: "8.8.8.8", NodeName: &nodeName},
+						{IP: "9.9.9.9"},
+					},
+					Ports: []v1.EndpointPort{{Name: "port", Port: 88, Protocol: v1.ProtocolTCP}},
+				},
+			},
+		}
+		_, err = fakeClientSet.CoreV1().Endpoints(tests.BookstoreService.Namespace).Create(context.TODO(), endp, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		<-cli.GetAnnouncementsChannel()
+		Eventually(func() []endpoint.Endpoint {
+			return cli.ListEndpointsForService(tests.BookstoreService)
+		}, 2*time.Second).Should(Equal([]endpoint.Endpoint{
+			{
+				IP:       net.IPv4(8, 8, 8, 8),
+				Port:     88,
+				Locality: endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+			},
+			{
+				IP:   net.IPv4(9, 9, 9, 9),
+				Port: 88,
+			},
+		}))
+	})
+
+	It("should return the not ready and terminating endpoints with their health status", func() {
+		svc := service.MeshService{Namespace: tests.Namespace, Name: "bookstore-health"}
+		deletionTimestamp := metav1.Now()
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "bookstore-terminating",
+				Namespace:         svc.Namespace,
+				DeletionTimestamp: &deletionTimestamp,
+			},
+		}
+		_, err := fakeClientSet.CoreV1().Pods(svc.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		endp := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{IP: "8.8.8.8"},
+						{IP: "9.9.9.9", TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}},
+					},
+					NotReadyAddresses: []v1.EndpointAddress{
+						{IP: "10.10.10.10"},
+					},
+					Ports: []v1.EndpointPort{{Name: "port", Port: 88, Protocol: v1.ProtocolTCP}},
+				},
+			},
+		}
+		_, err = fakeClientSet.CoreV1().Endpoints(svc.Namespace).Create(context.TODO(), endp, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		<-cli.GetAnnouncementsChannel()
+		Eventually(func() []endpoint.Endpoint {
+			return cli.ListEndpointsForService(svc)
+		}, 2*time.Second).Should(Equal([]endpoint.Endpoint{
+			{IP: net.IPv4(8, 8, 8, 8), Port: 88, Health: endpoint.Healthy},
+			{IP: net.IPv4(9, 9, 9, 9), Port: 88, Health: endpoint.Draining},
+			{IP: net.IPv4(10, 10, 10, 10), Port: 88, Health: endpoint.Unhealthy},
+		}))
+	})
+
 	It("tests GetAnnouncementChannel", func() {
 		ch := cli.GetAnnouncementsChannel()
 
@@ -0,0 +1,244 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var (
+	// endpointSliceResources are the EndpointSlice resources the provider can watch, from the most to the least preferred API version
+	endpointSliceResources = []schema.GroupVersionResource{
+		{Group: discoveryv1beta1.GroupName, Version: "v1", Resource: "endpointslices"},
+		{Group: discoveryv1beta1.GroupName, Version: "v1beta1", Resource: "endpointslices"},
+	}
+)
+
+// ServesEndpointSlices returns whether the cluster serves EndpointSlice resources in an API version the provider can watch.
+func ServesEndpointSlices(kubeClient kubernetes.Interface) bool {
+	_, found := k8s.GetServedResource(kubeClient.Discovery(), endpointSliceResources)
+	return found
+}
+
+// NewEndpointSliceProvider implements mesh.EndpointsProvider, which creates a new Kubernetes cluster/compute provider.
+// The endpoints of the services are discovered from their EndpointSlices, in the most recent API version served by the cluster.
+// The endpoints of a service are split across several slices, so that a change of an endpoint only updates its own slice,
+// while the Endpoints of a service list all its endpoints and are updated in full on every change.
+func NewEndpointSliceProvider(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, namespaceController namespace.Controller, stop chan struct{}, providerIdent string, cfg configurator.Configurator) (endpoint.Provider, error) {
+	endpointSliceResource, found := k8s.GetServedResource(kubeClient.Discovery(), endpointSliceResources)
+	if !found {
+		return nil, errEndpointSlicesNotServed
+	}
+	log.Info().Msgf("[%s] Watching %s EndpointSlice resources", providerIdent, endpointSliceResource.GroupVersion())
+
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
+	client := newClient(kubeClient, informerFactory, namespaceController, providerIdent)
+
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval)
+	client.informers.EndpointSlices = dynamicInformerFactory.ForResource(endpointSliceResource).Informer()
+	if err := client.informers.EndpointSlices.AddIndexers(cache.Indexers{serviceIndex: indexEndpointSliceByService}); err != nil {
+		return nil, errors.Errorf("Failed to index the endpoint slices by service: %+v", err)
+	}
+	client.caches.EndpointSlices = client.informers.EndpointSlices.GetIndexer()
+	client.informers.EndpointSlices.AddEventHandler(k8s.GetKubernetesEventHandlers("EndpointSlices", "Kubernetes", client.announcements, client.shouldObserve, k8s.EventTypes{
+		Add:    announcements.EndpointSliceAdded,
+		Update: announcements.EndpointSliceUpdated,
+		Delete: announcements.EndpointSliceDeleted,
+	}))
+
+	if err := client.run(stop); err != nil {
+		return nil, errors.Errorf("Failed to start Kubernetes EndpointSlice provider client: %+v", err)
+	}
+
+	return client, nil
+}
+
+// listEndpointsFromEndpointSlices returns the endpoints of the given service from its endpoint slices,
+// which carry the conditions, the topology and the topology aware hints of each endpoint.
+// The IPv4 and IPv6 addresses of the endpoints of a dual-stack service are listed by different slices.
+func (c Client) listEndpointsFromEndpointSlices(svc service.MeshService) []endpoint.Endpoint {
+	var endpoints []endpoint.Endpoint = []endpoint.Endpoint{}
+	endpointSliceInterfaces, err := c.caches.EndpointSlices.ByIndex(serviceIndex, svc.String())
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes EndpointSlices from cache", c.providerIdent)
+		return endpoints
+	}
+
+	var endpointSlices []*endpointSlice
+	for _, endpointSliceInterface := range endpointSliceInterfaces {
+		endpointSlice, err := toEndpointSlice(endpointSliceInterface)
+		if err != nil {
+			log.Error().Err(err).Msgf("[%s] Error converting Kubernetes EndpointSlice of service %s", c.providerIdent, svc)
+			continue
+		}
+		endpointSlices = append(endpointSlices, endpointSlice)
+	}
+	// The order is stable, so the endpoints returned do not change while the slices do not change
+	sort.Slice(endpointSlices, func(i, j int) bool {
+		return endpointSlices[i].Name < endpointSlices[j].Name
+	})
+
+	// An endpoint may be listed by two slices of the service for a while, when it moves from a slice to another
+	observedEndpoints := mapset.NewSet()
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.AddressType != string(discoveryv1beta1.AddressTypeIPv4) && endpointSlice.AddressType != string(discoveryv1beta1.AddressTypeIPv6) {
+			// The FQDN endpoints are not supported
+			continue
+		}
+		for _, sliceEndpoint := range endpointSlice.Endpoints {
+			health := c.getEndpointSliceHealthStatus(sliceEndpoint)
+			zoneHints := sliceEndpoint.getZoneHints()
+			for _, address := range sliceEndpoint.Addresses {
+				ip := net.ParseIP(address)
+				if ip == nil {
+					log.Error().Msgf("Error parsing IP address %s", address)
+					continue
+				}
+				locality := c.getEndpointSliceLocality(sliceEndpoint, address)
+				for _, port := range endpointSlice.Ports {
+					if port.Port == nil {
+						continue
+					}
+					hostPort := net.JoinHostPort(ip.String(), strconv.Itoa(int(*port.Port)))
+					if observedEndpoints.Contains(hostPort) {
+						continue
+					}
+					observedEndpoints.Add(hostPort)
+					endpoints = append(endpoints, endpoint.Endpoint{
+						IP:        ip,
+						Port:      endpoint.Port(*port.Port),
+						Locality:  locality,
+						Health:    health,
+						ZoneHints: zoneHints,
+					})
+				}
+			}
+		}
+	}
+	return endpoints
+}
+
+// getEndpointSliceHealthStatus returns the health status of an endpoint slice endpoint from its conditions.
+// The terminating endpoints still serving requests are draining, the other endpoints are healthy when ready.
+// The clusters before Kubernetes 1.20 do not report whether the endpoints are terminating: the endpoints
+// of the pods being deleted are draining then.
+func (c Client) getEndpointSliceHealthStatus(sliceEndpoint endpointSliceEndpoint) endpoint.HealthStatus {
+	conditions := sliceEndpoint.Conditions
+	ready := conditions.Ready == nil || *conditions.Ready
+	if conditions.Terminating == nil {
+		return c.getHealthStatus(sliceEndpoint.TargetRef, ready)
+	}
+
+	serving := ready
+	if conditions.Serving != nil {
+		serving = *conditions.Serving
+	}
+	switch {
+	case *conditions.Terminating && serving:
+		return endpoint.Draining
+	case *conditions.Terminating, !ready:
+		return endpoint.Unhealthy
+	default:
+		return endpoint.Healthy
+	}
+}
+
+// getEndpointSliceLocality returns the locality of an endpoint slice endpoint, from its zone and topology,
+// completed with the locality of its node: the v1 endpoints only report their zone.
+func (c Client) getEndpointSliceLocality(sliceEndpoint endpointSliceEndpoint, address string) endpoint.Locality {
+	locality := k8s.GetTopologyLocality(sliceEndpoint.getTopology())
+	if sliceEndpoint.Zone != nil && *sliceEndpoint.Zone != "" {
+		locality.Zone = *sliceEndpoint.Zone
+	}
+	if locality.Region != "" && locality.Zone != "" {
+		return locality
+	}
+
+	nodeLocality := c.getEndpointLocality(sliceEndpoint.getNodeName(), address)
+	if locality.Region == "" {
+		locality.Region = nodeLocality.Region
+	}
+	if locality.Zone == "" {
+		locality.Zone = nodeLocality.Zone
+	}
+	return locality
+}
+
+// getTopology returns the topology of the endpoint, in the v1 or v1beta1 API
+func (e endpointSliceEndpoint) getTopology() map[string]string {
+	if e.Topology != nil {
+		return e.Topology
+	}
+	return e.DeprecatedTopology
+}
+
+// getNodeName returns the name of the node of the endpoint, from its topology before Kubernetes 1.20, nil when unknown
+func (e endpointSliceEndpoint) getNodeName() *string {
+	if e.NodeName != nil {
+		return e.NodeName
+	}
+	if nodeName, ok := e.getTopology()[corev1.LabelHostname]; ok {
+		return &nodeName
+	}
+	return nil
+}
+
+// getZoneHints returns the zones of the topology aware hints of the endpoint, nil when it has no hints
+func (e endpointSliceEndpoint) getZoneHints() []string {
+	if e.Hints == nil {
+		return nil
+	}
+	var zones []string
+	for _, zone := range e.Hints.ForZones {
+		zones = append(zones, zone.Name)
+	}
+	return zones
+}
+
+// toEndpointSlice converts the given object of the endpoint slices cache to an endpoint slice
+func toEndpointSlice(obj interface{}) (*endpointSlice, error) {
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("unexpected type %T", obj)
+	}
+	endpointSlice := &endpointSlice{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), endpointSlice); err != nil {
+		return nil, err
+	}
+	return endpointSlice, nil
+}
+
+// indexEndpointSliceByService indexes the endpoint slices by the <namespace>/<name> of the service they belong to
+func indexEndpointSliceByService(obj interface{}) ([]string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, nil
+	}
+	serviceName, ok := accessor.GetLabels()[discoveryv1beta1.LabelServiceName]
+	if !ok {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("%s/%s", accessor.GetNamespace(), serviceName)}, nil
+}
@@ -0,0 +1,286 @@
+package kube
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func newEndpointSlice(apiVersion, name, serviceName, addressType string, endpoints []interface{}, ports []interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "EndpointSlice",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": tests.Namespace,
+			"labels": map[string]interface{}{
+				discoveryv1beta1.LabelServiceName: serviceName,
+			},
+		},
+		"addressType": addressType,
+		"endpoints":   endpoints,
+		"ports":       ports,
+	}}
+}
+
+func newTestEndpointSliceProvider(kubeClient *fake.Clientset, apiVersion string, objects ...runtime.Object) (endpoint.Provider, error) {
+	return newTestEndpointSliceProviderWithClients(kubeClient, fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...), apiVersion)
+}
+
+func newTestEndpointSliceProviderWithClients(kubeClient *fake.Clientset, dynamicClient dynamic.Interface, apiVersion string) (endpoint.Provider, error) {
+	mockCtrl := gomock.NewController(GinkgoT())
+	mockNsController := namespace.NewMockController(mockCtrl)
+	mockNsController.EXPECT().IsMonitoredNamespace(tests.Namespace).Return(true).AnyTimes()
+
+	if apiVersion != "" {
+		kubeClient.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: apiVersion,
+				APIResources: []metav1.APIResource{{Name: "endpointslices", Namespaced: true, Kind: "EndpointSlice"}},
+			},
+		}
+	}
+	return NewEndpointSliceProvider(kubeClient, dynamicClient, mockNsController, make(chan struct{}), "provider", configurator.NewFakeConfigurator())
+}
+
+var _ = Describe("Test EndpointSlice Provider", func() {
+	httpPort := []interface{}{
+		map[string]interface{}{"name": "http", "port": int64(8080), "protocol": "TCP"},
+	}
+
+	It("fails when the cluster does not serve EndpointSlice resources", func() {
+		kubeClient := fake.NewSimpleClientset()
+		Expect(ServesEndpointSlices(kubeClient)).To(BeFalse())
+		_, err := newTestEndpointSliceProvider(kubeClient, "")
+		Expect(err).To(Equal(errEndpointSlicesNotServed))
+	})
+
+	It("detects the EndpointSlice resources served by the cluster", func() {
+		kubeClient := fake.NewSimpleClientset()
+		kubeClient.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "discovery.k8s.io/v1beta1",
+				APIResources: []metav1.APIResource{{Name: "endpointslices", Namespaced: true, Kind: "EndpointSlice"}},
+			},
+		}
+		Expect(ServesEndpointSlices(kubeClient)).To(BeTrue())
+	})
+
+	It("returns the endpoints of the slices of a service with their health status from their conditions", func() {
+		provider, err := newTestEndpointSliceProvider(fake.NewSimpleClientset(), "discovery.k8s.io/v1",
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-abcde", tests.BookstoreServiceName, "IPv4", []interface{}{
+				map[string]interface{}{
+					"addresses":  []interface{}{"10.0.0.1"},
+					"conditions": map[string]interface{}{"ready": true, "serving": true, "terminating": false},
+				},
+				map[string]interface{}{
+					"addresses":  []interface{}{"10.0.0.2"},
+					"conditions": map[string]interface{}{"ready": false, "serving": false, "terminating": false},
+				},
+				map[string]interface{}{
+					"addresses":  []interface{}{"10.0.0.3"},
+					"conditions": map[string]interface{}{"ready": false, "serving": true, "terminating": true},
+				},
+				map[string]interface{}{
+					"addresses":  []interface{}{"10.0.0.4"},
+					"conditions": map[string]interface{}{"ready": false, "serving": false, "terminating": true},
+				},
+			}, httpPort),
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-fghij", tests.BookstoreServiceName, "IPv4", []interface{}{
+				map[string]interface{}{
+					"addresses": []interface{}{"10.0.0.5"},
+				},
+				// An endpoint moving from a slice to another is returned once
+				map[string]interface{}{
+					"addresses": []interface{}{"10.0.0.1"},
+				},
+			}, httpPort),
+			newEndpointSlice("discovery.k8s.io/v1", "bookbuyer-abcde", tests.BookbuyerServiceName, "IPv4", []interface{}{
+				map[string]interface{}{
+					"addresses": []interface{}{"10.0.1.1"},
+				},
+			}, httpPort),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(provider.ListEndpointsForService(tests.BookstoreService)).To(Equal([]endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080, Health: endpoint.Healthy},
+			{IP: net.ParseIP("10.0.0.2"), Port: 8080, Health: endpoint.Unhealthy},
+			{IP: net.ParseIP("10.0.0.3"), Port: 8080, Health: endpoint.Draining},
+			{IP: net.ParseIP("10.0.0.4"), Port: 8080, Health: endpoint.Unhealthy},
+			{IP: net.ParseIP("10.0.0.5"), Port: 8080, Health: endpoint.Healthy},
+		}))
+	})
+
+	It("returns the IPv4 and IPv6 endpoints of a dual-stack service and ignores the FQDN endpoints", func() {
+		provider, err := newTestEndpointSliceProvider(fake.NewSimpleClientset(), "discovery.k8s.io/v1",
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-ipv4", tests.BookstoreServiceName, "IPv4", []interface{}{
+				map[string]interface{}{"addresses": []interface{}{"10.0.0.1"}},
+			}, httpPort),
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-ipv6", tests.BookstoreServiceName, "IPv6", []interface{}{
+				map[string]interface{}{"addresses": []interface{}{"fd00::1"}},
+			}, httpPort),
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-fqdn", tests.BookstoreServiceName, "FQDN", []interface{}{
+				map[string]interface{}{"addresses": []interface{}{"bookstore.example.com"}},
+			}, httpPort),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(provider.ListEndpointsForService(tests.BookstoreService)).To(Equal([]endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080},
+			{IP: net.ParseIP("fd00::1"), Port: 8080},
+		}))
+	})
+
+	It("returns the locality and the topology aware hints of the v1 endpoints", func() {
+		kubeClient := fake.NewSimpleClientset(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					corev1.LabelZoneRegionStable:        "us-east-1",
+					corev1.LabelZoneFailureDomainStable: "us-east-1a",
+				},
+			},
+		})
+		provider, err := newTestEndpointSliceProvider(kubeClient, "discovery.k8s.io/v1",
+			newEndpointSlice("discovery.k8s.io/v1", "bookstore-abcde", tests.BookstoreServiceName, "IPv4", []interface{}{
+				map[string]interface{}{
+					"addresses": []interface{}{"10.0.0.1"},
+					"nodeName":  "node-1",
+					"zone":      "us-east-1a",
+					"hints": map[string]interface{}{
+						"forZones": []interface{}{map[string]interface{}{"name": "us-east-1b"}},
+					},
+				},
+			}, httpPort),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(provider.ListEndpointsForService(tests.BookstoreService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:        net.ParseIP("10.0.0.1"),
+				Port:      8080,
+				Locality:  endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+				ZoneHints: []string{"us-east-1b"},
+			},
+		}))
+	})
+
+	It("returns the locality of the v1beta1 endpoints and the draining endpoints of the terminating pods", func() {
+		deletionTimestamp := metav1.Now()
+		kubeClient := fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "bookstore-terminating",
+				Namespace:         tests.Namespace,
+				DeletionTimestamp: &deletionTimestamp,
+			},
+		})
+		provider, err := newTestEndpointSliceProvider(kubeClient, "discovery.k8s.io/v1beta1",
+			newEndpointSlice("discovery.k8s.io/v1beta1", "bookstore-abcde", tests.BookstoreServiceName, "IPv4", []interface{}{
+				map[string]interface{}{
+					"addresses":  []interface{}{"10.0.0.1"},
+					"conditions": map[string]interface{}{"ready": true},
+					"topology": map[string]interface{}{
+						corev1.LabelZoneRegionStable:        "us-east-1",
+						corev1.LabelZoneFailureDomainStable: "us-east-1a",
+					},
+					"targetRef": map[string]interface{}{"kind": "Pod", "namespace": tests.Namespace, "name": "bookstore-terminating"},
+				},
+			}, httpPort),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(func() []endpoint.Endpoint {
+			return provider.ListEndpointsForService(tests.BookstoreService)
+		}, 2*time.Second).Should(Equal([]endpoint.Endpoint{
+			{
+				IP:       net.ParseIP("10.0.0.1"),
+				Port:     8080,
+				Locality: endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+				Health:   endpoint.Draining,
+			},
+		}))
+	})
+
+	It("should return the endpoints of the endpoint slices of a service with their health status and locality", func() {
+		kubeClient := fake.NewSimpleClientset()
+		dynamicClient := fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme())
+		provider, err := newTestEndpointSliceProviderWithClients(kubeClient, dynamicClient, "discovery.k8s.io/v1beta1")
+		Expect(err).ToNot(HaveOccurred())
+
+		endpointSlice := newEndpointSlice("discovery.k8s.io/v1beta1", tests.BookstoreService.Name+"-abcde", tests.BookstoreService.Name, "IPv4", []interface{}{
+			map[string]interface{}{
+				"addresses": []interface{}{"8.8.8.8"},
+				"topology": map[string]interface{}{
+					corev1.LabelZoneRegionStable:        "us-east-1",
+					corev1.LabelZoneFailureDomainStable: "us-east-1a",
+				},
+			},
+			map[string]interface{}{
+				"addresses":  []interface{}{"9.9.9.9"},
+				"conditions": map[string]interface{}{"ready": false},
+			},
+		}, []interface{}{
+			map[string]interface{}{"port": int64(88)},
+		})
+		_, err = dynamicClient.Resource(endpointSliceResources[1]).Namespace(tests.Namespace).Create(context.TODO(), endpointSlice, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		<-provider.GetAnnouncementsChannel()
+		Eventually(func() []endpoint.Endpoint {
+			return provider.ListEndpointsForService(tests.BookstoreService)
+		}, 2*time.Second).Should(Equal([]endpoint.Endpoint{
+			{
+				IP:       net.ParseIP("8.8.8.8"),
+				Port:     88,
+				Locality: endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+				Health:   endpoint.Healthy,
+			},
+			{
+				IP:     net.ParseIP("9.9.9.9"),
+				Port:   88,
+				Health: endpoint.Unhealthy,
+			},
+		}))
+		Expect(provider.ListEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{}))
+	})
+
+	It("announces the changes of the endpoint slices", func() {
+		kubeClient := fake.NewSimpleClientset()
+		dynamicClient := fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme())
+		provider, err := newTestEndpointSliceProviderWithClients(kubeClient, dynamicClient, "discovery.k8s.io/v1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(provider.ListEndpointsForService(tests.BookstoreService)).To(Equal([]endpoint.Endpoint{}))
+
+		endpointSlice := newEndpointSlice("discovery.k8s.io/v1", "bookstore-abcde", tests.BookstoreServiceName, "IPv4", []interface{}{
+			map[string]interface{}{"addresses": []interface{}{"10.0.0.1"}},
+		}, httpPort)
+		_, err = dynamicClient.Resource(endpointSliceResources[0]).Namespace(tests.Namespace).Create(context.TODO(), endpointSlice, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		announcement := <-provider.GetAnnouncementsChannel()
+		Expect(announcement.Type).To(Equal(announcements.EndpointSliceAdded))
+		Expect(provider.ListEndpointsForService(tests.BookstoreService)).To(Equal([]endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080},
+		}))
+	})
+})
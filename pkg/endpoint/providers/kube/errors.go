@@ -6,4 +6,5 @@ var (
 	errSyncingCaches                      = errors.New("failed initial sync of resources required for ingress")
 	errInitInformers                      = errors.New("informers are not initialized")
 	errDidNotFindServiceForServiceAccount = errors.New("no service exists for the service account")
+	errEndpointSlicesNotServed            = errors.New("the cluster does not serve EndpointSlice resources")
 )
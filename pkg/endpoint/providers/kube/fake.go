@@ -3,6 +3,7 @@ package kube
 import (
 	"fmt"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -50,6 +51,6 @@ func (f fakeClient) GetID() string {
 }
 
 // GetAnnouncementsChannel obtains the channel on which providers will announce changes to the infrastructure.
-func (f fakeClient) GetAnnouncementsChannel() <-chan interface{} {
-	return make(chan interface{})
+func (f fakeClient) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return make(chan announcements.Announcement)
 }
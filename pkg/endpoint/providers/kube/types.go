@@ -1,9 +1,12 @@
 package kube
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 )
@@ -12,16 +15,27 @@ var (
 	log = logger.New("kube-provider")
 )
 
+const (
+	// serviceIndex is the name of the index of the endpoint slices by the <namespace>/<name> of their service
+	serviceIndex = "service"
+)
+
 // InformerCollection is a struct of the Kubernetes informers used in OSM
 type InformerCollection struct {
-	Endpoints   cache.SharedIndexInformer
-	Deployments cache.SharedIndexInformer
+	Endpoints      cache.SharedIndexInformer
+	EndpointSlices cache.SharedIndexInformer
+	Deployments    cache.SharedIndexInformer
+	Nodes          cache.SharedIndexInformer
+	Pods           cache.SharedIndexInformer
 }
 
 // CacheCollection is a struct of the Kubernetes caches used in OSM
 type CacheCollection struct {
-	Endpoints   cache.Store
-	Deployments cache.Store
+	Endpoints      cache.Store
+	EndpointSlices cache.Indexer
+	Deployments    cache.Store
+	Nodes          cache.Store
+	Pods           cache.Store
 }
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
@@ -31,6 +45,82 @@ type Client struct {
 	providerIdent       string
 	kubeClient          kubernetes.Interface
 	informers           *InformerCollection
-	announcements       chan interface{}
+	announcements       chan announcements.Announcement
 	namespaceController namespace.Controller
 }
+
+// endpointSlice is an EndpointSlice of the discovery.k8s.io API, in its v1 or v1beta1 version.
+// The endpoint slices are converted from the unstructured objects of a dynamic informer, as the EndpointSlice types
+// of the Kubernetes API library lack the conditions, zone and hints of the endpoints of the recent Kubernetes versions.
+type endpointSlice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// AddressType is the type of the addresses of the endpoints of the slice: IPv4, IPv6 or FQDN
+	AddressType string `json:"addressType"`
+
+	// Endpoints is the list of the endpoints of the slice
+	Endpoints []endpointSliceEndpoint `json:"endpoints"`
+
+	// Ports is the list of the ports exposed by each endpoint of the slice
+	Ports []endpointSlicePort `json:"ports,omitempty"`
+}
+
+// endpointSliceEndpoint is an endpoint of an endpoint slice
+type endpointSliceEndpoint struct {
+	// Addresses is the list of the addresses of the endpoint, of the address type of the slice
+	Addresses []string `json:"addresses"`
+
+	// Conditions is the set of the conditions of the endpoint
+	Conditions endpointConditions `json:"conditions,omitempty"`
+
+	// TargetRef is the reference to the object backing the endpoint, a pod
+	TargetRef *corev1.ObjectReference `json:"targetRef,omitempty"`
+
+	// Topology is the topology of the endpoint in the v1beta1 API, given by the labels of its node
+	Topology map[string]string `json:"topology,omitempty"`
+
+	// DeprecatedTopology is the topology of the endpoint in the v1 API, given by the labels of its node
+	DeprecatedTopology map[string]string `json:"deprecatedTopology,omitempty"`
+
+	// NodeName is the name of the node of the endpoint, from Kubernetes 1.20
+	NodeName *string `json:"nodeName,omitempty"`
+
+	// Zone is the zone of the endpoint in the v1 API
+	Zone *string `json:"zone,omitempty"`
+
+	// Hints are the topology aware hints of the endpoint, from Kubernetes 1.21
+	Hints *endpointHints `json:"hints,omitempty"`
+}
+
+// endpointConditions is the set of the conditions of an endpoint
+type endpointConditions struct {
+	// Ready is true when the endpoint is ready to receive traffic, which is the case when not set
+	Ready *bool `json:"ready,omitempty"`
+
+	// Serving is true when the endpoint passes its readiness probe, including while it is terminating, from Kubernetes 1.20
+	Serving *bool `json:"serving,omitempty"`
+
+	// Terminating is true when the endpoint is terminating, from Kubernetes 1.20
+	Terminating *bool `json:"terminating,omitempty"`
+}
+
+// endpointHints are the topology aware hints of an endpoint
+type endpointHints struct {
+	// ForZones is the list of the zones of the clients the endpoint should serve
+	ForZones []endpointZoneHint `json:"forZones,omitempty"`
+}
+
+// endpointZoneHint is a zone of the clients an endpoint should serve
+type endpointZoneHint struct {
+	Name string `json:"name"`
+}
+
+// endpointSlicePort is a port exposed by the endpoints of an endpoint slice
+type endpointSlicePort struct {
+	// Name is the name of the port, matching the name of the port of the service
+	Name *string `json:"name,omitempty"`
+
+	// Port is the port number, all the ports when not set
+	Port *int32 `json:"port,omitempty"`
+}
@@ -0,0 +1,193 @@
+package remote
+
+import (
+	"encoding/json"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewProvider implements endpoint.Provider, which discovers the services exported by the remote cluster the given client connects to,
+// from the export ConfigMap in the given namespace of the OSM controller of this cluster.
+// The remote cluster may be unreachable: its services are discovered once the export is received, without delaying the start of the local controller.
+func NewProvider(remoteKubeClient kubernetes.Interface, remoteOSMNamespace string, stop <-chan struct{}, providerIdent string, cfg configurator.Configurator) (endpoint.Provider, error) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(remoteKubeClient, k8s.DefaultKubeEventResyncInterval,
+		informers.WithNamespace(remoteOSMNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", constants.MulticlusterExportConfigMapName).String()
+		}))
+
+	client := Client{
+		providerIdent: providerIdent,
+		informer:      informerFactory.Core().V1().ConfigMaps().Informer(),
+		announcements: make(chan announcements.Announcement),
+		cfg:           cfg,
+	}
+
+	client.informer.AddEventHandler(k8s.GetKubernetesEventHandlers("ConfigMaps", providerIdent, client.announcements, nil, k8s.EventTypes{
+		Add:    announcements.MulticlusterExportAdded,
+		Update: announcements.MulticlusterExportUpdated,
+		Delete: announcements.MulticlusterExportDeleted,
+	}))
+
+	go client.informer.Run(stop)
+	go func() {
+		if cache.WaitForCacheSync(stop, client.informer.HasSynced) {
+			log.Info().Msgf("[%s] Cache sync finished for the export of the remote cluster", providerIdent)
+		}
+	}()
+
+	return &client, nil
+}
+
+// GetID returns a string descriptor / identifier of the compute provider.
+// Required by interface: EndpointsProvider
+func (c *Client) GetID() string {
+	return c.providerIdent
+}
+
+// ListEndpointsForService returns the addresses of the multicluster gateway of the remote cluster for the services it exports,
+// as the gateway proxies the traffic to all the ports of the exported services.
+func (c *Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	export := c.getExport()
+	if export == nil || svc.Cluster != export.ClusterID {
+		return nil
+	}
+	if _, found := getExportedService(export, svc); !found {
+		return nil
+	}
+	var endpoints []endpoint.Endpoint
+	for _, address := range export.Gateway {
+		ip := net.ParseIP(address.IP)
+		if ip == nil {
+			log.Error().Msgf("[%s] Invalid IP address %q of the multicluster gateway of cluster %s", c.providerIdent, address.IP, export.ClusterID)
+			continue
+		}
+		endpoints = append(endpoints, endpoint.Endpoint{IP: ip, Port: endpoint.Port(address.Port)})
+	}
+	log.Trace().Msgf("[%s] Endpoints of service %s: %+v", c.providerIdent, svc, endpoints)
+	return endpoints
+}
+
+// GetServicesForServiceAccount returns the services exported by the remote cluster backed by pods running as the given service account.
+// The service accounts of the remote cluster are the service accounts with the same namespace and name in the local cluster.
+func (c *Client) GetServicesForServiceAccount(svcAccount service.K8sServiceAccount) ([]service.MeshService, error) {
+	export := c.getExport()
+	if export == nil {
+		return nil, nil
+	}
+
+	var services []service.MeshService
+	for _, exportedService := range export.Services {
+		if exportedService.Namespace != svcAccount.Namespace {
+			continue
+		}
+		for _, serviceAccount := range exportedService.ServiceAccounts {
+			if serviceAccount == svcAccount.Name {
+				services = append(services, service.MeshService{
+					Namespace: exportedService.Namespace,
+					Name:      exportedService.Name,
+					Cluster:   export.ClusterID,
+				})
+				break
+			}
+		}
+	}
+	return services, nil
+}
+
+// ListServicePorts returns the ports of the given service, false if the remote cluster does not export the service.
+// Required by interface: endpoint.ServicePortsProvider
+func (c *Client) ListServicePorts(svc service.MeshService) ([]service.ServicePort, bool) {
+	export := c.getExport()
+	if export == nil || svc.Cluster != export.ClusterID {
+		return nil, false
+	}
+	exportedService, found := getExportedService(export, svc)
+	if !found {
+		return nil, false
+	}
+
+	var ports []service.ServicePort
+	for _, port := range exportedService.Ports {
+		ports = append(ports, service.ServicePort{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+			Protocol:   port.Protocol,
+		})
+	}
+	return ports, true
+}
+
+// GetAnnouncementsChannel returns the announcement channel for the remote cluster provider.
+func (c *Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return c.announcements
+}
+
+// getExport returns the current export of the remote cluster, nil if none was received or it is invalid.
+// The export is only parsed again when its ConfigMap changes.
+func (c *Client) getExport() *multicluster.Export {
+	configMaps := c.informer.GetStore().List()
+	if len(configMaps) == 0 {
+		return nil
+	}
+	configMap, ok := configMaps[0].(*corev1.ConfigMap)
+	if !ok {
+		log.Error().Msgf("[%s] Failed type assertion for ConfigMap in cache", c.providerIdent)
+		return nil
+	}
+
+	c.exportLock.Lock()
+	defer c.exportLock.Unlock()
+
+	if configMap.ResourceVersion != "" && configMap.ResourceVersion == c.exportVersion {
+		return c.export
+	}
+	c.exportVersion = configMap.ResourceVersion
+
+	export, err := c.parseExport(configMap)
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Invalid export in ConfigMap %s/%s of the remote cluster", c.providerIdent, configMap.Namespace, configMap.Name)
+	}
+	c.export = export
+	return c.export
+}
+
+// parseExport returns the export held by the given ConfigMap
+func (c *Client) parseExport(configMap *corev1.ConfigMap) (*multicluster.Export, error) {
+	export := &multicluster.Export{}
+	if err := json.Unmarshal([]byte(configMap.Data[multicluster.ExportConfigMapKey]), export); err != nil {
+		return nil, err
+	}
+	if export.ClusterID == "" {
+		return nil, errNoClusterID
+	}
+	if export.ClusterID == c.cfg.GetClusterID() {
+		return nil, errLocalExport
+	}
+	return export, nil
+}
+
+// getExportedService returns the exported service with the namespace and name of the given service
+func getExportedService(export *multicluster.Export, svc service.MeshService) (multicluster.ExportedService, bool) {
+	for _, exportedService := range export.Services {
+		if exportedService.Namespace == svc.Namespace && exportedService.Name == svc.Name {
+			return exportedService, true
+		}
+	}
+	return multicluster.ExportedService{}, false
+}
@@ -0,0 +1,133 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var _ = Describe("Test remote cluster endpoints provider", func() {
+	const (
+		remoteOSMNamespace = "osm-system"
+		providerID         = "RemoteCluster-cluster-2"
+	)
+
+	gateway := []multicluster.GatewayAddress{{IP: "1.2.3.4", Port: 15443}}
+	gatewayEndpoints := []endpoint.Endpoint{{IP: net.ParseIP("1.2.3.4"), Port: 15443}}
+	bookstore := service.MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-2"}
+
+	newExportConfigMap := func(export multicluster.Export) *corev1.ConfigMap {
+		marshalledExport, err := json.Marshal(export)
+		Expect(err).ToNot(HaveOccurred())
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.MulticlusterExportConfigMapName,
+				Namespace: remoteOSMNamespace,
+			},
+			Data: map[string]string{multicluster.ExportConfigMapKey: string(marshalledExport)},
+		}
+	}
+
+	newProvider := func(configMap *corev1.ConfigMap) endpoint.Provider {
+		fakeClientSet := fake.NewSimpleClientset()
+		cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{ClusterID: "cluster-1"})
+		provider, err := NewProvider(fakeClientSet, remoteOSMNamespace, make(chan struct{}), providerID, cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(provider.GetID()).To(Equal(providerID))
+
+		_, err = fakeClientSet.CoreV1().ConfigMaps(remoteOSMNamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-provider.GetAnnouncementsChannel()
+		return provider
+	}
+
+	Context("when the remote cluster exports services", func() {
+		var provider endpoint.Provider
+
+		BeforeEach(func() {
+			provider = newProvider(newExportConfigMap(multicluster.Export{
+				ClusterID: "cluster-2",
+				Gateway:   gateway,
+				Services: []multicluster.ExportedService{
+					{
+						Namespace:       "default",
+						Name:            "bookstore",
+						Ports:           []multicluster.ExportedPort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}},
+						ServiceAccounts: []string{"bookstore"},
+					},
+				},
+			}))
+		})
+
+		It("returns the gateway endpoints for the exported services", func() {
+			Expect(provider.ListEndpointsForService(bookstore)).To(Equal(gatewayEndpoints))
+		})
+
+		It("returns no endpoints for the services not exported by the remote cluster", func() {
+			Expect(provider.ListEndpointsForService(bookstore.GetLocal())).To(BeNil())
+			Expect(provider.ListEndpointsForService(service.MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-3"})).To(BeNil())
+			Expect(provider.ListEndpointsForService(service.MeshService{Namespace: "default", Name: "bookbuyer", Cluster: "cluster-2"})).To(BeNil())
+		})
+
+		It("returns the exported services of a service account", func() {
+			services, err := provider.GetServicesForServiceAccount(service.K8sServiceAccount{Namespace: "default", Name: "bookstore"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(services).To(Equal([]service.MeshService{bookstore}))
+
+			services, err = provider.GetServicesForServiceAccount(service.K8sServiceAccount{Namespace: "default", Name: "bookbuyer"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(services).To(BeEmpty())
+		})
+
+		It("returns the ports of the exported services", func() {
+			portsProvider, ok := provider.(endpoint.ServicePortsProvider)
+			Expect(ok).To(BeTrue())
+
+			ports, found := portsProvider.ListServicePorts(bookstore)
+			Expect(found).To(BeTrue())
+			Expect(ports).To(Equal([]service.ServicePort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}}))
+
+			_, found = portsProvider.ListServicePorts(bookstore.GetLocal())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the export of the remote cluster is invalid", func() {
+		It("ignores an export without cluster ID", func() {
+			provider := newProvider(newExportConfigMap(multicluster.Export{Gateway: gateway}))
+			Expect(provider.ListEndpointsForService(service.MeshService{Namespace: "default", Name: "bookstore"})).To(BeNil())
+		})
+
+		It("ignores an export of the local cluster", func() {
+			provider := newProvider(newExportConfigMap(multicluster.Export{
+				ClusterID: "cluster-1",
+				Gateway:   gateway,
+				Services:  []multicluster.ExportedService{{Namespace: "default", Name: "bookstore"}},
+			}))
+			Expect(provider.ListEndpointsForService(service.MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-1"})).To(BeNil())
+		})
+
+		It("ignores an export that is not valid JSON", func() {
+			configMap := newExportConfigMap(multicluster.Export{})
+			configMap.Data[multicluster.ExportConfigMapKey] = "{"
+			provider := newProvider(configMap)
+			Eventually(func() []endpoint.Endpoint {
+				return provider.ListEndpointsForService(bookstore)
+			}, 2*time.Second).Should(BeNil())
+		})
+	})
+})
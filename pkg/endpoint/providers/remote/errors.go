@@ -0,0 +1,8 @@
+package remote
+
+import "github.com/pkg/errors"
+
+var (
+	errNoClusterID = errors.New("the export of the remote cluster has no cluster ID")
+	errLocalExport = errors.New("the export has the cluster ID of the local cluster")
+)
@@ -0,0 +1,30 @@
+package remote
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+)
+
+var (
+	log = logger.New("remote-provider")
+)
+
+// Client is the endpoints provider discovering the services exported by a remote cluster of the multicluster mesh,
+// from the export ConfigMap published by the controller of the remote cluster.
+type Client struct {
+	providerIdent string
+	informer      cache.SharedIndexInformer
+	announcements chan announcements.Announcement
+	cfg           configurator.Configurator
+
+	// export is the last export of the remote cluster parsed, along with the resource version of its ConfigMap
+	exportLock    sync.Mutex
+	export        *multicluster.Export
+	exportVersion string
+}
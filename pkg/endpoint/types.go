@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -19,18 +20,81 @@ type Provider interface {
 	GetID() string
 
 	// GetAnnouncementsChannel obtains the channel on which providers will announce changes to the infrastructure.
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
+}
+
+// ServicePortsProvider is implemented by the providers discovering services which are not Kubernetes services
+// of the local cluster, such as the services exported by remote clusters, to return the ports of these services.
+type ServicePortsProvider interface {
+	// ListServicePorts returns the ports of the given service, false if the provider does not know the service
+	ListServicePorts(service.MeshService) ([]service.ServicePort, bool)
 }
 
 // Endpoint is a tuple of IP and Port, representing an Envoy proxy, fronting an instance of a service
 type Endpoint struct {
 	net.IP `json:"ip"`
 	Port   `json:"port"`
+
+	// Locality is the topology domain of the node the endpoint runs on, empty when unknown
+	Locality Locality `json:"locality"`
+
+	// Health is the health status of the endpoint, healthy unless the provider knows otherwise
+	Health HealthStatus `json:"health"`
+
+	// ZoneHints are the zones of the clients the endpoint is meant to serve, given by the topology aware hints of Kubernetes.
+	// The endpoints without hints serve the clients of any zone.
+	ZoneHints []string `json:"zoneHints,omitempty"`
 }
 
 func (ep Endpoint) String() string {
 	return fmt.Sprintf("(ip=%s, port=%d)", ep.IP, ep.Port)
 }
 
+// IsHintedForZone returns true if the endpoint has topology aware hints and is meant to serve the clients of the given zone
+func (ep Endpoint) IsHintedForZone(zone string) bool {
+	for _, hint := range ep.ZoneHints {
+		if hint == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// Locality is the topology domain of an endpoint or an Envoy proxy, given by the region and zone of the node it runs on
+type Locality struct {
+	Region string `json:"region,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+// IsEmpty returns true if the locality is unknown
+func (l Locality) IsEmpty() bool {
+	return l.Region == "" && l.Zone == ""
+}
+
+// HealthStatus is the health status of an endpoint, which determines whether it receives new traffic
+type HealthStatus int
+
+const (
+	// Healthy is the status of the endpoints ready to receive traffic
+	Healthy HealthStatus = iota
+
+	// Unhealthy is the status of the endpoints not ready to receive traffic, such as the pods failing their readiness probe
+	Unhealthy
+
+	// Draining is the status of the endpoints shutting down, such as the terminating pods, which must not receive new traffic
+	Draining
+)
+
+func (h HealthStatus) String() string {
+	switch h {
+	case Unhealthy:
+		return "unhealthy"
+	case Draining:
+		return "draining"
+	default:
+		return "healthy"
+	}
+}
+
 // Port is a numerical port of an Envoy proxy
 type Port uint32
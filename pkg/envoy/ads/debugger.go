@@ -8,6 +8,21 @@ import (
 )
 
 // GetXDSLog implements XDSDebugger interface and a log of the XDS responses sent to Envoy proxies.
-func (s Server) GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time {
+func (s *Server) GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time {
 	return &s.xdsLog
 }
+
+// GetXDSNACKs implements XDSDebugger interface and returns the most recent xDS responses rejected by each Envoy proxy.
+func (s *Server) GetXDSNACKs() map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK {
+	s.xdsNACKsMutex.RLock()
+	defer s.xdsNACKsMutex.RUnlock()
+
+	nacks := make(map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK)
+	for cn, nacksByType := range s.xdsNACKs {
+		nacks[cn] = make(map[envoy.TypeURI][]envoy.NACK)
+		for typeURI, typeNACKs := range nacksByType {
+			nacks[cn][typeURI] = append([]envoy.NACK(nil), typeNACKs...)
+		}
+	}
+	return nacks
+}
@@ -0,0 +1,341 @@
+package ads
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+// DeltaAggregatedResources implements discovery.AggregatedDiscoveryServiceServer and handles incremental (delta) xDS streams.
+// Only the resources which changed, or were removed, since they were last sent are pushed to the connected Envoy proxy.
+func (s *Server) DeltaAggregatedResources(server xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
+	// When a new Envoy proxy connects, ValidateClient would ensure that it has a valid certificate,
+	// and the Subject CN is in the allowedCommonNames set.
+	cn, err := utils.ValidateClient(server.Context(), nil)
+	if err != nil {
+		return errors.Wrap(err, "Could not start delta stream")
+	}
+
+	if err := s.checkRevoked(cn); err != nil {
+		return err
+	}
+
+	ip := utils.GetIPFromContext(server.Context())
+
+	svcList, err := s.catalog.GetServicesFromEnvoyCertificate(cn)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error fetching service for Envoy %s with CN %s", ip, cn)
+		return err
+	}
+	// Github Issue #1575
+	namespacedService := svcList[0]
+
+	log.Info().Msgf("Client %s connected to delta xDS: Subject CN=%s; Service=%s", ip, cn, namespacedService)
+
+	// This is the Envoy proxy that just connected to the control plane.
+	proxy := envoy.NewProxy(cn, ip)
+	if err := s.resolveProxyPodProperties(proxy); err != nil {
+		return err
+	}
+	s.catalog.RegisterProxy(proxy)
+	defer s.catalog.UnregisterProxy(proxy)
+	defer s.forgetNACKs(proxy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	quit := make(chan struct{})
+	requests := make(chan *xds_discovery.DeltaDiscoveryRequest)
+
+	// This helper handles receiving messages from the connected Envoys
+	// and any gRPC error states.
+	go receiveDelta(requests, &server, proxy, quit)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-quit:
+			log.Info().Msg("Delta stream closed!")
+			return nil
+
+		case deltaRequest, ok := <-requests:
+			if !ok {
+				log.Error().Msgf("Proxy %s closed GRPC!", proxy.GetCommonName())
+				return errGrpcClosed
+			}
+
+			if err := s.checkRevoked(proxy.GetCommonName()); err != nil {
+				return err
+			}
+
+			typeURL, ok := envoy.ValidURI[deltaRequest.TypeUrl]
+			if !ok {
+				log.Error().Msgf("Unknown/Unsupported URI: %s", deltaRequest.TypeUrl)
+				continue
+			}
+
+			log.Debug().Msgf("Received delta %s (nonce=%s; subscribe=%v; unsubscribe=%v) from Envoy %s",
+				typeURL, deltaRequest.ResponseNonce, deltaRequest.ResourceNamesSubscribe, deltaRequest.ResourceNamesUnsubscribe, proxy.GetCommonName())
+
+			if deltaRequest.ErrorDetail != nil {
+				s.recordNACK(proxy, typeURL, deltaRequest.ResponseNonce, deltaRequest.ErrorDetail.Message)
+				continue
+			}
+
+			if deltaRequest.ResponseNonce != "" {
+				ackDeltaResponse(proxy, typeURL, deltaRequest.ResponseNonce)
+			}
+
+			if !handleDeltaSubscription(proxy, typeURL, deltaRequest) {
+				log.Debug().Msgf("Delta request %s (nonce=%s) from Envoy %s is an ACK", typeURL, deltaRequest.ResponseNonce, proxy.GetCommonName())
+				continue
+			}
+
+			if err := s.sendDeltaResponse(proxy, &server, typeURL, true); err != nil {
+				log.Error().Err(err).Msgf("Error sending delta %s response to proxy %s", typeURL, proxy.GetCommonName())
+			}
+
+		case <-proxy.GetAnnouncementsChannel():
+			if err := s.checkRevoked(proxy.GetCommonName()); err != nil {
+				return err
+			}
+			log.Info().Msgf("Change detected - update Envoy %s with changed resources.", proxy.GetCommonName())
+			s.sendAllDeltaResponses(proxy, &server)
+		}
+	}
+}
+
+// handleDeltaSubscription updates the proxy's subscriptions based on the delta request received
+// and returns true if the request requires a response to be sent to the proxy.
+func handleDeltaSubscription(proxy *envoy.Proxy, typeURL envoy.TypeURI, request *xds_discovery.DeltaDiscoveryRequest) bool {
+	isInitialRequest := !proxy.IsSubscribed(typeURL)
+
+	if isInitialRequest {
+		// On (re)connect, the proxy tells us which versions of the resources it already has.
+		for name, version := range request.InitialResourceVersions {
+			proxy.SetResourceVersion(typeURL, name, version)
+		}
+		proxy.Subscribe(typeURL, request.ResourceNamesSubscribe)
+		return true
+	}
+
+	if len(request.ResourceNamesSubscribe) == 0 && len(request.ResourceNamesUnsubscribe) == 0 {
+		// Neither the subscription changed, nor is this the first request: this is an ACK
+		return false
+	}
+
+	proxy.Subscribe(typeURL, request.ResourceNamesSubscribe)
+	proxy.Unsubscribe(typeURL, request.ResourceNamesUnsubscribe)
+	return true
+}
+
+// ackDeltaResponse records that the proxy applied the delta response of the given type sent with the given nonce:
+// the proxy now holds the resources the response added, changed and removed.
+func ackDeltaResponse(proxy *envoy.Proxy, typeURL envoy.TypeURI, nonce string) {
+	sent, ok := proxy.GetSentResponse(typeURL, nonce)
+	if !ok {
+		return
+	}
+	proxy.RemoveSentResponse(typeURL, nonce)
+
+	for name, version := range sent.ResourceVersions {
+		proxy.SetResourceVersion(typeURL, name, version)
+	}
+	for _, name := range sent.RemovedResources {
+		proxy.RemoveResourceVersion(typeURL, name)
+	}
+}
+
+// sendAllDeltaResponses sends the changed resources of every type the proxy subscribed to.
+func (s *Server) sendAllDeltaResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) {
+	log.Trace().Msgf("A change announcement triggered delta *DS update for proxy with CN=%s", proxy.GetCommonName())
+
+	// Order is important: CDS, EDS, LDS, RDS
+	// See: https://github.com/envoyproxy/go-control-plane/issues/59
+	for idx, typeURI := range envoy.XDSResponseOrder {
+		if !proxy.IsSubscribed(typeURI) {
+			continue
+		}
+
+		prefix := fmt.Sprintf("[Delta *DS %d/%d]", idx+1, len(envoy.XDSResponseOrder))
+		if err := s.sendDeltaResponse(proxy, server, typeURI, false); err != nil {
+			log.Error().Err(err).Msgf("%s Error sending %s to proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
+		}
+	}
+}
+
+// sendDeltaResponse computes the resources of the given type which were added, changed or removed
+// since they were last sent to the proxy and sends them in a DeltaDiscoveryResponse.
+// When force is false and nothing changed, no response is sent.
+func (s *Server) sendDeltaResponse(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, typeURI envoy.TypeURI, force bool) error {
+	response, err := s.newDeltaDiscoveryResponse(proxy, typeURI)
+	if err != nil {
+		return err
+	}
+
+	if !force && len(response.Resources) == 0 && len(response.RemovedResources) == 0 {
+		log.Trace().Msgf("No %s resources changed for proxy with CN=%s", typeURI, proxy.GetCommonName())
+		return nil
+	}
+
+	response.SystemVersionInfo = getDeltaResponseVersion(response)
+
+	// The proxy rejected these exact changes before; they are not sent again until the generated config changes
+	if !force && response.SystemVersionInfo == proxy.GetLastRejectedVersion(typeURI) {
+		log.Warn().Msgf("Proxy with CN=%s rejected delta %s version %s before; skipping", proxy.GetCommonName(), typeURI, response.SystemVersionInfo)
+		return nil
+	}
+
+	response.Nonce = proxy.SetNewNonce(typeURI)
+	if err := (*server).Send(response); err != nil {
+		return err
+	}
+
+	// The proxy only holds the changes once it ACKs them: until then, the next response is diffed against what it held before
+	sent := envoy.SentResponse{
+		Version:          response.SystemVersionInfo,
+		ResourceVersions: make(map[string]string),
+		RemovedResources: response.RemovedResources,
+	}
+	for _, resource := range response.Resources {
+		sent.ResourceVersions[resource.Name] = resource.Version
+	}
+	proxy.SetLastSentVersion(typeURI, sent.Version)
+	proxy.SetSentResponse(typeURI, response.Nonce, sent)
+
+	log.Trace().Msgf("Sent delta %s response to proxy with CN=%s: %d changed, %d removed", typeURI, proxy.GetCommonName(), len(response.Resources), len(response.RemovedResources))
+	return nil
+}
+
+// newDeltaDiscoveryResponse generates the resources of the given type for the proxy and diffs them
+// against the resource versions the proxy currently holds.
+func (s *Server) newDeltaDiscoveryResponse(proxy *envoy.Proxy, typeURI envoy.TypeURI) (*xds_discovery.DeltaDiscoveryResponse, error) {
+	handler, ok := s.xdsHandlers[typeURI]
+	if !ok {
+		log.Error().Msgf("Responder for TypeUrl %s is not implemented", typeURI)
+		return nil, errUnknownTypeURL
+	}
+
+	request := makeRequestForSubscribedResources(proxy, typeURI, s.catalog)
+	if request == nil {
+		return nil, errCreatingResponse
+	}
+
+	s.recordXDSResponse(proxy, typeURI)
+
+	sotwResponse, err := s.getDiscoveryResponse(handler, proxy, request, s.cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating %s response for proxy with CN=%s", typeURI, proxy.GetCommonName())
+		return nil, errCreatingResponse
+	}
+
+	current, err := getVersionedResources(sotwResponse.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &xds_discovery.DeltaDiscoveryResponse{
+		TypeUrl: string(typeURI),
+	}
+
+	wildcard := proxy.IsWildcardSubscription(typeURI)
+	subscribed := make(map[string]struct{})
+	for _, name := range proxy.GetSubscribedResources(typeURI) {
+		subscribed[name] = struct{}{}
+	}
+
+	var names []string
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	held := proxy.GetResourceVersions(typeURI)
+	for _, name := range names {
+		if _, isSubscribed := subscribed[name]; !wildcard && !isSubscribed {
+			continue
+		}
+		resource := current[name]
+		if version, ok := held[name]; ok && version == resource.Version {
+			// The proxy already has this exact resource
+			continue
+		}
+		response.Resources = append(response.Resources, resource)
+	}
+
+	for name := range held {
+		if _, exists := current[name]; !exists {
+			response.RemovedResources = append(response.RemovedResources, name)
+		}
+	}
+	sort.Strings(response.RemovedResources)
+
+	return response, nil
+}
+
+// getDeltaResponseVersion returns a version derived from the resources the given delta response adds, changes and removes.
+func getDeltaResponseVersion(response *xds_discovery.DeltaDiscoveryResponse) string {
+	var changes []string
+	for _, resource := range response.Resources {
+		changes = append(changes, fmt.Sprintf("%s=%s", resource.Name, resource.Version))
+	}
+	for _, name := range response.RemovedResources {
+		changes = append(changes, fmt.Sprintf("-%s", name))
+	}
+	sort.Strings(changes)
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(changes, ";"))))
+}
+
+// makeRequestForSubscribedResources constructs the request passed to the xDS handlers
+// AS IF the proxy sent a state-of-the-world request for the resources it subscribed to.
+func makeRequestForSubscribedResources(proxy *envoy.Proxy, typeURI envoy.TypeURI, meshCatalog catalog.MeshCataloger) *xds_discovery.DiscoveryRequest {
+	if typeURI != envoy.TypeSDS {
+		return &xds_discovery.DiscoveryRequest{TypeUrl: string(typeURI)}
+	}
+
+	// SDS requires the names of the certificates
+	if proxy.IsWildcardSubscription(typeURI) {
+		return makeRequestForAllSecrets(proxy, meshCatalog)
+	}
+
+	return &xds_discovery.DiscoveryRequest{
+		TypeUrl:       string(typeURI),
+		ResourceNames: proxy.GetSubscribedResources(typeURI),
+	}
+}
+
+// getVersionedResources returns the given resources keyed by name, each with a version derived from its content.
+func getVersionedResources(resources []*any.Any) (map[string]*xds_discovery.Resource, error) {
+	versioned := make(map[string]*xds_discovery.Resource)
+	for _, resource := range resources {
+		name, err := envoy.GetResourceName(resource)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the name of resource of type %s", resource.TypeUrl)
+			return nil, err
+		}
+		version, err := envoy.GetResourceVersion(resource)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error computing the version of resource %s", name)
+			return nil, err
+		}
+		versioned[name] = &xds_discovery.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: resource,
+		}
+	}
+	return versioned, nil
+}
@@ -0,0 +1,180 @@
+package ads
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test delta ADS functions", func() {
+	kubeClient := testclient.NewSimpleClientset()
+	mc := catalog.NewFakeMeshCatalog(kubeClient)
+	cfg := configurator.NewFakeConfigurator()
+
+	pod := tests.NewPodTestFixture(tests.Namespace, fmt.Sprintf("pod-0-%s", uuid.New()))
+	pod.Labels[constants.EnvoyUniqueIDLabelName] = tests.EnvoyUID
+	_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+	It("should have created a pod", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	svc := tests.NewServiceFixture(tests.BookstoreServiceName, tests.Namespace, map[string]string{constants.EnvoyUniqueIDLabelName: tests.EnvoyUID})
+	_, err = kubeClient.CoreV1().Services(tests.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+	It("should have created a service", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.EnvoyUID, tests.BookstoreServiceAccountName, tests.Namespace))
+	meshService := service.MeshService{
+		Namespace: tests.Namespace,
+		Name:      tests.BookstoreServiceName,
+	}
+
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
+	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+	certPEM, _ := certManager.IssueCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookstoreServiceAccountName, tests.Namespace)), nil, "")
+	cert, _ := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
+
+	Context("Test handleDeltaSubscription()", func() {
+		It("treats the first request without resource names as a wildcard subscription", func() {
+			proxy := envoy.NewProxy(cn, nil)
+			request := &xds_discovery.DeltaDiscoveryRequest{
+				TypeUrl:                 string(envoy.TypeCDS),
+				InitialResourceVersions: map[string]string{"cluster-a": "v1"},
+			}
+			Expect(handleDeltaSubscription(proxy, envoy.TypeCDS, request)).To(BeTrue())
+			Expect(proxy.IsWildcardSubscription(envoy.TypeCDS)).To(BeTrue())
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).To(Equal(map[string]string{"cluster-a": "v1"}))
+		})
+
+		It("treats a request without subscription changes as an ACK", func() {
+			proxy := envoy.NewProxy(cn, nil)
+			Expect(handleDeltaSubscription(proxy, envoy.TypeCDS, &xds_discovery.DeltaDiscoveryRequest{TypeUrl: string(envoy.TypeCDS)})).To(BeTrue())
+
+			ack := &xds_discovery.DeltaDiscoveryRequest{
+				TypeUrl:       string(envoy.TypeCDS),
+				ResponseNonce: "1234",
+			}
+			Expect(handleDeltaSubscription(proxy, envoy.TypeCDS, ack)).To(BeFalse())
+		})
+
+		It("updates the subscription when resource names are subscribed and unsubscribed", func() {
+			proxy := envoy.NewProxy(cn, nil)
+			initial := &xds_discovery.DeltaDiscoveryRequest{
+				TypeUrl:                string(envoy.TypeEDS),
+				ResourceNamesSubscribe: []string{"ns/a", "ns/b"},
+			}
+			Expect(handleDeltaSubscription(proxy, envoy.TypeEDS, initial)).To(BeTrue())
+			Expect(proxy.IsWildcardSubscription(envoy.TypeEDS)).To(BeFalse())
+
+			update := &xds_discovery.DeltaDiscoveryRequest{
+				TypeUrl:                  string(envoy.TypeEDS),
+				ResourceNamesSubscribe:   []string{"ns/c"},
+				ResourceNamesUnsubscribe: []string{"ns/a"},
+			}
+			Expect(handleDeltaSubscription(proxy, envoy.TypeEDS, update)).To(BeTrue())
+			Expect(proxy.GetSubscribedResources(envoy.TypeEDS)).To(Equal([]string{"ns/b", "ns/c"}))
+		})
+	})
+
+	Context("Test sendDeltaResponse()", func() {
+		s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+
+		It("sends only the resources that changed since they were last sent", func() {
+			server, actualResponses := tests.NewFakeDeltaXDSServer(cert)
+			proxy := envoy.NewProxy(cn, nil)
+			proxy.Subscribe(envoy.TypeCDS, nil)
+
+			err := s.sendDeltaResponse(proxy, &server, envoy.TypeCDS, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(1))
+			first := (*actualResponses)[0]
+			Expect(first.TypeUrl).To(Equal(string(envoy.TypeCDS)))
+			Expect(first.Nonce).ToNot(BeEmpty())
+			Expect(first.Resources).ToNot(BeEmpty())
+			Expect(first.RemovedResources).To(BeEmpty())
+			Expect(first.SystemVersionInfo).ToNot(BeEmpty())
+
+			// The proxy only holds the resources once it ACKs them
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).To(BeEmpty())
+			ackDeltaResponse(proxy, envoy.TypeCDS, first.Nonce)
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).To(HaveLen(len(first.Resources)))
+
+			// Nothing changed - nothing is sent
+			err = s.sendDeltaResponse(proxy, &server, envoy.TypeCDS, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(1))
+
+			// A resource the proxy holds, which no longer exists, is removed
+			proxy.SetResourceVersion(envoy.TypeCDS, "stale-cluster", "v1")
+			err = s.sendDeltaResponse(proxy, &server, envoy.TypeCDS, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(2))
+			second := (*actualResponses)[1]
+			Expect(second.Resources).To(BeEmpty())
+			Expect(second.RemovedResources).To(Equal([]string{"stale-cluster"}))
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).To(HaveKey("stale-cluster"))
+			ackDeltaResponse(proxy, envoy.TypeCDS, second.Nonce)
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).ToNot(HaveKey("stale-cluster"))
+		})
+
+		It("records the version of a rejected response and reverts the changes it carried", func() {
+			server, actualResponses := tests.NewFakeDeltaXDSServer(cert)
+			proxy := envoy.NewProxy(cn, nil)
+			proxy.Subscribe(envoy.TypeCDS, nil)
+
+			err := s.sendDeltaResponse(proxy, &server, envoy.TypeCDS, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(1))
+			rejected := (*actualResponses)[0]
+
+			s.recordNACK(proxy, envoy.TypeCDS, rejected.Nonce, "invalid cluster")
+			Expect(proxy.GetLastRejectedVersion(envoy.TypeCDS)).To(Equal(rejected.SystemVersionInfo))
+			nacks := s.GetXDSNACKs()[cn][envoy.TypeCDS]
+			Expect(nacks[len(nacks)-1].Version).To(Equal(rejected.SystemVersionInfo))
+
+			// The proxy does not hold the rejected resources; the rejected response can no longer be ACKed
+			ackDeltaResponse(proxy, envoy.TypeCDS, rejected.Nonce)
+			Expect(proxy.GetResourceVersions(envoy.TypeCDS)).To(BeEmpty())
+
+			// The same changes are not sent again
+			err = s.sendDeltaResponse(proxy, &server, envoy.TypeCDS, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(1))
+		})
+
+		It("sends only the subscribed secrets", func() {
+			server, actualResponses := tests.NewFakeDeltaXDSServer(cert)
+			proxy := envoy.NewProxy(cn, nil)
+			serviceCert := envoy.SDSCert{
+				MeshService: meshService,
+				CertType:    envoy.ServiceCertType,
+			}.String()
+			proxy.Subscribe(envoy.TypeSDS, []string{serviceCert})
+
+			err := s.sendDeltaResponse(proxy, &server, envoy.TypeSDS, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actualResponses).To(HaveLen(1))
+			Expect((*actualResponses)[0].Resources).To(HaveLen(1))
+			Expect((*actualResponses)[0].Resources[0].Name).To(Equal(serviceCert))
+		})
+	})
+})
@@ -8,3 +8,4 @@ var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errEnvoyError = errors.New("Envoy error")
 var errGrpcClosed = errors.New("grpc closed")
+var errProxyRevoked = errors.New("proxy certificate revoked")
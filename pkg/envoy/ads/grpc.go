@@ -11,11 +11,10 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy"
 )
 
-func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, proxy *envoy.Proxy, quit chan struct{}) {
+func receive(requests chan *xds_discovery.DiscoveryRequest, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, proxy *envoy.Proxy, quit chan struct{}) {
 	defer close(requests)
 	defer close(quit)
 	for {
-		var request *xds_discovery.DiscoveryRequest
 		request, recvErr := (*server).Recv()
 		if recvErr != nil {
 			if status.Code(recvErr) == codes.Canceled || recvErr == io.EOF {
@@ -27,7 +26,29 @@ func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery
 		}
 		if request.TypeUrl != "" {
 			log.Trace().Msgf("[grpc] Received DiscoveryRequest from Envoy %s: %+v", proxy.GetCommonName(), request)
-			requests <- *request
+			requests <- request
+		} else {
+			log.Warn().Msgf("[grpc] Unknown resource: %+v", request)
+		}
+	}
+}
+
+func receiveDelta(requests chan *xds_discovery.DeltaDiscoveryRequest, server *xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, proxy *envoy.Proxy, quit chan struct{}) {
+	defer close(requests)
+	defer close(quit)
+	for {
+		request, recvErr := (*server).Recv()
+		if recvErr != nil {
+			if status.Code(recvErr) == codes.Canceled || recvErr == io.EOF {
+				log.Error().Msgf("[grpc] Delta connection terminated: %+v", recvErr)
+				return
+			}
+			log.Error().Msgf("[grpc] Delta connection terminated with error: %+v", recvErr)
+			return
+		}
+		if request.TypeUrl != "" {
+			log.Trace().Msgf("[grpc] Received DeltaDiscoveryRequest from Envoy %s: %+v", proxy.GetCommonName(), request)
+			requests <- request
 		} else {
 			log.Warn().Msgf("[grpc] Unknown resource: %+v", request)
 		}
@@ -0,0 +1,53 @@
+package ads
+
+import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// recordNACK records an xDS response of the given type rejected by the proxy.
+// The rejected version is remembered so that the same config is not sent to the proxy again:
+// the proxy keeps being served its last known good config until the generated config changes.
+func (s *Server) recordNACK(proxy *envoy.Proxy, typeURI envoy.TypeURI, nonce string, errorMessage string) {
+	// The rejected response is looked up by its nonce; it is forgotten, so none of its resources is recorded as applied by the proxy
+	var rejectedVersion string
+	if sent, ok := proxy.GetSentResponse(typeURI, nonce); ok {
+		rejectedVersion = sent.Version
+		proxy.RemoveSentResponse(typeURI, nonce)
+	}
+	if rejectedVersion != "" {
+		proxy.SetLastRejectedVersion(typeURI, rejectedVersion)
+	}
+
+	log.Error().Msgf("[NACK] Proxy with CN=%s rejected %s (nonce=%s; version=%s): %s", proxy.GetCommonName(), typeURI, nonce, rejectedVersion, errorMessage)
+
+	s.metricsStore.IncXDSNACKCounter(typeURI.String())
+
+	nack := envoy.NACK{
+		TypeURI: typeURI,
+		Version: rejectedVersion,
+		Nonce:   nonce,
+		Error:   errorMessage,
+		Time:    time.Now(),
+	}
+
+	s.xdsNACKsMutex.Lock()
+	defer s.xdsNACKsMutex.Unlock()
+	cn := proxy.GetCommonName()
+	if _, ok := s.xdsNACKs[cn]; !ok {
+		s.xdsNACKs[cn] = make(map[envoy.TypeURI][]envoy.NACK)
+	}
+	nacks := append(s.xdsNACKs[cn][typeURI], nack)
+	if len(nacks) > maxNACKsPerType {
+		nacks = nacks[len(nacks)-maxNACKsPerType:]
+	}
+	s.xdsNACKs[cn][typeURI] = nacks
+}
+
+// forgetNACKs forgets the xDS responses rejected by the given proxy, once it disconnected.
+func (s *Server) forgetNACKs(proxy *envoy.Proxy) {
+	s.xdsNACKsMutex.Lock()
+	defer s.xdsNACKsMutex.Unlock()
+	delete(s.xdsNACKs, proxy.GetCommonName())
+}
@@ -0,0 +1,134 @@
+package ads
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test ADS NACK handling", func() {
+	kubeClient := testclient.NewSimpleClientset()
+	mc := catalog.NewFakeMeshCatalog(kubeClient)
+	cfg := configurator.NewFakeConfigurator()
+
+	pod := tests.NewPodTestFixture(tests.Namespace, fmt.Sprintf("pod-0-%s", uuid.New()))
+	pod.Labels[constants.EnvoyUniqueIDLabelName] = tests.EnvoyUID
+	_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+	It("should have created a pod", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	svc := tests.NewServiceFixture(tests.BookstoreServiceName, tests.Namespace, map[string]string{constants.EnvoyUniqueIDLabelName: tests.EnvoyUID})
+	_, err = kubeClient.CoreV1().Services(tests.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+	It("should have created a service", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.EnvoyUID, tests.BookstoreServiceAccountName, tests.Namespace))
+
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
+	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+	certPEM, _ := certManager.IssueCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookstoreServiceAccountName, tests.Namespace)), nil, "")
+	cert, _ := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
+
+	Context("Test recordNACK()", func() {
+		It("records the rejected version, nonce and error of the response sent with the NACKed nonce", func() {
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			proxy := envoy.NewProxy(cn, nil)
+			nonce := proxy.SetNewNonce(envoy.TypeCDS)
+			proxy.SetSentResponse(envoy.TypeCDS, nonce, envoy.SentResponse{Version: "version-1"})
+			proxy.SetSentResponse(envoy.TypeCDS, "newer-nonce", envoy.SentResponse{Version: "version-2"})
+
+			s.recordNACK(proxy, envoy.TypeCDS, nonce, "invalid cluster")
+
+			Expect(proxy.GetLastRejectedVersion(envoy.TypeCDS)).To(Equal("version-1"))
+			_, pending := proxy.GetSentResponse(envoy.TypeCDS, nonce)
+			Expect(pending).To(BeFalse())
+			_, pending = proxy.GetSentResponse(envoy.TypeCDS, "newer-nonce")
+			Expect(pending).To(BeTrue())
+			nacks := s.GetXDSNACKs()
+			Expect(nacks).To(HaveKey(cn))
+			Expect(nacks[cn][envoy.TypeCDS]).To(HaveLen(1))
+			Expect(nacks[cn][envoy.TypeCDS][0].Version).To(Equal("version-1"))
+			Expect(nacks[cn][envoy.TypeCDS][0].Nonce).To(Equal(nonce))
+			Expect(nacks[cn][envoy.TypeCDS][0].Error).To(Equal("invalid cluster"))
+		})
+
+		It("keeps only the most recent NACKs", func() {
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			proxy := envoy.NewProxy(cn, nil)
+			for i := 0; i < maxNACKsPerType+5; i++ {
+				s.recordNACK(proxy, envoy.TypeLDS, fmt.Sprintf("%d", i), "invalid listener")
+			}
+
+			nacks := s.GetXDSNACKs()[cn][envoy.TypeLDS]
+			Expect(nacks).To(HaveLen(maxNACKsPerType))
+			Expect(nacks[len(nacks)-1].Nonce).To(Equal(fmt.Sprintf("%d", maxNACKsPerType+4)))
+		})
+	})
+
+	Context("Test forgetNACKs()", func() {
+		It("forgets the NACKs of the proxy only", func() {
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			proxy := envoy.NewProxy(cn, nil)
+			otherProxy := envoy.NewProxy(certificate.CommonName("other-proxy-cn"), nil)
+			s.recordNACK(proxy, envoy.TypeLDS, "1", "invalid listener")
+			s.recordNACK(otherProxy, envoy.TypeLDS, "1", "invalid listener")
+
+			s.forgetNACKs(proxy)
+
+			nacks := s.GetXDSNACKs()
+			Expect(nacks).ToNot(HaveKey(cn))
+			Expect(nacks).To(HaveKey(certificate.CommonName("other-proxy-cn")))
+		})
+	})
+
+	Context("Test sendAllResponses() after a NACK", func() {
+		It("serves the last known good config instead of the rejected config", func() {
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			proxy := envoy.NewProxy(cn, nil)
+			server, actualResponses := tests.NewFakeXDSServer(cert, nil, nil)
+
+			s.sendAllResponses(proxy, &server, cfg)
+			Expect(*actualResponses).To(HaveLen(5))
+			cdsResponse := (*actualResponses)[0]
+			Expect(cdsResponse.TypeUrl).To(Equal(string(envoy.TypeCDS)))
+
+			// The proxy applied a (different) previous CDS config, and rejected the config just sent
+			lastKnownGood := cdsResponse.Resources[:1]
+			lastKnownGoodVersion, err := envoy.GetResourcesVersion(lastKnownGood)
+			Expect(err).ToNot(HaveOccurred())
+			proxy.SetLastAckedResources(envoy.TypeCDS, lastKnownGood)
+			proxy.SetLastAppliedVersion(envoy.TypeCDS, lastKnownGoodVersion)
+			s.recordNACK(proxy, envoy.TypeCDS, cdsResponse.Nonce, "invalid cluster")
+
+			response, err := s.newAggregatedDiscoveryResponse(proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.VersionInfo).To(Equal(lastKnownGoodVersion))
+			Expect(response.Resources).To(Equal(lastKnownGood))
+
+			// The proxy already has the last known good config; the rejected config is not sent again
+			s.sendAllResponses(proxy, &server, cfg)
+			for _, response := range (*actualResponses)[5:] {
+				Expect(response.TypeUrl).ToNot(Equal(string(envoy.TypeCDS)))
+			}
+		})
+	})
+})
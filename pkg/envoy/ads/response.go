@@ -2,10 +2,10 @@ package ads
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/ptypes/any"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -36,7 +36,21 @@ func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.Aggr
 			log.Error().Err(err).Msgf("%s Failed to create %s discovery response for proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
 			continue
 		}
-		if err := (*server).Send(discoveryResponse); err != nil {
+
+		// The version is a hash of the resources: when it matches what the proxy last acknowledged,
+		// the proxy already has this exact config and there is no need to send it again.
+		if discoveryResponse.VersionInfo == proxy.GetLastAppliedVersion(typeURI) {
+			log.Trace().Msgf("%s Proxy with CN=%s already has %s version %s; skipping", prefix, proxy.GetCommonName(), typeURI, discoveryResponse.VersionInfo)
+			continue
+		}
+
+		// The proxy rejected this exact config before and there is no last known good config to fall back to.
+		if discoveryResponse.VersionInfo == proxy.GetLastRejectedVersion(typeURI) {
+			log.Warn().Msgf("%s Proxy with CN=%s rejected %s version %s before; skipping", prefix, proxy.GetCommonName(), typeURI, discoveryResponse.VersionInfo)
+			continue
+		}
+
+		if err := sendDiscoveryResponse(proxy, server, discoveryResponse); err != nil {
 			log.Error().Err(err).Msgf("%s Error sending %s to proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
 		}
 	}
@@ -85,22 +99,30 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 		return nil, errUnknownTypeURL
 	}
 
-	if s.enableDebug {
-		if _, ok := s.xdsLog[proxy.GetCommonName()]; !ok {
-			s.xdsLog[proxy.GetCommonName()] = make(map[envoy.TypeURI][]time.Time)
-		}
-		s.xdsLog[proxy.GetCommonName()][typeURL] = append(s.xdsLog[proxy.GetCommonName()][typeURL], time.Now())
-	}
+	s.recordXDSResponse(proxy, typeURL)
 
 	log.Trace().Msgf("Invoking handler for %s with request: %+v", typeURL, request)
-	response, err := handler(s.catalog, proxy, request, cfg)
+	response, err := s.getDiscoveryResponse(handler, proxy, request, cfg)
 	if err != nil {
 		log.Error().Msgf("Responder for TypeUrl %s is not implemented", request.TypeUrl)
 		return nil, errCreatingResponse
 	}
 
-	response.Nonce = proxy.SetNewNonce(typeURL)
-	response.VersionInfo = strconv.FormatUint(proxy.IncrementLastSentVersion(typeURL), 10)
+	response.VersionInfo, err = envoy.GetResourcesVersion(response.Resources)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error computing the version of %s response for proxy with CN=%s", typeURL, proxy.GetCommonName())
+		return nil, errCreatingResponse
+	}
+
+	// Do not send config the proxy already rejected; serve the last config it acknowledged instead
+	if response.VersionInfo == proxy.GetLastRejectedVersion(typeURL) {
+		if lastKnownGood := proxy.GetLastAckedResources(typeURL); lastKnownGood != nil {
+			log.Warn().Msgf("Proxy with CN=%s rejected %s version %s; serving the last known good version %s",
+				proxy.GetCommonName(), typeURL, response.VersionInfo, proxy.GetLastAppliedVersion(typeURL))
+			response.Resources = lastKnownGood
+			response.VersionInfo = proxy.GetLastAppliedVersion(typeURL)
+		}
+	}
 
 	if envoy.TypeURI(request.TypeUrl) == envoy.TypeSDS {
 		log.Trace().Msgf("Constructed %s response: VersionInfo=%s", response.TypeUrl, response.VersionInfo)
@@ -110,3 +132,89 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 
 	return response, nil
 }
+
+// getDiscoveryResponse invokes the xDS handler for the request. For the xDS types which depend mostly on the service of the proxy,
+// the resources generated for that service and the variant of the proxy at the current configuration generation are reused from the snapshot cache.
+func (s *Server) getDiscoveryResponse(handler xdsHandler, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	typeURL := envoy.TypeURI(request.TypeUrl)
+	getSnapshotVariant, ok := s.snapshotVariants[typeURL]
+	if !ok {
+		return handler(s.catalog, proxy, request, cfg)
+	}
+
+	svcList, err := s.catalog.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with CN=%q", proxy.GetCommonName())
+		return nil, err
+	}
+	// Github Issue #1575
+	proxyServiceName := svcList[0]
+
+	variant, err := getSnapshotVariant(s.catalog, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.snapshotCache.GetOrCreate(proxyServiceName, typeURL, variant, s.catalog.GetConfigGeneration(), func() ([]*any.Any, error) {
+		response, err := handler(s.catalog, proxy, request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return response.Resources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_discovery.DiscoveryResponse{
+		TypeUrl:   string(typeURL),
+		Resources: resources,
+	}, nil
+}
+
+// resolveProxyPodProperties records on the proxy the properties of its pod the xDS responses depend on, once when the proxy connects:
+// they do not change for the lifetime of the pod, and resolving them for every response would query the Kubernetes API every time.
+func (s *Server) resolveProxyPodProperties(proxy *envoy.Proxy) error {
+	// The endpoints closest to the proxy are preferred; all the endpoints are equally preferred when its locality is unknown
+	locality, err := s.catalog.GetLocalityFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up the locality of Envoy with CN=%q", proxy.GetCommonName())
+	}
+	proxy.SetLocality(locality)
+
+	// The listeners bind and the clusters reach the addresses of the IP families of the pod of the proxy
+	ipFamilies, err := s.catalog.GetIPFamiliesFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up the IP families of Envoy with CN=%q", proxy.GetCommonName())
+		return err
+	}
+	proxy.SetIPFamilies(ipFamilies)
+
+	return nil
+}
+
+// sendDiscoveryResponse stamps the response with a new nonce, sends it to the proxy and records the response sent under its nonce, pending an ACK.
+func sendDiscoveryResponse(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, response *xds_discovery.DiscoveryResponse) error {
+	typeURL := envoy.TypeURI(response.TypeUrl)
+	response.Nonce = proxy.SetNewNonce(typeURL)
+	if err := (*server).Send(response); err != nil {
+		return err
+	}
+	proxy.SetLastSentVersion(typeURL, response.VersionInfo)
+	proxy.SetSentResponse(typeURL, response.Nonce, envoy.SentResponse{
+		Version:   response.VersionInfo,
+		Resources: response.Resources,
+	})
+	return nil
+}
+
+// recordXDSResponse adds the time an xDS response of the given type was created for the proxy to the debug log.
+func (s *Server) recordXDSResponse(proxy *envoy.Proxy, typeURL envoy.TypeURI) {
+	if !s.enableDebug {
+		return
+	}
+	if _, ok := s.xdsLog[proxy.GetCommonName()]; !ok {
+		s.xdsLog[proxy.GetCommonName()] = make(map[envoy.TypeURI][]time.Time)
+	}
+	s.xdsLog[proxy.GetCommonName()][typeURL] = append(s.xdsLog[proxy.GetCommonName()][typeURL], time.Now())
+}
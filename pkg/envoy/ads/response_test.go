@@ -9,6 +9,7 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -21,6 +22,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -88,18 +91,119 @@ var _ = Describe("Test ADS response functions", func() {
 		})
 	})
 
+	Context("Test getDiscoveryResponse()", func() {
+		It("reuses the config generated for proxies of the same service", func() {
+			cfg := configurator.NewFakeConfigurator()
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			request := &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}
+
+			first, err := s.getDiscoveryResponse(s.xdsHandlers[envoy.TypeCDS], envoy.NewProxy(cn, nil), request, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first.Resources).ToNot(BeEmpty())
+
+			second, err := s.getDiscoveryResponse(s.xdsHandlers[envoy.TypeCDS], envoy.NewProxy(cn, nil), request, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second.Resources[0]).To(BeIdenticalTo(first.Resources[0]))
+		})
+	})
+
+	Context("Test the snapshot variants of the xDS types", func() {
+		s := NewADSServer(mc, true, tests.Namespace, configurator.NewFakeConfigurator(), metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+
+		It("shares the snapshots across the service accounts of a service without egress policies", func() {
+			variant, err := s.snapshotVariants[envoy.TypeLDS](s.catalog, proxy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(variant).To(Equal("ipFamilies=IPv4"))
+		})
+
+		It("separates the listeners and clusters of the service accounts of a service with egress policies", func() {
+			featureflags.Features.EgressPolicy = true
+			defer func() { featureflags.Features.EgressPolicy = false }()
+
+			for _, typeURL := range []envoy.TypeURI{envoy.TypeLDS, envoy.TypeCDS} {
+				variant, err := s.snapshotVariants[typeURL](s.catalog, proxy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(variant).To(Equal(fmt.Sprintf("ipFamilies=IPv4;serviceAccount=%s/%s", namespace, serviceAccountName)))
+			}
+
+			variant, err := s.snapshotVariants[envoy.TypeRDS](s.catalog, proxy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(variant).To(BeEmpty())
+		})
+
+		It("separates the endpoints of the proxies of a service in different localities", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := catalog.NewFakeMeshCatalog(kubeClient)
+			s := NewADSServer(mc, true, tests.Namespace, configurator.NewFakeConfigurator(), metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+
+			for _, zone := range []string{"us-east-1a", "us-east-1b"} {
+				node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name: zone,
+					Labels: map[string]string{
+						corev1.LabelZoneRegionStable:        "us-east-1",
+						corev1.LabelZoneFailureDomainStable: zone,
+					},
+				}}
+				_, err := kubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			var variants []string
+			for _, zone := range []string{"us-east-1a", "us-east-1b"} {
+				proxyUUID := uuid.New().String()
+				pod := tests.NewPodTestFixture(namespace, fmt.Sprintf("pod-%s", proxyUUID))
+				pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID
+				pod.Spec.NodeName = zone
+				_, err := kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", proxyUUID, serviceAccountName, namespace))
+				proxy := envoy.NewProxy(cn, nil)
+				Expect(s.resolveProxyPodProperties(proxy)).To(Succeed())
+				variant, err := s.snapshotVariants[envoy.TypeEDS](s.catalog, proxy)
+				Expect(err).ToNot(HaveOccurred())
+				variants = append(variants, variant)
+			}
+			Expect(variants).To(Equal([]string{"locality=us-east-1/us-east-1a", "locality=us-east-1/us-east-1b"}))
+		})
+
+		It("separates the listeners and clusters of the IPv4 and dual-stack proxies of a service", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			mc := catalog.NewFakeMeshCatalog(kubeClient)
+			s := NewADSServer(mc, true, tests.Namespace, configurator.NewFakeConfigurator(), metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+
+			var variants []string
+			for _, podIPs := range [][]corev1.PodIP{{{IP: "10.244.0.5"}}, {{IP: "10.244.0.6"}, {IP: "fd00:10:244::6"}}} {
+				proxyUUID := uuid.New().String()
+				pod := tests.NewPodTestFixture(namespace, fmt.Sprintf("pod-%s", proxyUUID))
+				pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID
+				pod.Status.PodIPs = podIPs
+				_, err := kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", proxyUUID, serviceAccountName, namespace))
+				proxy := envoy.NewProxy(cn, nil)
+				Expect(s.resolveProxyPodProperties(proxy)).To(Succeed())
+				variant, err := s.snapshotVariants[envoy.TypeLDS](s.catalog, proxy)
+				Expect(err).ToNot(HaveOccurred())
+				variants = append(variants, variant)
+			}
+			Expect(variants).To(Equal([]string{"ipFamilies=IPv4", "ipFamilies=IPv4,IPv6"}))
+		})
+	})
+
 	Context("Test sendAllResponses()", func() {
 
-		cache := make(map[certificate.CommonName]certificate.Certificater)
+		cache := make(map[certificate.CacheKey]certificate.Certificater)
 		certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 		cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), serviceAccountName, tests.Namespace))
-		certPEM, _ := certManager.IssueCertificate(cn, nil)
+		certPEM, _ := certManager.IssueCertificate(cn, nil, "")
 		cert, _ := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
 		server, actualResponses := tests.NewFakeXDSServer(cert, nil, nil)
 		cfg := configurator.NewFakeConfigurator()
 
 		It("returns Aggregated Discovery Service response", func() {
-			s := NewADSServer(mc, true, tests.Namespace, cfg)
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
 
 			Expect(s).ToNot(BeNil())
 
@@ -108,19 +212,19 @@ var _ = Describe("Test ADS response functions", func() {
 			Expect(actualResponses).ToNot(BeNil())
 			Expect(len(*actualResponses)).To(Equal(5))
 
-			Expect((*actualResponses)[0].VersionInfo).To(Equal("1"))
+			Expect((*actualResponses)[0].VersionInfo).To(Equal(getExpectedVersion((*actualResponses)[0])))
 			Expect((*actualResponses)[0].TypeUrl).To(Equal(string(envoy.TypeCDS)))
 
-			Expect((*actualResponses)[1].VersionInfo).To(Equal("1"))
+			Expect((*actualResponses)[1].VersionInfo).To(Equal(getExpectedVersion((*actualResponses)[1])))
 			Expect((*actualResponses)[1].TypeUrl).To(Equal(string(envoy.TypeEDS)))
 
-			Expect((*actualResponses)[2].VersionInfo).To(Equal("1"))
+			Expect((*actualResponses)[2].VersionInfo).To(Equal(getExpectedVersion((*actualResponses)[2])))
 			Expect((*actualResponses)[2].TypeUrl).To(Equal(string(envoy.TypeLDS)))
 
-			Expect((*actualResponses)[3].VersionInfo).To(Equal("1"))
+			Expect((*actualResponses)[3].VersionInfo).To(Equal(getExpectedVersion((*actualResponses)[3])))
 			Expect((*actualResponses)[3].TypeUrl).To(Equal(string(envoy.TypeRDS)))
 
-			Expect((*actualResponses)[4].VersionInfo).To(Equal("1"))
+			Expect((*actualResponses)[4].VersionInfo).To(Equal(getExpectedVersion((*actualResponses)[4])))
 			Expect((*actualResponses)[4].TypeUrl).To(Equal(string(envoy.TypeSDS)))
 			log.Printf("%v", len((*actualResponses)[4].Resources))
 			Expect(len((*actualResponses)[4].Resources)).To(Equal(4))
@@ -157,5 +261,28 @@ var _ = Describe("Test ADS response functions", func() {
 				CertType:    envoy.RootCertTypeForHTTPS,
 			}.String()))
 		})
+
+		It("does not resend responses the proxy already acknowledged", func() {
+			s := NewADSServer(mc, true, tests.Namespace, cfg, metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName"))
+			ackedProxy := envoy.NewProxy(proxy.GetCommonName(), nil)
+			ackedServer, ackedResponses := tests.NewFakeXDSServer(cert, nil, nil)
+
+			s.sendAllResponses(ackedProxy, &ackedServer, cfg)
+			Expect(len(*ackedResponses)).To(Equal(5))
+
+			// The proxy ACKs every response it received
+			for _, response := range *ackedResponses {
+				ackedProxy.SetLastAppliedVersion(envoy.TypeURI(response.TypeUrl), response.VersionInfo)
+			}
+
+			s.sendAllResponses(ackedProxy, &ackedServer, cfg)
+			Expect(len(*ackedResponses)).To(Equal(5))
+		})
 	})
 })
+
+func getExpectedVersion(response *xds_discovery.DiscoveryResponse) string {
+	version, err := envoy.GetResourcesVersion(response.Resources)
+	Expect(err).ToNot(HaveOccurred())
+	return version
+}
@@ -3,8 +3,6 @@ package ads
 import (
 	"time"
 
-	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
-
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -14,13 +12,14 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/lds"
 	"github.com/openservicemesh/osm/pkg/envoy/rds"
 	"github.com/openservicemesh/osm/pkg/envoy/sds"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 // NewADSServer creates a new Aggregated Discovery Service server
-func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamespace string, cfg configurator.Configurator) *Server {
+func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamespace string, cfg configurator.Configurator, metricsStore metricsstore.MetricStore) *Server {
 	server := Server{
 		catalog: meshCatalog,
-		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error){
+		xdsHandlers: map[envoy.TypeURI]xdsHandler{
 			envoy.TypeEDS: eds.NewResponse,
 			envoy.TypeCDS: cds.NewResponse,
 			envoy.TypeRDS: rds.NewResponse,
@@ -30,6 +29,16 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamesp
 		enableDebug:  enableDebug,
 		osmNamespace: osmNamespace,
 		cfg:          cfg,
+		metricsStore: metricsStore,
+
+		snapshotCache: envoy.NewSnapshotCache(),
+		snapshotVariants: map[envoy.TypeURI]snapshotVariantFunc{
+			envoy.TypeEDS: eds.GetSnapshotVariant,
+			envoy.TypeCDS: cds.GetSnapshotVariant,
+			envoy.TypeRDS: rds.GetSnapshotVariant,
+			envoy.TypeLDS: lds.GetSnapshotVariant,
+		},
+		xdsNACKs: make(map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK),
 	}
 
 	if enableDebug {
@@ -38,8 +47,3 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamesp
 
 	return &server
 }
-
-// DeltaAggregatedResources implements discovery.AggregatedDiscoveryServiceServer
-func (s *Server) DeltaAggregatedResources(xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
-	panic("NotImplemented")
-}
@@ -2,11 +2,11 @@ package ads
 
 import (
 	"context"
-	"strconv"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/pkg/errors"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
@@ -20,6 +20,10 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 		return errors.Wrap(err, "[%s] Could not start stream")
 	}
 
+	if err := s.checkRevoked(cn); err != nil {
+		return err
+	}
+
 	// TODO(draychev): check for envoy.ErrTooManyConnections
 
 	ip := utils.GetIPFromContext(server.Context())
@@ -36,14 +40,18 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 	// This is the Envoy proxy that just connected to the control plane.
 	proxy := envoy.NewProxy(cn, ip)
+	if err := s.resolveProxyPodProperties(proxy); err != nil {
+		return err
+	}
 	s.catalog.RegisterProxy(proxy)
 	defer s.catalog.UnregisterProxy(proxy)
+	defer s.forgetNACKs(proxy)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	quit := make(chan struct{})
-	requests := make(chan xds_discovery.DiscoveryRequest)
+	requests := make(chan *xds_discovery.DiscoveryRequest)
 
 	// This helper handles receiving messages from the connected Envoys
 	// and any gRPC error states.
@@ -59,70 +67,57 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			return nil
 
 		case discoveryRequest, ok := <-requests:
-			log.Info().Msgf("Received %s (nonce=%s; version=%s) from Envoy %s", discoveryRequest.TypeUrl, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo, proxy.GetCommonName())
-			log.Info().Msgf("Last sent for %s nonce=%s; last sent version=%s for Envoy %s", discoveryRequest.TypeUrl, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo, proxy.GetCommonName())
 			if !ok {
 				log.Error().Msgf("Proxy %s closed GRPC!", proxy.GetCommonName())
 				return errGrpcClosed
 			}
 
-			if discoveryRequest.ErrorDetail != nil {
-				log.Error().Msgf("[NACK] Discovery request error from proxy %s: %s", proxy, discoveryRequest.ErrorDetail)
-				// NOTE(draychev): We could also return errEnvoyError - but it seems appropriate to also ignore this request and continue on.
-				continue
+			if err := s.checkRevoked(proxy.GetCommonName()); err != nil {
+				return err
 			}
 
+			log.Info().Msgf("Received %s (nonce=%s; version=%s) from Envoy %s", discoveryRequest.TypeUrl, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo, proxy.GetCommonName())
+			log.Info().Msgf("Last sent for %s nonce=%s; last sent version=%s for Envoy %s", discoveryRequest.TypeUrl, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo, proxy.GetCommonName())
+
 			typeURL, ok := envoy.ValidURI[discoveryRequest.TypeUrl]
 			if !ok {
 				log.Error().Err(err).Msgf("Unknown/Unsupported URI: %s", discoveryRequest.TypeUrl)
 				continue
 			}
 
-			// It is possible for Envoy to return an empty VersionInfo.
-			// When that's the case - start with 0
-			ackVersion := uint64(0)
-			if discoveryRequest.VersionInfo != "" {
-				if ackVersion, err = strconv.ParseUint(discoveryRequest.VersionInfo, 10, 64); err != nil {
-					// It is probable that Envoy responded with a VersionInfo we did not understand
-					// We log this and continue. The ackVersion will be 0 in this state.
-					log.Error().Err(err).Msgf("Error parsing %s discovery request VersionInfo (%s) from proxy %s", typeURL, discoveryRequest.VersionInfo, proxy.GetCommonName())
-				}
+			if discoveryRequest.ErrorDetail != nil {
+				// The proxy rejected the config and keeps running with the config it last applied.
+				// Record the NACK and do not respond: responding would send the rejected config again.
+				s.recordNACK(proxy, typeURL, discoveryRequest.ResponseNonce, discoveryRequest.ErrorDetail.Message)
+				continue
 			}
 
-			log.Debug().Msgf("Incoming Discovery Request %s (nonce=%s; version=%d) from Envoy %s; last applied version: %d",
+			log.Debug().Msgf("Incoming Discovery Request %s (nonce=%s; version=%s) from Envoy %s; last applied version: %s",
 				discoveryRequest.TypeUrl,
 				discoveryRequest.ResponseNonce,
-				ackVersion,
+				discoveryRequest.VersionInfo,
 				proxy.GetCommonName(),
 				proxy.GetLastAppliedVersion(typeURL))
 
-			log.Debug().Msgf("Last sent nonce=%s; last sent version=%d for Envoy %s",
+			log.Debug().Msgf("Last sent nonce=%s; last sent version=%s for Envoy %s",
 				proxy.GetLastSentNonce(typeURL),
 				proxy.GetLastSentVersion(typeURL),
 				proxy.GetCommonName())
 
-			proxy.SetLastAppliedVersion(typeURL, ackVersion)
-
-			// In the DiscoveryRequest we have a VersionInfo field.
-			// When this is smaller or equal to what we last sent to this proxy - it is
-			// interpreted as an acknowledgement of a previously sent request.
-			// Such DiscoveryRequest requires no further action.
-			if ackVersion > 0 && ackVersion <= proxy.GetLastSentVersion(typeURL) {
-				log.Debug().Msgf("Request %s VersionInfo (%d) <= last sent VersionInfo (%d); ACK", typeURL, ackVersion, proxy.GetLastSentVersion(typeURL))
+			// The VersionInfo of a DiscoveryRequest is the version of the config the Envoy proxy last applied.
+			// It is empty when the proxy has no config of this type yet.
+			proxy.SetLastAppliedVersion(typeURL, discoveryRequest.VersionInfo)
+
+			// Versions are hashes of the resources in a response. A DiscoveryRequest carrying the nonce
+			// and the version of a response sent to this proxy is an acknowledgement of that response:
+			// its resources become the last known good config. Such DiscoveryRequest requires no further action.
+			if sent, ok := proxy.GetSentResponse(typeURL, discoveryRequest.ResponseNonce); ok && discoveryRequest.VersionInfo == sent.Version {
+				log.Debug().Msgf("Request %s VersionInfo (%s) matches the VersionInfo sent with Nonce=%s; ACK", typeURL, discoveryRequest.VersionInfo, discoveryRequest.ResponseNonce)
+				proxy.RemoveSentResponse(typeURL, discoveryRequest.ResponseNonce)
+				proxy.SetLastAckedResources(typeURL, sent.Resources)
 				continue
 			}
 
-			// The version of the config received along with the DiscoveryRequest (ackVersion)
-			// is what the Envoy proxy may be acknowledging. It is acknowledging
-			// and not requesting when the ackVersion is <= what we last sent.
-			// It is possible however for a proxy to have a version that is higher
-			// than what we last sent. (Perhaps the control plane restarted.)
-			// In that case we want to make sure that we send new responses with
-			// VersionInfo incremented starting with the version which the proxy last had.
-			if ackVersion > proxy.GetLastSentVersion(typeURL) {
-				proxy.SetLastSentVersion(typeURL, ackVersion)
-			}
-
 			lastNonce := proxy.GetLastSentNonce(typeURL)
 			if lastNonce != "" && discoveryRequest.ResponseNonce == lastNonce {
 				log.Debug().Msgf("Nothing changed since Nonce=%s", discoveryRequest.ResponseNonce)
@@ -134,19 +129,32 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			}
 			log.Info().Msgf("Received discovery request <%s> from Envoy <%s> with Nonce=%s", discoveryRequest.TypeUrl, proxy, discoveryRequest.ResponseNonce)
 
-			resp, err := s.newAggregatedDiscoveryResponse(proxy, &discoveryRequest, s.cfg)
+			resp, err := s.newAggregatedDiscoveryResponse(proxy, discoveryRequest, s.cfg)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error composing a DiscoveryResponse")
 				continue
 			}
 
-			if err := server.Send(resp); err != nil {
+			if err := sendDiscoveryResponse(proxy, &server, resp); err != nil {
 				log.Error().Err(err).Msgf("Error sending DiscoveryResponse")
 			}
 
 		case <-proxy.GetAnnouncementsChannel():
+			if err := s.checkRevoked(proxy.GetCommonName()); err != nil {
+				return err
+			}
 			log.Info().Msgf("Change detected - update all Envoys.")
 			s.sendAllResponses(proxy, &server, s.cfg)
 		}
 	}
 }
+
+// checkRevoked returns errProxyRevoked when the XDS certificate of the Envoy proxy with the given CN was revoked;
+// the stream of such a proxy is closed, and the proxy may no longer connect.
+func (s *Server) checkRevoked(cn certificate.CommonName) error {
+	if !s.catalog.IsProxyRevoked(cn) {
+		return nil
+	}
+	log.Warn().Msgf("Closing the stream of Envoy with CN=%s, whose certificate was revoked", cn)
+	return errProxyRevoked
+}
@@ -1,6 +1,7 @@
 package ads
 
 import (
+	"sync"
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
@@ -10,18 +11,39 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 var (
 	log = logger.New("envoy/ads")
 )
 
+// maxNACKsPerType is the number of most recent NACKs kept for each Envoy proxy and xDS type
+const maxNACKsPerType = 10
+
+// xdsHandler generates the xDS response of a given type for an Envoy proxy
+type xdsHandler func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error)
+
+// snapshotVariantFunc returns the inputs specific to an Envoy proxy, other than its service, which the xDS resources of a given type depend on.
+// The proxies of a service share the snapshot of the resources of that type only when they share these inputs.
+type snapshotVariantFunc func(catalog.MeshCataloger, *envoy.Proxy) (string, error)
+
 // Server implements the Envoy xDS Aggregate Discovery Services
 type Server struct {
 	catalog      catalog.MeshCataloger
-	xdsHandlers  map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error)
+	xdsHandlers  map[envoy.TypeURI]xdsHandler
 	xdsLog       map[certificate.CommonName]map[envoy.TypeURI][]time.Time
 	enableDebug  bool
 	osmNamespace string
 	cfg          configurator.Configurator
+	metricsStore metricsstore.MetricStore
+
+	// snapshotCache shares the config generated for a service across all proxies of that service.
+	// Only the xDS types with a snapshot variant, which depend mostly on the service of the proxy, are cached.
+	snapshotCache    *envoy.SnapshotCache
+	snapshotVariants map[envoy.TypeURI]snapshotVariantFunc
+
+	// xdsNACKs keeps the most recent xDS responses rejected by each Envoy proxy
+	xdsNACKs      map[certificate.CommonName]map[envoy.TypeURI][]envoy.NACK
+	xdsNACKsMutex sync.RWMutex
 }
@@ -6,12 +6,13 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
+	corev1 "k8s.io/api/core/v1"
 
-	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -23,11 +24,16 @@ const (
 	clusterConnectTimeout = 1 * time.Second
 )
 
-// getRemoteServiceCluster returns an Envoy Cluster corresponding to the remote service
-func getRemoteServiceCluster(remoteService, localService service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
-	clusterName := remoteService.String()
-	marshalledUpstreamTLSContext, err := envoy.MessageToAny(
-		envoy.GetUpstreamTLSContext(localService, remoteService.GetCommonName().String()))
+// getRemoteServiceCluster returns an Envoy Cluster corresponding to the given port of the remote service.
+// The services exported by remote clusters are reached through the multicluster gateway of their cluster,
+// which routes the connections to the port of the service based on their SNI.
+func getRemoteServiceCluster(remoteService, localService service.MeshService, port service.ServicePort, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
+	clusterName := envoy.GetClusterNameForPort(remoteService.String(), port.Port)
+	sni := remoteService.GetCommonName().String()
+	if remoteService.IsRemote() {
+		sni = remoteService.GetServerNameForPort(port.Port)
+	}
+	marshalledUpstreamTLSContext, err := envoy.MessageToAny(envoy.GetUpstreamTLSContext(localService, sni))
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +47,10 @@ func getRemoteServiceCluster(remoteService, localService service.MeshService, cf
 				TypedConfig: marshalledUpstreamTLSContext,
 			},
 		},
-		ProtocolSelection:    xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL,
-		Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
 	}
+	setProtocolOptions(remoteCluster, port.Protocol)
 
-	if cfg.IsPermissiveTrafficPolicyMode() {
+	if cfg.IsPermissiveTrafficPolicyMode() && !remoteService.IsRemote() {
 		// Since no traffic policies exist with permissive mode, rely on cluster provided service discovery.
 		remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_ORIGINAL_DST}
 		remoteCluster.LbPolicy = xds_cluster.Cluster_CLUSTER_PROVIDED
@@ -54,11 +59,26 @@ func getRemoteServiceCluster(remoteService, localService service.MeshService, cf
 		remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}
 		remoteCluster.EdsClusterConfig = &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()}
 		remoteCluster.LbPolicy = xds_cluster.Cluster_ROUND_ROBIN
+		remoteCluster.CommonLbConfig = getCommonLbConfig()
 	}
 
 	return remoteCluster, nil
 }
 
+// setProtocolOptions configures the protocol the given cluster uses to talk to its upstream hosts, based on the application protocol of the port
+func setProtocolOptions(cluster *xds_cluster.Cluster, appProtocol service.AppProtocol) {
+	switch appProtocol {
+	case service.AppProtocolHTTP:
+		// HTTP/1.1 or HTTP/2 is used upstream, following the protocol used downstream
+		cluster.ProtocolSelection = xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL
+		cluster.Http2ProtocolOptions = &xds_core.Http2ProtocolOptions{}
+	case service.AppProtocolGRPC, service.AppProtocolGRPCWeb:
+		// gRPC always runs over HTTP/2, gRPC-Web is translated to gRPC by the inbound listener
+		cluster.Http2ProtocolOptions = &xds_core.Http2ProtocolOptions{}
+	}
+	// TCP traffic is proxied as is
+}
+
 // getOutboundPassthroughCluster returns an Envoy cluster that is used for outbound passthrough traffic
 func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	return &xds_cluster.Cluster{
@@ -73,56 +93,61 @@ func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	}
 }
 
-// getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
-func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string) (*xds_cluster.Cluster, error) {
-	xdsCluster := xds_cluster.Cluster{
-		// The name must match the domain being cURLed in the demo
+// getLocalServiceCluster returns an Envoy Cluster proxying the inbound traffic of the local service to the given port of the local pod.
+// The traffic is proxied to the address of the given IP family, the family of the primary address of the pod, which the applications listen on.
+func getLocalServiceCluster(proxyServiceName service.MeshService, port service.ServicePort, ipFamily corev1.IPFamily) *xds_cluster.Cluster {
+	clusterName := envoy.GetLocalClusterNameForPort(proxyServiceName, port.TargetPort)
+	localCluster := &xds_cluster.Cluster{
 		Name:           clusterName,
 		AltStatName:    clusterName,
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
-		LbPolicy:       xds_cluster.Cluster_ROUND_ROBIN,
-		RespectDnsTtl:  true,
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
-			Type: xds_cluster.Cluster_STRICT_DNS,
+			Type: xds_cluster.Cluster_STATIC,
 		},
-		DnsLookupFamily: xds_cluster.Cluster_V4_ONLY,
+		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
-			// NOTE: results.MeshService is the top level service that is cURLed.
 			ClusterName: clusterName,
-			Endpoints:   []*xds_endpoint.LocalityLbEndpoints{
-				// Filled based on discovered endpoints for the service
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(envoy.GetWildcardIPAddr(ipFamily), port.TargetPort),
+							},
+						},
+						LoadBalancingWeight: &wrappers.UInt32Value{
+							Value: constants.ClusterWeightAcceptAll, // Local cluster accepts all traffic
+						},
+					}},
+				},
 			},
 		},
-		ProtocolSelection:    xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL,
-		Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
 	}
+	setProtocolOptions(localCluster, port.Protocol)
 
-	endpoints, err := catalog.ListEndpointsForService(proxyServiceName)
-	if err != nil {
-		log.Error().Err(err).Msgf("Failed to get endpoints for service %s", proxyServiceName)
-		return nil, err
-	}
+	return localCluster
+}
 
-	for _, ep := range endpoints {
-		localityEndpoint := &xds_endpoint.LocalityLbEndpoints{
-			Locality: &xds_core.Locality{
-				Zone: "zone",
-			},
-			LbEndpoints: []*xds_endpoint.LbEndpoint{{
-				HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
-					Endpoint: &xds_endpoint.Endpoint{
-						Address: envoy.GetAddress(constants.WildcardIPAddr, uint32(ep.Port)),
-					},
-				},
-				LoadBalancingWeight: &wrappers.UInt32Value{
-					Value: constants.ClusterWeightAcceptAll, // Local cluster accepts all traffic
-				},
-			}},
-		}
-		xdsCluster.LoadAssignment.Endpoints = append(xdsCluster.LoadAssignment.Endpoints, localityEndpoint)
+// getMulticlusterGatewayCluster returns the Envoy Cluster the multicluster gateway proxies the connections from remote clusters
+// to the given port of the given exported service with. TLS is not terminated by the gateway, but by the proxies of the service.
+func getMulticlusterGatewayCluster(exportedService service.MeshService, port service.ServicePort) *xds_cluster.Cluster {
+	return &xds_cluster.Cluster{
+		Name:                 envoy.GetMulticlusterGatewayClusterNameForPort(exportedService, port.Port),
+		ConnectTimeout:       ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS},
+		EdsClusterConfig:     &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()},
+		LbPolicy:             xds_cluster.Cluster_ROUND_ROBIN,
+		CommonLbConfig:       getCommonLbConfig(),
 	}
+}
 
-	return &xdsCluster, nil
+// getCommonLbConfig returns the load balancing config of the clusters discovered with EDS.
+// The panic mode is disabled, so that the unhealthy and draining endpoints never receive traffic,
+// even when most of the endpoints of the cluster are unhealthy or draining, as during a rolling deploy.
+func getCommonLbConfig() *xds_cluster.Cluster_CommonLbConfig {
+	return &xds_cluster.Cluster_CommonLbConfig{
+		HealthyPanicThreshold: &xds_type.Percent{Value: 0},
+	}
 }
 
 // getPrometheusCluster returns an Envoy Cluster responsible for scraping metrics by Prometheus
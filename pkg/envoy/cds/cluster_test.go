@@ -5,8 +5,12 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -14,6 +18,10 @@ var _ = Describe("Cluster configurations", func() {
 
 	localService := tests.BookbuyerService
 	remoteService := tests.BookstoreService
+	httpPort := service.ServicePort{Name: "http", Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+	grpcPort := service.ServicePort{Name: "grpc", Port: 9090, TargetPort: 9091, Protocol: service.AppProtocolGRPC}
+	tcpPort := service.ServicePort{Name: "tcp", Port: 3306, TargetPort: 3306, Protocol: service.AppProtocolTCP}
+
 	Context("Test getRemoteServiceCluster", func() {
 		It("Returns an EDS based cluster when permissive mode is disabled", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(
@@ -22,11 +30,12 @@ var _ = Describe("Cluster configurations", func() {
 				},
 			)
 
-			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, cfg)
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, httpPort, cfg)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_EDS))
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
 			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
+			Expect(remoteCluster.CommonLbConfig.HealthyPanicThreshold.Value).To(Equal(0.0))
 		})
 
 		It("Returns an Original Destination based cluster when permissive mode is enabled", func() {
@@ -36,11 +45,62 @@ var _ = Describe("Cluster configurations", func() {
 				},
 			)
 
-			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, cfg)
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, httpPort, cfg)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_ORIGINAL_DST))
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
 			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
+			Expect(remoteCluster.CommonLbConfig).To(BeNil())
+		})
+	})
+
+	Context("Test getRemoteServiceCluster with the ports of the remote service", func() {
+		cfg := configurator.NewFakeConfigurator()
+
+		It("Returns a cluster per port of the remote service", func() {
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, httpPort, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.Name).To(Equal("default/bookstore|80"))
+		})
+
+		It("Returns an HTTP/2 cluster for a gRPC port", func() {
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, grpcPort, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.Name).To(Equal("default/bookstore|9090"))
+			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_CONFIGURED_PROTOCOL))
+			Expect(remoteCluster.Http2ProtocolOptions).ToNot(BeNil())
+		})
+
+		It("Returns a cluster without HTTP options for a TCP port", func() {
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, tcpPort, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.Name).To(Equal("default/bookstore|3306"))
+			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_CONFIGURED_PROTOCOL))
+			Expect(remoteCluster.Http2ProtocolOptions).To(BeNil())
+		})
+	})
+
+	Context("Test getLocalServiceCluster", func() {
+		It("Returns a static cluster proxying the traffic to the target port of the local pod", func() {
+			localCluster := getLocalServiceCluster(localService, httpPort, corev1.IPv4Protocol)
+			Expect(localCluster.Name).To(Equal(envoy.GetLocalClusterNameForPort(localService, httpPort.TargetPort)))
+			Expect(localCluster.Name).To(Equal("default/bookbuyer|8080-local"))
+			Expect(localCluster.GetType()).To(Equal(xds_cluster.Cluster_STATIC))
+			Expect(localCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
+			Expect(len(localCluster.LoadAssignment.Endpoints)).To(Equal(1))
+			Expect(len(localCluster.LoadAssignment.Endpoints[0].LbEndpoints)).To(Equal(1))
+			Expect(localCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, httpPort.TargetPort)))
+		})
+
+		It("Returns a static cluster proxying the TCP traffic to the target port of the local pod", func() {
+			localCluster := getLocalServiceCluster(localService, tcpPort, corev1.IPv4Protocol)
+			Expect(localCluster.Name).To(Equal("default/bookbuyer|3306-local"))
+			Expect(localCluster.Http2ProtocolOptions).To(BeNil())
+		})
+
+		It("Returns a static cluster proxying the traffic to the IPv6 address of the local IPv6 pod", func() {
+			localCluster := getLocalServiceCluster(localService, httpPort, corev1.IPv6Protocol)
+			Expect(localCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address).To(Equal(envoy.GetAddress(constants.WildcardIPv6Addr, httpPort.TargetPort)))
 		})
 	})
 })
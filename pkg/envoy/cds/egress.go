@@ -0,0 +1,93 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+
+	"github.com/golang/protobuf/ptypes"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getEgressClusters returns the clusters proxying the egress traffic allowed by the given egress traffic policies.
+// The traffic sent to a host is proxied to the addresses this host resolves to, so that spoofing the SNI or Host header
+// of the traffic does not allow reaching another destination. The traffic sent to wildcard hosts and IP ranges is proxied
+// to its original destination. The hosts resolve to the addresses of the given IP families, the families of the pod of the proxy.
+func getEgressClusters(egressPolicies []trafficpolicy.EgressTrafficPolicy, ipFamilies []corev1.IPFamily) []*xds_cluster.Cluster {
+	var clusters []*xds_cluster.Cluster
+	clusterNames := make(map[string]interface{})
+
+	for _, policy := range egressPolicies {
+		var hosts []string
+		hosts = append(hosts, policy.HTTPHosts...)
+		hosts = append(hosts, policy.TLSHosts...)
+
+		for _, host := range hosts {
+			clusterName := envoy.GetEgressClusterNameForHost(host, policy.Port)
+			if _, found := clusterNames[clusterName]; found {
+				continue
+			}
+			clusterNames[clusterName] = nil
+
+			if clusterName == envoy.GetEgressClusterNameForPort(policy.Port) {
+				clusters = append(clusters, getEgressOriginalDestinationCluster(policy.Port))
+			} else {
+				clusters = append(clusters, getEgressHostCluster(clusterName, host, policy.Port, envoy.GetDNSLookupFamily(ipFamilies)))
+			}
+		}
+
+		if len(policy.IPRanges) > 0 {
+			clusterName := envoy.GetEgressClusterNameForPort(policy.Port)
+			if _, found := clusterNames[clusterName]; !found {
+				clusterNames[clusterName] = nil
+				clusters = append(clusters, getEgressOriginalDestinationCluster(policy.Port))
+			}
+		}
+	}
+
+	return clusters
+}
+
+// getEgressHostCluster returns an Envoy cluster proxying the egress traffic to the addresses of the given DNS lookup family the given host resolves to
+func getEgressHostCluster(clusterName string, host string, port uint32, dnsLookupFamily xds_cluster.Cluster_DnsLookupFamily) *xds_cluster.Cluster {
+	return &xds_cluster.Cluster{
+		Name:           clusterName,
+		AltStatName:    clusterName,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_STRICT_DNS,
+		},
+		DnsLookupFamily: dnsLookupFamily,
+		LbPolicy:        xds_cluster.Cluster_ROUND_ROBIN,
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(host, port),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// getEgressOriginalDestinationCluster returns an Envoy cluster proxying the egress traffic allowed on the given port to its original destination
+func getEgressOriginalDestinationCluster(port uint32) *xds_cluster.Cluster {
+	clusterName := envoy.GetEgressClusterNameForPort(port)
+	return &xds_cluster.Cluster{
+		Name:           clusterName,
+		AltStatName:    clusterName,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_ORIGINAL_DST,
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+}
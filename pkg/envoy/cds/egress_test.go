@@ -0,0 +1,47 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Egress clusters", func() {
+	Context("Test getEgressClusters", func() {
+		It("Returns a cluster per host and an original destination cluster per port", func() {
+			clusters := getEgressClusters([]trafficpolicy.EgressTrafficPolicy{
+				{
+					Port:      80,
+					HTTPHosts: []string{"httpbin.org"},
+				},
+				{
+					Port:     443,
+					TLSHosts: []string{"*.github.com", "httpbin.org"},
+					IPRanges: []string{"10.10.0.0/16"},
+				},
+			}, []corev1.IPFamily{corev1.IPv4Protocol})
+
+			clusterTypes := make(map[string]xds_cluster.Cluster_DiscoveryType)
+			for _, cluster := range clusters {
+				clusterTypes[cluster.Name] = cluster.GetType()
+			}
+			Expect(clusterTypes).To(Equal(map[string]xds_cluster.Cluster_DiscoveryType{
+				"egress/httpbin.org|80":  xds_cluster.Cluster_STRICT_DNS,
+				"egress/httpbin.org|443": xds_cluster.Cluster_STRICT_DNS,
+				"egress|443":             xds_cluster.Cluster_ORIGINAL_DST,
+			}))
+		})
+
+		It("Resolves the hosts of the egress traffic", func() {
+			cluster := getEgressHostCluster("egress/httpbin.org|443", "httpbin.org", 443, xds_cluster.Cluster_V6_ONLY)
+			address := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+			Expect(address.Address).To(Equal("httpbin.org"))
+			Expect(address.GetPortValue()).To(Equal(uint32(443)))
+			Expect(cluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V6_ONLY))
+		})
+	})
+})
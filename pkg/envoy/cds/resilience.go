@@ -0,0 +1,132 @@
+package cds
+
+import (
+	"fmt"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// defaultHealthCheckInterval is the time between two health checks of an endpoint when the ResiliencePolicy does not set it
+	defaultHealthCheckInterval = 10 * time.Second
+
+	// defaultHealthCheckTimeout is the time to wait for a health check response when the ResiliencePolicy does not set it
+	defaultHealthCheckTimeout = 1 * time.Second
+
+	// defaultHealthCheckUnhealthyThreshold is the number of failed health checks after which an endpoint is unhealthy when the ResiliencePolicy does not set it
+	defaultHealthCheckUnhealthyThreshold = 3
+
+	// defaultHealthCheckHealthyThreshold is the number of successful health checks after which an endpoint is healthy when the ResiliencePolicy does not set it
+	defaultHealthCheckHealthyThreshold = 1
+)
+
+// enableResiliencePolicy configures the circuit breakers, the outlier detection and the health checks of the given remote cluster
+// of the given port with the ResiliencePolicy of the destination service, overriding the thresholds set by a Backpressure policy
+func enableResiliencePolicy(catalog catalog.MeshCataloger, remoteCluster *xds_cluster.Cluster, svc service.MeshService, port service.ServicePort) {
+	resiliencePolicy := catalog.GetSMISpec().GetResiliencePolicy(svc)
+	if resiliencePolicy == nil {
+		log.Trace().Msgf("ResiliencePolicy not found for service %s", svc)
+		return
+	}
+
+	log.Trace().Msgf("ResiliencePolicy Spec for service %s: %+v", svc, resiliencePolicy.Spec)
+	if circuitBreaker := resiliencePolicy.Spec.CircuitBreaker; circuitBreaker != nil {
+		remoteCluster.CircuitBreakers = &xds_cluster.CircuitBreakers{
+			Thresholds: makeCircuitBreakerThresholds(circuitBreaker),
+		}
+	}
+
+	if outlierDetection := resiliencePolicy.Spec.OutlierDetection; outlierDetection != nil {
+		remoteCluster.OutlierDetection = makeOutlierDetection(outlierDetection)
+	}
+
+	// The endpoints of the clusters relying on the original destination of the connections are not known, hence not health checked
+	if healthCheck := resiliencePolicy.Spec.HealthCheck; healthCheck != nil && remoteCluster.GetType() == xds_cluster.Cluster_EDS {
+		remoteCluster.HealthChecks = makeHealthChecks(healthCheck, svc, port)
+	}
+}
+
+func makeCircuitBreakerThresholds(circuitBreaker *osmPolicy.CircuitBreaker) []*xds_cluster.CircuitBreakers_Thresholds {
+	// Envoy defaults are used for the thresholds which have not been defined
+	threshold := &xds_cluster.CircuitBreakers_Thresholds{
+		MaxConnections:     getUInt32Value(circuitBreaker.MaxConnections),
+		MaxPendingRequests: getUInt32Value(circuitBreaker.MaxPendingRequests),
+		MaxRequests:        getUInt32Value(circuitBreaker.MaxRequests),
+		MaxRetries:         getUInt32Value(circuitBreaker.MaxRetries),
+	}
+
+	return []*xds_cluster.CircuitBreakers_Thresholds{
+		threshold,
+	}
+}
+
+func makeOutlierDetection(outlierDetection *osmPolicy.OutlierDetection) *xds_cluster.OutlierDetection {
+	// Envoy defaults are used for the settings which have not been defined
+	xdsOutlierDetection := &xds_cluster.OutlierDetection{
+		Consecutive_5Xx: getUInt32Value(outlierDetection.Consecutive5xx),
+	}
+	if outlierDetection.BaseEjectionTime.Duration > 0 {
+		xdsOutlierDetection.BaseEjectionTime = ptypes.DurationProto(outlierDetection.BaseEjectionTime.Duration)
+	}
+	return xdsOutlierDetection
+}
+
+func makeHealthChecks(healthCheck *osmPolicy.HealthCheck, svc service.MeshService, port service.ServicePort) []*xds_core.HealthCheck {
+	xdsHealthCheck := &xds_core.HealthCheck{
+		Interval:           ptypes.DurationProto(getDuration(healthCheck.Interval.Duration, defaultHealthCheckInterval)),
+		Timeout:            ptypes.DurationProto(getDuration(healthCheck.Timeout.Duration, defaultHealthCheckTimeout)),
+		UnhealthyThreshold: &wrappers.UInt32Value{Value: getUInt32(healthCheck.UnhealthyThreshold, defaultHealthCheckUnhealthyThreshold)},
+		HealthyThreshold:   &wrappers.UInt32Value{Value: getUInt32(healthCheck.HealthyThreshold, defaultHealthCheckHealthyThreshold)},
+	}
+
+	if healthCheck.Path != "" && port.Protocol == service.AppProtocolHTTP {
+		xdsHealthCheck.HealthChecker = &xds_core.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &xds_core.HealthCheck_HttpHealthCheck{
+				// The host must match the virtual hosts of the inbound routes of the service
+				Host: fmt.Sprintf("%s.%s", svc.Name, svc.Namespace),
+				Path: healthCheck.Path,
+			},
+		}
+	} else {
+		// The health check succeeds when the connection is established
+		xdsHealthCheck.HealthChecker = &xds_core.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: &xds_core.HealthCheck_TcpHealthCheck{},
+		}
+	}
+
+	return []*xds_core.HealthCheck{
+		xdsHealthCheck,
+	}
+}
+
+// getDuration returns the given duration, or the given default when it is not set
+func getDuration(duration, defaultDuration time.Duration) time.Duration {
+	if duration <= 0 {
+		return defaultDuration
+	}
+	return duration
+}
+
+// getUInt32 returns the given value, or the given default when it is not set
+func getUInt32(value, defaultValue uint32) uint32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getUInt32Value returns the given value wrapped, or nil when it is not set
+func getUInt32Value(value uint32) *wrappers.UInt32Value {
+	if value == 0 {
+		return nil
+	}
+	return &wrappers.UInt32Value{Value: value}
+}
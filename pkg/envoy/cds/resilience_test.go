@@ -0,0 +1,90 @@
+package cds
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var _ = Describe("ResiliencePolicy cluster settings", func() {
+	Context("Test makeCircuitBreakerThresholds", func() {
+		It("Returns the thresholds which have been defined", func() {
+			thresholds := makeCircuitBreakerThresholds(&osmPolicy.CircuitBreaker{
+				MaxConnections: 100,
+				MaxRequests:    200,
+				MaxRetries:     5,
+			})
+			Expect(len(thresholds)).To(Equal(1))
+			Expect(thresholds[0].MaxConnections).To(Equal(&wrappers.UInt32Value{Value: 100}))
+			Expect(thresholds[0].MaxPendingRequests).To(BeNil())
+			Expect(thresholds[0].MaxRequests).To(Equal(&wrappers.UInt32Value{Value: 200}))
+			Expect(thresholds[0].MaxRetries).To(Equal(&wrappers.UInt32Value{Value: 5}))
+		})
+	})
+
+	Context("Test makeOutlierDetection", func() {
+		It("Returns the outlier detection settings", func() {
+			outlierDetection := makeOutlierDetection(&osmPolicy.OutlierDetection{
+				Consecutive5xx:   5,
+				BaseEjectionTime: metav1.Duration{Duration: 30 * time.Second},
+			})
+			Expect(outlierDetection.Consecutive_5Xx).To(Equal(&wrappers.UInt32Value{Value: 5}))
+			Expect(outlierDetection.BaseEjectionTime).To(Equal(ptypes.DurationProto(30 * time.Second)))
+		})
+
+		It("Uses Envoy defaults for the settings which have not been defined", func() {
+			outlierDetection := makeOutlierDetection(&osmPolicy.OutlierDetection{})
+			Expect(outlierDetection.Consecutive_5Xx).To(BeNil())
+			Expect(outlierDetection.BaseEjectionTime).To(BeNil())
+		})
+	})
+
+	Context("Test makeHealthChecks", func() {
+		svc := service.MeshService{Namespace: "bookstore", Name: "bookstore"}
+
+		It("Returns an HTTP health check for an HTTP port when the path is set", func() {
+			healthChecks := makeHealthChecks(&osmPolicy.HealthCheck{
+				Path:               "/healthz",
+				Interval:           metav1.Duration{Duration: 5 * time.Second},
+				Timeout:            metav1.Duration{Duration: 2 * time.Second},
+				UnhealthyThreshold: 2,
+				HealthyThreshold:   2,
+			}, svc, service.ServicePort{Port: 8080, TargetPort: 80, Protocol: service.AppProtocolHTTP})
+			Expect(len(healthChecks)).To(Equal(1))
+			Expect(healthChecks[0].Interval).To(Equal(ptypes.DurationProto(5 * time.Second)))
+			Expect(healthChecks[0].Timeout).To(Equal(ptypes.DurationProto(2 * time.Second)))
+			Expect(healthChecks[0].UnhealthyThreshold).To(Equal(&wrappers.UInt32Value{Value: 2}))
+			Expect(healthChecks[0].HealthyThreshold).To(Equal(&wrappers.UInt32Value{Value: 2}))
+			Expect(healthChecks[0].GetHttpHealthCheck()).ToNot(BeNil())
+			Expect(healthChecks[0].GetHttpHealthCheck().Host).To(Equal("bookstore.bookstore"))
+			Expect(healthChecks[0].GetHttpHealthCheck().Path).To(Equal("/healthz"))
+		})
+
+		It("Returns a TCP health check for a TCP port", func() {
+			healthChecks := makeHealthChecks(&osmPolicy.HealthCheck{
+				Path: "/healthz",
+			}, svc, service.ServicePort{Port: 5432, TargetPort: 5432, Protocol: service.AppProtocolTCP})
+			Expect(len(healthChecks)).To(Equal(1))
+			Expect(healthChecks[0].GetHttpHealthCheck()).To(BeNil())
+			Expect(healthChecks[0].GetTcpHealthCheck()).ToNot(BeNil())
+		})
+
+		It("Uses the defaults for the settings which have not been defined", func() {
+			healthChecks := makeHealthChecks(&osmPolicy.HealthCheck{}, svc, service.ServicePort{Port: 8080, TargetPort: 80, Protocol: service.AppProtocolHTTP})
+			Expect(len(healthChecks)).To(Equal(1))
+			Expect(healthChecks[0].Interval).To(Equal(ptypes.DurationProto(defaultHealthCheckInterval)))
+			Expect(healthChecks[0].Timeout).To(Equal(ptypes.DurationProto(defaultHealthCheckTimeout)))
+			Expect(healthChecks[0].UnhealthyThreshold).To(Equal(&wrappers.UInt32Value{Value: defaultHealthCheckUnhealthyThreshold}))
+			Expect(healthChecks[0].HealthyThreshold).To(Equal(&wrappers.UInt32Value{Value: defaultHealthCheckHealthyThreshold}))
+			Expect(healthChecks[0].GetTcpHealthCheck()).ToNot(BeNil())
+		})
+	})
+})
@@ -11,7 +11,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/featureflags"
-	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // NewResponse creates a new Cluster Discovery Response.
@@ -24,6 +23,9 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	// The clusters reach the addresses of the IP families of the pod of the proxy only
+	ipFamilies := proxy.GetIPFamilies()
+
 	resp := &xds_discovery.DiscoveryResponse{
 		TypeUrl: string(envoy.TypeCDS),
 	}
@@ -36,37 +38,71 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 		return nil, err
 	}
 
-	// Build remote clusters based on allowed outbound services
+	// Build remote clusters based on allowed outbound services, one per port of the services
 	for _, dstService := range outboundServices {
-		if _, found := clusterFactories[dstService.String()]; found {
-			// Guard against duplicates
+		ports, err := catalog.ListServicePorts(dstService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of service %s for proxy %s", dstService, proxyServiceName)
 			continue
 		}
 
-		remoteCluster, err := getRemoteServiceCluster(dstService, proxyServiceName, cfg)
-		if err != nil {
-			log.Error().Err(err).Msgf("Failed to construct service cluster for proxy %s", proxyServiceName)
-			return nil, err
-		}
+		for _, port := range ports {
+			remoteCluster, err := getRemoteServiceCluster(dstService, proxyServiceName, port, cfg)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to construct service cluster for proxy %s", proxyServiceName)
+				return nil, err
+			}
 
-		if featureflags.IsBackpressureEnabled() {
-			enableBackpressure(catalog, remoteCluster, dstService)
-		}
+			if featureflags.IsBackpressureEnabled() {
+				enableBackpressure(catalog, remoteCluster, dstService)
+			}
+
+			if featureflags.IsResiliencePolicyEnabled() {
+				enableResiliencePolicy(catalog, remoteCluster, dstService, port)
+			}
 
-		clusterFactories[remoteCluster.Name] = remoteCluster
+			clusterFactories[remoteCluster.Name] = remoteCluster
+		}
 	}
 
-	// Create a local cluster for the service.
-	// The local cluster will be used for incoming traffic.
-	localClusterName := getLocalClusterName(proxyServiceName)
-	localCluster, err := getLocalServiceCluster(catalog, proxyServiceName, localClusterName)
+	// Create a local cluster for each port of the service.
+	// The local clusters will be used for incoming traffic.
+	localPorts, err := catalog.ListServicePorts(proxyServiceName)
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to get local cluster config for proxy %s", proxyServiceName)
+		log.Error().Err(err).Msgf("Failed to list ports of service %s", proxyServiceName)
 		return nil, err
 	}
-	clusterFactories[localCluster.Name] = localCluster
+	for _, port := range localPorts {
+		localCluster := getLocalServiceCluster(proxyServiceName, port, ipFamilies[0])
+		clusterFactories[localCluster.Name] = localCluster
+	}
+
+	// The multicluster gateway proxies the traffic from remote clusters to each port of the exported services
+	if catalog.IsMulticlusterGateway(proxyServiceName) {
+		for _, exportedService := range catalog.ListExportedServices() {
+			ports, err := catalog.ListServicePorts(exportedService)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error listing ports of exported service %s for multicluster gateway %s", exportedService, proxyServiceName)
+				continue
+			}
+			for _, port := range ports {
+				gatewayCluster := getMulticlusterGatewayCluster(exportedService, port)
+				clusterFactories[gatewayCluster.Name] = gatewayCluster
+			}
+		}
+	}
 
-	if cfg.IsEgressEnabled() {
+	if featureflags.IsEgressPolicyEnabled() {
+		// Egress policies replace the pass-through cluster with a cluster per allowed destination
+		serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+			return nil, err
+		}
+		for _, egressCluster := range getEgressClusters(catalog.ListEgressTrafficPolicies(serviceAccount), ipFamilies) {
+			clusterFactories[egressCluster.Name] = egressCluster
+		}
+	} else if cfg.IsEgressEnabled() {
 		// Add a pass-through cluster for egress
 		passthroughCluster := getOutboundPassthroughCluster()
 		clusterFactories[passthroughCluster.Name] = passthroughCluster
@@ -93,7 +129,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	}
 
 	if cfg.IsTracingEnabled() {
-		tracingCluster := getTracingCluster(cfg)
+		tracingCluster := getTracingCluster(cfg, ipFamilies)
 		marshalledCluster, err := ptypes.MarshalAny(&tracingCluster)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling tracing cluster for proxy with CN=%s", proxy.GetCommonName())
@@ -105,6 +141,20 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	return resp, nil
 }
 
-func getLocalClusterName(proxyServiceName service.MeshService) string {
-	return fmt.Sprintf("%s%s", proxyServiceName, envoy.LocalClusterSuffix)
+// GetSnapshotVariant returns the inputs specific to the given proxy, other than its service, which the clusters depend on:
+// the clusters reach the addresses of the IP families of the pod of the proxy, and the egress policies allow the egress traffic
+// of the service account of the proxy, not of its service. The proxies of a service share the snapshot of the clusters
+// only when they share these inputs.
+func GetSnapshotVariant(catalog catalog.MeshCataloger, proxy *envoy.Proxy) (string, error) {
+	variant := envoy.GetIPFamiliesSnapshotVariant(proxy)
+	if !featureflags.IsEgressPolicyEnabled() {
+		return variant, nil
+	}
+
+	serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+		return "", err
+	}
+	return fmt.Sprintf("%s;serviceAccount=%s", variant, serviceAccount), nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -36,7 +38,7 @@ var _ = Describe("CDS Response", func() {
 	proxyServiceName := tests.BookbuyerServiceName
 	proxyServiceAccountName := tests.BookbuyerServiceAccountName
 	proxyService := tests.BookbuyerService
-	proxyServicePort := tests.ServicePort
+	proxyServicePort := service.ServicePort{Name: "servicePort", Port: tests.ServicePort, TargetPort: tests.ServicePort, Protocol: service.AppProtocolHTTP}
 
 	Context("Test cds.NewResponse", func() {
 		It("Returns unique list of clusters for CDS", func() {
@@ -84,11 +86,11 @@ var _ = Describe("CDS Response", func() {
 
 	Context("Test cds clusters", func() {
 		It("Returns a local cluster object", func() {
-			localCluster, err := getLocalServiceCluster(catalog, proxyService, getLocalClusterName(proxyService))
-			Expect(err).ToNot(HaveOccurred())
+			localCluster := getLocalServiceCluster(proxyService, proxyServicePort, corev1.IPv4Protocol)
+			localClusterName := envoy.GetLocalClusterNameForPort(proxyService, proxyServicePort.TargetPort)
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
-				ClusterName: getLocalClusterName(proxyService),
+				ClusterName: localClusterName,
 				Endpoints: []*xds_endpoint.LocalityLbEndpoints{
 					{
 						Locality: nil,
@@ -101,7 +103,7 @@ var _ = Describe("CDS Response", func() {
 												Protocol: xds_core.SocketAddress_TCP,
 												Address:  constants.WildcardIPAddr,
 												PortSpecifier: &xds_core.SocketAddress_PortValue{
-													PortValue: uint32(proxyServicePort),
+													PortValue: proxyServicePort.TargetPort,
 												},
 											},
 										},
@@ -118,8 +120,8 @@ var _ = Describe("CDS Response", func() {
 
 			expectedCluster := xds_cluster.Cluster{
 				TransportSocketMatches: nil,
-				Name:                   getLocalClusterName(proxyService),
-				AltStatName:            getLocalClusterName(proxyService),
+				Name:                   localClusterName,
+				AltStatName:            localClusterName,
 				ClusterDiscoveryType:   &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_STATIC},
 				EdsClusterConfig:       nil,
 				ConnectTimeout:         ptypes.DurationProto(1 * time.Second),
@@ -136,7 +138,7 @@ var _ = Describe("CDS Response", func() {
 		It("Returns a remote cluster object", func() {
 			localService := tests.BookbuyerService
 			remoteService := tests.BookstoreService
-			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, cfg)
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, proxyServicePort, cfg)
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
@@ -175,7 +177,7 @@ var _ = Describe("CDS Response", func() {
 
 			expectedCluster := xds_cluster.Cluster{
 				TransportSocketMatches: nil,
-				Name:                   "default/bookstore",
+				Name:                   "default/bookstore|8888",
 				AltStatName:            "",
 				ClusterDiscoveryType:   &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS},
 				EdsClusterConfig: &xds_cluster.Cluster_EdsClusterConfig{
@@ -200,6 +202,7 @@ var _ = Describe("CDS Response", func() {
 				LoadAssignment: expectedClusterLoadAssignment,
 			}
 
+			Expect(remoteCluster.Name).To(Equal(expectedCluster.Name))
 			Expect(remoteCluster.ClusterDiscoveryType).To(Equal(expectedCluster.ClusterDiscoveryType))
 			Expect(remoteCluster.EdsClusterConfig).To(Equal(expectedCluster.EdsClusterConfig))
 			Expect(remoteCluster.ConnectTimeout).To(Equal(expectedCluster.ConnectTimeout))
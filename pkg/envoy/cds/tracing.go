@@ -4,13 +4,15 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/golang/protobuf/ptypes"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 )
 
-func getTracingCluster(cfg configurator.Configurator) xds_cluster.Cluster {
+// getTracingCluster returns the Envoy Cluster the traces are sent with, resolving the tracing host to the addresses of the given IP families
+func getTracingCluster(cfg configurator.Configurator, ipFamilies []corev1.IPFamily) xds_cluster.Cluster {
 	return xds_cluster.Cluster{
 		Name:           constants.EnvoyTracingCluster,
 		AltStatName:    constants.EnvoyTracingCluster,
@@ -18,7 +20,8 @@ func getTracingCluster(cfg configurator.Configurator) xds_cluster.Cluster {
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
 			Type: xds_cluster.Cluster_LOGICAL_DNS,
 		},
-		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
+		DnsLookupFamily: envoy.GetDNSLookupFamily(ipFamilies),
+		LbPolicy:        xds_cluster.Cluster_ROUND_ROBIN,
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 			ClusterName: constants.EnvoyTracingCluster,
 			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
@@ -1,8 +1,11 @@
 package cds
 
 import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -12,10 +15,17 @@ var _ = Describe("Test CDS Tracing Configuration", func() {
 	Context("Test getTracingCluster()", func() {
 		It("Returns Tracing cluster config", func() {
 			cfg := configurator.NewFakeConfigurator()
-			actual := getTracingCluster(cfg)
+			actual := getTracingCluster(cfg, []corev1.IPFamily{corev1.IPv4Protocol})
 			Expect(actual.Name).To(Equal(constants.EnvoyTracingCluster))
 			Expect(actual.AltStatName).To(Equal(constants.EnvoyTracingCluster))
+			Expect(actual.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V4_ONLY))
 			Expect(len(actual.GetLoadAssignment().GetEndpoints())).To(Equal(1))
 		})
+
+		It("Resolves the tracing host to both IP families for a dual-stack pod", func() {
+			cfg := configurator.NewFakeConfigurator()
+			actual := getTracingCluster(cfg, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol})
+			Expect(actual.DnsLookupFamily).To(Equal(xds_cluster.Cluster_AUTO))
+		})
 	})
 })
@@ -1,6 +1,8 @@
 package cla
 
 import (
+	"sort"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 
@@ -12,21 +14,25 @@ import (
 )
 
 const (
-	zone = "zone"
+	// sameZonePriority is the priority of the endpoints in the zone of the proxy
+	sameZonePriority = iota
+
+	// sameRegionPriority is the priority of the endpoints in another zone of the region of the proxy
+	sameRegionPriority
+
+	// otherRegionPriority is the priority of the endpoints in another region, or with an unknown locality
+	otherRegionPriority
 )
 
-// NewClusterLoadAssignment constructs the Envoy struct necessary for TrafficSplit implementation.
-func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+// NewClusterLoadAssignment constructs the Envoy struct necessary for TrafficSplit implementation, for the cluster of the given port of the service.
+// The endpoints are grouped by locality, with priorities favoring the endpoints closest to the given locality of the proxy:
+// Envoy sends the traffic to the endpoints of the zone of the proxy, and fails over to the other zones of its region,
+// then to the other regions, as the endpoints of the closer localities become unhealthy.
+// The endpoints with topology aware hints are preferred by the proxies of the zones they are hinted for only.
+func NewClusterLoadAssignment(serviceName service.MeshService, port service.ServicePort, serviceEndpoints []endpoint.Endpoint, proxyLocality endpoint.Locality) *xds_endpoint.ClusterLoadAssignment {
 	cla := &xds_endpoint.ClusterLoadAssignment{
-		ClusterName: serviceName.String(),
-		Endpoints: []*xds_endpoint.LocalityLbEndpoints{
-			{
-				Locality: &xds_core.Locality{
-					Zone: zone,
-				},
-				LbEndpoints: []*xds_endpoint.LbEndpoint{},
-			},
-		},
+		ClusterName: envoy.GetClusterNameForPort(serviceName.String(), port.Port),
+		Endpoints:   []*xds_endpoint.LocalityLbEndpoints{},
 	}
 
 	lenIPs := len(serviceEndpoints)
@@ -35,20 +41,103 @@ func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints
 	}
 	weight := uint32(100 / lenIPs)
 
+	// The endpoints of a locality hinted for different zones may have different priorities
+	type localityPriority struct {
+		locality endpoint.Locality
+		priority uint32
+	}
+	localityEndpoints := make(map[localityPriority]*xds_endpoint.LocalityLbEndpoints)
 	for _, meshEndpoint := range serviceEndpoints {
-		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding Endpoint: Cluster=%s, Services=%s, Endpoint=%+v, Weight=%d", serviceName.String(), serviceName.String(), meshEndpoint, weight)
+		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding Endpoint: Cluster=%s, Services=%s, Endpoint=%+v, Weight=%d", cla.ClusterName, serviceName.String(), meshEndpoint, weight)
 		lbEpt := xds_endpoint.LbEndpoint{
 			HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 				Endpoint: &xds_endpoint.Endpoint{
 					Address: envoy.GetAddress(meshEndpoint.IP.String(), uint32(meshEndpoint.Port)),
 				},
 			},
+			HealthStatus: getHealthStatus(meshEndpoint.Health),
 			LoadBalancingWeight: &wrappers.UInt32Value{
 				Value: weight,
 			},
 		}
-		cla.Endpoints[0].LbEndpoints = append(cla.Endpoints[0].LbEndpoints, &lbEpt)
+
+		key := localityPriority{locality: meshEndpoint.Locality, priority: getPriority(meshEndpoint, proxyLocality)}
+		locality, ok := localityEndpoints[key]
+		if !ok {
+			locality = &xds_endpoint.LocalityLbEndpoints{
+				Locality: &xds_core.Locality{
+					Region: meshEndpoint.Locality.Region,
+					Zone:   meshEndpoint.Locality.Zone,
+				},
+				LbEndpoints: []*xds_endpoint.LbEndpoint{},
+				Priority:    key.priority,
+			}
+			localityEndpoints[key] = locality
+			cla.Endpoints = append(cla.Endpoints, locality)
+		}
+		locality.LbEndpoints = append(locality.LbEndpoints, &lbEpt)
 	}
+
+	// The order is stable, so the config generated does not change while the endpoints do not change
+	sort.SliceStable(cla.Endpoints, func(i, j int) bool {
+		if cla.Endpoints[i].Priority != cla.Endpoints[j].Priority {
+			return cla.Endpoints[i].Priority < cla.Endpoints[j].Priority
+		}
+		if cla.Endpoints[i].Locality.Region != cla.Endpoints[j].Locality.Region {
+			return cla.Endpoints[i].Locality.Region < cla.Endpoints[j].Locality.Region
+		}
+		return cla.Endpoints[i].Locality.Zone < cla.Endpoints[j].Locality.Zone
+	})
+	compactPriorities(cla.Endpoints)
+
 	log.Debug().Msgf("[EDS] Constructed ClusterLoadAssignment: %+v", cla)
 	return cla
 }
+
+// getHealthStatus returns the Envoy health status of an endpoint with the given health status.
+// Envoy does not send traffic to the unhealthy endpoints, nor new traffic to the draining endpoints.
+func getHealthStatus(health endpoint.HealthStatus) xds_core.HealthStatus {
+	switch health {
+	case endpoint.Unhealthy:
+		return xds_core.HealthStatus_UNHEALTHY
+	case endpoint.Draining:
+		return xds_core.HealthStatus_DRAINING
+	default:
+		return xds_core.HealthStatus_HEALTHY
+	}
+}
+
+// getPriority returns the priority of the given endpoint for a proxy in the given locality.
+// All the endpoints have the same priority when the locality of the proxy is unknown.
+// An endpoint with topology aware hints is in the zone of the proxies of the zones it is hinted for, and of these proxies only.
+func getPriority(ep endpoint.Endpoint, proxyLocality endpoint.Locality) uint32 {
+	if proxyLocality.IsEmpty() {
+		return sameZonePriority
+	}
+
+	sameZone := ep.Locality == proxyLocality
+	if len(ep.ZoneHints) > 0 && proxyLocality.Zone != "" {
+		sameZone = ep.IsHintedForZone(proxyLocality.Zone)
+	}
+
+	switch {
+	case sameZone:
+		return sameZonePriority
+	case ep.Locality.Region != "" && ep.Locality.Region == proxyLocality.Region:
+		return sameRegionPriority
+	default:
+		return otherRegionPriority
+	}
+}
+
+// compactPriorities renumbers the priorities of the given localities, sorted by priority, from 0 without gaps as Envoy requires
+func compactPriorities(localities []*xds_endpoint.LocalityLbEndpoints) {
+	var priority, previousPriority uint32
+	for i, locality := range localities {
+		if i > 0 && locality.Priority != previousPriority {
+			priority++
+		}
+		previousPriority = locality.Priority
+		locality.Priority = priority
+	}
+}
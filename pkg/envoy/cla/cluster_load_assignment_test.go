@@ -1,8 +1,12 @@
 package cla
 
 import (
+	"fmt"
 	"net"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/service"
 
@@ -29,19 +33,114 @@ var _ = Describe("Testing Cluster Load Assignment", func() {
 				},
 			}
 
-			cla := NewClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]])
+			port := service.ServicePort{Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+
+			cla := NewClusterLoadAssignment(namespacedServices[0], port, allServiceEndpoints[namespacedServices[0]], endpoint.Locality{})
 			Expect(cla).NotTo(Equal(nil))
-			Expect(cla.ClusterName).To(Equal("osm/bookstore-1"))
+			Expect(cla.ClusterName).To(Equal("osm/bookstore-1|80"))
 			Expect(len(cla.Endpoints)).To(Equal(1))
 			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(1))
 			Expect(cla.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(100)))
-			cla2 := NewClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]])
+			cla2 := NewClusterLoadAssignment(namespacedServices[1], port, allServiceEndpoints[namespacedServices[1]], endpoint.Locality{})
 			Expect(cla2).NotTo(Equal(nil))
-			Expect(cla2.ClusterName).To(Equal("osm/bookstore-2"))
+			Expect(cla2.ClusterName).To(Equal("osm/bookstore-2|80"))
 			Expect(len(cla2.Endpoints)).To(Equal(1))
 			Expect(len(cla2.Endpoints[0].LbEndpoints)).To(Equal(2))
 			Expect(cla2.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 			Expect(cla2.Endpoints[0].LbEndpoints[1].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 		})
 	})
+	Context("Testing the localities of NewClusterLoadAssignment", func() {
+		svc := service.MeshService{Namespace: "osm", Name: "bookstore"}
+		port := service.ServicePort{Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+		zoneA := endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"}
+		zoneB := endpoint.Locality{Region: "us-east-1", Zone: "us-east-1b"}
+		zoneC := endpoint.Locality{Region: "us-west-1", Zone: "us-west-1a"}
+		endpoints := []endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080, Locality: zoneC},
+			{IP: net.ParseIP("10.0.0.2"), Port: 8080, Locality: zoneB},
+			{IP: net.ParseIP("10.0.0.3"), Port: 8080, Locality: zoneA},
+			{IP: net.ParseIP("10.0.0.4"), Port: 8080, Locality: zoneA},
+			{IP: net.ParseIP("10.0.0.5"), Port: 8080},
+		}
+
+		getLocalities := func(cla *xds_endpoint.ClusterLoadAssignment) []string {
+			var localities []string
+			for _, locality := range cla.Endpoints {
+				localities = append(localities, fmt.Sprintf("%d:%s/%s:%d", locality.Priority, locality.Locality.Region, locality.Locality.Zone, len(locality.LbEndpoints)))
+			}
+			return localities
+		}
+
+		It("prefers the endpoints of the zone, then of the region of the proxy", func() {
+			cla := NewClusterLoadAssignment(svc, port, endpoints, zoneA)
+			Expect(getLocalities(cla)).To(Equal([]string{
+				"0:us-east-1/us-east-1a:2",
+				"1:us-east-1/us-east-1b:1",
+				"2:/:1",
+				"2:us-west-1/us-west-1a:1",
+			}))
+		})
+
+		It("does not leave gaps between the priorities", func() {
+			cla := NewClusterLoadAssignment(svc, port, endpoints[:2], zoneA)
+			Expect(getLocalities(cla)).To(Equal([]string{
+				"0:us-east-1/us-east-1b:1",
+				"1:us-west-1/us-west-1a:1",
+			}))
+		})
+
+		It("prefers all the endpoints equally when the locality of the proxy is unknown", func() {
+			cla := NewClusterLoadAssignment(svc, port, endpoints, endpoint.Locality{})
+			Expect(getLocalities(cla)).To(Equal([]string{
+				"0:/:1",
+				"0:us-east-1/us-east-1a:2",
+				"0:us-east-1/us-east-1b:1",
+				"0:us-west-1/us-west-1a:1",
+			}))
+		})
+	})
+
+	Context("Testing the topology aware hints of NewClusterLoadAssignment", func() {
+		svc := service.MeshService{Namespace: "osm", Name: "bookstore"}
+		port := service.ServicePort{Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+		zoneA := endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"}
+		zoneB := endpoint.Locality{Region: "us-east-1", Zone: "us-east-1b"}
+		endpoints := []endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080, Locality: zoneA, ZoneHints: []string{"us-east-1a"}},
+			{IP: net.ParseIP("10.0.0.2"), Port: 8080, Locality: zoneA, ZoneHints: []string{"us-east-1b"}},
+			{IP: net.ParseIP("10.0.0.3"), Port: 8080, Locality: zoneB, ZoneHints: []string{"us-east-1b"}},
+		}
+
+		It("prefers the endpoints hinted for the zone of the proxy", func() {
+			cla := NewClusterLoadAssignment(svc, port, endpoints, zoneB)
+			Expect(len(cla.Endpoints)).To(Equal(3))
+			Expect(cla.Endpoints[0].Priority).To(Equal(uint32(0)))
+			Expect(cla.Endpoints[0].Locality.Zone).To(Equal("us-east-1a"))
+			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(1))
+			Expect(cla.Endpoints[1].Priority).To(Equal(uint32(0)))
+			Expect(cla.Endpoints[1].Locality.Zone).To(Equal("us-east-1b"))
+			Expect(cla.Endpoints[2].Priority).To(Equal(uint32(1)))
+			Expect(cla.Endpoints[2].Locality.Zone).To(Equal("us-east-1a"))
+		})
+	})
+
+	Context("Testing the health status of the endpoints of NewClusterLoadAssignment", func() {
+		It("sets the health status of each endpoint", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore"}
+			port := service.ServicePort{Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 8080, Health: endpoint.Healthy},
+				{IP: net.ParseIP("10.0.0.2"), Port: 8080, Health: endpoint.Unhealthy},
+				{IP: net.ParseIP("10.0.0.3"), Port: 8080, Health: endpoint.Draining},
+			}
+
+			cla := NewClusterLoadAssignment(svc, port, endpoints, endpoint.Locality{})
+			Expect(len(cla.Endpoints)).To(Equal(1))
+			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(3))
+			Expect(cla.Endpoints[0].LbEndpoints[0].HealthStatus).To(Equal(xds_core.HealthStatus_HEALTHY))
+			Expect(cla.Endpoints[0].LbEndpoints[1].HealthStatus).To(Equal(xds_core.HealthStatus_UNHEALTHY))
+			Expect(cla.Endpoints[0].LbEndpoints[2].HealthStatus).To(Equal(xds_core.HealthStatus_DRAINING))
+		})
+	})
 })
@@ -1,6 +1,8 @@
 package eds
 
 import (
+	"fmt"
+
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"github.com/golang/protobuf/ptypes"
@@ -24,37 +26,75 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	// The endpoints closest to the proxy are preferred; all the endpoints are equally preferred when its locality is unknown
+	proxyLocality := proxy.GetLocality()
+
 	allTrafficPolicies, err := catalog.ListTrafficPolicies(proxyServiceName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Failed to list traffic policies for proxy service %q", proxyServiceName)
 		return nil, err
 	}
 
-	allServicesEndpoints := make(map[service.MeshService][]endpoint.Endpoint)
+	allTCPTrafficPolicies, err := catalog.ListTCPTrafficPolicies(proxyServiceName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to list TCP traffic policies for proxy service %q", proxyServiceName)
+		return nil, err
+	}
+
+	var destServices []service.MeshService
 	for _, trafficPolicy := range allTrafficPolicies {
-		isSourceService := trafficPolicy.Source.Equals(proxyServiceName)
-		if isSourceService {
-			destService := trafficPolicy.Destination
-			serviceEndpoints, err := catalog.ListEndpointsForService(destService)
-			if err != nil {
-				log.Error().Err(err).Msgf("Failed listing endpoints for proxy %s", proxyServiceName)
-				return nil, err
-			}
-			allServicesEndpoints[destService] = serviceEndpoints
+		if trafficPolicy.Source.Equals(proxyServiceName) {
+			destServices = append(destServices, trafficPolicy.Destination)
+		}
+	}
+	for _, trafficPolicy := range allTCPTrafficPolicies {
+		if trafficPolicy.Source.Equals(proxyServiceName) {
+			destServices = append(destServices, trafficPolicy.Destination)
 		}
 	}
 
+	allServicesEndpoints := make(map[service.MeshService][]endpoint.Endpoint)
+	for _, destService := range destServices {
+		if _, ok := allServicesEndpoints[destService]; ok {
+			continue
+		}
+		serviceEndpoints, err := catalog.ListEndpointsForService(destService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed listing endpoints for proxy %s", proxyServiceName)
+			return nil, err
+		}
+		allServicesEndpoints[destService] = serviceEndpoints
+	}
+
 	log.Debug().Msgf("Computed endpoints for proxy %s: %+v", proxyServiceName, allServicesEndpoints)
 	var protos []*any.Any
 	for serviceName, serviceEndpoints := range allServicesEndpoints {
-		loadAssignment := cla.NewClusterLoadAssignment(serviceName, serviceEndpoints)
-
-		proto, err := ptypes.MarshalAny(loadAssignment)
+		ports, err := catalog.ListServicePorts(serviceName)
 		if err != nil {
-			log.Error().Err(err).Msgf("Error marshalling EDS payload for proxy %s: %+v", proxyServiceName, loadAssignment)
+			log.Error().Err(err).Msgf("Error listing ports of service %s for proxy %s", serviceName, proxyServiceName)
 			continue
 		}
-		protos = append(protos, proto)
+
+		// There is a cluster for each port of the service
+		for _, port := range ports {
+			endpoints := serviceEndpoints
+			if !serviceName.IsRemote() {
+				// The multicluster gateway of a remote cluster receives the traffic for all the ports of the services it exports
+				endpoints = getEndpointsForPort(serviceEndpoints, port, len(ports))
+			}
+			loadAssignment := cla.NewClusterLoadAssignment(serviceName, port, endpoints, proxyLocality)
+
+			proto, err := ptypes.MarshalAny(loadAssignment)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error marshalling EDS payload for proxy %s: %+v", proxyServiceName, loadAssignment)
+				continue
+			}
+			protos = append(protos, proto)
+		}
+	}
+
+	if catalog.IsMulticlusterGateway(proxyServiceName) {
+		protos = append(protos, getMulticlusterGatewayLoadAssignments(catalog, proxyServiceName, proxyLocality)...)
 	}
 
 	resp := &xds_discovery.DiscoveryResponse{
@@ -63,3 +103,59 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	}
 	return resp, nil
 }
+
+// getEndpointsForPort returns the endpoints receiving the traffic sent to the given port of a service with the given number of ports.
+// All the endpoints of a service with a single port receive its traffic, including when its target port is named, hence unresolved.
+func getEndpointsForPort(serviceEndpoints []endpoint.Endpoint, port service.ServicePort, numPorts int) []endpoint.Endpoint {
+	if numPorts == 1 {
+		return serviceEndpoints
+	}
+
+	var endpoints []endpoint.Endpoint
+	for _, ep := range serviceEndpoints {
+		if uint32(ep.Port) == port.TargetPort {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints
+}
+
+// getMulticlusterGatewayLoadAssignments returns the load assignments of the clusters the multicluster gateway proxies
+// the traffic from remote clusters with: the endpoints of each port of the exported services.
+func getMulticlusterGatewayLoadAssignments(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, proxyLocality endpoint.Locality) []*any.Any {
+	var protos []*any.Any
+	for _, exportedService := range catalog.ListExportedServices() {
+		serviceEndpoints, err := catalog.ListEndpointsForService(exportedService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed listing endpoints of exported service %s for multicluster gateway %s", exportedService, proxyServiceName)
+			continue
+		}
+		ports, err := catalog.ListServicePorts(exportedService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of exported service %s for multicluster gateway %s", exportedService, proxyServiceName)
+			continue
+		}
+
+		for _, port := range ports {
+			loadAssignment := cla.NewClusterLoadAssignment(exportedService, port, getEndpointsForPort(serviceEndpoints, port, len(ports)), proxyLocality)
+			loadAssignment.ClusterName = envoy.GetMulticlusterGatewayClusterNameForPort(exportedService, port.Port)
+
+			proto, err := ptypes.MarshalAny(loadAssignment)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error marshalling EDS payload for multicluster gateway %s: %+v", proxyServiceName, loadAssignment)
+				continue
+			}
+			protos = append(protos, proto)
+		}
+	}
+	return protos
+}
+
+// GetSnapshotVariant returns the inputs specific to the given proxy, other than its service, which the endpoints depend on:
+// the endpoints are prioritized by their distance to the locality of the proxy, and by their topology aware hints for its zone.
+// The endpoints of the proxies of an unknown locality are not prioritized. The proxies of a service share the snapshot
+// of the endpoints only when they share these inputs.
+func GetSnapshotVariant(_ catalog.MeshCataloger, proxy *envoy.Proxy) (string, error) {
+	locality := proxy.GetLocality()
+	return fmt.Sprintf("locality=%s/%s", locality.Region, locality.Zone), nil
+}
@@ -3,6 +3,7 @@ package eds
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo"
@@ -14,7 +15,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -75,4 +78,21 @@ var _ = Describe("Test EDS response", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("Test getEndpointsForPort", func() {
+		endpoints := []endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.1"), Port: 8080},
+			{IP: net.ParseIP("10.0.0.1"), Port: 9090},
+		}
+
+		It("returns all the endpoints of a service with a single port", func() {
+			port := service.ServicePort{Name: "http", Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP}
+			Expect(getEndpointsForPort(endpoints, port, 1)).To(Equal(endpoints))
+		})
+
+		It("returns the endpoints of the target port of a service with multiple ports", func() {
+			port := service.ServicePort{Name: "grpc-metrics", Port: 90, TargetPort: 9090, Protocol: service.AppProtocolGRPC}
+			Expect(getEndpointsForPort(endpoints, port, 2)).To(Equal([]endpoint.Endpoint{endpoints[1]}))
+		})
+	})
 })
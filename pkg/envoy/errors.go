@@ -5,5 +5,6 @@ import (
 )
 
 var (
-	errInvalidCertFormat = errors.New("invalid certificate string resource format")
+	errInvalidCertFormat   = errors.New("invalid certificate string resource format")
+	errUnknownResourceType = errors.New("unknown xDS resource type")
 )
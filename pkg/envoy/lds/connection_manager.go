@@ -2,27 +2,28 @@ package lds
 
 import (
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_grpc_stats "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
 	statPrefix = "http"
 )
 
-func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *xds_hcm.HttpConnectionManager {
+func getHTTPConnectionManager(routeName string, cfg configurator.Configurator, appProtocol service.AppProtocol) *xds_hcm.HttpConnectionManager {
 	connManager := &xds_hcm.HttpConnectionManager{
-		StatPrefix: statPrefix,
-		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
-		HttpFilters: []*xds_hcm.HttpFilter{{
-			Name: wellknown.Router,
-		}},
+		StatPrefix:  statPrefix,
+		CodecType:   xds_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: append(getGRPCHTTPFilters(appProtocol, cfg), &xds_hcm.HttpFilter{Name: wellknown.Router}),
 
 		RouteSpecifier: &xds_hcm.HttpConnectionManager_Rds{
 			Rds: &xds_hcm.Rds{
@@ -50,6 +51,37 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *
 	return connManager
 }
 
+// getGRPCHTTPFilters returns the HTTP filters handling the gRPC traffic carried by a port with the given application protocol
+func getGRPCHTTPFilters(appProtocol service.AppProtocol, cfg configurator.Configurator) []*xds_hcm.HttpFilter {
+	var filters []*xds_hcm.HttpFilter
+
+	if appProtocol == service.AppProtocolGRPCWeb {
+		// Translate the gRPC-Web requests to gRPC
+		filters = append(filters, &xds_hcm.HttpFilter{
+			Name: wellknown.GRPCWeb,
+		})
+	}
+
+	if appProtocol.IsGRPC() && cfg.IsGRPCStatsEnabled() {
+		grpcStats := &xds_grpc_stats.FilterConfig{
+			EmitFilterState:        true,
+			EnableUpstreamStats:    true,
+			PerMethodStatSpecifier: &xds_grpc_stats.FilterConfig_StatsForAllMethods{StatsForAllMethods: &wrappers.BoolValue{Value: true}},
+		}
+		marshalledGRPCStats, err := ptypes.MarshalAny(grpcStats)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling gRPC stats filter config")
+			return filters
+		}
+		filters = append(filters, &xds_hcm.HttpFilter{
+			Name:       wellknown.HTTPGRPCStats,
+			ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: marshalledGRPCStats},
+		})
+	}
+
+	return filters
+}
+
 func getPrometheusConnectionManager(listenerName string, routeName string, clusterName string) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: listenerName,
@@ -0,0 +1,159 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	egressHTTPStatPrefix = "egress-http"
+)
+
+// updateOutboundListenerForEgressPolicies adds to the outbound listener a filter chain for each external destination
+// allowed by the given egress traffic policies. The in-mesh filter chains match the traffic sent to the mesh CIDR ranges,
+// so the egress traffic which does not match any egress filter chain matches no filter chain at all and is denied.
+func updateOutboundListenerForEgressPolicies(outboundListener *xds_listener.Listener, egressPolicies []trafficpolicy.EgressTrafficPolicy, cfg configurator.Configurator) error {
+	if err := matchMeshCIDRRanges(outboundListener, cfg); err != nil {
+		return err
+	}
+
+	matchesSNI := false
+	for _, policy := range egressPolicies {
+		filterChains, err := getEgressFilterChains(policy)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error making egress filter chains for port %d", policy.Port)
+			return err
+		}
+		outboundListener.FilterChains = append(outboundListener.FilterChains, filterChains...)
+		matchesSNI = matchesSNI || len(policy.TLSHosts) > 0
+	}
+
+	if matchesSNI {
+		// The TlsInspector ListenerFilter detects the SNI of the TLS traffic the egress filter chains match on.
+		// Server-first protocols send no data for the filter to inspect, so their connections proceed once the filter timed out.
+		outboundListener.ListenerFilters = append(outboundListener.ListenerFilters, &xds_listener.ListenerFilter{
+			Name: wellknown.TlsInspector,
+		})
+		outboundListener.ContinueOnListenerFiltersTimeout = true
+	}
+
+	return nil
+}
+
+// getEgressFilterChains returns the filter chains proxying the egress traffic allowed by the given egress traffic policy:
+// - a filter chain matching the traffic sent to the allowed IP ranges, whatever its protocol
+// - a filter chain per allowed host matching the SNI of the TLS traffic
+// - a filter chain routing the HTTP traffic based on its Host header, which rejects the requests sent to the other hosts
+func getEgressFilterChains(policy trafficpolicy.EgressTrafficPolicy) ([]*xds_listener.FilterChain, error) {
+	var filterChains []*xds_listener.FilterChain
+
+	if len(policy.IPRanges) > 0 {
+		var prefixRanges []*xds_core.CidrRange
+		for _, ipRange := range policy.IPRanges {
+			cidrRange, err := getCIDRRange(ipRange)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error parsing CIDR: %s", ipRange)
+				return nil, err
+			}
+			prefixRanges = append(prefixRanges, cidrRange)
+		}
+
+		filterChainName := fmt.Sprintf("%s:%d:ip", outboundEgressFilterChainName, policy.Port)
+		filterChain, err := getTCPProxyFilterChain(filterChainName, envoy.GetEgressClusterNameForPort(policy.Port))
+		if err != nil {
+			return nil, err
+		}
+		filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+			DestinationPort: &wrappers.UInt32Value{Value: policy.Port},
+			PrefixRanges:    prefixRanges,
+		}
+		filterChains = append(filterChains, filterChain)
+	}
+
+	for _, host := range policy.TLSHosts {
+		filterChainName := fmt.Sprintf("%s:%d:%s", outboundEgressFilterChainName, policy.Port, host)
+		filterChain, err := getTCPProxyFilterChain(filterChainName, envoy.GetEgressClusterNameForHost(host, policy.Port))
+		if err != nil {
+			return nil, err
+		}
+		filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+			DestinationPort:   &wrappers.UInt32Value{Value: policy.Port},
+			ServerNames:       []string{host},
+			TransportProtocol: envoy.TransportProtocolTLS,
+		}
+		filterChains = append(filterChains, filterChain)
+	}
+
+	if len(policy.HTTPHosts) > 0 {
+		marshalledConnManager, err := envoy.MessageToAny(getEgressHTTPConnectionManager(policy))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for egress port %d", policy.Port)
+			return nil, err
+		}
+		filterChains = append(filterChains, &xds_listener.FilterChain{
+			Name: fmt.Sprintf("%s:%d:http", outboundEgressFilterChainName, policy.Port),
+			FilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort: &wrappers.UInt32Value{Value: policy.Port},
+			},
+			Filters: []*xds_listener.Filter{
+				{
+					Name:       wellknown.HTTPConnectionManager,
+					ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledConnManager},
+				},
+			},
+		})
+	}
+
+	return filterChains, nil
+}
+
+// getEgressHTTPConnectionManager returns an HttpConnectionManager routing the HTTP traffic sent to the allowed hosts of the
+// given egress traffic policy to their cluster. The requests sent to the other hosts match no virtual host and are rejected.
+func getEgressHTTPConnectionManager(policy trafficpolicy.EgressTrafficPolicy) *xds_hcm.HttpConnectionManager {
+	routeConfig := &xds_route.RouteConfiguration{
+		Name: fmt.Sprintf("%s:%d", outboundEgressFilterChainName, policy.Port),
+	}
+	for _, host := range policy.HTTPHosts {
+		routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, &xds_route.VirtualHost{
+			Name:    fmt.Sprintf("%s:%d", host, policy.Port),
+			Domains: []string{host, fmt.Sprintf("%s:%d", host, policy.Port)},
+			Routes: []*xds_route.Route{{
+				Match: &xds_route.RouteMatch{
+					PathSpecifier: &xds_route.RouteMatch_Prefix{
+						Prefix: "/",
+					},
+				},
+				Action: &xds_route.Route_Route{
+					Route: &xds_route.RouteAction{
+						ClusterSpecifier: &xds_route.RouteAction_Cluster{
+							Cluster: envoy.GetEgressClusterNameForHost(host, policy.Port),
+						},
+					},
+				},
+			}},
+		})
+	}
+
+	return &xds_hcm.HttpConnectionManager{
+		StatPrefix: egressHTTPStatPrefix,
+		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: []*xds_hcm.HttpFilter{{
+			Name: wellknown.Router,
+		}},
+		RouteSpecifier: &xds_hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: routeConfig,
+		},
+		AccessLog: envoy.GetAccessLog(),
+	}
+}
@@ -0,0 +1,96 @@
+package lds
+
+import (
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Egress policies", func() {
+	egressPolicies := []trafficpolicy.EgressTrafficPolicy{
+		{
+			Port:      80,
+			HTTPHosts: []string{"*.github.com", "httpbin.org"},
+		},
+		{
+			Port:     443,
+			TLSHosts: []string{"*.github.com", "httpbin.org"},
+			IPRanges: []string{"10.10.0.0/16"},
+		},
+	}
+
+	Context("Test updateOutboundListenerForEgressPolicies", func() {
+		It("Adds a filter chain per allowed destination and restricts the in-mesh filter chains to the mesh CIDR ranges", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				MeshCIDRRanges: []string{"10.0.0.0/16"},
+			})
+			outboundListener := xds_listener.Listener{
+				FilterChains: []*xds_listener.FilterChain{
+					{
+						Name: "test",
+					},
+				},
+			}
+			err := updateOutboundListenerForEgressPolicies(&outboundListener, egressPolicies, cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(outboundListener.FilterChains[0].FilterChainMatch.PrefixRanges[0].AddressPrefix).To(Equal("10.0.0.0"))
+			var filterChainNames []string
+			for _, filterChain := range outboundListener.FilterChains {
+				filterChainNames = append(filterChainNames, filterChain.Name)
+			}
+			Expect(filterChainNames).To(Equal([]string{
+				"test",
+				"outbound-egress-filter-chain:80:http",
+				"outbound-egress-filter-chain:443:ip",
+				"outbound-egress-filter-chain:443:*.github.com",
+				"outbound-egress-filter-chain:443:httpbin.org",
+			}))
+			Expect(outboundListener.ListenerFilters[0].Name).To(Equal(wellknown.TlsInspector))
+		})
+
+		It("Fails without mesh CIDR ranges", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{})
+			outboundListener := xds_listener.Listener{}
+			err := updateOutboundListenerForEgressPolicies(&outboundListener, egressPolicies, cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(outboundListener.FilterChains).To(BeEmpty())
+		})
+	})
+
+	Context("Test getEgressFilterChains", func() {
+		It("Matches the TLS traffic on its SNI and the traffic to IP ranges on its destination address", func() {
+			filterChains, err := getEgressFilterChains(egressPolicies[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(3))
+
+			Expect(filterChains[0].FilterChainMatch.DestinationPort.Value).To(Equal(uint32(443)))
+			Expect(filterChains[0].FilterChainMatch.PrefixRanges[0].AddressPrefix).To(Equal("10.10.0.0"))
+			Expect(filterChains[0].FilterChainMatch.PrefixRanges[0].PrefixLen.Value).To(Equal(uint32(16)))
+
+			Expect(filterChains[2].FilterChainMatch.ServerNames).To(Equal([]string{"httpbin.org"}))
+			Expect(filterChains[2].FilterChainMatch.TransportProtocol).To(Equal("tls"))
+		})
+
+		It("Routes the HTTP traffic to the allowed hosts only", func() {
+			filterChains, err := getEgressFilterChains(egressPolicies[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(1))
+
+			connManager := &xds_hcm.HttpConnectionManager{}
+			Expect(ptypes.UnmarshalAny(filterChains[0].Filters[0].GetTypedConfig(), connManager)).To(Succeed())
+			virtualHosts := connManager.GetRouteConfig().VirtualHosts
+			Expect(len(virtualHosts)).To(Equal(2))
+			Expect(virtualHosts[1].Domains).To(Equal([]string{"httpbin.org", "httpbin.org:80"}))
+			Expect(virtualHosts[1].Routes[0].GetRoute().GetCluster()).To(Equal("egress/httpbin.org|80"))
+			Expect(virtualHosts[0].Routes[0].GetRoute().GetCluster()).To(Equal("egress|80"))
+		})
+	})
+})
@@ -3,5 +3,7 @@ package lds
 import "github.com/pkg/errors"
 
 var (
-	errInvalidCIDRRange = errors.New("invalid CIDR range")
+	errInvalidCIDRRange      = errors.New("invalid CIDR range")
+	errServiceNotFound       = errors.New("service not found")
+	errNoIPAddressForService = errors.New("no IP address found for service")
 )
@@ -0,0 +1,71 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const gatewayTLSFilterChainPrefix = "gateway-tls"
+
+// getGatewayTLSFilterChains returns the filter chains proxying the TLS connections routed by gateways with TLSRoute
+// resources to the ports of the local pod. The ports with ingress filter chains are skipped as their filter chains
+// would match the same connections.
+func getGatewayTLSFilterChains(catalog catalog.MeshCataloger, svc service.MeshService, servicePorts []service.ServicePort, ingressPorts []service.ServicePort) []*xds_listener.FilterChain {
+	var filterChains []*xds_listener.FilterChain
+
+	skippedPorts := make(map[uint32]interface{})
+	for _, ingressPort := range ingressPorts {
+		skippedPorts[ingressPort.TargetPort] = nil
+	}
+
+	for _, servicePort := range servicePorts {
+		if _, skipped := skippedPorts[servicePort.TargetPort]; skipped {
+			continue
+		}
+
+		hostnames, err := catalog.ListGatewayTLSHostnames(svc, servicePort)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing Gateway API TLS hostnames for service %s", svc)
+			continue
+		}
+		if len(hostnames) == 0 {
+			continue
+		}
+		skippedPorts[servicePort.TargetPort] = nil
+
+		filterChainName := fmt.Sprintf("%s:%s:%d", gatewayTLSFilterChainPrefix, svc, servicePort.TargetPort)
+		filterChain, err := getTCPProxyFilterChain(filterChainName, envoy.GetLocalClusterNameForPort(svc, servicePort.TargetPort))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building Gateway API TLS filter chain for service %s", svc)
+			continue
+		}
+
+		filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+			DestinationPort:   &wrappers.UInt32Value{Value: servicePort.TargetPort},
+			TransportProtocol: envoy.TransportProtocolTLS,
+		}
+		if !isWildcardHostname(hostnames) {
+			filterChain.FilterChainMatch.ServerNames = hostnames
+		}
+		filterChains = append(filterChains, filterChain)
+	}
+
+	return filterChains
+}
+
+// isWildcardHostname returns true if the hostnames match any SNI hostname
+func isWildcardHostname(hostnames []string) bool {
+	for _, hostname := range hostnames {
+		if hostname == constants.WildcardHTTPMethod {
+			return true
+		}
+	}
+	return false
+}
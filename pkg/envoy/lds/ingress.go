@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -21,14 +22,14 @@ func getIngressTransportProtocol(cfg configurator.Configurator) string {
 	return ""
 }
 
-func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshService) *xds_listener.FilterChain {
+func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshService, servicePort service.ServicePort) *xds_listener.FilterChain {
 	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(svc, false /* TLS */))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", svc)
 		return nil
 	}
 
-	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	inboundConnManager := getHTTPConnectionManager(route.GetInboundRouteConfigNameForPort(servicePort.TargetPort), cfg, servicePort.Protocol)
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", svc)
@@ -38,6 +39,7 @@ func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshServic
 	return &xds_listener.FilterChain{
 		// Filter chain with SNI matching enabled for clients that set the SNI
 		FilterChainMatch: &xds_listener.FilterChainMatch{
+			DestinationPort:   &wrappers.UInt32Value{Value: servicePort.TargetPort},
 			TransportProtocol: getIngressTransportProtocol(cfg),
 		},
 		TransportSocket: getIngressTransportSocket(cfg, marshalledDownstreamTLSContext),
@@ -52,19 +54,31 @@ func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshServic
 	}
 }
 
-func getIngressFilterChains(svc service.MeshService, cfg configurator.Configurator) []*xds_listener.FilterChain {
+// getIngressFilterChains returns the filter chains for the ingress traffic received on the HTTP ports of the local pod
+func getIngressFilterChains(svc service.MeshService, servicePorts []service.ServicePort, cfg configurator.Configurator) []*xds_listener.FilterChain {
 	var ingressFilterChains []*xds_listener.FilterChain
 
-	if cfg.UseHTTPSIngress() {
-		// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
-		ingressFilterChainWithSNI := newIngressFilterChain(cfg, svc)
-		ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.GetCommonName().String()}
-		ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
-	}
+	targetPorts := make(map[uint32]interface{})
+	for _, servicePort := range servicePorts {
+		if !servicePort.IsHTTP() {
+			continue
+		}
+		if _, found := targetPorts[servicePort.TargetPort]; found {
+			continue
+		}
+		targetPorts[servicePort.TargetPort] = nil
 
-	// Filter chain without SNI matching enabled for HTTP clients and HTTPS clients that don't set the SNI
-	ingressFilterChainWithoutSNI := newIngressFilterChain(cfg, svc)
-	ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithoutSNI)
+		if cfg.UseHTTPSIngress() {
+			// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
+			ingressFilterChainWithSNI := newIngressFilterChain(cfg, svc, servicePort)
+			ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.GetCommonName().String()}
+			ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
+		}
+
+		// Filter chain without SNI matching enabled for HTTP clients and HTTPS clients that don't set the SNI
+		ingressFilterChainWithoutSNI := newIngressFilterChain(cfg, svc, servicePort)
+		ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithoutSNI)
+	}
 
 	return ingressFilterChains
 }
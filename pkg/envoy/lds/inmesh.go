@@ -1,33 +1,106 @@
 package lds
 
 import (
+	"fmt"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-func getInboundInMeshFilterChain(proxyServiceName service.MeshService, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
-	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(proxyServiceName, true /* mTLS */))
+const (
+	inboundMeshFilterChainName = "inbound-mesh-filter-chain"
+)
+
+// getInboundInMeshFilterChains returns a filter chain for each port of the local pod receiving in-mesh traffic.
+// The ports receiving TCP traffic allowed by TCP traffic policies proxy the TCP traffic to the local pod,
+// the HTTP ports route the HTTP traffic with the inbound route configuration of the port.
+func getInboundInMeshFilterChains(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, cfg configurator.Configurator) ([]*xds_listener.FilterChain, error) {
+	servicePorts, err := catalog.ListServicePorts(proxyServiceName)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", proxyServiceName)
+		log.Error().Err(err).Msgf("Error listing ports of proxy service %s", proxyServiceName)
+		return nil, err
+	}
+
+	tcpTrafficPolicies, err := catalog.ListTCPTrafficPolicies(proxyServiceName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing TCP traffic policies for proxy service %s", proxyServiceName)
 		return nil, err
 	}
+	tcpPorts := make(map[uint32]interface{})
+	for _, policy := range tcpTrafficPolicies {
+		if policy.Destination.Equals(proxyServiceName) {
+			tcpPorts[policy.TargetPort] = nil
+		}
+	}
+
+	var rateLimit *trafficpolicy.RateLimit
+	if featureflags.IsBackpressureEnabled() {
+		rateLimit = getInboundRateLimit(catalog, proxyServiceName)
+	}
+
+	var filterChains []*xds_listener.FilterChain
+	targetPorts := make(map[uint32]interface{})
+	for _, servicePort := range servicePorts {
+		if _, found := targetPorts[servicePort.TargetPort]; found {
+			// Guard against service ports targeting the same port of the pod
+			continue
+		}
+		targetPorts[servicePort.TargetPort] = nil
+
+		var filterChain *xds_listener.FilterChain
+		if _, isTCP := tcpPorts[servicePort.TargetPort]; isTCP {
+			filterChainName := fmt.Sprintf("%s:%d", inboundTCPFilterChainPrefix, servicePort.TargetPort)
+			filterChain, err = getTCPProxyFilterChain(filterChainName, envoy.GetLocalClusterNameForPort(proxyServiceName, servicePort.TargetPort))
+		} else if servicePort.IsHTTP() {
+			filterChain, err = getInboundHTTPFilterChain(proxyServiceName, servicePort, rateLimit, cfg)
+		} else {
+			log.Trace().Msgf("No TCP traffic is allowed to port %d of service %s", servicePort.TargetPort, proxyServiceName)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		serverNames := getInboundInMeshServerNames(proxyServiceName, servicePorts, servicePort.TargetPort)
+		if err := setInboundInMeshFilterChainMatch(filterChain, proxyServiceName, servicePort.TargetPort, serverNames); err != nil {
+			return nil, err
+		}
+		filterChains = append(filterChains, filterChain)
+	}
 
-	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	return filterChains, nil
+}
+
+// getInboundHTTPFilterChain returns a filter chain routing the HTTP traffic received on the target port of the given service port
+// with the inbound route configuration of this port. When backpressure is enabled, the given rate limit is enforced on all the requests.
+func getInboundHTTPFilterChain(proxyServiceName service.MeshService, servicePort service.ServicePort, rateLimit *trafficpolicy.RateLimit, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
+	inboundConnManager := getHTTPConnectionManager(route.GetInboundRouteConfigNameForPort(servicePort.TargetPort), cfg, servicePort.Protocol)
+	if featureflags.IsBackpressureEnabled() {
+		if err := addLocalRateLimitFilter(inboundConnManager, rateLimit); err != nil {
+			log.Error().Err(err).Msgf("Error marshalling local rate limit filter config for proxy %s", proxyServiceName)
+			return nil, err
+		}
+	}
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", proxyServiceName)
 		return nil, err
 	}
 
-	filterChain := &xds_listener.FilterChain{
+	return &xds_listener.FilterChain{
+		Name: fmt.Sprintf("%s:%d", inboundMeshFilterChainName, servicePort.TargetPort),
 		Filters: []*xds_listener.Filter{
 			{
 				Name: wellknown.HTTPConnectionManager,
@@ -36,24 +109,50 @@ func getInboundInMeshFilterChain(proxyServiceName service.MeshService, cfg confi
 				},
 			},
 		},
+	}, nil
+}
 
-		// Apply this filter chain only to requests where the auth.UpstreamTlsContext.Sni matches
-		// one from the list of ServerNames provided below.
-		// This field is configured by the GetDownstreamTLSContext() function.
-		// This is not a field obtained from the mTLS Certificate.
-		FilterChainMatch: &xds_listener.FilterChainMatch{
-			ServerNames:          []string{proxyServiceName.GetCommonName().String()},
-			TransportProtocol:    envoy.TransportProtocolTLS,
-			ApplicationProtocols: envoy.ALPNInMesh, // in-mesh proxies will advertise this, set in UpstreamTlsContext
-		},
+// getInboundInMeshServerNames returns the SNIs the proxies of the mesh send to reach the given port of the local pod.
+// In multicluster mode, the proxies of remote clusters send an SNI per port of the service, as it is routed by the multicluster gateway.
+func getInboundInMeshServerNames(proxyServiceName service.MeshService, servicePorts []service.ServicePort, targetPort uint32) []string {
+	serverNames := []string{proxyServiceName.GetCommonName().String()}
+	if !featureflags.IsMulticlusterModeEnabled() {
+		return serverNames
+	}
+	for _, servicePort := range servicePorts {
+		if servicePort.TargetPort == targetPort {
+			serverNames = append(serverNames, proxyServiceName.GetServerNameForPort(servicePort.Port))
+		}
+	}
+	return serverNames
+}
 
-		TransportSocket: &xds_core.TransportSocket{
-			Name: wellknown.TransportSocketTls,
-			ConfigType: &xds_core.TransportSocket_TypedConfig{
-				TypedConfig: marshalledDownstreamTLSContext,
-			},
+// setInboundInMeshFilterChainMatch restricts the given filter chain to the mTLS traffic from the proxies of the mesh
+// sent to the given port of the local pod with one of the given SNIs, and terminates the mTLS connections.
+func setInboundInMeshFilterChainMatch(filterChain *xds_listener.FilterChain, proxyServiceName service.MeshService, port uint32, serverNames []string) error {
+	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(proxyServiceName, true /* mTLS */))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", proxyServiceName)
+		return err
+	}
+
+	// Apply this filter chain only to requests where the auth.UpstreamTlsContext.Sni matches
+	// one from the list of ServerNames provided below.
+	// This field is configured by the GetDownstreamTLSContext() function.
+	// This is not a field obtained from the mTLS Certificate.
+	filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+		DestinationPort:      &wrappers.UInt32Value{Value: port},
+		ServerNames:          serverNames,
+		TransportProtocol:    envoy.TransportProtocolTLS,
+		ApplicationProtocols: envoy.ALPNInMesh, // in-mesh proxies will advertise this, set in UpstreamTlsContext
+	}
+
+	filterChain.TransportSocket = &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledDownstreamTLSContext,
 		},
 	}
 
-	return filterChain, nil
+	return nil
 }
@@ -1,9 +1,9 @@
 package lds
 
 import (
+	"fmt"
 	"net"
-	"strconv"
-	"strings"
+	"sort"
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
@@ -11,13 +11,18 @@ import (
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
@@ -25,12 +30,10 @@ const (
 	outboundEgressFilterChainName = "outbound-egress-filter-chain"
 )
 
-func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener, error) {
-	connManager := getHTTPConnectionManager(route.OutboundRouteConfigName, cfg)
-
-	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+func newOutboundListener(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, cfg configurator.Configurator) (*xds_listener.Listener, error) {
+	meshFilterChains, err := getOutboundHTTPFilterChains(catalog, proxyServiceName, cfg)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object")
+		log.Error().Err(err).Msgf("Error making outbound HTTP filter chains for proxy %s", proxyServiceName)
 		return nil, err
 	}
 
@@ -38,19 +41,7 @@ func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener,
 		Name:             outboundListenerName,
 		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort),
 		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
-		FilterChains: []*xds_listener.FilterChain{
-			{
-				Name: outboundMeshFilterChainName,
-				Filters: []*xds_listener.Filter{
-					{
-						Name: wellknown.HTTPConnectionManager,
-						ConfigType: &xds_listener.Filter_TypedConfig{
-							TypedConfig: marshalledConnManager,
-						},
-					},
-				},
-			},
-		},
+		FilterChains:     meshFilterChains,
 		ListenerFilters: []*xds_listener.ListenerFilter{
 			{
 				// The OriginalDestination ListenerFilter is used to redirect traffic
@@ -60,7 +51,8 @@ func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener,
 		},
 	}
 
-	if cfg.IsEgressEnabled() {
+	// Egress policies replace the pass-through egress, see updateOutboundListenerForEgressPolicies
+	if cfg.IsEgressEnabled() && !featureflags.IsEgressPolicyEnabled() {
 		err := updateOutboundListenerForEgress(outboundListener, cfg)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error building egress config for outbound listener")
@@ -71,26 +63,84 @@ func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener,
 	return outboundListener, nil
 }
 
-func updateOutboundListenerForEgress(outboundListener *xds_listener.Listener, cfg configurator.Configurator) error {
-	// When egress, the in-mesh CIDR is used to distinguish in-mesh traffic
-	meshCIDRRanges := cfg.GetMeshCIDRRanges()
-	if len(meshCIDRRanges) == 0 {
-		log.Error().Err(errInvalidCIDRRange).Msg("Mesh CIDR ranges unspecified, required when egress is enabled")
-		return errInvalidCIDRRange
+// getOutboundHTTPFilterChains returns a filter chain for each port of the destination services the given service sends HTTP traffic to.
+// Each filter chain matches the traffic sent to its port, and routes it with the outbound route configuration of this port.
+func getOutboundHTTPFilterChains(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, cfg configurator.Configurator) ([]*xds_listener.FilterChain, error) {
+	appProtocols, err := getOutboundHTTPPorts(catalog, proxyServiceName)
+	if err != nil {
+		return nil, err
 	}
 
-	var prefixRanges []*xds_core.CidrRange
+	var ports []uint32
+	for port := range appProtocols {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
 
-	for _, cidr := range meshCIDRRanges {
-		cidrRange, err := getCIDRRange(cidr)
+	var filterChains []*xds_listener.FilterChain
+	for _, port := range ports {
+		connManager := getHTTPConnectionManager(route.GetOutboundRouteConfigNameForPort(port), cfg, appProtocols[port])
+		marshalledConnManager, err := ptypes.MarshalAny(connManager)
 		if err != nil {
-			log.Error().Err(err).Msgf("Error parsing CIDR: %s", cidr)
-			return err
+			log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object")
+			return nil, err
 		}
-		prefixRanges = append(prefixRanges, cidrRange)
+
+		filterChains = append(filterChains, &xds_listener.FilterChain{
+			Name: fmt.Sprintf("%s:%d", outboundMeshFilterChainName, port),
+			FilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort: &wrappers.UInt32Value{Value: port},
+			},
+			Filters: []*xds_listener.Filter{
+				{
+					Name: wellknown.HTTPConnectionManager,
+					ConfigType: &xds_listener.Filter_TypedConfig{
+						TypedConfig: marshalledConnManager,
+					},
+				},
+			},
+		})
 	}
-	outboundListener.FilterChains[0].FilterChainMatch = &xds_listener.FilterChainMatch{
-		PrefixRanges: prefixRanges,
+
+	return filterChains, nil
+}
+
+// getOutboundHTTPPorts returns the HTTP ports of the destination services the given service is allowed to send traffic to,
+// which are the ports of the outbound route configurations of its proxy, along with their application protocol.
+// A port carries gRPC traffic when it does for one of the destination services.
+func getOutboundHTTPPorts(catalog catalog.MeshCataloger, proxyServiceName service.MeshService) (map[uint32]service.AppProtocol, error) {
+	trafficPolicies, err := catalog.ListTrafficPolicies(proxyServiceName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing traffic policies for proxy service %s", proxyServiceName)
+		return nil, err
+	}
+
+	appProtocols := make(map[uint32]service.AppProtocol)
+	for _, trafficPolicy := range trafficPolicies {
+		if !trafficPolicy.Source.Equals(proxyServiceName) {
+			continue
+		}
+		servicePorts, err := catalog.ListServicePorts(trafficPolicy.Destination)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of service %s", trafficPolicy.Destination)
+			return nil, err
+		}
+		for _, servicePort := range servicePorts {
+			if !servicePort.IsHTTP() {
+				continue
+			}
+			if appProtocol, found := appProtocols[servicePort.Port]; !found || !appProtocol.IsGRPC() {
+				appProtocols[servicePort.Port] = servicePort.Protocol
+			}
+		}
+	}
+
+	return appProtocols, nil
+}
+
+func updateOutboundListenerForEgress(outboundListener *xds_listener.Listener, cfg configurator.Configurator) error {
+	if err := matchMeshCIDRRanges(outboundListener, cfg); err != nil {
+		return err
 	}
 
 	// With egress, a filter chain to match TLS traffic is added to the outbound listener.
@@ -106,6 +156,36 @@ func updateOutboundListenerForEgress(outboundListener *xds_listener.Listener, cf
 	return nil
 }
 
+// matchMeshCIDRRanges restricts the filter chains of the outbound listener to the traffic sent to the mesh CIDR ranges
+func matchMeshCIDRRanges(outboundListener *xds_listener.Listener, cfg configurator.Configurator) error {
+	// When egress, the in-mesh CIDR is used to distinguish in-mesh traffic
+	meshCIDRRanges := cfg.GetEffectiveMeshCIDRRanges()
+	if len(meshCIDRRanges) == 0 {
+		log.Error().Err(errInvalidCIDRRange).Msg("Mesh CIDR ranges neither specified nor discovered, required when egress is enabled")
+		return errInvalidCIDRRange
+	}
+
+	var prefixRanges []*xds_core.CidrRange
+
+	for _, cidr := range meshCIDRRanges {
+		cidrRange, err := getCIDRRange(cidr)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error parsing CIDR: %s", cidr)
+			return err
+		}
+		prefixRanges = append(prefixRanges, cidrRange)
+	}
+	// Every in-mesh filter chain matches the in-mesh CIDR, on top of its destination port
+	for _, filterChain := range outboundListener.FilterChains {
+		if filterChain.FilterChainMatch == nil {
+			filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{}
+		}
+		filterChain.FilterChainMatch.PrefixRanges = prefixRanges
+	}
+
+	return nil
+}
+
 func newInboundListener() *xds_listener.Listener {
 	return &xds_listener.Listener{
 		Name:             inboundListenerName,
@@ -116,6 +196,11 @@ func newInboundListener() *xds_listener.Listener {
 			{
 				Name: wellknown.TlsInspector,
 			},
+			{
+				// The OriginalDestination ListenerFilter restores the destination port of the redirected traffic,
+				// which the filter chains proxying TCP traffic match on.
+				Name: wellknown.OriginalDestination,
+			},
 		},
 	}
 }
@@ -168,21 +253,15 @@ func buildEgressFilterChain() (*xds_listener.FilterChain, error) {
 	}, nil
 }
 
+// parseCIDR returns the network address and the prefix length of the given IPv4 or IPv6 CIDR
 func parseCIDR(cidr string) (string, uint32, error) {
-	var addr string
-
-	_, _, err := net.ParseCIDR(cidr)
-	if err != nil {
-		return addr, 0, err
-	}
-	chunks := strings.Split(cidr, "/")
-	addr = chunks[0]
-	prefix, err := strconv.Atoi(chunks[1])
+	_, ipNet, err := net.ParseCIDR(cidr)
 	if err != nil {
-		return addr, 0, err
+		return "", 0, err
 	}
+	prefix, _ := ipNet.Mask.Size()
 
-	return addr, uint32(prefix), nil
+	return ipNet.IP.String(), uint32(prefix), nil
 }
 
 func getCIDRRange(cidr string) (*xds_core.CidrRange, error) {
@@ -200,3 +279,18 @@ func getCIDRRange(cidr string) (*xds_core.CidrRange, error) {
 
 	return cidrRange, nil
 }
+
+// getIPFamilyListeners returns a copy of the given listener for each of the given IP families, binding the wildcard address of the family.
+// The IPv4 listener keeps the name of the given listener, the name of the IPv6 listener is suffixed.
+func getIPFamilyListeners(listener *xds_listener.Listener, ipFamilies []corev1.IPFamily) []*xds_listener.Listener {
+	var listeners []*xds_listener.Listener
+	for _, ipFamily := range ipFamilies {
+		ipFamilyListener := proto.Clone(listener).(*xds_listener.Listener)
+		ipFamilyListener.Address = envoy.GetAddress(envoy.GetWildcardIPAddr(ipFamily), listener.Address.GetSocketAddress().GetPortValue())
+		if ipFamily == corev1.IPv6Protocol {
+			ipFamilyListener.Name += ipv6ListenerNameSuffix
+		}
+		listeners = append(listeners, ipFamilyListener)
+	}
+	return listeners
+}
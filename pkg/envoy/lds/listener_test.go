@@ -1,6 +1,8 @@
 package lds
 
 import (
+	"fmt"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
@@ -8,14 +10,21 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Construct inbound and outbound listeners", func() {
+	mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+
 	Context("Test creation of outbound listener", func() {
 		containsListenerFilter := func(filters []string, filterName string) bool {
 			for _, filter := range filters {
@@ -33,7 +42,7 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 				MeshCIDRRanges: []string{cidr1, cidr2},
 			})
 
-			listener, err := newOutboundListener(cfg)
+			listener, err := newOutboundListener(mc, tests.BookbuyerService, cfg)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort)))
@@ -41,7 +50,8 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 			// Test FilterChains
 			Expect(len(listener.FilterChains)).To(Equal(2)) // 1. in-mesh, 2. egress
 			// Test mesh FilterChain
-			Expect(listener.FilterChains[0].Name).To(Equal(outboundMeshFilterChainName))
+			Expect(listener.FilterChains[0].Name).To(Equal(fmt.Sprintf("%s:%d", outboundMeshFilterChainName, tests.ServicePort)))
+			Expect(listener.FilterChains[0].FilterChainMatch.DestinationPort.Value).To(Equal(uint32(tests.ServicePort)))
 			Expect(len(listener.FilterChains[0].FilterChainMatch.PrefixRanges)).To(Equal(2)) // 2 CIDRs
 			// Test egress FilterChain
 			Expect(listener.FilterChains[1].Name).To(Equal(outboundEgressFilterChainName))
@@ -61,14 +71,15 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 				Egress: false,
 			})
 
-			listener, err := newOutboundListener(cfg)
+			listener, err := newOutboundListener(mc, tests.BookbuyerService, cfg)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort)))
 
 			// Test FilterChains
 			Expect(len(listener.FilterChains)).To(Equal(1)) // Filter chain for in-mesh
-			Expect(listener.FilterChains[0].FilterChainMatch).Should(BeNil())
+			Expect(listener.FilterChains[0].FilterChainMatch.DestinationPort.Value).To(Equal(uint32(tests.ServicePort)))
+			Expect(listener.FilterChains[0].FilterChainMatch.PrefixRanges).To(BeNil())
 
 			// Test ListenerFilters
 			expectedListenerFilters := []string{wellknown.OriginalDestination}
@@ -80,6 +91,20 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 		})
 	})
 
+	Context("Test getOutboundHTTPPorts", func() {
+		It("returns the HTTP ports of the services the given service is allowed to send traffic to", func() {
+			ports, err := getOutboundHTTPPorts(mc, tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(Equal(map[uint32]service.AppProtocol{tests.ServicePort: service.AppProtocolHTTP}))
+		})
+
+		It("returns no port for a service not allowed to send traffic", func() {
+			ports, err := getOutboundHTTPPorts(mc, tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(BeEmpty())
+		})
+	})
+
 	Context("Tests building outbound egress listener", func() {
 		It("Tests that building the outbound egress filter chain succeeds with valid CIDRs", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
@@ -117,8 +142,9 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 		It("Tests the inbound listener config", func() {
 			listener := newInboundListener()
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort)))
-			Expect(len(listener.ListenerFilters)).To(Equal(1)) // tls-inspector listener filter
+			Expect(len(listener.ListenerFilters)).To(Equal(2)) // tls-inspector and original-destination listener filters
 			Expect(listener.ListenerFilters[0].Name).To(Equal(wellknown.TlsInspector))
+			Expect(listener.ListenerFilters[1].Name).To(Equal(wellknown.OriginalDestination))
 			Expect(listener.TrafficDirection).To(Equal(xds_core.TrafficDirection_INBOUND))
 		})
 	})
@@ -126,7 +152,7 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 	Context("Test creation of HTTP connection manager", func() {
 		It("Returns proper Zipkin config given a cfg", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{})
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg, service.AppProtocolHTTP)
 			var nilHcmTrace *xds_hcm.HttpConnectionManager_Tracing = nil
 
 			if cfg.IsTracingEnabled() {
@@ -158,10 +184,36 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 			Expect(prefix).To(Equal(uint32(24)))
 		})
 
+		It("Tests that a valid IPv6 CIDR is parsed to its network address", func() {
+			addr, prefix, err := parseCIDR("fd00:10:244::1/56")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(addr).To(Equal("fd00:10:244::"))
+			Expect(prefix).To(Equal(uint32(56)))
+		})
+
 		It("Tests that an invalid CIDR returns an error", func() {
 			cidr := "10.2.0.0/99"
 			_, _, err := parseCIDR(cidr)
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("Test getIPFamilyListeners", func() {
+		It("Returns the listener for an IPv4 pod", func() {
+			listeners := getIPFamilyListeners(newInboundListener(), []corev1.IPFamily{corev1.IPv4Protocol})
+			Expect(len(listeners)).To(Equal(1))
+			Expect(listeners[0].Name).To(Equal(inboundListenerName))
+			Expect(listeners[0].Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort)))
+		})
+
+		It("Returns an IPv4 and an IPv6 listener for a dual-stack pod", func() {
+			listeners := getIPFamilyListeners(newInboundListener(), []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol})
+			Expect(len(listeners)).To(Equal(2))
+			Expect(listeners[0].Name).To(Equal(inboundListenerName + ipv6ListenerNameSuffix))
+			Expect(listeners[0].Address).To(Equal(envoy.GetAddress(constants.WildcardIPv6Addr, constants.EnvoyInboundListenerPort)))
+			Expect(listeners[0].ListenerFilters).To(Equal(listeners[1].ListenerFilters))
+			Expect(listeners[1].Name).To(Equal(inboundListenerName))
+			Expect(listeners[1].Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort)))
+		})
+	})
 })
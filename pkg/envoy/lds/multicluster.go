@@ -0,0 +1,46 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const multiclusterGatewayFilterChainPrefix = "multicluster-gateway"
+
+// getMulticlusterGatewayFilterChains returns the filter chains of the multicluster gateway proxying the mTLS connections
+// from remote clusters to the ports of the exported services, selected by the SNI of the connections.
+// TLS is not terminated by the gateway: the connections are passed through to the proxies of the exported services.
+func getMulticlusterGatewayFilterChains(catalog catalog.MeshCataloger, proxyServiceName service.MeshService) []*xds_listener.FilterChain {
+	var filterChains []*xds_listener.FilterChain
+	for _, exportedService := range catalog.ListExportedServices() {
+		ports, err := catalog.ListServicePorts(exportedService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of exported service %s for multicluster gateway %s", exportedService, proxyServiceName)
+			continue
+		}
+
+		for _, port := range ports {
+			filterChainName := fmt.Sprintf("%s:%s:%d", multiclusterGatewayFilterChainPrefix, exportedService, port.Port)
+			filterChain, err := getTCPProxyFilterChain(filterChainName, envoy.GetMulticlusterGatewayClusterNameForPort(exportedService, port.Port))
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building multicluster gateway filter chain for service %s", exportedService)
+				continue
+			}
+
+			filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+				DestinationPort:   &wrappers.UInt32Value{Value: constants.MulticlusterGatewayPort},
+				ServerNames:       []string{exportedService.GetServerNameForPort(port.Port)},
+				TransportProtocol: envoy.TransportProtocolTLS,
+			}
+			filterChains = append(filterChains, filterChain)
+		}
+	}
+	return filterChains
+}
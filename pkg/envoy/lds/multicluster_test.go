@@ -0,0 +1,77 @@
+package lds
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// fakeExportingCatalog exports the bookstore service on ports 80 and 8080
+type fakeExportingCatalog struct {
+	catalog.MeshCataloger
+}
+
+func (fakeExportingCatalog) ListExportedServices() []service.MeshService {
+	return []service.MeshService{tests.BookstoreService}
+}
+
+func (fakeExportingCatalog) ListServicePorts(svc service.MeshService) ([]service.ServicePort, error) {
+	return []service.ServicePort{
+		{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"},
+		{Name: "http-alt", Port: 8080, TargetPort: 8080, Protocol: "http"},
+	}, nil
+}
+
+var _ = Describe("Multicluster listeners", func() {
+	servicePorts := []service.ServicePort{
+		{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"},
+		{Name: "metrics", Port: 9090, TargetPort: 9090, Protocol: "http"},
+	}
+
+	Context("Test getInboundInMeshServerNames", func() {
+		It("only matches the common name of the service when the multicluster mode is disabled", func() {
+			Expect(getInboundInMeshServerNames(tests.BookstoreService, servicePorts, 8080)).To(Equal([]string{
+				"bookstore.default.svc.cluster.local",
+			}))
+		})
+
+		It("also matches the server names of the service ports targeting the port when the multicluster mode is enabled", func() {
+			featureflags.Features.MulticlusterMode = true
+			defer func() { featureflags.Features.MulticlusterMode = false }()
+
+			Expect(getInboundInMeshServerNames(tests.BookstoreService, servicePorts, 8080)).To(Equal([]string{
+				"bookstore.default.svc.cluster.local",
+				"80.bookstore.default.svc.cluster.local",
+			}))
+		})
+	})
+
+	Context("Test getMulticlusterGatewayFilterChains", func() {
+		It("proxies the connections to each port of the exported services based on their SNI", func() {
+			gateway := service.MeshService{Namespace: "osm-system", Name: "osm-multicluster-gateway"}
+			filterChains := getMulticlusterGatewayFilterChains(fakeExportingCatalog{}, gateway)
+			Expect(filterChains).To(HaveLen(2))
+
+			for i, port := range []uint32{80, 8080} {
+				Expect(filterChains[i].Name).To(Equal(fmt.Sprintf("multicluster-gateway:default/bookstore:%d", port)))
+				Expect(filterChains[i].FilterChainMatch).To(Equal(&xds_listener.FilterChainMatch{
+					DestinationPort:   &wrappers.UInt32Value{Value: constants.MulticlusterGatewayPort},
+					ServerNames:       []string{tests.BookstoreService.GetServerNameForPort(port)},
+					TransportProtocol: envoy.TransportProtocolTLS,
+				}))
+				Expect(filterChains[i].TransportSocket).To(BeNil())
+			}
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package lds
+
+import (
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getInboundRateLimit returns the rate limit the Backpressure policy of the given service sets on all the requests it receives
+func getInboundRateLimit(catalog catalog.MeshCataloger, svc service.MeshService) *trafficpolicy.RateLimit {
+	backpressure := catalog.GetSMISpec().GetBackpressurePolicy(svc)
+	if backpressure == nil || backpressure.Spec.RateLimit == nil || backpressure.Spec.RateLimit.RequestsPerSecond == 0 {
+		return nil
+	}
+
+	return &trafficpolicy.RateLimit{
+		RequestsPerSecond: backpressure.Spec.RateLimit.RequestsPerSecond,
+		Burst:             backpressure.Spec.RateLimit.Burst,
+	}
+}
+
+// addLocalRateLimitFilter adds the local rate limit filter to the given connection manager, before its router filter.
+// The filter enforces the given rate limit on all the requests, or leaves the routes set their own limits when it is nil.
+func addLocalRateLimitFilter(connManager *xds_hcm.HttpConnectionManager, rateLimit *trafficpolicy.RateLimit) error {
+	var requestsPerSecond, burst uint32
+	if rateLimit != nil {
+		requestsPerSecond, burst = rateLimit.RequestsPerSecond, rateLimit.Burst
+	}
+
+	rateLimitConfig, err := envoy.GetLocalRateLimitConfig(requestsPerSecond, burst)
+	if err != nil {
+		return err
+	}
+
+	rateLimitFilter := &xds_hcm.HttpFilter{
+		Name:       envoy.LocalRateLimitFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: rateLimitConfig},
+	}
+
+	// The router filter is always the last filter
+	last := len(connManager.HttpFilters) - 1
+	connManager.HttpFilters = append(connManager.HttpFilters[:last], rateLimitFilter, connManager.HttpFilters[last])
+	return nil
+}
@@ -0,0 +1,30 @@
+package lds
+
+import (
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Local rate limit filter", func() {
+	Context("Test addLocalRateLimitFilter", func() {
+		It("Adds the local rate limit filter before the router filter", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{})
+			connManager := getHTTPConnectionManager(route.GetInboundRouteConfigNameForPort(8080), cfg, service.AppProtocolHTTP)
+
+			err := addLocalRateLimitFilter(connManager, &trafficpolicy.RateLimit{RequestsPerSecond: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(connManager.HttpFilters)).To(Equal(2))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(envoy.LocalRateLimitFilterName))
+			Expect(connManager.HttpFilters[0].GetTypedConfig()).ToNot(BeNil())
+			Expect(connManager.HttpFilters[1].Name).To(Equal(wellknown.Router))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package lds
 
 import (
+	"fmt"
+
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"github.com/golang/protobuf/ptypes"
@@ -9,12 +11,17 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
 	inboundListenerName    = "inbound_listener"
 	outboundListenerName   = "outbound_listener"
 	prometheusListenerName = "inbound_prometheus_listener"
+
+	// ipv6ListenerNameSuffix is the suffix of the names of the listeners binding the IPv6 wildcard address
+	ipv6ListenerNameSuffix = "_ipv6"
 )
 
 // NewResponse creates a new Listener Discovery Response.
@@ -22,6 +29,8 @@ const (
 // 1. Inbound listener to handle incoming traffic
 // 2. Outbound listener to handle outgoing traffic
 // 3. Prometheus listener for metrics
+// Each listener is built for each IP family of the pod of the proxy, so that the listeners of a dual-stack pod
+// accept both the IPv4 and the IPv6 connections.
 func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	svcList, err := catalog.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
 	if err != nil {
@@ -31,53 +40,102 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	ipFamilies := proxy.GetIPFamilies()
+
 	resp := &xds_discovery.DiscoveryResponse{
 		TypeUrl: string(envoy.TypeLDS),
 	}
 
 	// --- OUTBOUND -------------------
-	if outboundListener, err := newOutboundListener(cfg); err != nil {
+	if outboundListener, err := newOutboundListener(catalog, proxyServiceName, cfg); err != nil {
 		log.Error().Err(err).Msgf("Error making outbound listener config for proxy %s", proxyServiceName)
 	} else {
-		if marshalledOutbound, err := ptypes.MarshalAny(outboundListener); err != nil {
-			log.Error().Err(err).Msgf("Failed to marshal outbound listener config for proxy %s", proxyServiceName)
+		if featureflags.IsEgressPolicyEnabled() {
+			// Proxy the egress traffic allowed by Egress policies, and deny the rest of the egress traffic
+			if serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName()); err != nil {
+				log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+			} else if err := updateOutboundListenerForEgressPolicies(outboundListener, catalog.ListEgressTrafficPolicies(serviceAccount), cfg); err != nil {
+				log.Error().Err(err).Msgf("Error building egress config for outbound listener of proxy %s", proxyServiceName)
+				// An error in egress config should not disrupt in-mesh traffic, so only log an error
+			}
+		}
+
+		// Proxy the TCP traffic allowed by TCP traffic policies
+		if tcpFilterChains, err := getOutboundTCPFilterChains(catalog, proxyServiceName); err != nil {
+			log.Error().Err(err).Msgf("Error making outbound TCP filter chains for proxy %s", proxyServiceName)
 		} else {
-			resp.Resources = append(resp.Resources, marshalledOutbound)
+			outboundListener.FilterChains = append(outboundListener.FilterChains, tcpFilterChains...)
+		}
+
+		if len(outboundListener.FilterChains) > 0 {
+			// Outbound filter chains can be empty if the proxy service is not allowed to send traffic and egress is disabled.
+			// Configuring a listener without a filter chain is an error.
+			for _, listener := range getIPFamilyListeners(outboundListener, ipFamilies) {
+				if marshalledOutbound, err := ptypes.MarshalAny(listener); err != nil {
+					log.Error().Err(err).Msgf("Failed to marshal outbound listener config for proxy %s", proxyServiceName)
+				} else {
+					resp.Resources = append(resp.Resources, marshalledOutbound)
+				}
+			}
 		}
 	}
 
 	// --- INBOUND -------------------
 	inboundListener := newInboundListener()
-	if meshFilterChain, err := getInboundInMeshFilterChain(proxyServiceName, cfg); err != nil {
-		log.Error().Err(err).Msgf("Error making in-mesh filter chain for proxy %s", proxy.GetCommonName())
-	} else if meshFilterChain != nil {
-		inboundListener.FilterChains = append(inboundListener.FilterChains, meshFilterChain)
+	if meshFilterChains, err := getInboundInMeshFilterChains(catalog, proxyServiceName, cfg); err != nil {
+		log.Error().Err(err).Msgf("Error making in-mesh filter chains for proxy %s", proxy.GetCommonName())
+	} else {
+		inboundListener.FilterChains = append(inboundListener.FilterChains, meshFilterChains...)
+	}
+
+	// --- MULTICLUSTER GATEWAY -------------------
+	// The multicluster gateway proxies the traffic from remote clusters to the exported services
+	if catalog.IsMulticlusterGateway(proxyServiceName) {
+		inboundListener.FilterChains = append(inboundListener.FilterChains, getMulticlusterGatewayFilterChains(catalog, proxyServiceName)...)
 	}
 
 	// --- INGRESS -------------------
-	// Apply an ingress filter chain if there are any ingress routes
-	if ingressRoutesPerHost, err := catalog.GetIngressRoutesPerHost(proxyServiceName); err != nil {
-		log.Error().Err(err).Msgf("Error getting ingress routes per host for service %s", proxyServiceName)
+	// Apply an ingress filter chain for the ports of the service with ingress routes, from ingress controllers or gateways
+	if servicePorts, err := catalog.ListServicePorts(proxyServiceName); err != nil {
+		log.Error().Err(err).Msgf("Error listing ports of service %s", proxyServiceName)
 	} else {
-		thereAreIngressRoutes := len(ingressRoutesPerHost) > 0
+		var ingressPorts []service.ServicePort
+		for _, servicePort := range servicePorts {
+			if ingressRoutesPerHost, err := catalog.GetIngressRoutesPerHost(proxyServiceName, servicePort); err != nil {
+				log.Error().Err(err).Msgf("Error getting ingress routes per host for service %s", proxyServiceName)
+			} else if len(ingressRoutesPerHost) > 0 {
+				ingressPorts = append(ingressPorts, servicePort)
+				continue
+			}
+			if gatewayRoutesPerHost, err := catalog.GetGatewayHTTPRoutesPerHost(proxyServiceName, servicePort); err != nil {
+				log.Error().Err(err).Msgf("Error getting Gateway API routes per host for service %s", proxyServiceName)
+			} else if len(gatewayRoutesPerHost) > 0 {
+				ingressPorts = append(ingressPorts, servicePort)
+			}
+		}
 
-		if thereAreIngressRoutes {
+		if len(ingressPorts) > 0 {
 			log.Info().Msgf("Found k8s Ingress for MeshService %s, applying necessary filters", proxyServiceName)
 			// This proxy is fronting a service that is a backend for an ingress, add a FilterChain for it
-			ingressFilterChains := getIngressFilterChains(proxyServiceName, cfg)
+			ingressFilterChains := getIngressFilterChains(proxyServiceName, ingressPorts, cfg)
 			inboundListener.FilterChains = append(inboundListener.FilterChains, ingressFilterChains...)
 		} else {
 			log.Trace().Msgf("There is no k8s Ingress for service %s", proxyServiceName)
 		}
+
+		// The TLS connections routed by gateways with TLSRoute resources are proxied to the local pod without terminating TLS
+		inboundListener.FilterChains = append(inboundListener.FilterChains, getGatewayTLSFilterChains(catalog, proxyServiceName, servicePorts, ingressPorts)...)
 	}
 
 	if len(inboundListener.FilterChains) > 0 {
 		// Inbound filter chains can be empty if the there both ingress and in-mesh policies are not configued.
 		// Configuring a listener without a filter chain is an error.
-		if marshalledInbound, err := ptypes.MarshalAny(inboundListener); err != nil {
-			log.Error().Err(err).Msgf("Error marshalling inbound listener config for proxy %s", proxyServiceName)
-		} else {
-			resp.Resources = append(resp.Resources, marshalledInbound)
+		for _, listener := range getIPFamilyListeners(inboundListener, ipFamilies) {
+			if marshalledInbound, err := ptypes.MarshalAny(listener); err != nil {
+				log.Error().Err(err).Msgf("Error marshalling inbound listener config for proxy %s", proxyServiceName)
+			} else {
+				resp.Resources = append(resp.Resources, marshalledInbound)
+			}
 		}
 	}
 
@@ -87,13 +145,33 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
 			log.Error().Err(err).Msgf("Error building Prometheus listener config for proxy %s", proxyServiceName)
 		} else {
-			if marshalledPrometheus, err := ptypes.MarshalAny(prometheusListener); err != nil {
-				log.Error().Err(err).Msgf("Error marshalling Prometheus listener config for proxy %s", proxyServiceName)
-			} else {
-				resp.Resources = append(resp.Resources, marshalledPrometheus)
+			for _, listener := range getIPFamilyListeners(prometheusListener, ipFamilies) {
+				if marshalledPrometheus, err := ptypes.MarshalAny(listener); err != nil {
+					log.Error().Err(err).Msgf("Error marshalling Prometheus listener config for proxy %s", proxyServiceName)
+				} else {
+					resp.Resources = append(resp.Resources, marshalledPrometheus)
+				}
 			}
 		}
 	}
 
 	return resp, nil
 }
+
+// GetSnapshotVariant returns the inputs specific to the given proxy, other than its service, which the listeners depend on:
+// the listeners bind the addresses of the IP families of the pod of the proxy, and the egress policies allow the egress traffic
+// of the service account of the proxy, not of its service. The proxies of a service share the snapshot of the listeners
+// only when they share these inputs.
+func GetSnapshotVariant(catalog catalog.MeshCataloger, proxy *envoy.Proxy) (string, error) {
+	variant := envoy.GetIPFamiliesSnapshotVariant(proxy)
+	if !featureflags.IsEgressPolicyEnabled() {
+		return variant, nil
+	}
+
+	serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+		return "", err
+	}
+	return fmt.Sprintf("%s;serviceAccount=%s", variant, serviceAccount), nil
+}
@@ -4,23 +4,33 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	testclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Test LDS response", func() {
+	mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+	servicePorts := []service.ServicePort{
+		{Name: "http", Port: 80, TargetPort: 8080, Protocol: service.AppProtocolHTTP},
+		{Name: "tcp-db", Port: 3306, TargetPort: 3306, Protocol: service.AppProtocolTCP},
+	}
+
 	Context("Test getInboundIngressFilterChain()", func() {
 		It("constructs filter chain used for HTTPS ingress", func() {
 			expectedServerNames := []string{tests.BookstoreService.GetCommonName().String()}
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
 				HTTPSIngress: true, // HTTPS
 			})
-			filterChains := getIngressFilterChains(tests.BookstoreService, cfg)
+			filterChains := getIngressFilterChains(tests.BookstoreService, servicePorts, cfg)
 			Expect(len(filterChains)).To(Equal(2))
 			for _, filterChain := range filterChains {
 				Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
+				Expect(filterChain.FilterChainMatch.DestinationPort.Value).To(Equal(uint32(8080))) // only the HTTP port
 				Expect(len(filterChain.Filters)).To(Equal(1))
 				Expect(filterChain.Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
 			}
@@ -36,10 +46,11 @@ var _ = Describe("Test LDS response", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
 				HTTPSIngress: false, // HTTP
 			})
-			filterChains := getIngressFilterChains(tests.BookstoreService, cfg)
+			filterChains := getIngressFilterChains(tests.BookstoreService, servicePorts, cfg)
 			Expect(len(filterChains)).To(Equal(1))
 			for _, filterChain := range filterChains {
 				Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(""))
+				Expect(filterChain.FilterChainMatch.DestinationPort.Value).To(Equal(uint32(8080))) // only the HTTP port
 				Expect(len(filterChain.Filters)).To(Equal(1))
 				Expect(filterChain.Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
 			}
@@ -48,24 +59,28 @@ var _ = Describe("Test LDS response", func() {
 
 		It("constructs in-mesh filter chain", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{})
-			filterChain, err := getInboundInMeshFilterChain(tests.BookstoreService, cfg)
+			filterChains, err := getInboundInMeshFilterChains(mc, tests.BookbuyerService, cfg)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(1)) // a filter chain for the HTTP port of the service
+			filterChain := filterChains[0]
 
-			expectedServerNames := []string{tests.BookstoreService.GetCommonName().String()}
+			expectedServerNames := []string{tests.BookbuyerService.GetCommonName().String()}
 
 			// Show what this looks like (human readable)!  And ensure this is setup correctly!
-			Expect(expectedServerNames[0]).To(Equal("bookstore.default.svc.cluster.local"))
+			Expect(expectedServerNames[0]).To(Equal("bookbuyer.default.svc.cluster.local"))
 
+			Expect(filterChain.FilterChainMatch.DestinationPort.Value).To(Equal(uint32(tests.ServicePort)))
 			Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
 			Expect(filterChain.FilterChainMatch.ServerNames).To(Equal(expectedServerNames))
+			Expect(filterChain.Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
 
 			// Ensure the UpstreamTlsContext.Sni field from the client matches one of the strings
 			// in the servers FilterChainMatch.ServerNames
-			tlsContext := envoy.GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreService.GetCommonName().String())
+			tlsContext := envoy.GetUpstreamTLSContext(tests.BookstoreService, tests.BookbuyerService.GetCommonName().String())
 			Expect(tlsContext.Sni).To(Equal(filterChain.FilterChainMatch.ServerNames[0]))
 
 			// Show what that actually looks like
-			Expect(tlsContext.Sni).To(Equal("bookstore.default.svc.cluster.local"))
+			Expect(tlsContext.Sni).To(Equal("bookbuyer.default.svc.cluster.local"))
 		})
 	})
 })
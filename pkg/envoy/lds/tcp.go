@@ -0,0 +1,142 @@
+package lds
+
+import (
+	"fmt"
+	"net"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	outboundTCPFilterChainPrefix = "outbound-tcp-filter-chain"
+	inboundTCPFilterChainPrefix  = "inbound-tcp-filter-chain"
+
+	singleIPv4PrefixLen = 32
+	singleIPv6PrefixLen = 128
+)
+
+// getOutboundTCPFilterChains returns a filter chain for each destination service and port the given service is allowed
+// to send TCP traffic to by TCP traffic policies. The TCP traffic is proxied to the cluster of the destination service over mTLS.
+func getOutboundTCPFilterChains(catalog catalog.MeshCataloger, proxyServiceName service.MeshService) ([]*xds_listener.FilterChain, error) {
+	tcpTrafficPolicies, err := catalog.ListTCPTrafficPolicies(proxyServiceName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing TCP traffic policies for proxy service %s", proxyServiceName)
+		return nil, err
+	}
+
+	var filterChains []*xds_listener.FilterChain
+	filterChainNames := make(map[string]interface{})
+	for _, policy := range tcpTrafficPolicies {
+		if !policy.Source.Equals(proxyServiceName) {
+			continue
+		}
+
+		filterChainName := fmt.Sprintf("%s:%s:%d", outboundTCPFilterChainPrefix, policy.Destination, policy.Port)
+		if _, found := filterChainNames[filterChainName]; found {
+			// Guard against duplicates
+			continue
+		}
+
+		prefixRanges, err := getDestinationPrefixRanges(catalog, policy.Destination)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the IP addresses of service %s", policy.Destination)
+			continue
+		}
+
+		filterChain, err := getTCPProxyFilterChain(filterChainName, envoy.GetClusterNameForPort(policy.Destination.String(), policy.Port))
+		if err != nil {
+			return nil, err
+		}
+		filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+			DestinationPort: &wrappers.UInt32Value{Value: policy.Port},
+			PrefixRanges:    prefixRanges,
+		}
+
+		filterChainNames[filterChainName] = nil
+		filterChains = append(filterChains, filterChain)
+	}
+
+	return filterChains, nil
+}
+
+// getTCPProxyFilterChain returns a filter chain with the given name proxying the TCP traffic to the given cluster.
+func getTCPProxyFilterChain(filterChainName, clusterName string) (*xds_listener.FilterChain, error) {
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       clusterName,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: clusterName},
+	}
+	marshalledTCPProxy, err := envoy.MessageToAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for filter chain %s", filterChainName)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: filterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// getDestinationPrefixRanges returns the IP addresses the traffic to the given service is sent to:
+// the cluster IPs of the service, or the IP addresses of its endpoints when the service is headless.
+func getDestinationPrefixRanges(catalog catalog.MeshCataloger, meshService service.MeshService) ([]*xds_core.CidrRange, error) {
+	svc := catalog.GetSMISpec().GetService(meshService)
+	if svc == nil {
+		return nil, errServiceNotFound
+	}
+
+	ips := k8s.GetServiceClusterIPs(svc)
+	if len(ips) == 0 {
+		endpoints, err := catalog.ListEndpointsForService(meshService)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range endpoints {
+			ips = append(ips, ep.IP)
+		}
+	}
+
+	var prefixRanges []*xds_core.CidrRange
+	ipSet := make(map[string]interface{})
+	for _, ip := range ips {
+		if ip == nil {
+			continue
+		}
+		if _, found := ipSet[ip.String()]; found {
+			continue
+		}
+		ipSet[ip.String()] = nil
+		prefixRanges = append(prefixRanges, &xds_core.CidrRange{
+			AddressPrefix: ip.String(),
+			PrefixLen:     &wrappers.UInt32Value{Value: getSingleIPPrefixLen(ip)},
+		})
+	}
+
+	if len(prefixRanges) == 0 {
+		return nil, errNoIPAddressForService
+	}
+	return prefixRanges, nil
+}
+
+// getSingleIPPrefixLen returns the length of the prefix matching the given IP address alone
+func getSingleIPPrefixLen(ip net.IP) uint32 {
+	if ip.To4() != nil {
+		return singleIPv4PrefixLen
+	}
+	return singleIPv6PrefixLen
+}
@@ -0,0 +1,86 @@
+package lds
+
+import (
+	"net"
+
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("TCP filter chains", func() {
+	mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+
+	Context("Test getOutboundTCPFilterChains()", func() {
+		It("constructs a filter chain per destination service and port allowed TCP traffic", func() {
+			filterChains, err := getOutboundTCPFilterChains(mc, tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(2))
+
+			for _, filterChain := range filterChains {
+				Expect(filterChain.FilterChainMatch.DestinationPort.Value).To(Equal(uint32(tests.ServicePort)))
+				// The fixture services are headless, so the IP addresses of their endpoints are matched
+				Expect(len(filterChain.FilterChainMatch.PrefixRanges)).To(Equal(1))
+				Expect(filterChain.FilterChainMatch.PrefixRanges[0].AddressPrefix).To(Equal(tests.ServiceIP))
+				Expect(filterChain.FilterChainMatch.PrefixRanges[0].PrefixLen.Value).To(Equal(uint32(32)))
+
+				Expect(len(filterChain.Filters)).To(Equal(1))
+				Expect(filterChain.Filters[0].Name).To(Equal(wellknown.TCPProxy))
+				tcpProxy := &xds_tcp_proxy.TcpProxy{}
+				Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy)).To(Succeed())
+				Expect(tcpProxy.GetCluster()).To(BeElementOf("default/bookstore|8888", "default/bookstore-apex|8888"))
+			}
+		})
+
+		It("does not construct filter chains for a service not allowed to send TCP traffic", func() {
+			filterChains, err := getOutboundTCPFilterChains(mc, tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filterChains).To(BeEmpty())
+		})
+	})
+
+	Context("Test getInboundInMeshFilterChains()", func() {
+		It("constructs an mTLS filter chain proxying the TCP traffic for a port receiving TCP traffic", func() {
+			filterChains, err := getInboundInMeshFilterChains(mc, tests.BookstoreService, configurator.NewFakeConfigurator())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(1))
+
+			filterChain := filterChains[0]
+			Expect(filterChain.FilterChainMatch.DestinationPort.Value).To(Equal(uint32(tests.ServicePort)))
+			Expect(filterChain.FilterChainMatch.ServerNames).To(Equal([]string{tests.BookstoreService.GetCommonName().String()}))
+			Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
+			Expect(filterChain.FilterChainMatch.ApplicationProtocols).To(Equal(envoy.ALPNInMesh))
+			Expect(filterChain.TransportSocket.Name).To(Equal(wellknown.TransportSocketTls))
+
+			Expect(filterChain.Filters[0].Name).To(Equal(wellknown.TCPProxy))
+			tcpProxy := &xds_tcp_proxy.TcpProxy{}
+			Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy)).To(Succeed())
+			Expect(tcpProxy.GetCluster()).To(Equal(envoy.GetLocalClusterNameForPort(tests.BookstoreService, tests.ServicePort)))
+		})
+
+		It("does not proxy the TCP traffic for a service not receiving TCP traffic", func() {
+			filterChains, err := getInboundInMeshFilterChains(mc, tests.BookbuyerService, configurator.NewFakeConfigurator())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(filterChains)).To(Equal(1))
+			Expect(filterChains[0].Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
+		})
+	})
+
+	Context("Test getSingleIPPrefixLen()", func() {
+		It("matches an IPv4 address alone", func() {
+			Expect(getSingleIPPrefixLen(net.ParseIP("10.0.0.1"))).To(Equal(uint32(32)))
+		})
+
+		It("matches an IPv6 address alone", func() {
+			Expect(getSingleIPPrefixLen(net.ParseIP("fd00::1"))).To(Equal(uint32(128)))
+		})
+	})
+})
@@ -3,9 +3,14 @@ package envoy
 import (
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/any"
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 )
 
 // Proxy is a representation of an Envoy proxy connected to the xDS server.
@@ -18,35 +23,104 @@ type Proxy struct {
 	// The time this Proxy connected to the OSM control plane
 	connectedAt time.Time
 
-	lastSentVersion    map[TypeURI]uint64
-	lastAppliedVersion map[TypeURI]uint64
+	// The locality of the node the pod of the proxy runs on, and the IP families of the addresses of its pod, resolved when the proxy connects
+	locality   endpoint.Locality
+	ipFamilies []corev1.IPFamily
+
+	// The versions of the xDS responses are hashes of the resources in them
+	lastSentVersion    map[TypeURI]string
+	lastAppliedVersion map[TypeURI]string
 	lastNonce          map[TypeURI]string
+
+	// The responses sent to the proxy pending an ACK or a NACK, keyed by nonce, and the resources the proxy last acknowledged.
+	// The last acknowledged resources are the last known good config, which is served in place of rejected config.
+	sentResponses       map[TypeURI]map[string]SentResponse
+	sentNonces          map[TypeURI][]string
+	lastAckedResources  map[TypeURI][]*any.Any
+	lastRejectedVersion map[TypeURI]string
+
+	// Incremental (delta) xDS state: the resources the proxy subscribed to
+	// and the versions of the resources the proxy currently holds.
+	subscriptions    map[TypeURI]*subscription
+	resourceVersions map[TypeURI]map[string]string
+}
+
+// subscription is the set of resources of a given type an Envoy proxy subscribed to via delta xDS.
+type subscription struct {
+	// wildcard is true when the proxy asked for all resources of the given type.
+	wildcard bool
+
+	names map[string]struct{}
 }
 
 // SetLastAppliedVersion records the version of the given Envoy proxy that was last acknowledged.
-func (p *Proxy) SetLastAppliedVersion(typeURI TypeURI, version uint64) {
+func (p *Proxy) SetLastAppliedVersion(typeURI TypeURI, version string) {
 	p.lastAppliedVersion[typeURI] = version
 }
 
 // GetLastAppliedVersion returns the last version successfully applied to the given Envoy proxy.
-func (p Proxy) GetLastAppliedVersion(typeURI TypeURI) uint64 {
+func (p Proxy) GetLastAppliedVersion(typeURI TypeURI) string {
 	return p.lastAppliedVersion[typeURI]
 }
 
 // GetLastSentVersion returns the last sent version.
-func (p Proxy) GetLastSentVersion(typeURI TypeURI) uint64 {
+func (p Proxy) GetLastSentVersion(typeURI TypeURI) string {
 	return p.lastSentVersion[typeURI]
 }
 
-// IncrementLastSentVersion increments last sent version.
-func (p *Proxy) IncrementLastSentVersion(typeURI TypeURI) uint64 {
-	p.lastSentVersion[typeURI]++
-	return p.GetLastSentVersion(typeURI)
+// SetLastSentVersion records the version of the given config last sent to the proxy.
+func (p *Proxy) SetLastSentVersion(typeURI TypeURI, version string) {
+	p.lastSentVersion[typeURI] = version
+}
+
+// GetSentResponse returns the response of the given type sent to the proxy with the given nonce, which the proxy did not ACK or NACK yet.
+func (p Proxy) GetSentResponse(typeURI TypeURI, nonce string) (SentResponse, bool) {
+	response, ok := p.sentResponses[typeURI][nonce]
+	return response, ok
 }
 
-// SetLastSentVersion records the version of the given config last sent to the proxy.
-func (p *Proxy) SetLastSentVersion(typeURI TypeURI, ver uint64) {
-	p.lastSentVersion[typeURI] = ver
+// SetSentResponse records the response of the given type sent to the proxy with the given nonce, until the proxy ACKs or NACKs it.
+func (p *Proxy) SetSentResponse(typeURI TypeURI, nonce string, response SentResponse) {
+	if _, ok := p.sentResponses[typeURI]; !ok {
+		p.sentResponses[typeURI] = make(map[string]SentResponse)
+	}
+	p.sentResponses[typeURI][nonce] = response
+	p.sentNonces[typeURI] = append(p.sentNonces[typeURI], nonce)
+}
+
+// RemoveSentResponse forgets the response of the given type sent to the proxy with the given nonce, once the proxy ACKed or NACKed it,
+// along with the responses of that type sent before it: the proxy handles the responses in order, so they were superseded.
+func (p *Proxy) RemoveSentResponse(typeURI TypeURI, nonce string) {
+	if _, ok := p.sentResponses[typeURI][nonce]; !ok {
+		return
+	}
+	for i, sentNonce := range p.sentNonces[typeURI] {
+		delete(p.sentResponses[typeURI], sentNonce)
+		if sentNonce == nonce {
+			p.sentNonces[typeURI] = p.sentNonces[typeURI][i+1:]
+			return
+		}
+	}
+}
+
+// GetLastAckedResources returns the resources of the given type the proxy last acknowledged - the last known good config.
+func (p Proxy) GetLastAckedResources(typeURI TypeURI) []*any.Any {
+	return p.lastAckedResources[typeURI]
+}
+
+// SetLastAckedResources records the resources of the given type the proxy last acknowledged.
+func (p *Proxy) SetLastAckedResources(typeURI TypeURI, resources []*any.Any) {
+	p.lastAckedResources[typeURI] = resources
+}
+
+// GetLastRejectedVersion returns the version of the given type the proxy last rejected.
+func (p Proxy) GetLastRejectedVersion(typeURI TypeURI) string {
+	return p.lastRejectedVersion[typeURI]
+}
+
+// SetLastRejectedVersion records the version of the given type the proxy last rejected.
+func (p *Proxy) SetLastRejectedVersion(typeURI TypeURI, version string) {
+	p.lastRejectedVersion[typeURI] = version
 }
 
 // GetLastSentNonce returns last sent nonce.
@@ -65,6 +139,87 @@ func (p *Proxy) SetNewNonce(typeURI TypeURI) string {
 	return p.lastNonce[typeURI]
 }
 
+// Subscribe records the resource names the proxy subscribed to via delta xDS.
+// An initial subscription without any resource names is a wildcard subscription.
+func (p *Proxy) Subscribe(typeURI TypeURI, names []string) {
+	sub, ok := p.subscriptions[typeURI]
+	if !ok {
+		sub = &subscription{
+			wildcard: len(names) == 0,
+			names:    make(map[string]struct{}),
+		}
+		p.subscriptions[typeURI] = sub
+	}
+	for _, name := range names {
+		if name == wildcardResourceName {
+			sub.wildcard = true
+			continue
+		}
+		sub.names[name] = struct{}{}
+	}
+}
+
+// Unsubscribe removes the given resource names from the proxy's delta xDS subscription.
+// The proxy no longer holds these resources.
+func (p *Proxy) Unsubscribe(typeURI TypeURI, names []string) {
+	sub, ok := p.subscriptions[typeURI]
+	if !ok {
+		return
+	}
+	for _, name := range names {
+		if name == wildcardResourceName {
+			sub.wildcard = false
+			continue
+		}
+		delete(sub.names, name)
+		delete(p.resourceVersions[typeURI], name)
+	}
+}
+
+// IsSubscribed returns true if the proxy subscribed to resources of the given type via delta xDS.
+func (p Proxy) IsSubscribed(typeURI TypeURI) bool {
+	_, ok := p.subscriptions[typeURI]
+	return ok
+}
+
+// IsWildcardSubscription returns true if the proxy subscribed to all resources of the given type.
+func (p Proxy) IsWildcardSubscription(typeURI TypeURI) bool {
+	sub, ok := p.subscriptions[typeURI]
+	return ok && sub.wildcard
+}
+
+// GetSubscribedResources returns the sorted names of the resources of the given type the proxy explicitly subscribed to.
+func (p Proxy) GetSubscribedResources(typeURI TypeURI) []string {
+	sub, ok := p.subscriptions[typeURI]
+	if !ok {
+		return nil
+	}
+	var names []string
+	for name := range sub.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetResourceVersions returns the versions of the resources of the given type the proxy holds, keyed by resource name.
+func (p Proxy) GetResourceVersions(typeURI TypeURI) map[string]string {
+	return p.resourceVersions[typeURI]
+}
+
+// SetResourceVersion records the version of a resource the proxy holds.
+func (p *Proxy) SetResourceVersion(typeURI TypeURI, name, version string) {
+	if _, ok := p.resourceVersions[typeURI]; !ok {
+		p.resourceVersions[typeURI] = make(map[string]string)
+	}
+	p.resourceVersions[typeURI][name] = version
+}
+
+// RemoveResourceVersion records that the proxy no longer holds the given resource.
+func (p *Proxy) RemoveResourceVersion(typeURI TypeURI, name string) {
+	delete(p.resourceVersions[typeURI], name)
+}
+
 // String returns the CommonName of the proxy.
 func (p Proxy) String() string {
 	return string(p.GetCommonName())
@@ -85,6 +240,30 @@ func (p Proxy) GetIP() net.Addr {
 	return p.Addr
 }
 
+// SetLocality records the locality of the node the pod of the proxy runs on.
+func (p *Proxy) SetLocality(locality endpoint.Locality) {
+	p.locality = locality
+}
+
+// GetLocality returns the locality of the node the pod of the proxy runs on, empty when it is unknown.
+func (p Proxy) GetLocality() endpoint.Locality {
+	return p.locality
+}
+
+// SetIPFamilies records the IP families of the addresses of the pod of the proxy, the family of its primary address first.
+func (p *Proxy) SetIPFamilies(ipFamilies []corev1.IPFamily) {
+	p.ipFamilies = ipFamilies
+}
+
+// GetIPFamilies returns the IP families of the addresses of the pod of the proxy, the family of its primary address first.
+// The pod of a proxy whose IP families are unknown is assumed to be an IPv4 pod.
+func (p Proxy) GetIPFamilies() []corev1.IPFamily {
+	if len(p.ipFamilies) == 0 {
+		return []corev1.IPFamily{corev1.IPv4Protocol}
+	}
+	return p.ipFamilies
+}
+
 // GetAnnouncementsChannel returns the announcement channel for the given Envoy proxy.
 func (p Proxy) GetAnnouncementsChannel() chan interface{} {
 	return p.announcements
@@ -98,9 +277,18 @@ func NewProxy(cn certificate.CommonName, ip net.Addr) *Proxy {
 
 		connectedAt: time.Now(),
 
-		announcements:      make(chan interface{}),
+		// A single pending announcement is enough for the proxy to pick up all changes
+		announcements:      make(chan interface{}, 1),
 		lastNonce:          make(map[TypeURI]string),
-		lastSentVersion:    make(map[TypeURI]uint64),
-		lastAppliedVersion: make(map[TypeURI]uint64),
+		lastSentVersion:    make(map[TypeURI]string),
+		lastAppliedVersion: make(map[TypeURI]string),
+
+		sentResponses:       make(map[TypeURI]map[string]SentResponse),
+		sentNonces:          make(map[TypeURI][]string),
+		lastAckedResources:  make(map[TypeURI][]*any.Any),
+		lastRejectedVersion: make(map[TypeURI]string),
+
+		subscriptions:    make(map[TypeURI]*subscription),
+		resourceVersions: make(map[TypeURI]map[string]string),
 	}
 }
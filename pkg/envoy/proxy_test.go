@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
@@ -25,3 +26,59 @@ var _ = Describe("Test proxy methods", func() {
 		})
 	})
 })
+
+var _ = Describe("Test proxy delta xDS subscriptions", func() {
+	Context("Testing proxy.Subscribe() and proxy.Unsubscribe()", func() {
+		It("should track wildcard and named subscriptions", func() {
+			proxy := NewProxy(certificate.CommonName("proxy-cn"), nil)
+			Expect(proxy.IsSubscribed(TypeCDS)).To(BeFalse())
+
+			proxy.Subscribe(TypeCDS, nil)
+			Expect(proxy.IsSubscribed(TypeCDS)).To(BeTrue())
+			Expect(proxy.IsWildcardSubscription(TypeCDS)).To(BeTrue())
+
+			proxy.Subscribe(TypeRDS, []string{"b", "a"})
+			Expect(proxy.IsWildcardSubscription(TypeRDS)).To(BeFalse())
+			Expect(proxy.GetSubscribedResources(TypeRDS)).To(Equal([]string{"a", "b"}))
+
+			proxy.SetResourceVersion(TypeRDS, "a", "v1")
+			proxy.Unsubscribe(TypeRDS, []string{"a"})
+			Expect(proxy.GetSubscribedResources(TypeRDS)).To(Equal([]string{"b"}))
+			Expect(proxy.GetResourceVersions(TypeRDS)).ToNot(HaveKey("a"))
+		})
+	})
+
+	Context("Testing proxy.RemoveSentResponse()", func() {
+		It("should forget the response with the given nonce and the responses of that type sent before it", func() {
+			proxy := NewProxy(certificate.CommonName("proxy-cn"), nil)
+			proxy.SetSentResponse(TypeCDS, "1", SentResponse{Version: "1"})
+			proxy.SetSentResponse(TypeCDS, "2", SentResponse{Version: "2"})
+			proxy.SetSentResponse(TypeCDS, "3", SentResponse{Version: "3"})
+			proxy.SetSentResponse(TypeLDS, "0", SentResponse{Version: "0"})
+
+			proxy.RemoveSentResponse(TypeCDS, "2")
+			_, pending := proxy.GetSentResponse(TypeCDS, "1")
+			Expect(pending).To(BeFalse())
+			_, pending = proxy.GetSentResponse(TypeCDS, "2")
+			Expect(pending).To(BeFalse())
+			_, pending = proxy.GetSentResponse(TypeCDS, "3")
+			Expect(pending).To(BeTrue())
+			_, pending = proxy.GetSentResponse(TypeLDS, "0")
+			Expect(pending).To(BeTrue())
+
+			proxy.RemoveSentResponse(TypeCDS, "unknown")
+			_, pending = proxy.GetSentResponse(TypeCDS, "3")
+			Expect(pending).To(BeTrue())
+		})
+	})
+
+	Context("Testing proxy.GetIPFamilies()", func() {
+		It("should return the IP families of the pod of the proxy, IPv4 when they are unknown", func() {
+			proxy := NewProxy(certificate.CommonName("proxy-cn"), nil)
+			Expect(proxy.GetIPFamilies()).To(Equal([]corev1.IPFamily{corev1.IPv4Protocol}))
+
+			proxy.SetIPFamilies([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol})
+			Expect(proxy.GetIPFamilies()).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+		})
+	})
+})
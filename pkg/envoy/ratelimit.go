@@ -0,0 +1,75 @@
+package envoy
+
+import (
+	"fmt"
+	"time"
+
+	udpa_type "github.com/cncf/udpa/go/udpa/type/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+const (
+	// LocalRateLimitFilterName is the name of the Envoy HTTP filter enforcing local rate limits
+	LocalRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+
+	// localRateLimitTypeURL is the type of the config of the Envoy HTTP local rate limit filter
+	localRateLimitTypeURL = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+
+	localRateLimitStatPrefix = "http_local_rate_limiter"
+
+	// localRateLimitFillInterval is the interval the token buckets of the local rate limits are filled at
+	localRateLimitFillInterval = 1 * time.Second
+)
+
+// GetLocalRateLimitConfig returns the config of the Envoy HTTP local rate limit filter accepting the given number of requests per second,
+// plus the given burst. The filter does not limit the requests when requestsPerSecond is 0, which lets the routes set their own limits.
+// The config is wrapped in a TypedStruct, as the local rate limit filter is more recent than the go-control-plane module in use.
+func GetLocalRateLimitConfig(requestsPerSecond uint32, burst uint32) (*any.Any, error) {
+	fields := map[string]*structpb.Value{
+		"stat_prefix": pbStringValue(localRateLimitStatPrefix),
+	}
+
+	if requestsPerSecond > 0 {
+		fields["token_bucket"] = pbStructValue(map[string]*structpb.Value{
+			"max_tokens":      pbNumberValue(requestsPerSecond + burst),
+			"tokens_per_fill": pbNumberValue(requestsPerSecond),
+			"fill_interval":   pbStringValue(fmt.Sprintf("%gs", localRateLimitFillInterval.Seconds())),
+		})
+		fields["filter_enabled"] = getRuntimeFractionalPercentAll("local_rate_limit_enabled")
+		fields["filter_enforced"] = getRuntimeFractionalPercentAll("local_rate_limit_enforced")
+	}
+
+	return ptypes.MarshalAny(&udpa_type.TypedStruct{
+		TypeUrl: localRateLimitTypeURL,
+		Value:   &structpb.Struct{Fields: fields},
+	})
+}
+
+// getRuntimeFractionalPercentAll returns a RuntimeFractionalPercent applying to all the requests, unless overridden by the given runtime key
+func getRuntimeFractionalPercentAll(runtimeKey string) *structpb.Value {
+	return pbStructValue(map[string]*structpb.Value{
+		"runtime_key": pbStringValue(runtimeKey),
+		"default_value": pbStructValue(map[string]*structpb.Value{
+			"numerator":   pbNumberValue(100),
+			"denominator": pbStringValue("HUNDRED"),
+		}),
+	})
+}
+
+func pbNumberValue(v uint32) *structpb.Value {
+	return &structpb.Value{
+		Kind: &structpb.Value_NumberValue{
+			NumberValue: float64(v),
+		},
+	}
+}
+
+func pbStructValue(fields map[string]*structpb.Value) *structpb.Value {
+	return &structpb.Value{
+		Kind: &structpb.Value_StructValue{
+			StructValue: &structpb.Struct{Fields: fields},
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package envoy
+
+import (
+	udpa_type "github.com/cncf/udpa/go/udpa/type/v1"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test local rate limit filter config", func() {
+	Context("Test GetLocalRateLimitConfig()", func() {
+		It("returns a config limiting the requests with a token bucket", func() {
+			rateLimitConfig, err := GetLocalRateLimitConfig(10, 5)
+			Expect(err).ToNot(HaveOccurred())
+
+			typedStruct := &udpa_type.TypedStruct{}
+			Expect(ptypes.UnmarshalAny(rateLimitConfig, typedStruct)).To(Succeed())
+			Expect(typedStruct.TypeUrl).To(Equal(localRateLimitTypeURL))
+
+			tokenBucket := typedStruct.Value.Fields["token_bucket"].GetStructValue()
+			Expect(tokenBucket.Fields["max_tokens"].GetNumberValue()).To(Equal(float64(15)))
+			Expect(tokenBucket.Fields["tokens_per_fill"].GetNumberValue()).To(Equal(float64(10)))
+			Expect(tokenBucket.Fields["fill_interval"].GetStringValue()).To(Equal("1s"))
+			Expect(typedStruct.Value.Fields).To(HaveKey("filter_enabled"))
+			Expect(typedStruct.Value.Fields).To(HaveKey("filter_enforced"))
+		})
+
+		It("returns a config which does not limit the requests without requests per second", func() {
+			rateLimitConfig, err := GetLocalRateLimitConfig(0, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			typedStruct := &udpa_type.TypedStruct{}
+			Expect(ptypes.UnmarshalAny(rateLimitConfig, typedStruct)).To(Succeed())
+			Expect(typedStruct.Value.Fields).To(HaveLen(1))
+			Expect(typedStruct.Value.Fields["stat_prefix"].GetStringValue()).To(Equal(localRateLimitStatPrefix))
+		})
+	})
+})
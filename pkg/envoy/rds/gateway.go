@@ -0,0 +1,54 @@
+package rds
+
+import (
+	set "github.com/deckarep/golang-set"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// gatewayRouteKeyPrefix prefixes the keys of the Gateway API routes, which are not aggregated with the other routes
+// of their host since they match more than the path of the requests
+const gatewayRouteKeyPrefix = "gateway:"
+
+// updateRoutesForGateway adds the Gateway API HTTPRoute routes of the service ports received on the given port of the local pod
+func updateRoutesForGateway(svc service.MeshService, port uint32, servicePorts []service.ServicePort, catalog catalog.MeshCataloger, routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters) error {
+	gatewayWeightedCluster := service.WeightedCluster{
+		ClusterName: service.ClusterName(envoy.GetClusterNameForPort(svc.String(), port)),
+		Weight:      constants.ClusterWeightAcceptAll,
+	}
+
+	for _, servicePort := range servicePorts {
+		if servicePort.TargetPort != port || !servicePort.IsHTTP() {
+			continue
+		}
+
+		gatewayRoutesPerHost, err := catalog.GetGatewayHTTPRoutesPerHost(svc, servicePort)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to get Gateway API route configuration for proxy %s", svc)
+			return err
+		}
+
+		for host, routes := range gatewayRoutesPerHost {
+			if _, exists := routesPerHost[host]; !exists {
+				routesPerHost[host] = make(map[string]trafficpolicy.RouteWeightedClusters)
+			}
+			for _, rt := range routes {
+				routesPerHost[host][gatewayRouteKeyPrefix+rt.Name] = trafficpolicy.RouteWeightedClusters{
+					HTTPRoute:        rt.HTTPRoute,
+					WeightedClusters: set.NewSet(gatewayWeightedCluster),
+					AppProtocol:      servicePort.Protocol,
+					RequestHeaders:   rt.RequestHeaders,
+					ResponseHeaders:  rt.ResponseHeaders,
+				}
+			}
+		}
+	}
+
+	log.Trace().Msgf("Gateway API routes for service %s: %+v", svc, routesPerHost)
+
+	return nil
+}
@@ -3,29 +3,33 @@ package rds
 import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-func updateRoutesForIngress(svc service.MeshService, catalog catalog.MeshCataloger, routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters) error {
-	ingressRoutesPerHost, err := catalog.GetIngressRoutesPerHost(svc)
-	if err != nil {
-		log.Error().Err(err).Msgf("Failed to get ingress route configuration for proxy %s", svc)
-		return err
-	}
-
-	if len(ingressRoutesPerHost) == 0 {
-		return nil
-	}
-
+// updateRoutesForIngress adds the ingress routes of the service ports received on the given port of the local pod
+func updateRoutesForIngress(svc service.MeshService, port uint32, servicePorts []service.ServicePort, catalog catalog.MeshCataloger, routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters) error {
 	ingressWeightedCluster := service.WeightedCluster{
-		ClusterName: service.ClusterName(svc.String()),
+		ClusterName: service.ClusterName(envoy.GetClusterNameForPort(svc.String(), port)),
 		Weight:      constants.ClusterWeightAcceptAll,
 	}
 
-	for host, routes := range ingressRoutesPerHost {
-		for _, rt := range routes {
-			aggregateRoutesByHost(routesPerHost, rt, ingressWeightedCluster, host)
+	for _, servicePort := range servicePorts {
+		if servicePort.TargetPort != port || !servicePort.IsHTTP() {
+			continue
+		}
+
+		ingressRoutesPerHost, err := catalog.GetIngressRoutesPerHost(svc, servicePort)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to get ingress route configuration for proxy %s", svc)
+			return err
+		}
+
+		for host, routes := range ingressRoutesPerHost {
+			for _, rt := range routes {
+				aggregateRoutesByHost(routesPerHost, rt, ingressWeightedCluster, host, servicePort.Protocol)
+			}
 		}
 	}
 
@@ -0,0 +1,52 @@
+package rds
+
+import (
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getRouteRateLimits returns the rate limits the given Backpressure policy sets on the routes of the HTTPRouteGroup matches,
+// keyed by the path regex of the matches.
+func getRouteRateLimits(meshSpec smi.MeshSpec, backpressure *osmPolicy.Backpressure) map[string]*trafficpolicy.RateLimit {
+	routeRateLimits := make(map[string]*trafficpolicy.RateLimit)
+	if backpressure == nil || backpressure.Spec.RateLimit == nil || len(backpressure.Spec.RateLimit.Routes) == 0 {
+		return routeRateLimits
+	}
+
+	routeGroups := meshSpec.ListHTTPTrafficSpecs()
+	for _, routeRateLimit := range backpressure.Spec.RateLimit.Routes {
+		found := false
+		for _, routeGroup := range routeGroups {
+			if routeGroup.Namespace != backpressure.Namespace || routeGroup.Name != routeRateLimit.HTTPRouteGroup {
+				continue
+			}
+			for _, match := range routeGroup.Spec.Matches {
+				if match.Name == routeRateLimit.Match {
+					routeRateLimits[match.PathRegex] = &trafficpolicy.RateLimit{
+						RequestsPerSecond: routeRateLimit.RequestsPerSecond,
+						Burst:             routeRateLimit.Burst,
+					}
+					found = true
+				}
+			}
+		}
+		if !found {
+			log.Error().Msgf("Match %s of HTTPRouteGroup %s/%s referenced by Backpressure policy %s/%s not found",
+				routeRateLimit.Match, backpressure.Namespace, routeRateLimit.HTTPRouteGroup, backpressure.Namespace, backpressure.Name)
+		}
+	}
+	return routeRateLimits
+}
+
+// applyRouteRateLimits sets the given rate limits, keyed by path regex, on the routes of all the hosts with the same path regex
+func applyRouteRateLimits(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters, routeRateLimits map[string]*trafficpolicy.RateLimit) {
+	for _, routes := range routesPerHost {
+		for pathRegex, rateLimit := range routeRateLimits {
+			if routePolicyWeightedClusters, found := routes[pathRegex]; found {
+				routePolicyWeightedClusters.RateLimit = rateLimit
+				routes[pathRegex] = routePolicyWeightedClusters
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package rds
+
+import (
+	set "github.com/deckarep/golang-set"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Rate limits of the routes", func() {
+	backpressure := &osmPolicy.Backpressure{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: tests.HTTPRouteGroup.Namespace,
+			Name:      "bookstore-rate-limit",
+		},
+		Spec: osmPolicy.BackpressureSpec{
+			RateLimit: &osmPolicy.RateLimit{
+				Routes: []osmPolicy.RouteRateLimit{{HTTPRouteGroup: tests.HTTPRouteGroup.Name, Match: tests.BuyBooksMatchName, RequestsPerSecond: 5}},
+			},
+		},
+	}
+
+	Context("Testing getRouteRateLimits and applyRouteRateLimits", func() {
+		It("Sets the rate limit on the routes of the HTTPRouteGroup match", func() {
+			routeRateLimits := getRouteRateLimits(smi.NewFakeMeshSpecClient(), backpressure)
+			Expect(routeRateLimits).To(HaveKey(tests.BookstoreBuyPath))
+
+			routesPerHost := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+				"bookstore.mesh": {
+					tests.BookstoreBuyPath:  {HTTPRoute: trafficpolicy.HTTPRoute{PathRegex: tests.BookstoreBuyPath}, WeightedClusters: set.NewSet(tests.WeightedService)},
+					tests.BookstoreSellPath: {HTTPRoute: trafficpolicy.HTTPRoute{PathRegex: tests.BookstoreSellPath}, WeightedClusters: set.NewSet(tests.WeightedService)},
+				},
+			}
+			applyRouteRateLimits(routesPerHost, routeRateLimits)
+			Expect(routesPerHost["bookstore.mesh"][tests.BookstoreBuyPath].RateLimit).To(Equal(&trafficpolicy.RateLimit{RequestsPerSecond: 5}))
+			Expect(routesPerHost["bookstore.mesh"][tests.BookstoreSellPath].RateLimit).To(BeNil())
+		})
+	})
+})
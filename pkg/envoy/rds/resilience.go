@@ -0,0 +1,21 @@
+package rds
+
+import (
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyResiliencePolicy sets the timeout and the retry policy of the given ResiliencePolicy on the outbound routes to its destination service
+func applyResiliencePolicy(routes map[string]trafficpolicy.RouteWeightedClusters, resiliencePolicy *osmPolicy.ResiliencePolicy) {
+	for pathRegex, routePolicyWeightedClusters := range routes {
+		routePolicyWeightedClusters.Timeout = resiliencePolicy.Spec.Timeout.Duration
+		if retry := resiliencePolicy.Spec.Retry; retry != nil {
+			routePolicyWeightedClusters.RetryPolicy = &trafficpolicy.RetryPolicy{
+				NumRetries:    retry.Attempts,
+				PerTryTimeout: retry.PerTryTimeout.Duration,
+				RetryOn:       retry.RetryOn,
+			}
+		}
+		routes[pathRegex] = routePolicyWeightedClusters
+	}
+}
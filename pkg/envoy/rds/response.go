@@ -6,10 +6,12 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/golang/protobuf/ptypes"
 
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
@@ -35,11 +37,21 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 		TypeUrl: string(envoy.TypeRDS),
 	}
 
-	var routeConfiguration []*xds_route.RouteConfiguration
-	outboundRouteConfig := route.NewRouteConfigurationStub(route.OutboundRouteConfigName)
-	inboundRouteConfig := route.NewRouteConfigurationStub(route.InboundRouteConfigName)
-	outboundAggregatedRoutesByHostnames := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
-	inboundAggregatedRoutesByHostnames := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+	// There is a route configuration for each port traffic is routed on: the ports of the destination services
+	// for outbound traffic, and the ports of the local pod for inbound traffic.
+	outboundAggregatedRoutesByPort := make(map[uint32]map[string]map[string]trafficpolicy.RouteWeightedClusters)
+	inboundAggregatedRoutesByPort := make(map[uint32]map[string]map[string]trafficpolicy.RouteWeightedClusters)
+
+	localPorts, err := catalog.ListServicePorts(proxyServiceName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed listing ports of service %s", proxyServiceName)
+		return nil, err
+	}
+	for _, port := range localPorts {
+		if port.IsHTTP() {
+			inboundAggregatedRoutesByPort[port.TargetPort] = make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+		}
+	}
 
 	for _, trafficPolicies := range allTrafficPolicies {
 		isSourceService := trafficPolicies.Source.Equals(proxyServiceName)
@@ -55,24 +67,69 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 			log.Error().Err(err).Msg("Failed listing clusters")
 			return nil, err
 		}
-
-		if isSourceService {
-			aggregateRoutesByHost(outboundAggregatedRoutesByHostnames, trafficPolicies.HTTPRoute, weightedCluster, hostnames)
+		ports, err := catalog.ListServicePorts(svc)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed listing ports")
+			return nil, err
+		}
+		var resiliencePolicy *osmPolicy.ResiliencePolicy
+		if isSourceService && featureflags.IsResiliencePolicyEnabled() {
+			resiliencePolicy = catalog.GetSMISpec().GetResiliencePolicy(svc)
 		}
 
-		if isDestinationService {
-			aggregateRoutesByHost(inboundAggregatedRoutesByHostnames, trafficPolicies.HTTPRoute, weightedCluster, hostnames)
+		for _, port := range ports {
+			if !port.IsHTTP() {
+				// Only HTTP based traffic is routed
+				continue
+			}
+
+			if isSourceService {
+				// Outbound traffic is sent to the port of the destination service
+				routesPerHost, found := outboundAggregatedRoutesByPort[port.Port]
+				if !found {
+					routesPerHost = make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+					outboundAggregatedRoutesByPort[port.Port] = routesPerHost
+				}
+				aggregateRoutesByHost(routesPerHost, trafficPolicies.HTTPRoute, getWeightedClusterForPort(weightedCluster, port.Port), hostnames, port.Protocol)
+				if resiliencePolicy != nil {
+					applyResiliencePolicy(routesPerHost[hostnames], resiliencePolicy)
+				}
+			}
+
+			if isDestinationService {
+				// Inbound traffic is received on the port of the local pod
+				if routesPerHost, found := inboundAggregatedRoutesByPort[port.TargetPort]; found {
+					aggregateRoutesByHost(routesPerHost, trafficPolicies.HTTPRoute, getWeightedClusterForPort(weightedCluster, port.TargetPort), hostnames, port.Protocol)
+				}
+			}
 		}
 	}
 
-	if err = updateRoutesForIngress(proxyServiceName, catalog, inboundAggregatedRoutesByHostnames); err != nil {
-		return nil, err
+	var routeConfiguration []*xds_route.RouteConfiguration
+	for port, routesPerHost := range outboundAggregatedRoutesByPort {
+		outboundRouteConfig := route.NewRouteConfigurationStub(route.GetOutboundRouteConfigNameForPort(port))
+		route.UpdateRouteConfiguration(routesPerHost, outboundRouteConfig, route.OutboundRoute)
+		routeConfiguration = append(routeConfiguration, outboundRouteConfig)
+	}
+
+	routeRateLimits := make(map[string]*trafficpolicy.RateLimit)
+	if featureflags.IsBackpressureEnabled() {
+		routeRateLimits = getRouteRateLimits(catalog.GetSMISpec(), catalog.GetSMISpec().GetBackpressurePolicy(proxyServiceName))
 	}
 
-	route.UpdateRouteConfiguration(outboundAggregatedRoutesByHostnames, outboundRouteConfig, route.OutboundRoute)
-	route.UpdateRouteConfiguration(inboundAggregatedRoutesByHostnames, inboundRouteConfig, route.InboundRoute)
-	routeConfiguration = append(routeConfiguration, outboundRouteConfig)
-	routeConfiguration = append(routeConfiguration, inboundRouteConfig)
+	for port, routesPerHost := range inboundAggregatedRoutesByPort {
+		if err = updateRoutesForIngress(proxyServiceName, port, localPorts, catalog, routesPerHost); err != nil {
+			return nil, err
+		}
+		if err = updateRoutesForGateway(proxyServiceName, port, localPorts, catalog, routesPerHost); err != nil {
+			return nil, err
+		}
+		applyRouteRateLimits(routesPerHost, routeRateLimits)
+
+		inboundRouteConfig := route.NewRouteConfigurationStub(route.GetInboundRouteConfigNameForPort(port))
+		route.UpdateRouteConfiguration(routesPerHost, inboundRouteConfig, route.InboundRoute)
+		routeConfiguration = append(routeConfiguration, inboundRouteConfig)
+	}
 
 	for _, config := range routeConfiguration {
 		marshalledRouteConfig, err := ptypes.MarshalAny(config)
@@ -85,7 +142,15 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	return resp, nil
 }
 
-func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters, routePolicy trafficpolicy.HTTPRoute, weightedCluster service.WeightedCluster, host string) {
+// getWeightedClusterForPort returns the weighted cluster for the given port of the service backing the given weighted cluster
+func getWeightedClusterForPort(weightedCluster service.WeightedCluster, port uint32) service.WeightedCluster {
+	return service.WeightedCluster{
+		ClusterName: service.ClusterName(envoy.GetClusterNameForPort(string(weightedCluster.ClusterName), port)),
+		Weight:      weightedCluster.Weight,
+	}
+}
+
+func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters, routePolicy trafficpolicy.HTTPRoute, weightedCluster service.WeightedCluster, host string, appProtocol service.AppProtocol) {
 	_, exists := routesPerHost[host]
 	if !exists {
 		// no host found, create a new route map
@@ -105,13 +170,20 @@ func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.Rou
 		routesPerHost[host][routePolicy.PathRegex] = routePolicyWeightedCluster
 	} else {
 		// no route found, create a new route and cluster mapping on host
-		routesPerHost[host][routePolicy.PathRegex] = createRoutePolicyWeightedClusters(routePolicy, weightedCluster)
+		routesPerHost[host][routePolicy.PathRegex] = createRoutePolicyWeightedClusters(routePolicy, weightedCluster, appProtocol)
 	}
 }
 
-func createRoutePolicyWeightedClusters(routePolicy trafficpolicy.HTTPRoute, weightedCluster service.WeightedCluster) trafficpolicy.RouteWeightedClusters {
+func createRoutePolicyWeightedClusters(routePolicy trafficpolicy.HTTPRoute, weightedCluster service.WeightedCluster, appProtocol service.AppProtocol) trafficpolicy.RouteWeightedClusters {
 	return trafficpolicy.RouteWeightedClusters{
 		HTTPRoute:        routePolicy,
 		WeightedClusters: set.NewSet(weightedCluster),
+		AppProtocol:      appProtocol,
 	}
 }
+
+// GetSnapshotVariant returns the inputs specific to the given proxy, other than its service, which the routes depend on:
+// there are none, so all the proxies of a service share the snapshot of the routes.
+func GetSnapshotVariant(_ catalog.MeshCataloger, _ *envoy.Proxy) (string, error) {
+	return "", nil
+}
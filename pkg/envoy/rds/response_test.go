@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
@@ -19,6 +20,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -45,7 +47,7 @@ var _ = Describe("Construct RoutePolicyWeightedClusters object", func() {
 				Methods:   []string{"GET"},
 			}
 
-			routePolicyWeightedClusters := createRoutePolicyWeightedClusters(routePolicy, weightedCluster)
+			routePolicyWeightedClusters := createRoutePolicyWeightedClusters(routePolicy, weightedCluster, service.AppProtocolHTTP)
 			Expect(routePolicyWeightedClusters).NotTo(Equal(nil))
 			Expect(routePolicyWeightedClusters.HTTPRoute.PathRegex).To(Equal("/books-bought"))
 			Expect(routePolicyWeightedClusters.HTTPRoute.Methods).To(Equal([]string{"GET"}))
@@ -71,7 +73,7 @@ var _ = Describe("AggregateRoutesByDomain", func() {
 			weightedClustersMap.Add(weightedCluster)
 
 			for _, routePolicy := range routePolicies {
-				aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh")
+				aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh", service.AppProtocolHTTP)
 			}
 			Expect(domainRoutesMap).NotTo(Equal(nil))
 			Expect(len(domainRoutesMap)).To(Equal(1))
@@ -104,7 +106,7 @@ var _ = Describe("AggregateRoutesByDomain", func() {
 			}
 			weightedClustersMap.Add(weightedCluster)
 
-			aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh")
+			aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh", service.AppProtocolHTTP)
 			Expect(domainRoutesMap).NotTo(Equal(nil))
 			Expect(len(domainRoutesMap)).To(Equal(1))
 			Expect(len(domainRoutesMap["bookstore.mesh"])).To(Equal(3))
@@ -131,7 +133,7 @@ var _ = Describe("AggregateRoutesByDomain", func() {
 			}
 			weightedClustersMap.Add(weightedCluster)
 
-			aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh")
+			aggregateRoutesByHost(domainRoutesMap, routePolicy, weightedCluster, "bookstore.mesh", service.AppProtocolHTTP)
 			Expect(domainRoutesMap).NotTo(Equal(nil))
 			Expect(len(domainRoutesMap)).To(Equal(1))
 			Expect(len(domainRoutesMap["bookstore.mesh"])).To(Equal(3))
@@ -152,7 +154,7 @@ var _ = Describe("RDS Response", func() {
 
 	endpointProviders := []endpoint.Provider{kube.NewFakeProvider()}
 	kubeClient := testclient.NewSimpleClientset()
-	cache := make(map[certificate.CommonName]certificate.Certificater)
+	cache := make(map[certificate.CacheKey]certificate.Certificater)
 	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 
 	stop := make(<-chan struct{})
@@ -160,7 +162,7 @@ var _ = Describe("RDS Response", func() {
 	osmConfigMapName := "-test-osm-config-map-"
 	cfg := configurator.NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
 
-	testChan := make(chan interface{})
+	testChan := make(chan announcements.Announcement)
 	monitoredNamespace := []string{
 		tests.BookstoreService.Namespace,
 		tests.BookbuyerService.Namespace,
@@ -173,7 +175,7 @@ var _ = Describe("RDS Response", func() {
 	mockNsController.EXPECT().ListMonitoredNamespaces().Return(monitoredNamespace, nil).AnyTimes()
 
 	meshCatalog := catalog.NewMeshCatalog(mockNsController, kubeClient, smi.NewFakeMeshSpecClient(), certManager,
-		ingress.NewFakeIngressMonitor(), make(<-chan struct{}), cfg, endpointProviders...)
+		ingress.NewFakeIngressMonitor(), gateway.NewFakeGatewayMonitor(), make(<-chan struct{}), cfg, endpointProviders...)
 
 	Context("Test GetHostnamesForService", func() {
 		contains := func(domains []string, expected string) bool {
@@ -254,4 +256,17 @@ var _ = Describe("RDS Response", func() {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("Test getWeightedClusterForPort", func() {
+		It("returns the weighted cluster for the port of the service", func() {
+			weightedCluster := service.WeightedCluster{
+				ClusterName: service.ClusterName(tests.BookstoreService.String()),
+				Weight:      tests.Weight,
+			}
+
+			actual := getWeightedClusterForPort(weightedCluster, 8080)
+			Expect(actual.ClusterName).To(Equal(service.ClusterName("default/bookstore|8080")))
+			Expect(actual.Weight).To(Equal(tests.Weight))
+		})
+	})
 })
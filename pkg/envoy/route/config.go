@@ -29,10 +29,10 @@ const (
 )
 
 const (
-	//InboundRouteConfigName is the name of the route config that the envoy will identify
+	//InboundRouteConfigName is the prefix of the names of the inbound route configs that the envoy will identify
 	InboundRouteConfigName = "RDS_Inbound"
 
-	//OutboundRouteConfigName is the name of the route config that the envoy will identify
+	//OutboundRouteConfigName is the prefix of the names of the outbound route configs that the envoy will identify
 	OutboundRouteConfigName = "RDS_Outbound"
 
 	// maxRegexProgramSize is the max supported regex complexity
@@ -54,6 +54,16 @@ const (
 	httpHostHeader = "host"
 )
 
+// GetInboundRouteConfigNameForPort returns the name of the route config for the inbound HTTP traffic received on the given port of the local pod
+func GetInboundRouteConfigNameForPort(port uint32) string {
+	return fmt.Sprintf("%s_%d", InboundRouteConfigName, port)
+}
+
+// GetOutboundRouteConfigNameForPort returns the name of the route config for the outbound HTTP traffic sent to the given port of the destination services
+func GetOutboundRouteConfigNameForPort(port uint32) string {
+	return fmt.Sprintf("%s_%d", OutboundRouteConfigName, port)
+}
+
 //UpdateRouteConfiguration consrtucts the Envoy construct necessary for TrafficTarget implementation
 func UpdateRouteConfiguration(domainRoutesMap map[string]map[string]trafficpolicy.RouteWeightedClusters, routeConfig *xds_route.RouteConfiguration, direction Direction) {
 	log.Trace().Msgf("[RDS] Updating Route Configuration")
@@ -101,22 +111,76 @@ func createRoutes(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteW
 		weightedClusters := getDistinctWeightedClusters(routePolicyWeightedClustersMap)
 		totalClustersWeight := getTotalWeightForClusters(weightedClusters)
 		emptyHeaders := make(map[string]string)
+		timeout, retryPolicy := getResiliencePolicy(routePolicyWeightedClustersMap)
+		rateLimit := getRateLimit(routePolicyWeightedClustersMap)
+		if isGRPCRoute(routePolicyWeightedClustersMap) {
+			route := getGRPCRoute(constants.RegexMatchAll, emptyHeaders, weightedClusters, totalClustersWeight, OutboundRoute)
+			applyResiliencePolicy(route, timeout, retryPolicy, grpcRetryOn)
+			applyRateLimit(route, rateLimit)
+			routes = append(routes, route)
+			return routes
+		}
 		route := getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, emptyHeaders, weightedClusters, totalClustersWeight, OutboundRoute)
+		applyResiliencePolicy(route, timeout, retryPolicy, httpRetryOn)
+		applyRateLimit(route, rateLimit)
 		routes = append(routes, route)
 		return routes
 	}
-	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+	for _, routeKey := range getRouteKeysBySpecificity(routePolicyWeightedClustersMap) {
+		routePolicyWeightedClusters := routePolicyWeightedClustersMap[routeKey]
+		if routePolicyWeightedClusters.AppProtocol.IsGRPC() {
+			// gRPC methods are identified by their path, and are always called with the POST HTTP method
+			route := getGRPCRoute(routePolicyWeightedClusters.HTTPRoute.PathRegex, routePolicyWeightedClusters.HTTPRoute.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction)
+			route.Match.QueryParameters = getQueryParametersForRoute(routePolicyWeightedClusters.HTTPRoute.QueryParams)
+			applyRateLimit(route, routePolicyWeightedClusters.RateLimit)
+			applyHeaderModifiers(route, routePolicyWeightedClusters.RequestHeaders, routePolicyWeightedClusters.ResponseHeaders)
+			routes = append(routes, route)
+			continue
+		}
 		// For a given route path, sanitize the methods in case there
 		// is wildcard or if there are duplicates
 		allowedMethods := sanitizeHTTPMethods(routePolicyWeightedClusters.HTTPRoute.Methods)
 		for _, method := range allowedMethods {
 			route := getRoute(routePolicyWeightedClusters.HTTPRoute.PathRegex, method, routePolicyWeightedClusters.HTTPRoute.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction)
+			route.Match.QueryParameters = getQueryParametersForRoute(routePolicyWeightedClusters.HTTPRoute.QueryParams)
+			applyRateLimit(route, routePolicyWeightedClusters.RateLimit)
+			applyHeaderModifiers(route, routePolicyWeightedClusters.RequestHeaders, routePolicyWeightedClusters.ResponseHeaders)
 			routes = append(routes, route)
 		}
 	}
 	return routes
 }
 
+// getRouteKeysBySpecificity returns the keys of the given routes, the most specific routes first.
+// Envoy applies the first route matching a request, the routes with the most match conditions and then
+// the longest paths are ordered first so that they are not shadowed by less specific routes.
+func getRouteKeysBySpecificity(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) []string {
+	var routeKeys []string
+	for routeKey := range routePolicyWeightedClustersMap {
+		routeKeys = append(routeKeys, routeKey)
+	}
+
+	matchConditions := func(routePolicy trafficpolicy.HTTPRoute) int {
+		conditions := len(routePolicy.Headers) + len(routePolicy.QueryParams)
+		if methods := sanitizeHTTPMethods(routePolicy.Methods); len(methods) > 0 && methods[0] != constants.WildcardHTTPMethod {
+			conditions++
+		}
+		return conditions
+	}
+	sort.Slice(routeKeys, func(i, j int) bool {
+		routeI := routePolicyWeightedClustersMap[routeKeys[i]].HTTPRoute
+		routeJ := routePolicyWeightedClustersMap[routeKeys[j]].HTTPRoute
+		if conditionsI, conditionsJ := matchConditions(routeI), matchConditions(routeJ); conditionsI != conditionsJ {
+			return conditionsI > conditionsJ
+		}
+		if len(routeI.PathRegex) != len(routeJ.PathRegex) {
+			return len(routeI.PathRegex) > len(routeJ.PathRegex)
+		}
+		return routeKeys[i] < routeKeys[j]
+	})
+	return routeKeys
+}
+
 func getRoute(pathRegex string, method string, headersMap map[string]string, weightedClusters set.Set, totalClustersWeight int, direction Direction) *xds_route.Route {
 	route := xds_route.Route{
 		Match: &xds_route.RouteMatch{
@@ -155,6 +219,12 @@ func getHeadersForRoute(method string, headersMap map[string]string) []*xds_rout
 	headers = append(headers, &methodsHeader)
 
 	// add all other custom headers
+	headers = append(headers, getCustomHeadersForRoute(headersMap)...)
+	return headers
+}
+
+func getCustomHeadersForRoute(headersMap map[string]string) []*xds_route.HeaderMatcher {
+	var headers []*xds_route.HeaderMatcher
 	for headerKey, headerValue := range headersMap {
 		// omit the host header as we have already configured this
 		if headerKey == httpHostHeader {
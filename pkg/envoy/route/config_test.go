@@ -205,6 +205,13 @@ var _ = Describe("Routes with weighted clusters", func() {
 })
 
 var _ = Describe("Route Configuration", func() {
+	Context("Testing the names of the route configurations", func() {
+		It("Returns the name of the route configuration of a port", func() {
+			Expect(GetInboundRouteConfigNameForPort(8080)).To(Equal("RDS_Inbound_8080"))
+			Expect(GetOutboundRouteConfigNameForPort(80)).To(Equal("RDS_Outbound_80"))
+		})
+	})
+
 	Context("Testing creation of RouteConfiguration object", func() {
 		It("Returns outbound route configuration", func() {
 
@@ -292,6 +299,13 @@ var _ = Describe("Route Configuration", func() {
 })
 
 var _ = Describe("Route Configuration", func() {
+	Context("Testing the names of the route configurations", func() {
+		It("Returns the name of the route configuration of a port", func() {
+			Expect(GetInboundRouteConfigNameForPort(8080)).To(Equal("RDS_Inbound_8080"))
+			Expect(GetOutboundRouteConfigNameForPort(80)).To(Equal("RDS_Outbound_80"))
+		})
+	})
+
 	Context("Testing regex matches for HTTP methods", func() {
 		It("Tests that the wildcard HTTP method correctly translates to a match all regex", func() {
 			regex := getRegexForMethod("*")
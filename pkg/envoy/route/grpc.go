@@ -0,0 +1,63 @@
+package route
+
+import (
+	set "github.com/deckarep/golang-set"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// grpcRetryOn is the list of the gRPC status codes of the failed requests retried by the outbound gRPC routes
+	grpcRetryOn = "cancelled,resource-exhausted,unavailable"
+
+	// grpcNumRetries is the number of times the outbound gRPC routes retry a failed request
+	grpcNumRetries = 2
+)
+
+// getGRPCRoute returns a route matching the gRPC requests whose path, of the form /<package>.<service>/<method>, matches the given regex.
+// The outbound gRPC routes retry the requests failing with a transient gRPC status.
+func getGRPCRoute(pathRegex string, headersMap map[string]string, weightedClusters set.Set, totalClustersWeight int, direction Direction) *xds_route.Route {
+	routeAction := &xds_route.RouteAction{
+		ClusterSpecifier: &xds_route.RouteAction_WeightedClusters{
+			WeightedClusters: getWeightedCluster(weightedClusters, totalClustersWeight, direction),
+		},
+	}
+
+	if direction == OutboundRoute {
+		routeAction.RetryPolicy = &xds_route.RetryPolicy{
+			RetryOn:    grpcRetryOn,
+			NumRetries: &wrappers.UInt32Value{Value: grpcNumRetries},
+		}
+	}
+
+	return &xds_route.Route{
+		Match: &xds_route.RouteMatch{
+			PathSpecifier: &xds_route.RouteMatch_SafeRegex{
+				SafeRegex: &xds_matcher.RegexMatcher{
+					EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+					Regex:      pathRegex,
+				},
+			},
+			// The gRPC methods are always called with the POST HTTP method, so the route does not match the method
+			Headers: getCustomHeadersForRoute(headersMap),
+			// Only match the gRPC requests, identified by their content-type
+			Grpc: &xds_route.RouteMatch_GrpcRouteMatchOptions{},
+		},
+		Action: &xds_route.Route_Route{
+			Route: routeAction,
+		},
+	}
+}
+
+// isGRPCRoute returns true when the given routes match gRPC traffic
+func isGRPCRoute(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) bool {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.AppProtocol.IsGRPC() {
+			return true
+		}
+	}
+	return false
+}
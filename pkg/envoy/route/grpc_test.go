@@ -0,0 +1,50 @@
+package route
+
+import (
+	set "github.com/deckarep/golang-set"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("gRPC routes", func() {
+	Context("Testing creation of gRPC routes", func() {
+		weightedClusters := set.NewSet(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-grpc"), Weight: 100})
+		routePolicy := trafficpolicy.HTTPRoute{
+			PathRegex: "/bookstore.Books/Buy",
+			Methods:   []string{"GET", "POST"},
+		}
+		routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+			routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters, AppProtocol: service.AppProtocolGRPC},
+		}
+
+		It("Returns a single gRPC route per method path", func() {
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetSafeRegex().Regex).To(Equal(routePolicy.PathRegex))
+			Expect(rt[0].Match.Grpc).ToNot(BeNil())
+			Expect(rt[0].Match.GetHeaders()).To(BeEmpty())
+			Expect(rt[0].GetRoute().RetryPolicy).To(BeNil())
+		})
+
+		It("Returns a wildcard outbound gRPC route retrying the failed requests", func() {
+			rt := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetSafeRegex().Regex).To(Equal(constants.RegexMatchAll))
+			Expect(rt[0].Match.Grpc).ToNot(BeNil())
+			Expect(rt[0].GetRoute().RetryPolicy.RetryOn).To(Equal(grpcRetryOn))
+			Expect(rt[0].GetRoute().RetryPolicy.NumRetries.Value).To(Equal(uint32(grpcNumRetries)))
+		})
+
+		It("Detects the routes matching gRPC traffic", func() {
+			Expect(isGRPCRoute(routeWeightedClustersMap)).To(BeTrue())
+			Expect(isGRPCRoute(map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters, AppProtocol: service.AppProtocolHTTP},
+			})).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package route
+
+import (
+	"sort"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getQueryParametersForRoute returns the matchers of the query parameters mapped to the regexes their values match
+func getQueryParametersForRoute(queryParams map[string]string) []*xds_route.QueryParameterMatcher {
+	var queryParameters []*xds_route.QueryParameterMatcher
+	for _, name := range sortedKeys(queryParams) {
+		queryParameters = append(queryParameters, &xds_route.QueryParameterMatcher{
+			Name: name,
+			QueryParameterMatchSpecifier: &xds_route.QueryParameterMatcher_StringMatch{
+				StringMatch: &xds_matcher.StringMatcher{
+					MatchPattern: &xds_matcher.StringMatcher_SafeRegex{
+						SafeRegex: &xds_matcher.RegexMatcher{
+							EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+							Regex:      queryParams[name],
+						},
+					},
+				},
+			},
+		})
+	}
+	return queryParameters
+}
+
+// applyHeaderModifiers configures the route to modify the headers of the requests it matches and of their responses
+func applyHeaderModifiers(route *xds_route.Route, requestHeaders, responseHeaders *trafficpolicy.HeaderModifier) {
+	if requestHeaders != nil {
+		route.RequestHeadersToAdd = getHeadersToAdd(requestHeaders)
+		route.RequestHeadersToRemove = requestHeaders.Remove
+	}
+	if responseHeaders != nil {
+		route.ResponseHeadersToAdd = getHeadersToAdd(responseHeaders)
+		route.ResponseHeadersToRemove = responseHeaders.Remove
+	}
+}
+
+// getHeadersToAdd returns the headers set, replacing the existing values, and added, appended to the existing values
+func getHeadersToAdd(headerModifier *trafficpolicy.HeaderModifier) []*xds_core.HeaderValueOption {
+	var headers []*xds_core.HeaderValueOption
+	for _, name := range sortedKeys(headerModifier.Set) {
+		headers = append(headers, &xds_core.HeaderValueOption{
+			Header: &xds_core.HeaderValue{Key: name, Value: headerModifier.Set[name]},
+			Append: &wrappers.BoolValue{Value: false},
+		})
+	}
+	for _, name := range sortedKeys(headerModifier.Add) {
+		headers = append(headers, &xds_core.HeaderValueOption{
+			Header: &xds_core.HeaderValue{Key: name, Value: headerModifier.Add[name]},
+			Append: &wrappers.BoolValue{Value: true},
+		})
+	}
+	return headers
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,79 @@
+package route
+
+import (
+	set "github.com/deckarep/golang-set"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Routes with query parameters and header modifications", func() {
+	weightedClusters := set.NewSet(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore"), Weight: 100})
+
+	Context("Testing the query parameters and header modifications of the inbound routes", func() {
+		It("Matches the query parameters and modifies the headers", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				"books": {
+					HTTPRoute: trafficpolicy.HTTPRoute{
+						PathRegex:   "/books",
+						Methods:     []string{"GET"},
+						QueryParams: map[string]string{"page": "[0-9]+", "author": "tolstoy"},
+					},
+					WeightedClusters: weightedClusters,
+					RequestHeaders: &trafficpolicy.HeaderModifier{
+						Set:    map[string]string{"x-set": "set"},
+						Add:    map[string]string{"x-add": "add"},
+						Remove: []string{"x-remove"},
+					},
+					ResponseHeaders: &trafficpolicy.HeaderModifier{Remove: []string{"server"}},
+				},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+
+			queryParameters := rt[0].Match.QueryParameters
+			Expect(len(queryParameters)).To(Equal(2))
+			Expect(queryParameters[0].Name).To(Equal("author"))
+			Expect(queryParameters[0].GetStringMatch().GetSafeRegex().Regex).To(Equal("tolstoy"))
+			Expect(queryParameters[1].Name).To(Equal("page"))
+			Expect(queryParameters[1].GetStringMatch().GetSafeRegex().Regex).To(Equal("[0-9]+"))
+
+			Expect(len(rt[0].RequestHeadersToAdd)).To(Equal(2))
+			Expect(rt[0].RequestHeadersToAdd[0].Header.Key).To(Equal("x-set"))
+			Expect(rt[0].RequestHeadersToAdd[0].Append.Value).To(BeFalse())
+			Expect(rt[0].RequestHeadersToAdd[1].Header.Key).To(Equal("x-add"))
+			Expect(rt[0].RequestHeadersToAdd[1].Append.Value).To(BeTrue())
+			Expect(rt[0].RequestHeadersToRemove).To(Equal([]string{"x-remove"}))
+			Expect(rt[0].ResponseHeadersToAdd).To(BeEmpty())
+			Expect(rt[0].ResponseHeadersToRemove).To(Equal([]string{"server"}))
+		})
+
+		It("Orders the most specific routes first", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				"all": {
+					HTTPRoute:        trafficpolicy.HTTPRoute{PathRegex: constants.RegexMatchAll, Methods: []string{constants.WildcardHTTPMethod}},
+					WeightedClusters: weightedClusters,
+				},
+				"books": {
+					HTTPRoute:        trafficpolicy.HTTPRoute{PathRegex: "/books", Methods: []string{constants.WildcardHTTPMethod}},
+					WeightedClusters: weightedClusters,
+				},
+				"books-v2": {
+					HTTPRoute: trafficpolicy.HTTPRoute{
+						PathRegex: constants.RegexMatchAll,
+						Methods:   []string{constants.WildcardHTTPMethod},
+						Headers:   map[string]string{"x-version": "v2"},
+					},
+					WeightedClusters: weightedClusters,
+				},
+			}
+
+			Expect(getRouteKeysBySpecificity(routeWeightedClustersMap)).To(Equal([]string{"books-v2", "books", "all"}))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package route
+
+import (
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getRateLimit returns the local rate limit set on the given routes
+func getRateLimit(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) *trafficpolicy.RateLimit {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.RateLimit != nil {
+			return routePolicyWeightedClusters.RateLimit
+		}
+	}
+	return nil
+}
+
+// applyRateLimit configures the local rate limit filter of the connection manager to enforce the given rate limit on the given route
+func applyRateLimit(route *xds_route.Route, rateLimit *trafficpolicy.RateLimit) {
+	if rateLimit == nil {
+		return
+	}
+
+	rateLimitConfig, err := envoy.GetLocalRateLimitConfig(rateLimit.RequestsPerSecond, rateLimit.Burst)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling local rate limit config of route %s", route.Match.GetSafeRegex().GetRegex())
+		return
+	}
+
+	if route.TypedPerFilterConfig == nil {
+		route.TypedPerFilterConfig = make(map[string]*any.Any)
+	}
+	route.TypedPerFilterConfig[envoy.LocalRateLimitFilterName] = rateLimitConfig
+}
@@ -0,0 +1,47 @@
+package route
+
+import (
+	set "github.com/deckarep/golang-set"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Routes with a rate limit", func() {
+	Context("Testing the local rate limit of the routes", func() {
+		weightedClusters := set.NewSet(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore"), Weight: 100})
+		routePolicy := trafficpolicy.HTTPRoute{
+			PathRegex: "/books-bought",
+			Methods:   []string{"GET", "POST"},
+		}
+
+		It("Configures the local rate limit filter on each inbound route of the rate limited path", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {
+					HTTPRoute:        routePolicy,
+					WeightedClusters: weightedClusters,
+					RateLimit:        &trafficpolicy.RateLimit{RequestsPerSecond: 5},
+				},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(len(routePolicy.Methods)))
+			for _, route := range rt {
+				Expect(route.TypedPerFilterConfig).To(HaveKey(envoy.LocalRateLimitFilterName))
+			}
+		})
+
+		It("Does not configure the local rate limit filter on the routes without rate limit", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			Expect(rt[0].TypedPerFilterConfig).To(BeEmpty())
+		})
+	})
+})
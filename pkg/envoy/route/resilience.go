@@ -0,0 +1,59 @@
+package route
+
+import (
+	"strings"
+	"time"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// httpRetryOn is the list of the conditions under which the outbound HTTP routes retry a failed request, when a retry policy does not set them
+const httpRetryOn = "5xx"
+
+// getResiliencePolicy returns the timeout and the retry policy set on the given routes
+func getResiliencePolicy(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) (time.Duration, *trafficpolicy.RetryPolicy) {
+	var timeout time.Duration
+	var retryPolicy *trafficpolicy.RetryPolicy
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.Timeout > 0 {
+			timeout = routePolicyWeightedClusters.Timeout
+		}
+		if routePolicyWeightedClusters.RetryPolicy != nil {
+			retryPolicy = routePolicyWeightedClusters.RetryPolicy
+		}
+	}
+	return timeout, retryPolicy
+}
+
+// applyResiliencePolicy sets the given timeout and retry policy on the action of the given route.
+// defaultRetryOn is used when the retry policy does not list the conditions under which a request is retried.
+func applyResiliencePolicy(route *xds_route.Route, timeout time.Duration, retryPolicy *trafficpolicy.RetryPolicy, defaultRetryOn string) {
+	routeAction := route.GetRoute()
+	if routeAction == nil {
+		return
+	}
+
+	if timeout > 0 {
+		routeAction.Timeout = ptypes.DurationProto(timeout)
+	}
+
+	if retryPolicy == nil {
+		return
+	}
+
+	retryOn := defaultRetryOn
+	if len(retryPolicy.RetryOn) > 0 {
+		retryOn = strings.Join(retryPolicy.RetryOn, ",")
+	}
+	routeAction.RetryPolicy = &xds_route.RetryPolicy{
+		RetryOn:    retryOn,
+		NumRetries: &wrappers.UInt32Value{Value: retryPolicy.NumRetries},
+	}
+	if retryPolicy.PerTryTimeout > 0 {
+		routeAction.RetryPolicy.PerTryTimeout = ptypes.DurationProto(retryPolicy.PerTryTimeout)
+	}
+}
@@ -0,0 +1,77 @@
+package route
+
+import (
+	"time"
+
+	set "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Routes with a resilience policy", func() {
+	Context("Testing the timeout and retry policy of the outbound routes", func() {
+		weightedClusters := set.NewSet(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore"), Weight: 100})
+		routePolicy := trafficpolicy.HTTPRoute{
+			PathRegex: "/books-bought",
+			Methods:   []string{"GET"},
+		}
+
+		It("Sets the timeout and retry policy on the outbound route", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {
+					HTTPRoute:        routePolicy,
+					WeightedClusters: weightedClusters,
+					AppProtocol:      service.AppProtocolHTTP,
+					Timeout:          10 * time.Second,
+					RetryPolicy: &trafficpolicy.RetryPolicy{
+						NumRetries:    3,
+						PerTryTimeout: 2 * time.Second,
+						RetryOn:       []string{"5xx", "connect-failure"},
+					},
+				},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			routeAction := rt[0].GetRoute()
+			Expect(routeAction.Timeout).To(Equal(ptypes.DurationProto(10 * time.Second)))
+			Expect(routeAction.RetryPolicy.RetryOn).To(Equal("5xx,connect-failure"))
+			Expect(routeAction.RetryPolicy.NumRetries.Value).To(Equal(uint32(3)))
+			Expect(routeAction.RetryPolicy.PerTryTimeout).To(Equal(ptypes.DurationProto(2 * time.Second)))
+		})
+
+		It("Retries the failed gRPC requests on a transient gRPC status by default", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {
+					HTTPRoute:        routePolicy,
+					WeightedClusters: weightedClusters,
+					AppProtocol:      service.AppProtocolGRPC,
+					RetryPolicy:      &trafficpolicy.RetryPolicy{NumRetries: 5},
+				},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			routeAction := rt[0].GetRoute()
+			Expect(routeAction.Timeout).To(BeNil())
+			Expect(routeAction.RetryPolicy.RetryOn).To(Equal(grpcRetryOn))
+			Expect(routeAction.RetryPolicy.NumRetries.Value).To(Equal(uint32(5)))
+			Expect(routeAction.RetryPolicy.PerTryTimeout).To(BeNil())
+		})
+
+		It("Does not set a retry policy on the HTTP routes without a resilience policy", func() {
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters, AppProtocol: service.AppProtocolHTTP},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			Expect(rt[0].GetRoute().Timeout).To(BeNil())
+			Expect(rt[0].GetRoute().RetryPolicy).To(BeNil())
+		})
+	})
+})
@@ -23,7 +23,7 @@ var directionMap = map[envoy.SDSCertType]string{
 }
 
 // NewResponse creates a new Secrets Discovery Response.
-func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, _ configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	log.Info().Msgf("Composing SDS Discovery Response for proxy: %s", proxy.GetCommonName())
 
 	svcList, err := catalog.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
@@ -34,7 +34,13 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds
 		return nil, err
 	}
 
-	cert, err := catalog.GetCertificateForService(serviceForProxy)
+	serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+		return nil, err
+	}
+
+	cert, err := catalog.GetCertificateForService(serviceForProxy, serviceAccount)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error obtaining a certificate for client %s", proxy.GetCommonName())
 		return nil, err
@@ -48,7 +54,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds
 	log.Trace().Msgf("Received SDS request for ResourceNames (certificates) %+v", requestedCerts)
 
 	// request.ResourceNames is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
-	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog) {
+	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog, cfg) {
 		marshalledSecret, err := ptypes.MarshalAny(envoyProto)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling Envoy secret %s for proxy %s for service %s", envoyProto.Name, proxy.GetCommonName(), serviceForProxy.String())
@@ -63,7 +69,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds
 	}, nil
 }
 
-func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger) []*xds_auth.Secret {
+func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger, cfg configurator.Configurator) []*xds_auth.Secret {
 	// requestedCerts is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
 
 	var envoySecrets []*xds_auth.Secret
@@ -76,6 +82,18 @@ func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, reque
 	// Github Issue #1575
 	serviceForProxy := svcList[0]
 
+	serviceAccount, err := catalog.GetServiceAccountFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up service account for Envoy with CN=%q", proxy.GetCommonName())
+		return nil
+	}
+
+	trustBundle, err := catalog.GetTrustBundle()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the root certificates trusted by the mesh for proxy %s", proxy.GetCommonName())
+		return nil
+	}
+
 	// The Envoy makes a request for a list of resources (aka certificates), which we will send as a response to the SDS request.
 	for _, requestedCertificate := range requestedCerts {
 		// requestedCertType could be either "service-cert" or "root-cert"
@@ -106,7 +124,7 @@ func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, reque
 			fallthrough
 		case envoy.RootCertTypeForHTTPS:
 			log.Info().Msgf("proxy %s (member of service %s) requested %s", proxy.GetCommonName(), serviceForProxy.String(), requestedCertificate)
-			envoySecret, err := getRootCert(cert, *sdsCert, serviceForProxy, catalog)
+			envoySecret, err := getRootCert(trustBundle, *sdsCert, serviceAccount, catalog, cfg)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error creating cert %s for proxy %s for service %s", requestedCertificate, proxy.GetCommonName(), serviceForProxy.String())
 				continue
@@ -141,7 +159,10 @@ func getServiceCertSecret(cert certificate.Certificater, name string) (*xds_auth
 	return secret, nil
 }
 
-func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServiceName service.MeshService, mc catalog.MeshCataloger) (*xds_auth.Secret, error) {
+// getRootCert creates the struct with the root certificates trusted by the mesh, which the connected Envoy proxy
+// validates its peers with. While the root certificate is being rotated, the trust bundle holds both the old and the new root certificates.
+// For mTLS, the peers must also present a certificate carrying the SPIFFE ID of a service account SMI TrafficTargets authorize.
+func getRootCert(trustBundle []byte, sdscert envoy.SDSCert, proxyServiceAccount service.K8sServiceAccount, mc catalog.MeshCataloger, cfg configurator.Configurator) (*xds_auth.Secret, error) {
 	secret := &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
 		Name: sdscert.String(),
@@ -149,7 +170,7 @@ func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServ
 			ValidationContext: &xds_auth.CertificateValidationContext{
 				TrustedCa: &xds_core.DataSource{
 					Specifier: &xds_core.DataSource_InlineBytes{
-						InlineBytes: cert.GetIssuingCA(),
+						InlineBytes: trustBundle,
 					},
 				},
 			},
@@ -160,44 +181,53 @@ func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServ
 	case envoy.RootCertTypeForMTLSOutbound:
 		fallthrough
 	case envoy.RootCertTypeForMTLSInbound:
-		var matchSANs []*xds_matcher.StringMatcher
-		var serverNames []service.MeshService
-		var err error
-
-		// This block constructs a list of Server Names (peers) that are allowed to connect to the given service.
-		// The allowed list is derived from SMI's Traffic Policy.
-		if sdscert.CertType == envoy.RootCertTypeForMTLSOutbound {
-			// Outbound
-			serverNames, err = mc.ListAllowedOutboundServices(proxyServiceName)
-		} else {
-			// Inbound
-			serverNames, err = mc.ListAllowedInboundServices(proxyServiceName)
-		}
-
-		if err != nil {
-			log.Error().Err(err).Msgf("Error getting server names for %s allowed Services %s", directionMap[sdscert.CertType], proxyServiceName)
-			return nil, err
-		}
-
-		var matchingCerts []string
-		for _, serverName := range serverNames {
-			matchingCerts = append(matchingCerts, serverName.GetCommonName().String())
-			match := xds_matcher.StringMatcher{
-				MatchPattern: &xds_matcher.StringMatcher_Exact{
-					Exact: serverName.GetCommonName().String(),
-				},
-			}
-			matchSANs = append(matchSANs, &match)
-		}
-
-		log.Trace().Msgf("Proxy for service %s will only allow %s SANs exactly matching: %+v", proxyServiceName, directionMap[sdscert.CertType], matchingCerts)
-
 		// Ensure the Subject Alternate Names (SAN) added by CertificateManager.IssueCertificate()
 		// matches what is allowed to connect to the downstream service as defined in TrafficPolicy.
-		secret.GetValidationContext().MatchSubjectAltNames = matchSANs
+		secret.GetValidationContext().MatchSubjectAltNames = getMatchSubjectAltNames(sdscert, proxyServiceAccount, mc, cfg)
 	default:
 		log.Debug().Msgf("SAN matching not needed for cert type %s", sdscert.CertType.String())
 	}
 
 	return secret, nil
 }
+
+// getMatchSubjectAltNames returns the matchers of the SPIFFE IDs the peers of the connected Envoy proxy must present.
+// In permissive traffic policy mode, every workload of the trust domain of the mesh is allowed.
+func getMatchSubjectAltNames(sdscert envoy.SDSCert, proxyServiceAccount service.K8sServiceAccount, mc catalog.MeshCataloger, cfg configurator.Configurator) []*xds_matcher.StringMatcher {
+	if cfg.IsPermissiveTrafficPolicyMode() {
+		trustDomainPrefix := certificate.GetSPIFFETrustDomainPrefix(cfg.GetTrustDomain())
+		log.Trace().Msgf("Proxy for service account %s will allow %s SANs with prefix %s", proxyServiceAccount, directionMap[sdscert.CertType], trustDomainPrefix)
+		return []*xds_matcher.StringMatcher{{
+			MatchPattern: &xds_matcher.StringMatcher_Prefix{
+				Prefix: trustDomainPrefix,
+			},
+		}}
+	}
+
+	// This block constructs a list of service accounts (peers) that are allowed to connect to the given service account.
+	// The allowed list is derived from SMI's Traffic Targets.
+	var serviceAccounts []service.K8sServiceAccount
+	if sdscert.CertType == envoy.RootCertTypeForMTLSOutbound {
+		// Outbound
+		serviceAccounts = mc.ListAllowedOutboundServiceAccounts(proxyServiceAccount)
+	} else {
+		// Inbound
+		serviceAccounts = mc.ListAllowedInboundServiceAccounts(proxyServiceAccount)
+	}
+
+	var matchSANs []*xds_matcher.StringMatcher
+	var matchingSPIFFEIDs []string
+	for _, serviceAccount := range serviceAccounts {
+		spiffeID := mc.GetSPIFFEID(serviceAccount).String()
+		matchingSPIFFEIDs = append(matchingSPIFFEIDs, spiffeID)
+		matchSANs = append(matchSANs, &xds_matcher.StringMatcher{
+			MatchPattern: &xds_matcher.StringMatcher_Exact{
+				Exact: spiffeID,
+			},
+		})
+	}
+
+	log.Trace().Msgf("Proxy for service account %s will only allow %s SANs exactly matching: %+v", proxyServiceAccount, directionMap[sdscert.CertType], matchingSPIFFEIDs)
+
+	return matchSANs
+}
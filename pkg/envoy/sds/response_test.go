@@ -19,6 +19,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -48,11 +49,11 @@ var _ = Describe("Test SDS response functions", func() {
 		cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", proxyID, serviceAccount, namespace))
 
 		proxy := envoy.NewProxy(cn, nil)
-		cache := make(map[certificate.CommonName]certificate.Certificater)
+		cache := make(map[certificate.CacheKey]certificate.Certificater)
 		validityPeriod := 1 * time.Hour
 		certManager := tresor.NewFakeCertManager(&cache, validityPeriod)
 
-		cert, err := certManager.IssueCertificate(cn, nil)
+		cert, err := certManager.IssueCertificate(cn, nil, "")
 		Expect(err).ToNot(HaveOccurred())
 
 		mc := catalog.NewFakeMeshCatalog(kubeClient)
@@ -62,10 +63,10 @@ var _ = Describe("Test SDS response functions", func() {
 
 	Context("Test getRootCert()", func() {
 		It("returns a properly formatted struct", func() {
-			cache := make(map[certificate.CommonName]certificate.Certificater)
+			cache := make(map[certificate.CacheKey]certificate.Certificater)
 			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 
-			cert, err := certManager.IssueCertificate("blah", nil)
+			cert, err := certManager.IssueCertificate("blah", nil, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			svc := service.MeshService{
@@ -80,7 +81,7 @@ var _ = Describe("Test SDS response functions", func() {
 
 			resourceName := sdsc.String()
 			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
-			actual, err := getRootCert(cert, sdsc, tests.BookstoreService, mc)
+			actual, err := getRootCert(cert.GetIssuingCA(), sdsc, tests.BookstoreServiceAccount, mc, configurator.NewFakeConfigurator())
 			Expect(err).ToNot(HaveOccurred())
 
 			expected := &xds_auth.Secret{
@@ -95,9 +96,9 @@ var _ = Describe("Test SDS response functions", func() {
 						},
 						MatchSubjectAltNames: []*xds_matcher.StringMatcher{{
 							MatchPattern: &xds_matcher.StringMatcher_Exact{
-								// The Certificates Subject Common Name will look like this: "bookbuyer.default.svc.cluster.local"
-								// BookbuyerService is an inbound service that is allowed.
-								Exact: tests.BookbuyerService.GetCommonName().String(),
+								// The certificates of the bookbuyer service account carry the SPIFFE ID "spiffe://cluster.local/ns/default/sa/bookbuyer"
+								// BookbuyerServiceAccount is an inbound service account that is allowed.
+								Exact: mc.GetSPIFFEID(tests.BookbuyerServiceAccount).String(),
 							}},
 						},
 					},
@@ -106,12 +107,46 @@ var _ = Describe("Test SDS response functions", func() {
 
 			Expect(actual.Name).To(Equal(expected.Name))
 
-			Expect(actual.GetValidationContext().MatchSubjectAltNames[0].GetExact()).To(Equal("bookbuyer.default.svc.cluster.local"))
+			Expect(actual.GetValidationContext().MatchSubjectAltNames[0].GetExact()).To(Equal("spiffe://cluster.local/ns/default/sa/bookbuyer"))
 			Expect(actual.GetValidationContext().MatchSubjectAltNames).To(Equal(expected.GetValidationContext().MatchSubjectAltNames))
 
 			Expect(actual.GetValidationContext()).To(Equal(expected.GetValidationContext()))
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("pins the outbound SANs to the service accounts the proxy is allowed to connect to", func() {
+			sdsc := envoy.SDSCert{
+				MeshService: tests.BookbuyerService,
+				CertType:    envoy.RootCertTypeForMTLSOutbound,
+			}
+
+			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+			actual, err := getRootCert([]byte("trust-bundle"), sdsc, tests.BookbuyerServiceAccount, mc, configurator.NewFakeConfigurator())
+			Expect(err).ToNot(HaveOccurred())
+
+			matchSANs := actual.GetValidationContext().MatchSubjectAltNames
+			Expect(matchSANs).To(HaveLen(1))
+			Expect(matchSANs[0].GetExact()).To(Equal("spiffe://cluster.local/ns/default/sa/bookstore"))
+		})
+
+		It("allows the whole trust domain in permissive traffic policy mode", func() {
+			sdsc := envoy.SDSCert{
+				MeshService: tests.BookstoreService,
+				CertType:    envoy.RootCertTypeForMTLSInbound,
+			}
+
+			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				PermissiveTrafficPolicyMode: true,
+				TrustDomain:                 "example.org",
+			})
+			actual, err := getRootCert([]byte("trust-bundle"), sdsc, tests.BookstoreServiceAccount, mc, cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			matchSANs := actual.GetValidationContext().MatchSubjectAltNames
+			Expect(matchSANs).To(HaveLen(1))
+			Expect(matchSANs[0].GetPrefix()).To(Equal("spiffe://example.org/"))
+		})
 	})
 
 	Context("Test getEnvoySDSSecrets()", func() {
@@ -131,7 +166,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{sdsc.String()}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(sdsc.String()))
@@ -146,7 +181,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)))
@@ -161,7 +196,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)))
@@ -176,7 +211,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{"service-cert:SomeOtherNamespace/SomeOtherService"}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(0))
 		})
@@ -0,0 +1,100 @@
+package envoy
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// SnapshotCache keeps the xDS resources generated for a mesh service, so that they can be reused
+// by all the Envoy proxies fronting the same service instead of being generated for each of them.
+// The resources which also depend on inputs specific to the proxies, such as their service account,
+// are only shared by the proxies of the service with the same inputs, which are the variant of the snapshot.
+// The snapshots are valid only for the configuration generation they were created at: once the configuration
+// changes, all the snapshots are dropped, and the next request for a snapshot generates the resources again.
+type SnapshotCache struct {
+	generation    uint64
+	snapshots     map[snapshotKey]*snapshot
+	snapshotsLock sync.Mutex
+}
+
+type snapshotKey struct {
+	service service.MeshService
+	typeURI TypeURI
+	variant string
+}
+
+type snapshot struct {
+	// The lock is held while the resources are generated, so that concurrent requests for the same snapshot generate them only once
+	sync.Mutex
+
+	resources []*any.Any
+	populated bool
+}
+
+// NewSnapshotCache creates a new empty SnapshotCache.
+func NewSnapshotCache() *SnapshotCache {
+	return &SnapshotCache{
+		snapshots: make(map[snapshotKey]*snapshot),
+	}
+}
+
+// GetOrCreate returns the resources of the given type and variant for the given service at the given configuration generation.
+// When there is no such snapshot, the resources are generated with the given function and cached.
+// The returned resources are shared and must not be modified.
+func (c *SnapshotCache) GetOrCreate(svc service.MeshService, typeURI TypeURI, variant string, generation uint64, create func() ([]*any.Any, error)) ([]*any.Any, error) {
+	key := snapshotKey{
+		service: svc,
+		typeURI: typeURI,
+		variant: variant,
+	}
+
+	c.snapshotsLock.Lock()
+	if generation > c.generation {
+		// The snapshots of the previous generations are stale; dropping them also evicts the snapshots
+		// of the deleted services and of the variants no proxy has anymore
+		c.snapshots = make(map[snapshotKey]*snapshot)
+		c.generation = generation
+	}
+	if generation < c.generation {
+		// The configuration changed since the request was made; its stale resources are not cached
+		c.snapshotsLock.Unlock()
+		return create()
+	}
+	snap, ok := c.snapshots[key]
+	if !ok {
+		snap = &snapshot{}
+		c.snapshots[key] = snap
+	}
+	c.snapshotsLock.Unlock()
+
+	snap.Lock()
+	defer snap.Unlock()
+
+	if snap.populated {
+		log.Trace().Msgf("Using cached %s snapshot for service %s, variant %q (generation %d)", typeURI, svc, variant, generation)
+		return snap.resources, nil
+	}
+
+	resources, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	snap.resources = resources
+	snap.populated = true
+
+	return resources, nil
+}
+
+// GetIPFamiliesSnapshotVariant returns the variant of the snapshots of the resources which depend on the IP families of the pod of the given proxy.
+func GetIPFamiliesSnapshotVariant(proxy *Proxy) string {
+	var families []string
+	for _, ipFamily := range proxy.GetIPFamilies() {
+		families = append(families, string(ipFamily))
+	}
+	return "ipFamilies=" + strings.Join(families, ",")
+}
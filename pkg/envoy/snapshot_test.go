@@ -0,0 +1,96 @@
+package envoy
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test snapshot cache", func() {
+	Context("Testing SnapshotCache.GetOrCreate()", func() {
+		cluster, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: "cluster-name"})
+		It("should have marshaled the cluster", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		created := 0
+		create := func() ([]*any.Any, error) {
+			created++
+			return []*any.Any{cluster}, nil
+		}
+
+		It("should generate the resources once per service and generation", func() {
+			cache := NewSnapshotCache()
+
+			resources, err := cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(Equal([]*any.Any{cluster}))
+			Expect(created).To(Equal(1))
+
+			// Same service, type and generation: the cached resources are reused
+			_, err = cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(1))
+
+			// A different service or type has its own snapshot
+			_, err = cache.GetOrCreate(tests.BookbuyerService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = cache.GetOrCreate(tests.BookstoreService, TypeRDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(3))
+
+			// A different variant of the same service and type has its own snapshot
+			_, err = cache.GetOrCreate(tests.BookstoreService, TypeCDS, "default/bookstore-v2", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(4))
+
+			// A new generation invalidates the snapshot
+			_, err = cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 2, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(5))
+		})
+
+		It("should drop the snapshots of the previous generations", func() {
+			cache := NewSnapshotCache()
+
+			_, err := cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = cache.GetOrCreate(tests.BookbuyerService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cache.snapshots).To(HaveLen(2))
+
+			// The snapshot of a service no proxy requests anymore, such as a deleted service, is evicted
+			_, err = cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 2, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cache.snapshots).To(HaveLen(1))
+			Expect(cache.snapshots).To(HaveKey(snapshotKey{service: tests.BookstoreService, typeURI: TypeCDS}))
+
+			// The resources requested for a previous generation are not cached
+			createdBefore := created
+			_, err = cache.GetOrCreate(tests.BookbuyerService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(createdBefore + 1))
+			Expect(cache.snapshots).To(HaveLen(1))
+		})
+
+		It("should not cache errors", func() {
+			cache := NewSnapshotCache()
+			failure := errors.New("failure")
+
+			_, err := cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 1, func() ([]*any.Any, error) {
+				return nil, failure
+			})
+			Expect(err).To(Equal(failure))
+
+			resources, err := cache.GetOrCreate(tests.BookstoreService, TypeCDS, "", 1, create)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(Equal([]*any.Any{cluster}))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package envoy
 
 import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -56,4 +60,46 @@ const (
 
 	//LocalClusterSuffix is the tag to append to local clusters
 	LocalClusterSuffix = "-local"
+
+	// MulticlusterGatewayClusterSuffix is the tag to append to the clusters of the multicluster gateway
+	MulticlusterGatewayClusterSuffix = "-gateway"
+
+	// clusterPortSeparator separates the name of a service from the port in the name of the cluster for this port of the service
+	clusterPortSeparator = "|"
+
+	// wildcardResourceName is the resource name used by delta xDS clients to subscribe to all resources of a type
+	wildcardResourceName = "*"
 )
+
+// NACK is a record of an xDS response rejected by an Envoy proxy.
+type NACK struct {
+	// TypeURI is the type of the rejected xDS response.
+	TypeURI TypeURI `json:"type_uri"`
+
+	// Version is the version of the rejected xDS response.
+	Version string `json:"version"`
+
+	// Nonce is the nonce of the rejected xDS response.
+	Nonce string `json:"nonce"`
+
+	// Error is the error detail the Envoy proxy sent along with the NACK.
+	Error string `json:"error"`
+
+	// Time is when the NACK was received.
+	Time time.Time `json:"time"`
+}
+
+// SentResponse is an xDS response sent to an Envoy proxy, which the proxy did not acknowledge (ACK) or reject (NACK) yet.
+type SentResponse struct {
+	// Version is the version of the response, derived from the resources in it.
+	Version string
+
+	// Resources are the resources of a state-of-the-world response.
+	Resources []*any.Any
+
+	// ResourceVersions are the versions of the resources added or changed by a delta response, keyed by resource name.
+	ResourceVersions map[string]string
+
+	// RemovedResources are the names of the resources removed by a delta response.
+	RemovedResources []string
+}
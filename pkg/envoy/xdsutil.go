@@ -1,22 +1,31 @@
 package envoy
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"sort"
 	"strings"
 
 	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/copier"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -67,6 +76,9 @@ const (
 
 	// OutboundPassthroughCluster is the outbound passthrough cluster name
 	OutboundPassthroughCluster = "passthrough-outbound"
+
+	// EgressClusterPrefix is the prefix of the names of the clusters proxying the egress traffic allowed by Egress policies
+	EgressClusterPrefix = "egress"
 )
 
 // Defines valid cert types
@@ -143,6 +155,28 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
+// GetWildcardIPAddr returns the wildcard address of the given IP family, which the listeners of this family bind to.
+// The IPv6 listeners only accept IPv6 connections, the IPv4 connections of a dual-stack pod are accepted by the IPv4 listeners.
+func GetWildcardIPAddr(ipFamily corev1.IPFamily) string {
+	if ipFamily == corev1.IPv6Protocol {
+		return constants.WildcardIPv6Addr
+	}
+	return constants.WildcardIPAddr
+}
+
+// GetDNSLookupFamily returns the DNS lookup family of the clusters resolving hostnames for a proxy whose pod has addresses of the given IP families.
+// The hostnames resolve to the addresses the pod can reach only: both families for a dual-stack pod, IPv6 first.
+func GetDNSLookupFamily(ipFamilies []corev1.IPFamily) xds_cluster.Cluster_DnsLookupFamily {
+	switch {
+	case len(ipFamilies) > 1:
+		return xds_cluster.Cluster_AUTO
+	case len(ipFamilies) == 1 && ipFamilies[0] == corev1.IPv6Protocol:
+		return xds_cluster.Cluster_V6_ONLY
+	default:
+		return xds_cluster.Cluster_V4_ONLY
+	}
+}
+
 // GetTLSParams creates Envoy TlsParameters struct.
 func GetTLSParams() *xds_auth.TlsParameters {
 	return &xds_auth.TlsParameters{
@@ -248,6 +282,36 @@ func getCommonTLSContext(serviceName service.MeshService, mTLS bool, dir SDSDire
 	}
 }
 
+// GetClusterNameForPort returns the name of the cluster proxying the traffic to the given port of the service with the given cluster name.
+func GetClusterNameForPort(clusterName string, port uint32) string {
+	return fmt.Sprintf("%s%s%d", clusterName, clusterPortSeparator, port)
+}
+
+// GetLocalClusterNameForPort returns the name of the cluster proxying the inbound traffic of the given service to the given port of the local pod.
+func GetLocalClusterNameForPort(serviceName service.MeshService, port uint32) string {
+	return GetClusterNameForPort(serviceName.String(), port) + LocalClusterSuffix
+}
+
+// GetMulticlusterGatewayClusterNameForPort returns the name of the cluster the multicluster gateway proxies the traffic
+// from remote clusters to the given port of the given exported service with.
+func GetMulticlusterGatewayClusterNameForPort(serviceName service.MeshService, port uint32) string {
+	return GetClusterNameForPort(serviceName.String(), port) + MulticlusterGatewayClusterSuffix
+}
+
+// GetEgressClusterNameForPort returns the name of the cluster proxying the egress traffic allowed on the given port to its original destination.
+func GetEgressClusterNameForPort(port uint32) string {
+	return GetClusterNameForPort(EgressClusterPrefix, port)
+}
+
+// GetEgressClusterNameForHost returns the name of the cluster proxying the egress traffic allowed on the given port to the given host.
+// Wildcard hosts can not be resolved, so the traffic to these hosts is proxied to its original destination.
+func GetEgressClusterNameForHost(host string, port uint32) string {
+	if strings.HasPrefix(host, "*") {
+		return GetEgressClusterNameForPort(port)
+	}
+	return GetClusterNameForPort(EgressClusterPrefix+"/"+host, port)
+}
+
 // MessageToAny converts from proto message to proto Any and returns an error if any
 func MessageToAny(pb proto.Message) (*any.Any, error) {
 	msg, err := ptypes.MarshalAny(pb)
@@ -292,3 +356,57 @@ func GetADSConfigSource() *xds_core.ConfigSource {
 		ResourceApiVersion: xds_core.ApiVersion_V3,
 	}
 }
+
+// GetResourceName returns the name of the given xDS resource.
+func GetResourceName(resource *any.Any) (string, error) {
+	var msg ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(resource, &msg); err != nil {
+		return "", err
+	}
+
+	switch res := msg.Message.(type) {
+	case *xds_cluster.Cluster:
+		return res.Name, nil
+	case *xds_endpoint.ClusterLoadAssignment:
+		return res.ClusterName, nil
+	case *xds_listener.Listener:
+		return res.Name, nil
+	case *xds_route.RouteConfiguration:
+		return res.Name, nil
+	case *xds_auth.Secret:
+		return res.Name, nil
+	default:
+		return "", errUnknownResourceType
+	}
+}
+
+// GetResourceVersion returns a version for the given xDS resource, which is derived from its content.
+// The same resource content always results in the same version.
+func GetResourceVersion(resource *any.Any) (string, error) {
+	// The binary encoding of a resource is not stable: nested Any fields carry bytes which were
+	// serialized with map entries in random order. The JSON encoding expands nested Any fields
+	// and sorts map keys, so it is used to derive the version instead.
+	marshaler := jsonpb.Marshaler{}
+	content, err := marshaler.MarshalToString(resource)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content))), nil
+}
+
+// GetResourcesVersion returns a version derived from the content of the given resources.
+// The version does not depend on the order of the resources, so the same set of resources always yields the same version.
+func GetResourcesVersion(resources []*any.Any) (string, error) {
+	var versions []string
+	for _, resource := range resources {
+		version, err := GetResourceVersion(resource)
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(versions, "")))), nil
+}
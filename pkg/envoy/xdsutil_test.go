@@ -1,12 +1,16 @@
 package envoy
 
 import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -34,6 +38,14 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetDNSLookupFamily()", func() {
+		It("resolves the hostnames to the addresses of the IP families of the pod", func() {
+			Expect(GetDNSLookupFamily([]corev1.IPFamily{corev1.IPv4Protocol})).To(Equal(xds_cluster.Cluster_V4_ONLY))
+			Expect(GetDNSLookupFamily([]corev1.IPFamily{corev1.IPv6Protocol})).To(Equal(xds_cluster.Cluster_V6_ONLY))
+			Expect(GetDNSLookupFamily([]corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol})).To(Equal(xds_cluster.Cluster_AUTO))
+		})
+	})
+
 	Context("Test CertName interface", func() {
 		It("Interface marshals and unmarshals preserving the exact same data", func() {
 			InitialObj := SDSCert{
@@ -332,4 +344,60 @@ var _ = Describe("Test Envoy tools", func() {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("Test GetResourceName() and GetResourceVersion()", func() {
+		It("should return the name of the resource and a version derived from its content", func() {
+			cluster := &xds_cluster.Cluster{Name: "cluster-name"}
+			resource, err := ptypes.MarshalAny(cluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			name, err := GetResourceName(resource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(Equal("cluster-name"))
+
+			version, err := GetResourceVersion(resource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version).ToNot(BeEmpty())
+
+			sameResource, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: "cluster-name"})
+			Expect(err).ToNot(HaveOccurred())
+			sameVersion, err := GetResourceVersion(sameResource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sameVersion).To(Equal(version))
+
+			otherResource, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: "other-cluster-name"})
+			Expect(err).ToNot(HaveOccurred())
+			otherVersion, err := GetResourceVersion(otherResource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherVersion).ToNot(Equal(version))
+		})
+
+		It("should return an error for unknown resource types", func() {
+			resource, err := ptypes.MarshalAny(&wrappers.BoolValue{Value: true})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = GetResourceName(resource)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Test GetResourcesVersion()", func() {
+		It("should return the same version for the same resources regardless of their order", func() {
+			clusterA, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: "cluster-a"})
+			Expect(err).ToNot(HaveOccurred())
+			clusterB, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: "cluster-b"})
+			Expect(err).ToNot(HaveOccurred())
+
+			version, err := GetResourcesVersion([]*any.Any{clusterA, clusterB})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version).ToNot(BeEmpty())
+
+			reordered, err := GetResourcesVersion([]*any.Any{clusterB, clusterA})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reordered).To(Equal(version))
+
+			fewer, err := GetResourcesVersion([]*any.Any{clusterA})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fewer).ToNot(Equal(version))
+		})
+	})
 })
@@ -9,7 +9,10 @@ import (
 // OptionalFeatures is a struct to enable/disable optional features
 type OptionalFeatures struct {
 	// FeatureName bool
-	Backpressure bool
+	Backpressure     bool
+	ResiliencePolicy bool
+	EgressPolicy     bool
+	MulticlusterMode bool
 }
 
 var (
@@ -38,3 +41,18 @@ func IsFeatureNameEnabled() bool {
 func IsBackpressureEnabled() bool {
 	return Features.Backpressure
 }
+
+// IsResiliencePolicyEnabled returns a boolean indicating if the experimental resilience policy feature is enabled
+func IsResiliencePolicyEnabled() bool {
+	return Features.ResiliencePolicy
+}
+
+// IsEgressPolicyEnabled returns a boolean indicating if the experimental egress policy feature is enabled
+func IsEgressPolicyEnabled() bool {
+	return Features.EgressPolicy
+}
+
+// IsMulticlusterModeEnabled returns a boolean indicating if the experimental multicluster mode is enabled
+func IsMulticlusterModeEnabled() bool {
+	return Features.MulticlusterMode
+}
@@ -0,0 +1,227 @@
+package gateway
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// gatewayGroup is the API group of the Gateway API resources
+	gatewayGroup = "gateway.networking.k8s.io"
+
+	// serviceKind is the kind of the backends referencing a Kubernetes service
+	serviceKind = "Service"
+)
+
+var (
+	// httpRouteResources are the HTTPRoute resources OSM can watch, from the most to the least preferred API version
+	httpRouteResources = []schema.GroupVersionResource{
+		{Group: gatewayGroup, Version: "v1", Resource: "httproutes"},
+		{Group: gatewayGroup, Version: "v1beta1", Resource: "httproutes"},
+		{Group: gatewayGroup, Version: "v1alpha2", Resource: "httproutes"},
+	}
+
+	// tlsRouteResources are the TLSRoute resources OSM can watch, from the most to the least preferred API version
+	tlsRouteResources = []schema.GroupVersionResource{
+		{Group: gatewayGroup, Version: "v1alpha3", Resource: "tlsroutes"},
+		{Group: gatewayGroup, Version: "v1alpha2", Resource: "tlsroutes"},
+	}
+)
+
+// NewGatewayClient implements gateway.Monitor and creates the Kubernetes client to monitor the Gateway API route resources.
+// The route resources are watched in the most recent API version served by the cluster, if the Gateway API is installed.
+func NewGatewayClient(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, namespaceController namespace.Controller, stop chan struct{}) (Monitor, error) {
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval)
+
+	client := Client{
+		cacheSynced:         make(chan interface{}),
+		announcements:       make(chan announcements.Announcement),
+		namespaceController: namespaceController,
+	}
+
+	shouldObserve := func(obj interface{}) bool {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		return namespaceController.IsMonitoredNamespace(accessor.GetNamespace())
+	}
+
+	if httpRouteResource, found := k8s.GetServedResource(kubeClient.Discovery(), httpRouteResources); found {
+		log.Info().Msgf("Watching %s HTTPRoute resources", httpRouteResource.GroupVersion())
+		client.httpRouteInformer = informerFactory.ForResource(httpRouteResource).Informer()
+		client.httpRouteCache = client.httpRouteInformer.GetStore()
+		client.httpRouteInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("HTTPRoute", "Kubernetes", client.announcements, shouldObserve, k8s.EventTypes{
+			Add:    announcements.GatewayHTTPRouteAdded,
+			Update: announcements.GatewayHTTPRouteUpdated,
+			Delete: announcements.GatewayHTTPRouteDeleted,
+		}))
+	} else {
+		log.Info().Msg("The cluster does not serve Gateway API HTTPRoute resources")
+	}
+
+	if tlsRouteResource, found := k8s.GetServedResource(kubeClient.Discovery(), tlsRouteResources); found {
+		log.Info().Msgf("Watching %s TLSRoute resources", tlsRouteResource.GroupVersion())
+		client.tlsRouteInformer = informerFactory.ForResource(tlsRouteResource).Informer()
+		client.tlsRouteCache = client.tlsRouteInformer.GetStore()
+		client.tlsRouteInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("TLSRoute", "Kubernetes", client.announcements, shouldObserve, k8s.EventTypes{
+			Add:    announcements.GatewayTLSRouteAdded,
+			Update: announcements.GatewayTLSRouteUpdated,
+			Delete: announcements.GatewayTLSRouteDeleted,
+		}))
+	} else {
+		log.Info().Msg("The cluster does not serve Gateway API TLSRoute resources")
+	}
+
+	if err := client.run(stop); err != nil {
+		log.Error().Err(err).Msg("Could not start Kubernetes Gateway client")
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// run executes informer collection.
+func (c *Client) run(stop <-chan struct{}) error {
+	log.Info().Msg("Gateway client started")
+
+	var hasSynced []cache.InformerSynced
+	for _, informer := range []cache.SharedIndexInformer{c.httpRouteInformer, c.tlsRouteInformer} {
+		if informer == nil {
+			continue
+		}
+		hasSynced = append(hasSynced, informer.HasSynced)
+		go informer.Run(stop)
+	}
+
+	log.Info().Msgf("Waiting for Gateway informers cache sync")
+	if !cache.WaitForCacheSync(stop, hasSynced...) {
+		return errSyncingCaches
+	}
+
+	// Closing the cacheSynced channel signals to the rest of the system that caches have been synced.
+	close(c.cacheSynced)
+
+	log.Info().Msgf("Cache sync finished for Gateway informers")
+	return nil
+}
+
+// GetAnnouncementsChannel returns the announcement channel for the Gateway client
+func (c Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return c.announcements
+}
+
+// GetHTTPRoutes returns the HTTPRoute resources with a backend corresponding to the service
+func (c Client) GetHTTPRoutes(meshService service.MeshService) ([]*HTTPRoute, error) {
+	var httpRoutes []*HTTPRoute
+	for _, obj := range c.listRouteResources(c.httpRouteCache, meshService) {
+		httpRoute := &HTTPRoute{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), httpRoute); err != nil {
+			log.Error().Err(err).Msgf("Error converting HTTPRoute %s/%s", obj.GetNamespace(), obj.GetName())
+			continue
+		}
+
+	httpRouteRule:
+		for _, rule := range httpRoute.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				if backendRef.IsServiceBackend(httpRoute.Namespace, meshService) {
+					httpRoutes = append(httpRoutes, httpRoute)
+					break httpRouteRule
+				}
+			}
+		}
+	}
+	return httpRoutes, nil
+}
+
+// GetTLSRoutes returns the TLSRoute resources with a backend corresponding to the service
+func (c Client) GetTLSRoutes(meshService service.MeshService) ([]*TLSRoute, error) {
+	var tlsRoutes []*TLSRoute
+	for _, obj := range c.listRouteResources(c.tlsRouteCache, meshService) {
+		tlsRoute := &TLSRoute{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), tlsRoute); err != nil {
+			log.Error().Err(err).Msgf("Error converting TLSRoute %s/%s", obj.GetNamespace(), obj.GetName())
+			continue
+		}
+
+	tlsRouteRule:
+		for _, rule := range tlsRoute.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				if backendRef.IsServiceBackend(tlsRoute.Namespace, meshService) {
+					tlsRoutes = append(tlsRoutes, tlsRoute)
+					break tlsRouteRule
+				}
+			}
+		}
+	}
+	return tlsRoutes, nil
+}
+
+// listRouteResources returns the route resources of the given cache in the namespace of the service
+func (c Client) listRouteResources(routeCache cache.Store, meshService service.MeshService) []*unstructured.Unstructured {
+	if routeCache == nil {
+		return nil
+	}
+
+	var routes []*unstructured.Unstructured
+	for _, routeInterface := range routeCache.List() {
+		route, ok := routeInterface.(*unstructured.Unstructured)
+		if !ok {
+			log.Error().Msg("Failed type assertion for route in Gateway cache")
+			continue
+		}
+
+		// Extra safety - make sure we do not pay attention to routes outside of observed namespaces
+		if !c.namespaceController.IsMonitoredNamespace(route.GetNamespace()) {
+			continue
+		}
+
+		// Routes reference the backends of their own namespace only
+		if route.GetNamespace() != meshService.Namespace {
+			continue
+		}
+
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// IsServiceBackend returns true if the backend of a route of the given namespace references the service
+func (b BackendRef) IsServiceBackend(routeNamespace string, meshService service.MeshService) bool {
+	if b.Group != nil && *b.Group != "" {
+		return false
+	}
+	if b.Kind != nil && *b.Kind != serviceKind {
+		return false
+	}
+	backendNamespace := routeNamespace
+	if b.Namespace != nil && *b.Namespace != "" {
+		backendNamespace = *b.Namespace
+	}
+	// Backends in other namespaces require a ReferenceGrant, which is not supported
+	return backendNamespace == routeNamespace && backendNamespace == meshService.Namespace && b.Name == meshService.Name
+}
+
+// IsServicePortBackend returns true if the backend of a route of the given namespace references the given port of the service
+func (b BackendRef) IsServicePortBackend(routeNamespace string, meshService service.MeshService, port service.ServicePort) bool {
+	return b.IsServiceBackend(routeNamespace, meshService) && b.Port != nil && uint32(*b.Port) == port.Port
+}
+
+// GetWeight returns the weight of the backend
+func (b BackendRef) GetWeight() int32 {
+	if b.Weight == nil {
+		return 1
+	}
+	return *b.Weight
+}
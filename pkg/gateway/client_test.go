@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	testNamespace = "bookstore-ns"
+	testService   = "bookstore"
+)
+
+func newRoute(apiVersion, kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": spec,
+	}}
+}
+
+func newTestGatewayClient(servedResources []*metav1.APIResourceList, objects ...runtime.Object) Monitor {
+	mockCtrl := gomock.NewController(GinkgoT())
+	mockNsController := namespace.NewMockController(mockCtrl)
+	mockNsController.EXPECT().IsMonitoredNamespace(testNamespace).Return(true).AnyTimes()
+	mockNsController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(false).AnyTimes()
+
+	kubeClient := testclient.NewSimpleClientset()
+	kubeClient.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = servedResources
+	dynamicClient := fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+
+	gatewayClient, err := NewGatewayClient(kubeClient, dynamicClient, mockNsController, make(chan struct{}))
+	Expect(err).ToNot(HaveOccurred())
+	return gatewayClient
+}
+
+var _ = Describe("Test Gateway client", func() {
+	servedResources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "httproutes", Namespaced: true, Kind: "HTTPRoute"},
+			},
+		},
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1alpha2",
+			APIResources: []metav1.APIResource{
+				{Name: "httproutes", Namespaced: true, Kind: "HTTPRoute"},
+				{Name: "tlsroutes", Namespaced: true, Kind: "TLSRoute"},
+			},
+		},
+	}
+	meshService := service.MeshService{Namespace: testNamespace, Name: testService}
+
+	httpRouteSpec := func(backendName string) map[string]interface{} {
+		return map[string]interface{}{
+			"hostnames": []interface{}{"bookstore.example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path": map[string]interface{}{"type": PathMatchExact, "value": "/books"},
+							"headers": []interface{}{
+								map[string]interface{}{"name": "x-version", "value": "v1"},
+							},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": backendName, "port": int64(80), "weight": int64(90)},
+					},
+				},
+			},
+		}
+	}
+
+	It("returns the HTTPRoute resources with a backend corresponding to the service", func() {
+		gatewayClient := newTestGatewayClient(servedResources,
+			newRoute("gateway.networking.k8s.io/v1beta1", "HTTPRoute", testNamespace, "bookstore", httpRouteSpec(testService)),
+			newRoute("gateway.networking.k8s.io/v1beta1", "HTTPRoute", testNamespace, "bookbuyer", httpRouteSpec("bookbuyer")),
+		)
+
+		httpRoutes, err := gatewayClient.GetHTTPRoutes(meshService)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(httpRoutes)).To(Equal(1))
+
+		httpRoute := httpRoutes[0]
+		Expect(httpRoute.Name).To(Equal("bookstore"))
+		Expect(httpRoute.Spec.Hostnames).To(Equal([]string{"bookstore.example.com"}))
+
+		rule := httpRoute.Spec.Rules[0]
+		Expect(*rule.Matches[0].Path.Type).To(Equal(PathMatchExact))
+		Expect(*rule.Matches[0].Path.Value).To(Equal("/books"))
+		Expect(rule.Matches[0].Headers[0].Name).To(Equal("x-version"))
+		Expect(rule.BackendRefs[0].Name).To(Equal(testService))
+		Expect(*rule.BackendRefs[0].Port).To(Equal(int32(80)))
+		Expect(rule.BackendRefs[0].GetWeight()).To(Equal(int32(90)))
+	})
+
+	It("ignores the HTTPRoute resources of other namespaces", func() {
+		gatewayClient := newTestGatewayClient(servedResources,
+			newRoute("gateway.networking.k8s.io/v1beta1", "HTTPRoute", "other-ns", "bookstore", httpRouteSpec(testService)),
+		)
+
+		httpRoutes, err := gatewayClient.GetHTTPRoutes(service.MeshService{Namespace: "other-ns", Name: testService})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpRoutes).To(BeEmpty())
+	})
+
+	It("returns the TLSRoute resources with a backend corresponding to the service", func() {
+		gatewayClient := newTestGatewayClient(servedResources,
+			newRoute("gateway.networking.k8s.io/v1alpha2", "TLSRoute", testNamespace, "bookstore", map[string]interface{}{
+				"hostnames": []interface{}{"bookstore.example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"backendRefs": []interface{}{
+							map[string]interface{}{"name": testService, "port": int64(443)},
+						},
+					},
+				},
+			}),
+		)
+
+		tlsRoutes, err := gatewayClient.GetTLSRoutes(meshService)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(tlsRoutes)).To(Equal(1))
+		Expect(tlsRoutes[0].Spec.Hostnames).To(Equal([]string{"bookstore.example.com"}))
+	})
+
+	It("returns no routes when the cluster does not serve the Gateway API", func() {
+		gatewayClient := newTestGatewayClient(nil)
+
+		httpRoutes, err := gatewayClient.GetHTTPRoutes(meshService)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(httpRoutes).To(BeEmpty())
+
+		tlsRoutes, err := gatewayClient.GetTLSRoutes(meshService)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tlsRoutes).To(BeEmpty())
+	})
+
+	Context("Test BackendRef", func() {
+		otherNamespace := "other-ns"
+		serviceKind := serviceKind
+		otherKind := "Bucket"
+		port := int32(80)
+
+		It("references the services of the namespace of the route only", func() {
+			Expect(BackendRef{Name: testService}.IsServiceBackend(testNamespace, meshService)).To(BeTrue())
+			Expect(BackendRef{Name: testService, Kind: &serviceKind}.IsServiceBackend(testNamespace, meshService)).To(BeTrue())
+			Expect(BackendRef{Name: testService, Kind: &otherKind}.IsServiceBackend(testNamespace, meshService)).To(BeFalse())
+			Expect(BackendRef{Name: testService, Namespace: &otherNamespace}.IsServiceBackend(testNamespace, meshService)).To(BeFalse())
+			Expect(BackendRef{Name: "bookbuyer"}.IsServiceBackend(testNamespace, meshService)).To(BeFalse())
+		})
+
+		It("references the port of the service", func() {
+			Expect(BackendRef{Name: testService, Port: &port}.IsServicePortBackend(testNamespace, meshService, service.ServicePort{Port: 80})).To(BeTrue())
+			Expect(BackendRef{Name: testService, Port: &port}.IsServicePortBackend(testNamespace, meshService, service.ServicePort{Port: 8080})).To(BeFalse())
+			Expect(BackendRef{Name: testService}.IsServicePortBackend(testNamespace, meshService, service.ServicePort{Port: 80})).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,7 @@
+package gateway
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches = errors.New("Failed initial cache sync for Gateway informers")
+)
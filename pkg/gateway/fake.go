@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// FakeGatewayMonitor returns a fake gateway monitor object
+type FakeGatewayMonitor struct {
+	FakeHTTPRoutes []*HTTPRoute
+	FakeTLSRoutes  []*TLSRoute
+	Monitor
+}
+
+// NewFakeGatewayMonitor returns a fake gateway.Monitor used for testing
+func NewFakeGatewayMonitor() FakeGatewayMonitor {
+	return FakeGatewayMonitor{}
+}
+
+// GetHTTPRoutes returns the HTTPRoute resources with a backend corresponding to the service
+func (f FakeGatewayMonitor) GetHTTPRoutes(service.MeshService) ([]*HTTPRoute, error) {
+	return f.FakeHTTPRoutes, nil
+}
+
+// GetTLSRoutes returns the TLSRoute resources with a backend corresponding to the service
+func (f FakeGatewayMonitor) GetTLSRoutes(service.MeshService) ([]*TLSRoute, error) {
+	return f.FakeTLSRoutes, nil
+}
+
+// GetAnnouncementsChannel returns the channel on which Gateway Monitor makes annoucements
+func (f FakeGatewayMonitor) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return make(chan announcements.Announcement)
+}
@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var (
+	log = logger.New("kube-gateway")
+)
+
+// Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
+type Client struct {
+	httpRouteInformer   cache.SharedIndexInformer
+	httpRouteCache      cache.Store
+	tlsRouteInformer    cache.SharedIndexInformer
+	tlsRouteCache       cache.Store
+	cacheSynced         chan interface{}
+	announcements       chan announcements.Announcement
+	namespaceController namespace.Controller
+}
+
+// Monitor is the client interface for the Kubernetes Gateway API route resources
+type Monitor interface {
+	// GetHTTPRoutes returns the HTTPRoute resources with a backend corresponding to the service
+	GetHTTPRoutes(service.MeshService) ([]*HTTPRoute, error)
+
+	// GetTLSRoutes returns the TLSRoute resources with a backend corresponding to the service
+	GetTLSRoutes(service.MeshService) ([]*TLSRoute, error)
+
+	// GetAnnouncementsChannel returns the channel on which Gateway Monitor makes annoucements
+	GetAnnouncementsChannel() <-chan announcements.Announcement
+}
+
+// The following types are the subset of the Gateway API (gateway.networking.k8s.io) route resources
+// used to configure the backends of the routes. Their fields are the same in every API version.
+
+// HTTPRoute routes HTTP requests from gateways to backends
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec"`
+}
+
+// HTTPRouteSpec is the spec of an HTTPRoute
+type HTTPRouteSpec struct {
+	// Hostnames are the hostnames matched against the Host header of the requests; all hostnames when empty
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Rules are the rules matching the requests and routing them to backends
+	Rules []HTTPRouteRule `json:"rules,omitempty"`
+}
+
+// HTTPRouteRule routes the requests matching any of its matches to its backends
+type HTTPRouteRule struct {
+	// Matches are the conditions matched against the requests; all requests when empty
+	Matches []HTTPRouteMatch `json:"matches,omitempty"`
+
+	// Filters are the modifications applied to the requests matched by the rule
+	Filters []HTTPRouteFilter `json:"filters,omitempty"`
+
+	// BackendRefs are the backends the matched requests are routed to
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch matches the requests whose path, headers, query parameters and method all match
+type HTTPRouteMatch struct {
+	Path        *HTTPPathMatch        `json:"path,omitempty"`
+	Headers     []HTTPHeaderMatch     `json:"headers,omitempty"`
+	QueryParams []HTTPQueryParamMatch `json:"queryParams,omitempty"`
+	Method      *string               `json:"method,omitempty"`
+}
+
+// Types of matches of paths, headers and query parameters
+const (
+	PathMatchExact             = "Exact"
+	PathMatchPathPrefix        = "PathPrefix"
+	PathMatchRegularExpression = "RegularExpression"
+
+	HeaderMatchExact             = "Exact"
+	HeaderMatchRegularExpression = "RegularExpression"
+)
+
+// HTTPPathMatch matches the path of a request; the path prefix / when not set
+type HTTPPathMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch matches a header of a request
+type HTTPHeaderMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+// HTTPQueryParamMatch matches a query parameter of a request
+type HTTPQueryParamMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+}
+
+// Types of HTTPRoute filters
+const (
+	FilterRequestHeaderModifier  = "RequestHeaderModifier"
+	FilterResponseHeaderModifier = "ResponseHeaderModifier"
+)
+
+// HTTPRouteFilter modifies the requests matched by a rule, or their responses
+type HTTPRouteFilter struct {
+	Type                   string            `json:"type"`
+	RequestHeaderModifier  *HTTPHeaderFilter `json:"requestHeaderModifier,omitempty"`
+	ResponseHeaderModifier *HTTPHeaderFilter `json:"responseHeaderModifier,omitempty"`
+}
+
+// HTTPHeaderFilter sets, adds and removes headers
+type HTTPHeaderFilter struct {
+	Set    []HTTPHeader `json:"set,omitempty"`
+	Add    []HTTPHeader `json:"add,omitempty"`
+	Remove []string     `json:"remove,omitempty"`
+}
+
+// HTTPHeader is a header name and value
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPBackendRef is a backend of an HTTPRoute rule, along with the filters applied to the requests routed to it
+type HTTPBackendRef struct {
+	BackendRef `json:",inline"`
+
+	Filters []HTTPRouteFilter `json:"filters,omitempty"`
+}
+
+// BackendRef references a backend of a route; a Service when its group and kind are not set
+type BackendRef struct {
+	Group     *string `json:"group,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace,omitempty"`
+	Port      *int32  `json:"port,omitempty"`
+
+	// Weight is the proportion of the requests routed to the backend; 1 when not set
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// TLSRoute routes TLS connections from gateways to backends based on their SNI, without terminating TLS
+type TLSRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TLSRouteSpec `json:"spec"`
+}
+
+// TLSRouteSpec is the spec of a TLSRoute
+type TLSRouteSpec struct {
+	// Hostnames are the hostnames matched against the SNI of the connections; all hostnames when empty
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Rules are the rules routing the connections to backends
+	Rules []TLSRouteRule `json:"rules,omitempty"`
+}
+
+// TLSRouteRule routes the connections to its backends
+type TLSRouteRule struct {
+	BackendRefs []BackendRef `json:"backendRefs,omitempty"`
+}
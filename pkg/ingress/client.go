@@ -1,37 +1,84 @@
 package ingress
 
 import (
-	"reflect"
-
-	extensionsV1beta "k8s.io/api/extensions/v1beta1"
-	"k8s.io/client-go/informers"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
+var (
+	// ingressResources are the Ingress resources OSM can watch, from the most to the least preferred API version
+	ingressResources = []schema.GroupVersionResource{
+		{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"},
+		{Group: "extensions", Version: "v1beta1", Resource: "ingresses"},
+	}
+
+	// ingressClassResources are the IngressClass resources OSM can watch, from the most to the least preferred API version
+	ingressClassResources = []schema.GroupVersionResource{
+		{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingressclasses"},
+	}
+)
+
 // NewIngressClient implements ingress.Monitor and creates the Kubernetes client to monitor Ingress resources.
-func NewIngressClient(kubeClient kubernetes.Interface, namespaceController namespace.Controller, stop chan struct{}, cfg configurator.Configurator) (Monitor, error) {
-	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
-	informer := informerFactory.Extensions().V1beta1().Ingresses().Informer()
+// The Ingress resources are watched in the most recent API version served by the cluster.
+func NewIngressClient(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, namespaceController namespace.Controller, stop chan struct{}, cfg configurator.Configurator) (Monitor, error) {
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval)
+
+	ingressResource, found := k8s.GetServedResource(kubeClient.Discovery(), ingressResources)
+	if !found {
+		// Clusters which do not advertise their Ingress API still serve extensions/v1beta1
+		ingressResource = ingressResources[len(ingressResources)-1]
+		log.Warn().Msgf("Could not discover the Ingress API served by the cluster, falling back to %s", ingressResource.GroupVersion())
+	}
+	log.Info().Msgf("Watching %s Ingress resources", ingressResource.GroupVersion())
+	informer := informerFactory.ForResource(ingressResource).Informer()
 
 	client := Client{
 		informer:            informer,
 		cache:               informer.GetStore(),
+		ingressVersion:      ingressResource.GroupVersion(),
 		cacheSynced:         make(chan interface{}),
-		announcements:       make(chan interface{}),
+		announcements:       make(chan announcements.Announcement),
 		namespaceController: namespaceController,
+		cfg:                 cfg,
 	}
 
 	shouldObserve := func(obj interface{}) bool {
-		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
-		return namespaceController.IsMonitoredNamespace(ns)
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		return namespaceController.IsMonitoredNamespace(accessor.GetNamespace())
+	}
+	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("Ingress", "Kubernetes", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.IngressAdded,
+		Update: announcements.IngressUpdated,
+		Delete: announcements.IngressDeleted,
+	}))
+
+	// The default IngressClass applies to the Ingress resources without a class
+	if ingressClassResource, found := k8s.GetServedResource(kubeClient.Discovery(), ingressClassResources); found {
+		client.classInformer = informerFactory.ForResource(ingressClassResource).Informer()
+		client.classCache = client.classInformer.GetStore()
+		client.classInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("IngressClass", "Kubernetes", client.announcements, nil, k8s.EventTypes{
+			Add:    announcements.IngressClassAdded,
+			Update: announcements.IngressClassUpdated,
+			Delete: announcements.IngressClassDeleted,
+		}))
 	}
-	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("Ingress", "Kubernetes", client.announcements, shouldObserve))
 
 	if err := client.run(stop); err != nil {
 		log.Error().Err(err).Msg("Could not start Kubernetes Ingress client")
@@ -49,9 +96,15 @@ func (c *Client) run(stop <-chan struct{}) error {
 		return errInitInformers
 	}
 
+	hasSynced := []cache.InformerSynced{c.informer.HasSynced}
 	go c.informer.Run(stop)
+	if c.classInformer != nil {
+		hasSynced = append(hasSynced, c.classInformer.HasSynced)
+		go c.classInformer.Run(stop)
+	}
+
 	log.Info().Msgf("Waiting for Ingress informer cache sync")
-	if !cache.WaitForCacheSync(stop, c.informer.HasSynced) {
+	if !cache.WaitForCacheSync(stop, hasSynced...) {
 		return errSyncingCaches
 	}
 
@@ -63,30 +116,45 @@ func (c *Client) run(stop <-chan struct{}) error {
 }
 
 // GetAnnouncementsChannel returns the announcement channel for the Ingress client
-func (c Client) GetAnnouncementsChannel() <-chan interface{} {
+func (c Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return c.announcements
 }
 
 // GetIngressResources returns the ingress resources whose backends correspond to the service
-func (c Client) GetIngressResources(meshService service.MeshService) ([]*extensionsV1beta.Ingress, error) {
-	var ingressResources []*extensionsV1beta.Ingress
+func (c Client) GetIngressResources(meshService service.MeshService) ([]*networkingV1beta1.Ingress, error) {
+	var ingressResources []*networkingV1beta1.Ingress
+	ingressClass := c.cfg.GetIngressClass()
+	defaultIngressClass := c.getDefaultIngressClass()
+
 	for _, ingressInterface := range c.cache.List() {
-		ingress, ok := ingressInterface.(*extensionsV1beta.Ingress)
+		obj, ok := ingressInterface.(*unstructured.Unstructured)
 		if !ok {
 			log.Error().Msg("Failed type assertion for Ingress in ingress cache")
 			continue
 		}
 
 		// Extra safety - make sure we do not pay attention to Ingresses outside of observed namespaces
-		if !c.namespaceController.IsMonitoredNamespace(ingress.Namespace) {
+		if !c.namespaceController.IsMonitoredNamespace(obj.GetNamespace()) {
 			continue
 		}
 
 		// Check if the ingress resource belongs to the same namespace as the service
-		if ingress.Namespace != meshService.Namespace {
+		if obj.GetNamespace() != meshService.Namespace {
 			// The ingress resource does not belong to the namespace of the service
 			continue
 		}
+
+		ingress, err := toIngress(obj, c.ingressVersion)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error converting %s Ingress %s/%s", c.ingressVersion, obj.GetNamespace(), obj.GetName())
+			continue
+		}
+
+		if ingressClass != "" && getIngressClass(ingress, defaultIngressClass) != ingressClass {
+			// The ingress resource is implemented by another ingress controller
+			continue
+		}
+
 		if backend := ingress.Spec.Backend; backend != nil && backend.ServiceName == meshService.Name {
 			// Default backend service
 			ingressResources = append(ingressResources, ingress)
@@ -95,6 +163,9 @@ func (c Client) GetIngressResources(meshService service.MeshService) ([]*extensi
 
 	ingressRule:
 		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
 			for _, path := range rule.HTTP.Paths {
 				if path.Backend.ServiceName == meshService.Name {
 					ingressResources = append(ingressResources, ingress)
@@ -105,3 +176,33 @@ func (c Client) GetIngressResources(meshService service.MeshService) ([]*extensi
 	}
 	return ingressResources, nil
 }
+
+// getDefaultIngressClass returns the name of the IngressClass marked as the default one, if any
+func (c Client) getDefaultIngressClass() string {
+	if c.classCache == nil {
+		return ""
+	}
+	for _, ingressClassInterface := range c.classCache.List() {
+		ingressClass, ok := ingressClassInterface.(*unstructured.Unstructured)
+		if !ok {
+			log.Error().Msg("Failed type assertion for IngressClass in ingress class cache")
+			continue
+		}
+		if ingressClass.GetAnnotations()[networkingV1beta1.AnnotationIsDefaultIngressClass] == "true" {
+			return ingressClass.GetName()
+		}
+	}
+	return ""
+}
+
+// getIngressClass returns the class of the ingress resource. The deprecated annotation takes precedence
+// over the ingressClassName field, and the default class applies when neither is set.
+func getIngressClass(ingress *networkingV1beta1.Ingress, defaultIngressClass string) string {
+	if ingressClass, found := ingress.Annotations[networkingV1beta1.AnnotationIngressClass]; found {
+		return ingressClass
+	}
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+	return defaultIngressClass
+}
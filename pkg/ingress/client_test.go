@@ -0,0 +1,214 @@
+package ingress
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	testNamespace = "bookstore-ns"
+	testService   = "bookstore"
+)
+
+func newIngressV1(name string, spec map[string]interface{}, annotations map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   testNamespace,
+			"annotations": annotations,
+		},
+		"spec": spec,
+	}}
+}
+
+func newIngressClassV1(name string, isDefault bool) *unstructured.Unstructured {
+	ingressClass := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "IngressClass",
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": map[string]interface{}{
+			"controller": "example.com/" + name,
+		},
+	}}
+	if isDefault {
+		ingressClass.SetAnnotations(map[string]string{networkingV1beta1.AnnotationIsDefaultIngressClass: "true"})
+	}
+	return ingressClass
+}
+
+func newTestIngressClient(servedResources []*metav1.APIResourceList, cfg configurator.Configurator, objects ...runtime.Object) Monitor {
+	mockCtrl := gomock.NewController(GinkgoT())
+	mockNsController := namespace.NewMockController(mockCtrl)
+	mockNsController.EXPECT().IsMonitoredNamespace(testNamespace).Return(true).AnyTimes()
+
+	kubeClient := testclient.NewSimpleClientset()
+	kubeClient.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = servedResources
+	dynamicClient := fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+
+	ingressClient, err := NewIngressClient(kubeClient, dynamicClient, mockNsController, make(chan struct{}), cfg)
+	Expect(err).ToNot(HaveOccurred())
+	return ingressClient
+}
+
+var _ = Describe("Test Ingress client", func() {
+	servedV1Resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "ingresses", Namespaced: true, Kind: "Ingress"},
+				{Name: "ingressclasses", Kind: "IngressClass"},
+			},
+		},
+	}
+	meshService := service.MeshService{Namespace: testNamespace, Name: testService}
+
+	Context("Test networking.k8s.io/v1 Ingress", func() {
+		prefix := string(networkingV1beta1.PathTypePrefix)
+		ingress := newIngressV1("bookstore", map[string]interface{}{
+			"ingressClassName": "nginx",
+			"defaultBackend": map[string]interface{}{
+				"service": map[string]interface{}{
+					"name": testService,
+					"port": map[string]interface{}{"name": "http"},
+				},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"host": "bookstore.example.com",
+					"http": map[string]interface{}{
+						"paths": []interface{}{
+							map[string]interface{}{
+								"path":     "/books",
+								"pathType": prefix,
+								"backend": map[string]interface{}{
+									"service": map[string]interface{}{
+										"name": testService,
+										"port": map[string]interface{}{"number": int64(8080)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+
+		It("converts the Ingress resources to networking.k8s.io/v1beta1", func() {
+			ingressClient := newTestIngressClient(servedV1Resources, configurator.NewFakeConfigurator(), ingress)
+
+			ingresses, err := ingressClient.GetIngressResources(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(ingresses)).To(Equal(1))
+
+			spec := ingresses[0].Spec
+			Expect(*spec.IngressClassName).To(Equal("nginx"))
+			Expect(spec.Backend.ServiceName).To(Equal(testService))
+			Expect(spec.Backend.ServicePort).To(Equal(intstr.FromString("http")))
+
+			path := spec.Rules[0].HTTP.Paths[0]
+			Expect(spec.Rules[0].Host).To(Equal("bookstore.example.com"))
+			Expect(path.Path).To(Equal("/books"))
+			Expect(*path.PathType).To(Equal(networkingV1beta1.PathTypePrefix))
+			Expect(path.Backend.ServiceName).To(Equal(testService))
+			Expect(path.Backend.ServicePort).To(Equal(intstr.FromInt(8080)))
+		})
+
+		It("ignores the Ingress resources of other services", func() {
+			ingressClient := newTestIngressClient(servedV1Resources, configurator.NewFakeConfigurator(), ingress)
+
+			ingresses, err := ingressClient.GetIngressResources(service.MeshService{Namespace: testNamespace, Name: "bookbuyer"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ingresses).To(BeEmpty())
+		})
+	})
+
+	Context("Test IngressClass filtering", func() {
+		backend := map[string]interface{}{
+			"service": map[string]interface{}{
+				"name": testService,
+				"port": map[string]interface{}{"number": int64(80)},
+			},
+		}
+		nginxIngress := newIngressV1("nginx", map[string]interface{}{"ingressClassName": "nginx", "defaultBackend": backend}, nil)
+		annotatedIngress := newIngressV1("annotated", map[string]interface{}{"ingressClassName": "nginx", "defaultBackend": backend},
+			map[string]interface{}{networkingV1beta1.AnnotationIngressClass: "gloo"})
+		unclassifiedIngress := newIngressV1("unclassified", map[string]interface{}{"defaultBackend": backend}, nil)
+
+		getIngressNames := func(ingressClient Monitor) []string {
+			ingresses, err := ingressClient.GetIngressResources(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			var names []string
+			for _, ingress := range ingresses {
+				names = append(names, ingress.Name)
+			}
+			return names
+		}
+
+		It("returns the Ingress resources of every class when no class is configured", func() {
+			ingressClient := newTestIngressClient(servedV1Resources, configurator.NewFakeConfigurator(),
+				nginxIngress, annotatedIngress, unclassifiedIngress)
+			Expect(getIngressNames(ingressClient)).To(ConsistOf("nginx", "annotated", "unclassified"))
+		})
+
+		It("returns the Ingress resources of the configured class only", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IngressClass: "nginx"})
+			ingressClient := newTestIngressClient(servedV1Resources, cfg, nginxIngress, annotatedIngress, unclassifiedIngress)
+			Expect(getIngressNames(ingressClient)).To(ConsistOf("nginx"))
+
+			cfg = configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IngressClass: "gloo"})
+			ingressClient = newTestIngressClient(servedV1Resources, cfg, nginxIngress, annotatedIngress, unclassifiedIngress)
+			Expect(getIngressNames(ingressClient)).To(ConsistOf("annotated"))
+		})
+
+		It("applies the default IngressClass to the Ingress resources without a class", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IngressClass: "nginx"})
+			ingressClient := newTestIngressClient(servedV1Resources, cfg,
+				nginxIngress, annotatedIngress, unclassifiedIngress, newIngressClassV1("nginx", true), newIngressClassV1("gloo", false))
+			Expect(getIngressNames(ingressClient)).To(ConsistOf("nginx", "unclassified"))
+		})
+	})
+
+	Context("Test extensions/v1beta1 Ingress", func() {
+		It("falls back to extensions/v1beta1 when the cluster serves no other Ingress API", func() {
+			ingress := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "extensions/v1beta1",
+				"kind":       "Ingress",
+				"metadata": map[string]interface{}{
+					"name":      "bookstore",
+					"namespace": testNamespace,
+				},
+				"spec": map[string]interface{}{
+					"backend": map[string]interface{}{
+						"serviceName": testService,
+						"servicePort": int64(80),
+					},
+				},
+			}}
+			ingressClient := newTestIngressClient(nil, configurator.NewFakeConfigurator(), ingress)
+
+			ingresses, err := ingressClient.GetIngressResources(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(ingresses)).To(Equal(1))
+			Expect(ingresses[0].Spec.Backend.ServiceName).To(Equal(testService))
+			Expect(ingresses[0].Spec.Backend.ServicePort).To(Equal(intstr.FromInt(80)))
+		})
+	})
+})
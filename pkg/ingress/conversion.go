@@ -0,0 +1,77 @@
+package ingress
+
+import (
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ingressV1 = schema.GroupVersion{Group: "networking.k8s.io", Version: "v1"}
+
+// toIngress converts an Ingress resource of the given API version to a networking.k8s.io/v1beta1 Ingress.
+// The extensions/v1beta1 and networking.k8s.io/v1beta1 Ingress resources have the same schema.
+func toIngress(obj *unstructured.Unstructured, version schema.GroupVersion) (*networkingV1beta1.Ingress, error) {
+	content := obj.UnstructuredContent()
+	if version == ingressV1 {
+		content = convertIngressV1(obj.DeepCopy().UnstructuredContent())
+	}
+
+	ingress := &networkingV1beta1.Ingress{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, ingress); err != nil {
+		return nil, err
+	}
+	return ingress, nil
+}
+
+// convertIngressV1 rewrites the fields of a networking.k8s.io/v1 Ingress renamed since networking.k8s.io/v1beta1:
+// spec.defaultBackend was spec.backend, and backend.service.name and backend.service.port were
+// backend.serviceName and backend.servicePort.
+func convertIngressV1(content map[string]interface{}) map[string]interface{} {
+	spec, ok := content["spec"].(map[string]interface{})
+	if !ok {
+		return content
+	}
+
+	if defaultBackend, ok := spec["defaultBackend"].(map[string]interface{}); ok {
+		spec["backend"] = convertIngressV1Backend(defaultBackend)
+		delete(spec, "defaultBackend")
+	}
+
+	rules, _ := spec["rules"].([]interface{})
+	for _, rule := range rules {
+		paths, _ := asMap(asMap(rule)["http"])["paths"].([]interface{})
+		for _, path := range paths {
+			if backend, ok := asMap(path)["backend"].(map[string]interface{}); ok {
+				convertIngressV1Backend(backend)
+			}
+		}
+	}
+
+	return content
+}
+
+// convertIngressV1Backend rewrites a networking.k8s.io/v1 IngressBackend to a networking.k8s.io/v1beta1 IngressBackend
+func convertIngressV1Backend(backend map[string]interface{}) map[string]interface{} {
+	svc, ok := backend["service"].(map[string]interface{})
+	if !ok {
+		// Resource backends are unchanged
+		return backend
+	}
+	delete(backend, "service")
+
+	backend["serviceName"] = svc["name"]
+	if port, ok := svc["port"].(map[string]interface{}); ok {
+		if number, found := port["number"]; found {
+			backend["servicePort"] = number
+		} else if name, found := port["name"]; found {
+			backend["servicePort"] = name
+		}
+	}
+	return backend
+}
+
+func asMap(obj interface{}) map[string]interface{} {
+	m, _ := obj.(map[string]interface{})
+	return m
+}
@@ -1,14 +1,15 @@
 package ingress
 
 import (
-	extensionsV1beta "k8s.io/api/extensions/v1beta1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // FakeIngressMonitor returns a fake ingress monitor object
 type FakeIngressMonitor struct {
-	FakeIngresses []*extensionsV1beta.Ingress
+	FakeIngresses []*networkingV1beta1.Ingress
 	Monitor
 }
 
@@ -18,11 +19,11 @@ func NewFakeIngressMonitor() FakeIngressMonitor {
 }
 
 // GetIngressResources returns the ingress resources whose backends correspond to the service
-func (f FakeIngressMonitor) GetIngressResources(service.MeshService) ([]*extensionsV1beta.Ingress, error) {
+func (f FakeIngressMonitor) GetIngressResources(service.MeshService) ([]*networkingV1beta1.Ingress, error) {
 	return f.FakeIngresses, nil
 }
 
 // GetAnnouncementsChannel returns the channel on which Ingress Monitor makes annoucements
-func (f FakeIngressMonitor) GetAnnouncementsChannel() <-chan interface{} {
-	return make(chan interface{})
+func (f FakeIngressMonitor) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return make(chan announcements.Announcement)
 }
@@ -1,9 +1,12 @@
 package ingress
 
 import (
-	extensionsV1beta "k8s.io/api/extensions/v1beta1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -17,16 +20,21 @@ var (
 type Client struct {
 	informer            cache.SharedIndexInformer
 	cache               cache.Store
+	ingressVersion      schema.GroupVersion
+	classInformer       cache.SharedIndexInformer
+	classCache          cache.Store
 	cacheSynced         chan interface{}
-	announcements       chan interface{}
+	announcements       chan announcements.Announcement
 	namespaceController namespace.Controller
+	cfg                 configurator.Configurator
 }
 
 // Monitor is the client interface for K8s Ingress resource
 type Monitor interface {
-	// GetIngressResources returns the ingress resources whose backends correspond to the service
-	GetIngressResources(service.MeshService) ([]*extensionsV1beta.Ingress, error)
+	// GetIngressResources returns the ingress resources whose backends correspond to the service.
+	// The ingress resources of every API version are returned as networking.k8s.io/v1beta1 resources.
+	GetIngressResources(service.MeshService) ([]*networkingV1beta1.Ingress, error)
 
 	// GetAnnouncementsChannel returns the channel on which Ingress Monitor makes annoucements
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
 }
@@ -16,6 +16,11 @@ import (
 )
 
 func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Configurator) ([]byte, error) {
+	adsAPIType := "GRPC"
+	if cfg.IsDeltaXDSEnabled() {
+		adsAPIType = "DELTA_GRPC"
+	}
+
 	m := map[interface{}]interface{}{
 		"admin": map[string]interface{}{
 			"access_log_path": "/dev/stdout",
@@ -29,7 +34,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 
 		"dynamic_resources": map[string]interface{}{
 			"ads_config": map[string]interface{}{
-				"api_type":              "GRPC",
+				"api_type":              adsAPIType,
 				"transport_api_version": "V3",
 				"grpc_services": []map[string]interface{}{
 					{
@@ -120,11 +125,18 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 }
 
 func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater) (*corev1.Secret, error) {
+	// The proxy trusts all the root certificates trusted by the mesh, so it keeps trusting the XDS server while the root certificate is being rotated
+	trustBundle, err := wh.certManager.GetTrustBundle()
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting the root certificates trusted by the mesh")
+		return nil, err
+	}
+
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
 		XDSClusterName: constants.OSMControllerName,
 
-		RootCert: base64.StdEncoding.EncodeToString(cert.GetIssuingCA()),
+		RootCert: base64.StdEncoding.EncodeToString(trustBundle),
 		Cert:     base64.StdEncoding.EncodeToString(cert.GetCertificateChain()),
 		Key:      base64.StdEncoding.EncodeToString(cert.GetPrivateKey()),
 
@@ -143,6 +155,8 @@ func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace stri
 		},
 		Data: map[string][]byte{
 			envoyBootstrapConfigFile: yamlContent,
+			// The certificate of the proxy is also kept on its own, so `osm ca rotate retire` can check which root certificate issued it
+			corev1.TLSCertKey: cert.GetCertificateChain(),
 		},
 	}
 	if existing, err := wh.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
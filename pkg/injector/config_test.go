@@ -2,6 +2,7 @@ package injector
 
 import (
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -88,6 +89,27 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			Expect(string(actual)).To(Equal(expectedEnvoyConfig[1:]),
 				fmt.Sprintf("Expected:\n%s\nActual:\n%s\n", expectedEnvoyConfig, string(actual)))
 		})
+
+		It("creates envoy config using delta xDS", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				RootCert:       "RootCert",
+				Cert:           "Cert",
+				Key:            "Key",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				DeltaXDS: true,
+			})
+			actual, err := getEnvoyConfigYAML(config, cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := strings.Replace(expectedEnvoyConfig[1:], "api_type: GRPC", "api_type: DELTA_GRPC", 1)
+			Expect(string(actual)).To(Equal(expected))
+		})
 	})
 })
 
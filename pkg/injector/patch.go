@@ -12,6 +12,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
@@ -35,7 +36,11 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 	cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, pod.Spec.ServiceAccountName, namespace)
 	log.Info().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN=%s", pod.Spec.ServiceAccountName, namespace, cn)
 	validityPeriod := constants.XDSCertificateValidityPeriod
-	bootstrapCertificate, err := wh.certManager.IssueCertificate(cn, &validityPeriod)
+	spiffeID := wh.meshCatalog.GetSPIFFEID(service.K8sServiceAccount{
+		Namespace: namespace,
+		Name:      pod.Spec.ServiceAccountName,
+	})
+	bootstrapCertificate, err := wh.certManager.IssueCertificate(cn, &validityPeriod, spiffeID)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN=%s", cn)
 		return nil, err
@@ -44,7 +49,7 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 	wh.meshCatalog.ExpectProxy(cn)
 
 	// Create kube secret for Envoy bootstrap config
-	envoyBootstrapConfigName := fmt.Sprintf("envoy-bootstrap-config-%s", proxyUUID)
+	envoyBootstrapConfigName := constants.EnvoyBootstrapConfigSecretPrefix + proxyUUID
 	_, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, bootstrapCertificate)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create bootstrap config for Envoy sidecar")
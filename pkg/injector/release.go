@@ -0,0 +1,48 @@
+package injector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// ReleaseCertificatesOfDeletedPods watches the pods whose sidecar was injected, and releases the certificate issued for their proxy once they are deleted:
+// the Common Name of this certificate holds the unique ID of the proxy, so no other proxy uses it, and it would otherwise be kept and rotated forever.
+// Without a shared certificate store, every osm-controller replica releases the certificate from the cache of its own certificate manager.
+// With a shared store, only the leader releases it, and the other replicas drop it from their cache once it is removed from the store.
+func ReleaseCertificatesOfDeletedPods(kubeClient kubernetes.Interface, certManager certificate.Manager, stop <-chan struct{}) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.LabelSelector = constants.EnvoyUniqueIDLabelName
+	}))
+	podInformer := informerFactory.Core().V1().Pods().Informer()
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			// The informer missed the deletion; the tombstone holds the last known state of the pod
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			releaseProxyCertificate(certManager, pod)
+		},
+	})
+
+	go podInformer.Run(stop)
+}
+
+// releaseProxyCertificate releases the certificate issued for the proxy of the given pod when its sidecar was injected.
+func releaseProxyCertificate(certManager certificate.Manager, pod *corev1.Pod) {
+	cn := catalog.NewCertCommonNameWithProxyID(pod.Labels[constants.EnvoyUniqueIDLabelName], pod.Spec.ServiceAccountName, pod.Namespace)
+	if err := certManager.ReleaseCertificate(cn); err != nil {
+		log.Error().Err(err).Msgf("Error releasing the certificate with CN=%s of the proxy of deleted pod %s/%s", cn, pod.Namespace, pod.Name)
+	}
+}
@@ -0,0 +1,43 @@
+package injector
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test releasing the certificates of the proxies of deleted pods", func() {
+	Context("Test releaseProxyCertificate()", func() {
+		It("releases only the certificate of the proxy of the deleted pod", func() {
+			cache := make(map[certificate.CacheKey]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+
+			pod := tests.NewPodTestFixture(tests.Namespace, "pod-name")
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = tests.EnvoyUID
+			spiffeID := certificate.NewSPIFFEID("cluster.local", tests.Namespace, tests.BookstoreServiceAccountName)
+			cn := catalog.NewCertCommonNameWithProxyID(tests.EnvoyUID, tests.BookstoreServiceAccountName, tests.Namespace)
+			otherCN := catalog.NewCertCommonNameWithProxyID("other-proxy", tests.BookstoreServiceAccountName, tests.Namespace)
+			_, err := certManager.IssueCertificate(cn, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = certManager.IssueCertificate(otherCN, nil, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+
+			releaseProxyCertificate(certManager, &pod)
+
+			_, err = certManager.GetCertificate(cn, spiffeID)
+			Expect(err).To(HaveOccurred())
+			_, err = certManager.GetCertificate(otherCN, spiffeID)
+			Expect(err).ToNot(HaveOccurred())
+
+			// The certificate was released, not revoked
+			Expect(certManager.IsRevoked(cn)).To(BeFalse())
+		})
+	})
+})
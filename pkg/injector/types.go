@@ -1,6 +1,9 @@
 package injector
 
 import (
+	"crypto/tls"
+	"sync"
+
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
@@ -29,6 +32,10 @@ type webhook struct {
 	osmNamespace        string
 	cert                certificate.Certificater
 	configurator        configurator.Configurator
+
+	// servingCert is the key pair parsed from cert, which is replaced when the certificate manager issues the certificate again
+	servingCert     *tls.Certificate
+	servingCertLock sync.Mutex
 }
 
 // Config is the type used to represent the config options for the sidecar injection
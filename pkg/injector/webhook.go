@@ -1,6 +1,7 @@
 package injector
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -41,11 +42,12 @@ const (
 	osmWebhookMutatePath = "/mutate"
 )
 
-// NewWebhook starts a new web server handling requests from the injector MutatingWebhookConfiguration
-func NewWebhook(config Config, kubeClient kubernetes.Interface, certManager certificate.Manager, meshCatalog catalog.MeshCataloger, namespaceController namespace.Controller, meshName, osmNamespace, webhookName string, stop <-chan struct{}, cfg configurator.Configurator) error {
+// NewWebhook starts a new web server handling requests from the injector MutatingWebhookConfiguration.
+// The MutatingWebhookConfiguration is configured separately, by PatchMutatingWebhookConfiguration.
+func NewWebhook(config Config, kubeClient kubernetes.Interface, certManager certificate.Manager, meshCatalog catalog.MeshCataloger, namespaceController namespace.Controller, osmNamespace string, stop <-chan struct{}, cfg configurator.Configurator) error {
 	cn := certificate.CommonName(fmt.Sprintf("%s.%s.svc", constants.OSMControllerName, osmNamespace))
 	validityPeriod := constants.XDSCertificateValidityPeriod
-	cert, err := certManager.IssueCertificate(cn, &validityPeriod)
+	cert, err := certManager.IssueCertificate(cn, &validityPeriod, "")
 	if err != nil {
 		return errors.Errorf("Error issuing certificate for the mutating webhook: %+v", err)
 	}
@@ -62,7 +64,17 @@ func NewWebhook(config Config, kubeClient kubernetes.Interface, certManager cert
 	}
 
 	go wh.run(stop)
-	if err = patchMutatingWebhookConfiguration(cert, meshName, osmNamespace, webhookName, wh.kubeClient); err != nil {
+	return nil
+}
+
+// PatchMutatingWebhookConfiguration configures the MutatingWebhookConfiguration with the root certificates trusted by the given certificate manager,
+// so the Kubernetes API server trusts the webhook of every osm-controller replica, including while the root certificate is being rotated.
+func PatchMutatingWebhookConfiguration(certManager certificate.Manager, meshName, osmNamespace, webhookName string, kubeClient kubernetes.Interface) error {
+	trustBundle, err := certManager.GetTrustBundle()
+	if err != nil {
+		return errors.Errorf("Error getting the root certificates for the MutatingWebhookConfiguration: %+v", err)
+	}
+	if err = patchMutatingWebhookConfiguration(trustBundle, meshName, osmNamespace, webhookName, kubeClient); err != nil {
 		return errors.Errorf("Error configuring MutatingWebhookConfiguration: %+v", err)
 	}
 	return nil
@@ -84,15 +96,8 @@ func (wh *webhook) run(stop <-chan struct{}) {
 
 	log.Info().Msgf("Starting sidecar-injection webhook server on :%v", wh.config.ListenPort)
 	go func() {
-		// Generate a key pair from your pem-encoded cert and key ([]byte).
-		cert, err := tls.X509KeyPair(wh.cert.GetCertificateChain(), wh.cert.GetPrivateKey())
-		if err != nil {
-			log.Error().Err(err).Msgf("Error parsing webhook certificate: %+v", err)
-			return
-		}
-
 		server.TLSConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
+			GetCertificate: wh.getServingCertificate,
 		}
 
 		if err := server.ListenAndServeTLS("", ""); err != nil {
@@ -112,6 +117,30 @@ func (wh *webhook) run(stop <-chan struct{}) {
 	}
 }
 
+// getServingCertificate returns the key pair of the latest certificate of the webhook, which the certificate manager issues again
+// when it expires or when the root certificate is rotated.
+func (wh *webhook) getServingCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	wh.servingCertLock.Lock()
+	defer wh.servingCertLock.Unlock()
+
+	cert, err := wh.certManager.GetCertificate(wh.cert.GetCommonName(), "")
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the latest webhook certificate CN=%s; serving the previous one", wh.cert.GetCommonName())
+		cert = wh.cert
+	}
+
+	if wh.servingCert == nil || !bytes.Equal(cert.GetCertificateChain(), wh.cert.GetCertificateChain()) {
+		// Generate a key pair from your pem-encoded cert and key ([]byte).
+		servingCert, err := tls.X509KeyPair(cert.GetCertificateChain(), cert.GetPrivateKey())
+		if err != nil {
+			return nil, errors.Errorf("Error parsing webhook certificate: %+v", err)
+		}
+		wh.cert = cert
+		wh.servingCert = &servingCert
+	}
+	return wh.servingCert, nil
+}
+
 func (wh *webhook) healthReadyHandler(w http.ResponseWriter, req *http.Request) {
 	// TODO(shashank): If TLS certificate is not present, mark as not ready
 	w.WriteHeader(http.StatusOK)
@@ -275,7 +304,7 @@ func patchAdmissionResponse(resp *v1beta1.AdmissionResponse, patchBytes []byte)
 	}()
 }
 
-func patchMutatingWebhookConfiguration(cert certificate.Certificater, meshName, osmNamespace, webhookName string, clientSet kubernetes.Interface) error {
+func patchMutatingWebhookConfiguration(caBundle []byte, meshName, osmNamespace, webhookName string, clientSet kubernetes.Interface) error {
 	if err := hookExists(clientSet, webhookName); err != nil {
 		log.Error().Err(err).Msgf("Error getting webhook %s", webhookName)
 	}
@@ -287,7 +316,7 @@ func patchMutatingWebhookConfiguration(cert certificate.Certificater, meshName,
 			{
 				Name: osmWebhookName,
 				ClientConfig: admissionv1beta1.WebhookClientConfig{
-					CABundle: cert.GetCertificateChain(),
+					CABundle: caBundle,
 				},
 				Rules: []admissionv1beta1.RuleWithOperations{
 					{
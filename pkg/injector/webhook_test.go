@@ -2,6 +2,7 @@ package injector
 
 import (
 	"context"
+	"crypto/tls"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 )
 
 var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
@@ -58,7 +60,7 @@ var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
 		})
 
 		It("patches a webhook", func() {
-			err := patchMutatingWebhookConfiguration(cert, meshName, osmNamespace, webhookName, kubeClient)
+			err := patchMutatingWebhookConfiguration(cert.GetCertificateChain(), meshName, osmNamespace, webhookName, kubeClient)
 			Expect(err).ToNot(HaveOccurred())
 
 		})
@@ -86,6 +88,42 @@ var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
 	})
 })
 
+var _ = Describe("Test the serving certificate of the webhook", func() {
+	Context("Test getServingCertificate()", func() {
+		It("serves the latest certificate issued by the certificate manager", func() {
+			cache := make(map[certificate.CacheKey]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+			go func() {
+				for range certManager.GetAnnouncementsChannel() {
+				}
+			}()
+			cn := certificate.CommonName("osm-controller.osm-system.svc")
+			cert, err := certManager.IssueCertificate(cn, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			wh := &webhook{
+				certManager: certManager,
+				cert:        cert,
+			}
+
+			servingCert, err := wh.getServingCertificate(nil)
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := tls.X509KeyPair(cert.GetCertificateChain(), cert.GetPrivateKey())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(servingCert.Certificate).To(Equal(expected.Certificate))
+
+			rotated, err := certManager.RotateCertificate(cn, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			servingCert, err = wh.getServingCertificate(nil)
+			Expect(err).ToNot(HaveOccurred())
+			expected, err = tls.X509KeyPair(rotated.GetCertificateChain(), rotated.GetPrivateKey())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(servingCert.Certificate).To(Equal(expected.Certificate))
+		})
+	})
+})
+
 type mockCertificate struct{}
 
 func (mc mockCertificate) GetCommonName() certificate.CommonName { return "" }
@@ -2,10 +2,11 @@ package kubernetes
 
 import (
 	"os"
-	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -21,68 +22,80 @@ var emitLogs = os.Getenv(constants.EnvVarLogKubernetesEvents) == "true"
 // This filter could be added optionally by anything using GetKubernetesEventHandlers()
 type observeFilter func(obj interface{}) bool
 
-// GetKubernetesEventHandlers creates Kubernetes events handlers.
-func GetKubernetesEventHandlers(informerName string, providerName string, announcements chan interface{}, shouldObserve observeFilter) cache.ResourceEventHandlerFuncs {
+// GetKubernetesEventHandlers creates Kubernetes events handlers, which announce the changes observed as the given kinds of announcements.
+func GetKubernetesEventHandlers(informerName string, providerName string, announce chan announcements.Announcement, shouldObserve observeFilter, eventTypes EventTypes) cache.ResourceEventHandlerFuncs {
 	if shouldObserve == nil {
 		shouldObserve = func(obj interface{}) bool { return true }
 	}
 	return cache.ResourceEventHandlerFuncs{
-		AddFunc:    addEvent(informerName, providerName, announcements, shouldObserve, eventAdd),
-		UpdateFunc: updateEvent(informerName, providerName, announcements, shouldObserve, eventUpdate),
-		DeleteFunc: deleteEvent(informerName, providerName, announcements, shouldObserve, eventDelete),
+		AddFunc:    addEvent(informerName, providerName, announce, shouldObserve, eventTypes.Add),
+		UpdateFunc: updateEvent(informerName, providerName, announce, shouldObserve, eventTypes.Update),
+		DeleteFunc: deleteEvent(informerName, providerName, announce, shouldObserve, eventTypes.Delete),
 	}
 }
 
-func addEvent(informerName string, providerName string, announce chan interface{}, shouldObserve observeFilter, eventType string) func(obj interface{}) {
+func addEvent(informerName string, providerName string, announce chan announcements.Announcement, shouldObserve observeFilter, kind announcements.Kind) func(obj interface{}) {
 	return func(obj interface{}) {
 		if !shouldObserve(obj) {
-			logNotObservedNamespace(obj, eventType)
+			logNotObservedNamespace(obj, eventAdd)
 			return
 		}
-		logEvent(eventType, providerName, informerName, obj)
+		logEvent(eventAdd, providerName, informerName, obj)
 		if announce != nil {
-			announce <- Event{
-				Type:  CreateEvent,
-				Value: obj,
-			}
+			announce <- newAnnouncement(kind, obj)
 		}
 	}
 }
 
-func updateEvent(informerName string, providerName string, announce chan interface{}, shouldObserve observeFilter, eventType string) func(oldObj, newObj interface{}) {
+func updateEvent(informerName string, providerName string, announce chan announcements.Announcement, shouldObserve observeFilter, kind announcements.Kind) func(oldObj, newObj interface{}) {
 	return func(oldObj, newObj interface{}) {
 		if !shouldObserve(newObj) {
-			logNotObservedNamespace(newObj, eventType)
+			logNotObservedNamespace(newObj, eventUpdate)
 			return
 		}
-		logEvent(eventType, providerName, informerName, oldObj)
+		logEvent(eventUpdate, providerName, informerName, oldObj)
 		if announce != nil {
-			announce <- Event{
-				Type:  UpdateEvent,
-				Value: newObj,
-			}
+			announce <- newAnnouncement(kind, newObj)
 		}
 	}
 }
 
-func deleteEvent(informerName string, providerName string, announce chan interface{}, shouldObserve observeFilter, eventType string) func(obj interface{}) {
+func deleteEvent(informerName string, providerName string, announce chan announcements.Announcement, shouldObserve observeFilter, kind announcements.Kind) func(obj interface{}) {
 	return func(obj interface{}) {
+		// The informer missed the deletion; the tombstone holds the last known state of the object
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
 		if !shouldObserve(obj) {
-			logNotObservedNamespace(obj, eventType)
+			logNotObservedNamespace(obj, eventDelete)
 			return
 		}
-		logEvent(eventType, providerName, informerName, obj)
+		logEvent(eventDelete, providerName, informerName, obj)
 		if announce != nil {
-			announce <- Event{
-				Type:  DeleteEvent,
-				Value: obj,
-			}
+			announce <- newAnnouncement(kind, obj)
 		}
 	}
 }
 
+// newAnnouncement creates an announcement of the given kind about the given Kubernetes object.
+func newAnnouncement(kind announcements.Kind, obj interface{}) announcements.Announcement {
+	announcement := announcements.Announcement{
+		Type:   kind,
+		Object: obj,
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		announcement.Namespace = accessor.GetNamespace()
+		announcement.Name = accessor.GetName()
+	}
+	return announcement
+}
+
 func getNamespace(obj interface{}) string {
-	return reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetNamespace()
 }
 
 func logNotObservedNamespace(obj interface{}, eventType string) {
@@ -5,10 +5,12 @@ import (
 
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -16,6 +18,9 @@ const (
 	testProvider  = "test-provider"
 	testInformer  = "test-informer"
 	testNamespace = "test-namespace"
+
+	testAddKind    announcements.Kind = "test-added"
+	testDeleteKind announcements.Kind = "test-deleted"
 )
 
 var _ = Describe("Testing event handlers", func() {
@@ -26,19 +31,37 @@ var _ = Describe("Testing event handlers", func() {
 		}
 
 		It("Should add the event to the announcement channel", func() {
-			announcements := make(chan interface{}, 1)
+			announce := make(chan announcements.Announcement, 1)
 			pod := tests.NewPodTestFixture(testNamespace, "pod-name")
-			addEvent(testInformer, testProvider, announcements, shouldObserve, "ADD")(&pod)
-			Expect(len(announcements)).To(Equal(1))
-			<-announcements
+			addEvent(testInformer, testProvider, announce, shouldObserve, testAddKind)(&pod)
+			Expect(len(announce)).To(Equal(1))
+			announcement := <-announce
+			Expect(announcement.Type).To(Equal(testAddKind))
+			Expect(announcement.Namespace).To(Equal(testNamespace))
+			Expect(announcement.Name).To(Equal("pod-name"))
+			Expect(announcement.Object).To(Equal(&pod))
 		})
 
 		It("Should not add the event to the announcement channel", func() {
-			announcements := make(chan interface{}, 1)
+			announce := make(chan announcements.Announcement, 1)
 			var pod corev1.Pod
 			pod.Namespace = "not-a-monitored-namespace"
-			addEvent(testInformer, testProvider, announcements, shouldObserve, "ADD")(&pod)
-			Expect(len(announcements)).To(Equal(0))
+			addEvent(testInformer, testProvider, announce, shouldObserve, testAddKind)(&pod)
+			Expect(len(announce)).To(Equal(0))
+		})
+	})
+
+	Context("Test delete of an object the informer missed the deletion of", func() {
+		It("Should announce the last known state of the object", func() {
+			announce := make(chan announcements.Announcement, 1)
+			pod := tests.NewPodTestFixture(testNamespace, "pod-name")
+			tombstone := cache.DeletedFinalStateUnknown{Key: testNamespace + "/pod-name", Obj: &pod}
+			deleteEvent(testInformer, testProvider, announce, func(interface{}) bool { return true }, testDeleteKind)(tombstone)
+			Expect(len(announce)).To(Equal(1))
+			announcement := <-announce
+			Expect(announcement.Type).To(Equal(testDeleteKind))
+			Expect(announcement.Name).To(Equal("pod-name"))
+			Expect(announcement.Object).To(Equal(&pod))
 		})
 	})
 
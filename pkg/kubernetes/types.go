@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -10,27 +11,14 @@ var (
 	log = logger.New("kube-events")
 )
 
-// EventType is the type of event we have received from Kubernetes
-type EventType int
-
-const (
-	// CreateEvent is a type of a Kubernetes API event.
-	CreateEvent EventType = iota + 1
-
-	// UpdateEvent is a type of a Kubernetes API event.
-	UpdateEvent
-
-	// DeleteEvent is a type of a Kubernetes API event.
-	DeleteEvent
-)
-
 const (
 	// DefaultKubeEventResyncInterval is the default resync interval for k8s events
 	DefaultKubeEventResyncInterval = 30 * time.Second
 )
 
-// Event is the combined type and actual object we received from Kubernetes
-type Event struct {
-	Type  EventType
-	Value interface{}
+// EventTypes are the kinds of announcements made for the Kubernetes events observed by an informer
+type EventTypes struct {
+	Add    announcements.Kind
+	Update announcements.Kind
+	Delete announcements.Kind
 }
@@ -2,9 +2,15 @@ package kubernetes
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
@@ -44,3 +50,121 @@ func GetServiceFromHostname(host string) string {
 	// Ex. service.namespace, service.namespace.cluster.local
 	return strings.Split(host, ".")[0]
 }
+
+// GetServicePorts returns the TCP ports of the given service, along with the application protocol each port carries.
+func GetServicePorts(svc *corev1.Service) []service.ServicePort {
+	var ports []service.ServicePort
+	if svc == nil {
+		return ports
+	}
+
+	for _, portSpec := range svc.Spec.Ports {
+		if portSpec.Protocol != "" && portSpec.Protocol != corev1.ProtocolTCP {
+			// Only TCP based traffic is proxied
+			continue
+		}
+		ports = append(ports, service.ServicePort{
+			Name:       portSpec.Name,
+			Port:       uint32(portSpec.Port),
+			TargetPort: GetTargetPort(portSpec),
+			Protocol:   GetAppProtocol(portSpec),
+		})
+	}
+	return ports
+}
+
+// GetTargetPort returns the port the pods backing a service listen on for the given service port.
+// Named target ports are not resolved, and default to the service port.
+func GetTargetPort(portSpec corev1.ServicePort) uint32 {
+	if targetPort := portSpec.TargetPort.IntValue(); targetPort != 0 {
+		return uint32(targetPort)
+	}
+	return uint32(portSpec.Port)
+}
+
+// GetAppProtocol returns the application protocol carried by the given service port.
+// The protocol is given by the appProtocol field of the port, or else by the prefix of its name, as in `grpc-api` or `tcp-db`.
+// Ports without any hint carry HTTP.
+func GetAppProtocol(portSpec corev1.ServicePort) service.AppProtocol {
+	if portSpec.AppProtocol != nil {
+		if appProtocol, ok := getAppProtocolFromName(*portSpec.AppProtocol); ok {
+			return appProtocol
+		}
+	}
+
+	name := portSpec.Name
+	if idx := strings.Index(name, "-"); idx != -1 {
+		name = name[:idx]
+	}
+	if appProtocol, ok := getAppProtocolFromName(name); ok {
+		return appProtocol
+	}
+
+	return service.AppProtocolHTTP
+}
+
+func getAppProtocolFromName(name string) (service.AppProtocol, bool) {
+	switch strings.ToLower(name) {
+	case "http", "http2", "h2c":
+		return service.AppProtocolHTTP, true
+	case "grpc":
+		return service.AppProtocolGRPC, true
+	case "grpc-web":
+		return service.AppProtocolGRPCWeb, true
+	case "tcp", "tls", "https":
+		return service.AppProtocolTCP, true
+	}
+	return "", false
+}
+
+// GetServiceClusterIPs returns the cluster IPs of the given service, none when the service is headless.
+// The Kubernetes API types used by OSM predate dual-stack services and their spec.clusterIPs,
+// so only the primary cluster IP of a dual-stack service is returned.
+func GetServiceClusterIPs(svc *corev1.Service) []net.IP {
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return nil
+	}
+	ip := net.ParseIP(svc.Spec.ClusterIP)
+	if ip == nil {
+		return nil
+	}
+	return []net.IP{ip}
+}
+
+// GetServedResource returns the first of the given resources served by the cluster
+func GetServedResource(discoveryClient discovery.DiscoveryInterface, resources []schema.GroupVersionResource) (schema.GroupVersionResource, bool) {
+	for _, resource := range resources {
+		resourceList, err := discoveryClient.ServerResourcesForGroupVersion(resource.GroupVersion().String())
+		if err != nil {
+			log.Debug().Msgf("API %s is not served by the cluster: %s", resource.GroupVersion(), err)
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if apiResource.Name == resource.Resource {
+				return resource, true
+			}
+		}
+	}
+	return schema.GroupVersionResource{}, false
+}
+
+// GetNodeLocality returns the locality of the endpoints running on the given node, from its well-known topology labels.
+func GetNodeLocality(node *corev1.Node) endpoint.Locality {
+	return GetTopologyLocality(node.Labels)
+}
+
+// GetTopologyLocality returns the locality given by the well-known topology labels of a node or of an endpoint slice endpoint.
+// The deprecated failure-domain.beta.kubernetes.io labels are used by the clusters without the topology.kubernetes.io labels.
+func GetTopologyLocality(topology map[string]string) endpoint.Locality {
+	locality := endpoint.Locality{
+		Region: topology[corev1.LabelZoneRegionStable],
+		Zone:   topology[corev1.LabelZoneFailureDomainStable],
+	}
+	if locality.Region == "" {
+		locality.Region = topology[corev1.LabelZoneRegion]
+	}
+	if locality.Zone == "" {
+		locality.Zone = topology[corev1.LabelZoneFailureDomain]
+	}
+	return locality
+}
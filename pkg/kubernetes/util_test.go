@@ -2,10 +2,15 @@ package kubernetes
 
 import (
 	"fmt"
+	"net"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -63,3 +68,115 @@ var _ = Describe("Hostnames for a kubernetes service", func() {
 		})
 	})
 })
+
+var _ = Describe("Ports of a kubernetes service", func() {
+	Context("Testing GetServicePorts", func() {
+		It("Returns the TCP ports of the service", func() {
+			svc := tests.NewServiceFixture(tests.BookbuyerServiceName, tests.Namespace, nil)
+			svc.Spec.Ports = append(svc.Spec.Ports,
+				corev1.ServicePort{Name: "grpc-api", Port: 9090, TargetPort: intstr.FromInt(9091), Protocol: corev1.ProtocolTCP},
+				corev1.ServicePort{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			)
+
+			Expect(GetServicePorts(svc)).To(Equal([]service.ServicePort{
+				{Name: "servicePort", Port: tests.ServicePort, TargetPort: tests.ServicePort, Protocol: service.AppProtocolHTTP},
+				{Name: "grpc-api", Port: 9090, TargetPort: 9091, Protocol: service.AppProtocolGRPC},
+			}))
+		})
+
+		It("Returns no ports without a service", func() {
+			Expect(GetServicePorts(nil)).To(BeEmpty())
+		})
+	})
+
+	Context("Testing GetTargetPort", func() {
+		It("Returns the numeric target port", func() {
+			Expect(GetTargetPort(corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)})).To(Equal(uint32(8080)))
+		})
+
+		It("Defaults to the service port when the target port is named or unset", func() {
+			Expect(GetTargetPort(corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")})).To(Equal(uint32(80)))
+			Expect(GetTargetPort(corev1.ServicePort{Port: 80})).To(Equal(uint32(80)))
+		})
+	})
+
+	Context("Testing GetAppProtocol", func() {
+		appProtocol := func(name string) *string {
+			return &name
+		}
+
+		It("Returns the protocol given by the appProtocol field", func() {
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "http-api", AppProtocol: appProtocol("grpc")})).To(Equal(service.AppProtocolGRPC))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "http-api", AppProtocol: appProtocol("grpc-web")})).To(Equal(service.AppProtocolGRPCWeb))
+			Expect(GetAppProtocol(corev1.ServicePort{AppProtocol: appProtocol("TCP")})).To(Equal(service.AppProtocolTCP))
+		})
+
+		It("Returns the protocol given by the prefix of the port name", func() {
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "http"})).To(Equal(service.AppProtocolHTTP))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "http2-api"})).To(Equal(service.AppProtocolHTTP))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "grpc-metrics"})).To(Equal(service.AppProtocolGRPC))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "tcp-db"})).To(Equal(service.AppProtocolTCP))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "https"})).To(Equal(service.AppProtocolTCP))
+		})
+
+		It("Falls back to the port name when the appProtocol is unknown", func() {
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "tcp-db", AppProtocol: appProtocol("example.com/custom")})).To(Equal(service.AppProtocolTCP))
+		})
+
+		It("Defaults to HTTP", func() {
+			Expect(GetAppProtocol(corev1.ServicePort{})).To(Equal(service.AppProtocolHTTP))
+			Expect(GetAppProtocol(corev1.ServicePort{Name: "web"})).To(Equal(service.AppProtocolHTTP))
+		})
+	})
+})
+
+var _ = Describe("Cluster IPs of a kubernetes service", func() {
+	Context("Testing GetServiceClusterIPs", func() {
+		newService := func(clusterIP string) *corev1.Service {
+			svc := &corev1.Service{}
+			svc.Spec.ClusterIP = clusterIP
+			return svc
+		}
+
+		It("returns the cluster IP of the service", func() {
+			Expect(GetServiceClusterIPs(newService("fd00::1"))).To(Equal([]net.IP{net.ParseIP("fd00::1")}))
+		})
+
+		It("returns no cluster IP for a headless service", func() {
+			Expect(GetServiceClusterIPs(newService(corev1.ClusterIPNone))).To(BeEmpty())
+			Expect(GetServiceClusterIPs(newService(""))).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Locality of a kubernetes node", func() {
+	Context("Testing GetNodeLocality", func() {
+		newNode := func(labels map[string]string) *corev1.Node {
+			node := &corev1.Node{}
+			node.Labels = labels
+			return node
+		}
+
+		It("returns the region and zone of the topology labels", func() {
+			node := newNode(map[string]string{
+				corev1.LabelZoneRegionStable:        "us-east-1",
+				corev1.LabelZoneFailureDomainStable: "us-east-1a",
+				corev1.LabelZoneRegion:              "us-west-1",
+				corev1.LabelZoneFailureDomain:       "us-west-1a",
+			})
+			Expect(GetNodeLocality(node)).To(Equal(endpoint.Locality{Region: "us-east-1", Zone: "us-east-1a"}))
+		})
+
+		It("falls back on the deprecated failure domain labels", func() {
+			node := newNode(map[string]string{
+				corev1.LabelZoneRegion:        "us-west-1",
+				corev1.LabelZoneFailureDomain: "us-west-1a",
+			})
+			Expect(GetNodeLocality(node)).To(Equal(endpoint.Locality{Region: "us-west-1", Zone: "us-west-1a"}))
+		})
+
+		It("returns an empty locality for a node without topology labels", func() {
+			Expect(GetNodeLocality(newNode(nil)).IsEmpty()).To(BeTrue())
+		})
+	})
+})
@@ -21,6 +21,7 @@ type MetricStore interface {
 	Handler() http.Handler
 	SetUpdateLatencySec(time.Duration)
 	IncK8sAPIEventCounter()
+	IncXDSNACKCounter(typeURI string)
 }
 
 // OSMMetricsStore is store
@@ -28,6 +29,7 @@ type OSMMetricsStore struct {
 	constLabels        prometheus.Labels
 	updateLatency      prometheus.Gauge
 	k8sAPIEventCounter prometheus.Counter
+	xdsNACKCounter     *prometheus.CounterVec
 
 	registry *prometheus.Registry
 }
@@ -53,6 +55,12 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 			Name:        "k8s_api_event_counter",
 			Help:        "This counter represents the number of events received from Kubernetes API Server",
 		}),
+		xdsNACKCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   PrometheusNamespace,
+			ConstLabels: constLabels,
+			Name:        "xds_nack_counter",
+			Help:        "This counter represents the number of xDS responses rejected (NACKed) by Envoy proxies",
+		}, []string{"type"}),
 		registry: prometheus.NewRegistry(),
 	}
 }
@@ -61,12 +69,14 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 func (ms *OSMMetricsStore) Start() {
 	ms.registry.MustRegister(ms.updateLatency)
 	ms.registry.MustRegister(ms.k8sAPIEventCounter)
+	ms.registry.MustRegister(ms.xdsNACKCounter)
 }
 
 // Stop store
 func (ms *OSMMetricsStore) Stop() {
 	ms.registry.Unregister(ms.updateLatency)
 	ms.registry.Unregister(ms.k8sAPIEventCounter)
+	ms.registry.Unregister(ms.xdsNACKCounter)
 }
 
 // SetUpdateLatencySec updates latency
@@ -79,6 +89,11 @@ func (ms *OSMMetricsStore) IncK8sAPIEventCounter() {
 	ms.k8sAPIEventCounter.Inc()
 }
 
+// IncXDSNACKCounter increases the counter of xDS responses of the given type rejected by Envoy proxies
+func (ms *OSMMetricsStore) IncXDSNACKCounter(typeURI string) {
+	ms.xdsNACKCounter.WithLabelValues(typeURI).Inc()
+}
+
 // Handler return the registry
 func (ms *OSMMetricsStore) Handler() http.Handler {
 	return promhttp.InstrumentMetricHandler(
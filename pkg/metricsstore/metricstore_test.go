@@ -46,5 +46,25 @@ promhttp_metric_handler_requests_total{code="503"} 0
 
 			metricsStore.Stop()
 		})
+
+		It("counts the xDS responses rejected by Envoy proxies", func() {
+			metricsStore := NewMetricStore("a", "b")
+			metricsStore.Start()
+			metricsStore.IncXDSNACKCounter("type.googleapis.com/envoy.config.cluster.v3.Cluster")
+			metricsStore.IncXDSNACKCounter("type.googleapis.com/envoy.config.cluster.v3.Cluster")
+
+			handler := metricsStore.Handler()
+
+			req, err := http.NewRequest("GET", "/metrics", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`osm_xds_nack_counter{osm_namespace="a",osm_pod="b",osm_version="//",type="type.googleapis.com/envoy.config.cluster.v3.Cluster"} 2`))
+
+			metricsStore.Stop()
+		})
 	})
 })
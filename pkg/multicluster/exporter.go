@@ -0,0 +1,274 @@
+package multicluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewExporter creates the facility publishing the services of the local cluster exported to the remote clusters
+// in the export ConfigMap of the given namespace, which the controllers of the remote clusters read.
+func NewExporter(kubeClient kubernetes.Interface, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator, osmNamespace string) *Exporter {
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
+	osmNamespaceInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(osmNamespace))
+
+	e := &Exporter{
+		kubeClient:        kubeClient,
+		meshCatalog:       meshCatalog,
+		cfg:               cfg,
+		osmNamespace:      osmNamespace,
+		podInformer:       informerFactory.Core().V1().Pods().Informer(),
+		serviceInformer:   informerFactory.Core().V1().Services().Informer(),
+		configMapInformer: osmNamespaceInformerFactory.Core().V1().ConfigMaps().Informer(),
+		changes:           make(chan interface{}, 1),
+	}
+
+	// Pods are updated whenever their status changes, while only their labels select the services they back
+	e.podInformer.AddEventHandler(e.getEventHandlers(func(oldObj, newObj interface{}) bool {
+		return !reflect.DeepEqual(oldObj.(*corev1.Pod).Labels, newObj.(*corev1.Pod).Labels)
+	}))
+	e.serviceInformer.AddEventHandler(e.getEventHandlers(func(oldObj, newObj interface{}) bool {
+		return oldObj.(*corev1.Service).ResourceVersion != newObj.(*corev1.Service).ResourceVersion
+	}))
+	// The OSM ConfigMap holds the cluster ID and the multicluster gateway, while the export ConfigMap is written by the exporter itself
+	e.configMapInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			configMap, ok := obj.(*corev1.ConfigMap)
+			return ok && configMap.Name != constants.MulticlusterExportConfigMapName
+		},
+		Handler: e.getEventHandlers(func(oldObj, newObj interface{}) bool {
+			return !reflect.DeepEqual(oldObj.(*corev1.ConfigMap).Data, newObj.(*corev1.ConfigMap).Data)
+		}),
+	})
+
+	return e
+}
+
+// Start starts publishing the exported services, which are exported again whenever the resources they are derived from change,
+// until the stop channel is closed.
+func (e *Exporter) Start(stop <-chan struct{}) {
+	go func() {
+		if err := e.runInformers(stop); err != nil {
+			log.Error().Err(err).Msg("Error starting the multicluster exporter")
+			return
+		}
+		for {
+			if err := e.export(); err != nil {
+				log.Error().Err(err).Msgf("Error exporting services to remote clusters in ConfigMap %s/%s", e.osmNamespace, constants.MulticlusterExportConfigMapName)
+			}
+			select {
+			case <-stop:
+				return
+			case <-e.changes:
+			}
+		}
+	}()
+}
+
+// runInformers runs the informers of the resources the export is derived from, until their caches synced
+func (e *Exporter) runInformers(stop <-chan struct{}) error {
+	go e.podInformer.Run(stop)
+	go e.serviceInformer.Run(stop)
+	go e.configMapInformer.Run(stop)
+	log.Info().Msg("[Multicluster] Waiting for exporter informers' cache to sync")
+	if !cache.WaitForCacheSync(stop, e.podInformer.HasSynced, e.serviceInformer.HasSynced, e.configMapInformer.HasSynced) {
+		return fmt.Errorf("failed initial cache sync for exporter informers")
+	}
+	return nil
+}
+
+// getEventHandlers announces the changes of the resources the export is derived from.
+// The announcements are coalesced: a pending announcement already triggers an export including the following changes.
+func (e *Exporter) getEventHandlers(hasChanged func(oldObj, newObj interface{}) bool) cache.ResourceEventHandlerFuncs {
+	announce := func() {
+		select {
+		case e.changes <- nil:
+		default:
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { announce() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if hasChanged(oldObj, newObj) {
+				announce()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { announce() },
+	}
+}
+
+// export creates or updates the export ConfigMap when the exported services changed
+func (e *Exporter) export() error {
+	clusterID := e.cfg.GetClusterID()
+	if clusterID == "" {
+		log.Error().Msg("No cluster ID is configured in the OSM ConfigMap; services are not exported to remote clusters")
+		return nil
+	}
+
+	export := e.getExport(clusterID)
+	marshalledExport, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{ExportConfigMapKey: string(marshalledExport)}
+
+	configMaps := e.kubeClient.CoreV1().ConfigMaps(e.osmNamespace)
+	existingConfigMap, err := configMaps.Get(context.TODO(), constants.MulticlusterExportConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.MulticlusterExportConfigMapName,
+				Namespace: e.osmNamespace,
+			},
+			Data: data,
+		}
+		if _, err := configMaps.Create(context.TODO(), configMap, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		log.Info().Msgf("Exported %d services of cluster %s to remote clusters", len(export.Services), clusterID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if existingConfigMap.Data[ExportConfigMapKey] == data[ExportConfigMapKey] {
+		return nil
+	}
+	existingConfigMap.Data = data
+	if _, err := configMaps.Update(context.TODO(), existingConfigMap, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	log.Info().Msgf("Exported %d services of cluster %s to remote clusters", len(export.Services), clusterID)
+	return nil
+}
+
+// getExport returns the services exported by the local cluster, along with the addresses of its multicluster gateway
+func (e *Exporter) getExport(clusterID string) Export {
+	export := Export{
+		ClusterID: clusterID,
+		Gateway:   e.getGatewayAddresses(),
+		Services:  []ExportedService{},
+	}
+
+	for _, meshService := range e.meshCatalog.ListExportedServices() {
+		ports, err := e.meshCatalog.ListServicePorts(meshService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of exported service %s", meshService)
+			continue
+		}
+
+		exportedService := ExportedService{
+			Namespace:       meshService.Namespace,
+			Name:            meshService.Name,
+			Ports:           []ExportedPort{},
+			ServiceAccounts: e.listServiceAccounts(meshService),
+		}
+		for _, port := range ports {
+			exportedService.Ports = append(exportedService.Ports, ExportedPort{
+				Name:       port.Name,
+				Port:       port.Port,
+				TargetPort: port.TargetPort,
+				Protocol:   port.Protocol,
+			})
+		}
+		export.Services = append(export.Services, exportedService)
+	}
+
+	return export
+}
+
+// listServiceAccounts returns the service accounts of the pods backing the given service
+func (e *Exporter) listServiceAccounts(meshService service.MeshService) []string {
+	svc := e.meshCatalog.GetSMISpec().GetService(meshService)
+	if svc == nil || len(svc.Spec.Selector) == 0 {
+		return []string{}
+	}
+
+	pods, err := e.podInformer.GetIndexer().ByIndex(cache.NamespaceIndex, meshService.Namespace)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing pods of exported service %s", meshService)
+		return []string{}
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	serviceAccounts := make(map[string]interface{})
+	for _, obj := range pods {
+		pod := obj.(*corev1.Pod)
+		if selector.Matches(labels.Set(pod.Labels)) {
+			serviceAccounts[pod.Spec.ServiceAccountName] = nil
+		}
+	}
+	serviceAccountNames := []string{}
+	for serviceAccount := range serviceAccounts {
+		serviceAccountNames = append(serviceAccountNames, serviceAccount)
+	}
+	sort.Strings(serviceAccountNames)
+	return serviceAccountNames
+}
+
+// getGatewayAddresses returns the addresses the multicluster gateway is reached on from the remote clusters:
+// the load balancer ingress and external IP addresses of its service, on the port targeting the gateway port.
+func (e *Exporter) getGatewayAddresses() []GatewayAddress {
+	gatewayAddresses := []GatewayAddress{}
+	gatewayService, err := service.UnmarshalMeshService(e.cfg.GetMulticlusterGateway())
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid multicluster gateway %q in the OSM ConfigMap", e.cfg.GetMulticlusterGateway())
+		return gatewayAddresses
+	}
+
+	obj, exists, err := e.serviceInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", gatewayService.Namespace, gatewayService.Name))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the service of the multicluster gateway %s", gatewayService)
+		return gatewayAddresses
+	}
+	if !exists {
+		log.Error().Msgf("The service of the multicluster gateway %s does not exist", gatewayService)
+		return gatewayAddresses
+	}
+	svc := obj.(*corev1.Service)
+
+	port := uint32(constants.MulticlusterGatewayPort)
+	for _, servicePort := range svc.Spec.Ports {
+		if k8s.GetTargetPort(servicePort) == constants.MulticlusterGatewayPort {
+			port = uint32(servicePort.Port)
+			break
+		}
+	}
+
+	var ips []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		ips = append(ips, ingress.IP)
+	}
+	ips = append(ips, svc.Spec.ExternalIPs...)
+	for _, ip := range ips {
+		if net.ParseIP(ip) != nil {
+			gatewayAddresses = append(gatewayAddresses, GatewayAddress{IP: ip, Port: port})
+		}
+	}
+
+	if len(gatewayAddresses) == 0 {
+		log.Error().Msgf("The service of the multicluster gateway %s has no load balancer or external IP address", gatewayService)
+	}
+	return gatewayAddresses
+}
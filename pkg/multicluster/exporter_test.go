@@ -0,0 +1,162 @@
+package multicluster
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// fakeCatalog exports the bookstore service, backed by the pods selected by app: bookstore
+type fakeCatalog struct {
+	catalog.MeshCataloger
+}
+
+func (fakeCatalog) ListExportedServices() []service.MeshService {
+	return []service.MeshService{tests.BookstoreService}
+}
+
+func (fakeCatalog) ListServicePorts(svc service.MeshService) ([]service.ServicePort, error) {
+	return []service.ServicePort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}}, nil
+}
+
+func (fakeCatalog) GetSMISpec() smi.MeshSpec {
+	return fakeMeshSpec{}
+}
+
+type fakeMeshSpec struct {
+	smi.MeshSpec
+}
+
+func (fakeMeshSpec) GetService(svc service.MeshService) *corev1.Service {
+	return tests.NewServiceFixture(svc.Name, svc.Namespace, map[string]string{"app": "bookstore"})
+}
+
+var _ = Describe("Test exporting services to remote clusters", func() {
+	const osmNamespace = "osm-system"
+
+	newPod := func(name, serviceAccount string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: tests.BookstoreService.Namespace,
+				Labels:    map[string]string{"app": "bookstore"},
+			},
+			Spec: corev1.PodSpec{ServiceAccountName: serviceAccount},
+		}
+	}
+
+	gatewayService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-multicluster-gateway",
+			Namespace: osmNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{
+				Port:       443,
+				TargetPort: intstr.FromInt(constants.MulticlusterGatewayPort),
+			}},
+			ExternalIPs: []string{"5.6.7.8"},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+		},
+	}
+
+	getExport := func(kubeClient kubernetes.Interface) Export {
+		configMap, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), constants.MulticlusterExportConfigMapName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		export := Export{}
+		Expect(json.Unmarshal([]byte(configMap.Data[ExportConfigMapKey]), &export)).To(Succeed())
+		return export
+	}
+
+	Context("when a cluster ID is configured", func() {
+		var (
+			kubeClient kubernetes.Interface
+			exporter   *Exporter
+			stop       chan struct{}
+		)
+
+		BeforeEach(func() {
+			kubeClient = fake.NewSimpleClientset(
+				gatewayService,
+				newPod("bookstore-1", "bookstore"),
+				newPod("bookstore-2", "bookstore"),
+				newPod("bookstore-3", "bookstore-v2"),
+			)
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				ClusterID:           "cluster-1",
+				MulticlusterGateway: osmNamespace + "/osm-multicluster-gateway",
+			})
+			exporter = NewExporter(kubeClient, fakeCatalog{}, cfg, osmNamespace)
+			stop = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			close(stop)
+		})
+
+		It("exports the services with their ports, service accounts and the addresses of the gateway", func() {
+			Expect(exporter.runInformers(stop)).To(Succeed())
+			Expect(exporter.export()).To(Succeed())
+			Expect(getExport(kubeClient)).To(Equal(Export{
+				ClusterID: "cluster-1",
+				Gateway: []GatewayAddress{
+					{IP: "1.2.3.4", Port: 443},
+					{IP: "5.6.7.8", Port: 443},
+				},
+				Services: []ExportedService{
+					{
+						Namespace:       tests.BookstoreService.Namespace,
+						Name:            tests.BookstoreService.Name,
+						Ports:           []ExportedPort{{Name: "http", Port: 80, TargetPort: 8080, Protocol: "http"}},
+						ServiceAccounts: []string{"bookstore", "bookstore-v2"},
+					},
+				},
+			}))
+		})
+
+		It("updates the export when the exported services change", func() {
+			exporter.Start(stop)
+			Eventually(func() []ExportedService {
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), constants.MulticlusterExportConfigMapName, metav1.GetOptions{})
+				if err != nil {
+					return nil
+				}
+				return getExport(kubeClient).Services
+			}).Should(HaveLen(1))
+
+			_, err := kubeClient.CoreV1().Pods(tests.BookstoreService.Namespace).Create(context.TODO(), newPod("bookstore-4", "bookstore-v3"), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() []string {
+				return getExport(kubeClient).Services[0].ServiceAccounts
+			}).Should(Equal([]string{"bookstore", "bookstore-v2", "bookstore-v3"}))
+		})
+	})
+
+	Context("when no cluster ID is configured", func() {
+		It("does not export the services", func() {
+			kubeClient := fake.NewSimpleClientset()
+			exporter := NewExporter(kubeClient, fakeCatalog{}, configurator.NewFakeConfigurator(), osmNamespace)
+			Expect(exporter.export()).To(Succeed())
+
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), constants.MulticlusterExportConfigMapName, metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+// Package multicluster implements the export of the services of the local cluster to the remote clusters of a multicluster mesh.
+package multicluster
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var (
+	log = logger.New("multicluster")
+)
+
+const (
+	// ExportConfigMapKey is the key of the export ConfigMap holding the JSON encoded Export of the cluster
+	ExportConfigMapKey = "export.json"
+)
+
+// Export is the set of services a cluster exports to the remote clusters of the multicluster mesh,
+// which reach these services through the multicluster gateway of the cluster.
+type Export struct {
+	// ClusterID is the ID of the exporting cluster
+	ClusterID string `json:"clusterID"`
+
+	// Gateway are the addresses the multicluster gateway of the exporting cluster is reached on
+	Gateway []GatewayAddress `json:"gateway"`
+
+	// Services are the exported services
+	Services []ExportedService `json:"services"`
+}
+
+// GatewayAddress is an address the multicluster gateway of the exporting cluster is reached on
+type GatewayAddress struct {
+	// IP is the IP address of the gateway
+	IP string `json:"ip"`
+
+	// Port is the port of the gateway
+	Port uint32 `json:"port"`
+}
+
+// ExportedService is a service exported to the remote clusters of the multicluster mesh
+type ExportedService struct {
+	// Namespace is the namespace of the service
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service
+	Name string `json:"name"`
+
+	// Ports are the ports of the service
+	Ports []ExportedPort `json:"ports"`
+
+	// ServiceAccounts are the service accounts, in the namespace of the service, of the pods backing the service
+	ServiceAccounts []string `json:"serviceAccounts"`
+}
+
+// ExportedPort is a port of an exported service
+type ExportedPort struct {
+	// Name is the name of the port
+	Name string `json:"name,omitempty"`
+
+	// Port is the port the service is reached on
+	Port uint32 `json:"port"`
+
+	// TargetPort is the port the pods backing the service listen on
+	TargetPort uint32 `json:"targetPort"`
+
+	// Protocol is the application protocol carried by the port
+	Protocol service.AppProtocol `json:"protocol"`
+}
+
+// Exporter publishes the services of the local cluster exported to the remote clusters in the export ConfigMap
+type Exporter struct {
+	kubeClient   kubernetes.Interface
+	meshCatalog  catalog.MeshCataloger
+	cfg          configurator.Configurator
+	osmNamespace string
+
+	podInformer       cache.SharedIndexInformer
+	serviceInformer   cache.SharedIndexInformer
+	configMapInformer cache.SharedIndexInformer
+
+	// changes holds a pending announcement that the resources the export is derived from changed
+	changes chan interface{}
+}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
@@ -19,7 +20,7 @@ var (
 )
 
 // GetAnnouncementsChannel returns the announcement channel for the SMI client.
-func (c Client) GetAnnouncementsChannel() <-chan interface{} {
+func (c Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return c.announcements
 }
 
@@ -38,14 +39,18 @@ func NewNamespaceController(kubeClient kubernetes.Interface, meshName string, st
 		informer:      informer,
 		cache:         informer.GetStore(),
 		cacheSynced:   make(chan interface{}),
-		announcements: make(chan interface{}),
+		announcements: make(chan announcements.Announcement),
 	}
 
 	if err := client.run(stop); err != nil {
 		log.Fatal().Err(err).Msg("Could not start Kubernetes Namespaces client")
 	}
 
-	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("Namespace", "NamespaceClient", client.announcements, nil))
+	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("Namespace", "NamespaceClient", client.announcements, nil, k8s.EventTypes{
+		Add:    announcements.NamespaceAdded,
+		Update: announcements.NamespaceUpdated,
+		Delete: announcements.NamespaceDeleted,
+	}))
 
 	log.Info().Msgf("Monitoring namespaces with the label: %s=%s", constants.OSMKubeResourceMonitorAnnotation, meshName)
 	return client
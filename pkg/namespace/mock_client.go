@@ -8,6 +8,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+
+	announcements "github.com/openservicemesh/osm/pkg/announcements"
 )
 
 // MockController is a mock of Controller interface
@@ -34,10 +36,10 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 }
 
 // GetAnnouncementsChannel mocks base method
-func (m *MockController) GetAnnouncementsChannel() <-chan interface{} {
+func (m *MockController) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAnnouncementsChannel")
-	ret0, _ := ret[0].(<-chan interface{})
+	ret0, _ := ret[0].(<-chan announcements.Announcement)
 	return ret0
 }
 
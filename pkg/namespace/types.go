@@ -3,6 +3,7 @@ package namespace
 import (
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -15,7 +16,7 @@ type Client struct {
 	informer      cache.SharedIndexInformer
 	cache         cache.Store
 	cacheSynced   chan interface{}
-	announcements chan interface{}
+	announcements chan announcements.Announcement
 }
 
 // Controller is the controller interface for K8s namespaces
@@ -28,5 +29,5 @@ type Controller interface {
 	ListMonitoredNamespaces() ([]string, error)
 
 	// GetAnnouncementsChannel returns the channel on which namespace makes announcements
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
 }
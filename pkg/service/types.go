@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -11,6 +12,9 @@ const (
 	// namespaceNameSeparator used upon marshalling/unmarshalling MeshService to a string
 	// or viceversa
 	namespaceNameSeparator = "/"
+
+	// clusterSeparator separates the name of a MeshService from the ID of the remote cluster exporting it
+	clusterSeparator = "@"
 )
 
 // MeshService is the struct defining a service (Kubernetes or otherwise) within a service mesh.
@@ -20,12 +24,38 @@ type MeshService struct {
 
 	// The name of the service
 	Name string
+
+	// Cluster is the ID of the remote cluster exporting the service, empty for the services of the local cluster
+	Cluster string `json:",omitempty"`
+}
+
+// NewMeshService returns the MeshService referenced by the given name in the given namespace.
+// Services exported by a remote cluster are referenced by their name followed by @<cluster ID>.
+func NewMeshService(namespace, name string) MeshService {
+	slices := strings.SplitN(name, clusterSeparator, 2)
+	if len(slices) == 2 {
+		return MeshService{Namespace: namespace, Name: slices[0], Cluster: slices[1]}
+	}
+	return MeshService{Namespace: namespace, Name: name}
 }
 
 func (ms MeshService) String() string {
+	if ms.IsRemote() {
+		return strings.Join([]string{ms.Namespace, namespaceNameSeparator, ms.Name, clusterSeparator, ms.Cluster}, "")
+	}
 	return strings.Join([]string{ms.Namespace, namespaceNameSeparator, ms.Name}, "")
 }
 
+// IsRemote returns true if the service is exported by a remote cluster
+func (ms MeshService) IsRemote() bool {
+	return ms.Cluster != ""
+}
+
+// GetLocal returns the service of the local cluster with the same namespace and name
+func (ms MeshService) GetLocal() MeshService {
+	return MeshService{Namespace: ms.Namespace, Name: ms.Name}
+}
+
 // Equals checks if two namespaced services are equal
 func (ms MeshService) Equals(service MeshService) bool {
 	return reflect.DeepEqual(ms, service)
@@ -45,17 +75,27 @@ func UnmarshalMeshService(str string) (*MeshService, error) {
 		}
 	}
 
-	return &MeshService{
-		Namespace: slices[0],
-		Name:      slices[1],
-	}, nil
+	// The name may be followed by the ID of the remote cluster exporting the service
+	meshService := NewMeshService(slices[0], slices[1])
+	if meshService.Name == "" || strings.HasSuffix(slices[1], clusterSeparator) || strings.Contains(meshService.Cluster, clusterSeparator) {
+		return nil, errInvalidMeshServiceFormat
+	}
+
+	return &meshService, nil
 }
 
 // GetCommonName returns the Subject CN for the MeshService to be used for its certificate.
+// The services exported by remote clusters have the common name they have in their own cluster.
 func (ms MeshService) GetCommonName() certificate.CommonName {
 	return certificate.CommonName(strings.Join([]string{ms.Name, ms.Namespace, "svc", "cluster", "local"}, "."))
 }
 
+// GetServerNameForPort returns the SNI the clients of the given port of the service send to reach it through
+// the multicluster gateway of its cluster, which routes the TLS connections to the port based on their SNI.
+func (ms MeshService) GetServerNameForPort(port uint32) string {
+	return fmt.Sprintf("%d.%s", port, ms.GetCommonName())
+}
+
 // K8sServiceAccount is a type for a namespaced service account
 type K8sServiceAccount struct {
 	Namespace string
@@ -81,3 +121,45 @@ type WeightedCluster struct {
 	ClusterName ClusterName `json:"cluster_name:omitempty"`
 	Weight      int         `json:"weight:omitempty"`
 }
+
+// AppProtocol is the application protocol carried by a port of a service
+type AppProtocol string
+
+const (
+	// AppProtocolHTTP is the application protocol of ports carrying HTTP/1.1 or HTTP/2 traffic
+	AppProtocolHTTP AppProtocol = "http"
+
+	// AppProtocolGRPC is the application protocol of ports carrying gRPC traffic
+	AppProtocolGRPC AppProtocol = "grpc"
+
+	// AppProtocolGRPCWeb is the application protocol of ports carrying gRPC-Web traffic, translated to gRPC by the proxies
+	AppProtocolGRPCWeb AppProtocol = "grpc-web"
+
+	// AppProtocolTCP is the application protocol of ports carrying opaque TCP traffic
+	AppProtocolTCP AppProtocol = "tcp"
+)
+
+// ServicePort is a port of a MeshService
+type ServicePort struct {
+	// Name is the name of the port
+	Name string `json:"name:omitempty"`
+
+	// Port is the port the service is reached on
+	Port uint32 `json:"port:omitempty"`
+
+	// TargetPort is the port the pods backing the service listen on
+	TargetPort uint32 `json:"target_port:omitempty"`
+
+	// Protocol is the application protocol carried by the port
+	Protocol AppProtocol `json:"protocol:omitempty"`
+}
+
+// IsHTTP returns true when the port carries HTTP based traffic, which is routed by the proxies
+func (sp ServicePort) IsHTTP() bool {
+	return sp.Protocol == AppProtocolHTTP || sp.Protocol.IsGRPC()
+}
+
+// IsGRPC returns true when the application protocol is gRPC based
+func (p AppProtocol) IsGRPC() bool {
+	return p == AppProtocolGRPC || p == AppProtocolGRPCWeb
+}
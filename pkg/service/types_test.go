@@ -55,4 +55,40 @@ var _ = Describe("Test types helpers", func() {
 
 	})
 
+	Context("Test services exported by remote clusters", func() {
+		It("references a remote service by its name followed by the cluster ID", func() {
+			svc := NewMeshService("default", "bookstore@cluster-2")
+			Expect(svc).To(Equal(MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-2"}))
+			Expect(svc.IsRemote()).To(BeTrue())
+			Expect(svc.GetLocal()).To(Equal(MeshService{Namespace: "default", Name: "bookstore"}))
+			Expect(svc.String()).To(Equal("default/bookstore@cluster-2"))
+		})
+
+		It("references a local service by its name", func() {
+			svc := NewMeshService("default", "bookstore")
+			Expect(svc).To(Equal(MeshService{Namespace: "default", Name: "bookstore"}))
+			Expect(svc.IsRemote()).To(BeFalse())
+			Expect(svc.String()).To(Equal("default/bookstore"))
+		})
+
+		It("marshals and unmarshals a remote service preserving the exact same data", func() {
+			svc := MeshService{Namespace: "default", Name: "bookstore", Cluster: "cluster-2"}
+			svc2, err := UnmarshalMeshService(svc.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*svc2).To(Equal(svc))
+		})
+
+		It("should fail for incomplete remote names", func() {
+			for _, str := range []string{"default/@cluster-2", "default/bookstore@", "default/bookstore@cluster@2"} {
+				_, err := UnmarshalMeshService(str)
+				Expect(err).To(HaveOccurred(), str)
+			}
+		})
+
+		It("returns the server name of a port of the service", func() {
+			svc := MeshService{Namespace: "default", Name: "bookstore"}
+			Expect(svc.GetServerNameForPort(8080)).To(Equal("8080." + svc.GetCommonName().String()))
+		})
+	})
+
 })
@@ -23,6 +23,7 @@ import (
 	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
 	osmPolicyClient "github.com/openservicemesh/osm/experimental/pkg/client/clientset/versioned"
 	backpressureInformers "github.com/openservicemesh/osm/experimental/pkg/client/informers/externalversions"
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/featureflags"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
@@ -39,7 +40,7 @@ func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interfa
 	smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(smiKubeConfig)
 
 	var backpressureClientSet *osmPolicyClient.Clientset
-	if featureflags.IsBackpressureEnabled() {
+	if featureflags.IsBackpressureEnabled() || featureflags.IsResiliencePolicyEnabled() || featureflags.IsEgressPolicyEnabled() {
 		backpressureClientSet = osmPolicyClient.NewForConfigOrDie(smiKubeConfig)
 	}
 
@@ -70,6 +71,7 @@ func (c *Client) run(stop <-chan struct{}) error {
 		"TrafficSplit":   c.informers.TrafficSplit,
 		"Services":       c.informers.Services,
 		"HTTPRouteGroup": c.informers.HTTPRouteGroup,
+		"TCPRoute":       c.informers.TCPRoute,
 		"TrafficTarget":  c.informers.TrafficTarget,
 	}
 
@@ -77,6 +79,14 @@ func (c *Client) run(stop <-chan struct{}) error {
 		sharedInformers["Backpressure"] = c.informers.Backpressure
 	}
 
+	if featureflags.IsResiliencePolicyEnabled() {
+		sharedInformers["ResiliencePolicy"] = c.informers.ResiliencePolicy
+	}
+
+	if featureflags.IsEgressPolicyEnabled() {
+		sharedInformers["Egress"] = c.informers.Egress
+	}
+
 	var names []string
 	for name, informer := range sharedInformers {
 		// Depending on the use-case, some Informers from the collection may not have been initialized.
@@ -102,7 +112,7 @@ func (c *Client) run(stop <-chan struct{}) error {
 }
 
 // GetAnnouncementsChannel returns the announcement channel for the SMI client.
-func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
+func (c *Client) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return c.announcements
 }
 
@@ -117,6 +127,7 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 		Services:       informerFactory.Core().V1().Services().Informer(),
 		TrafficSplit:   smiTrafficSplitInformerFactory.Split().V1alpha2().TrafficSplits().Informer(),
 		HTTPRouteGroup: smiTrafficSpecInformerFactory.Specs().V1alpha3().HTTPRouteGroups().Informer(),
+		TCPRoute:       smiTrafficSpecInformerFactory.Specs().V1alpha3().TCPRoutes().Informer(),
 		TrafficTarget:  smiTrafficTargetInformerFactory.Access().V1alpha2().TrafficTargets().Informer(),
 	}
 
@@ -124,6 +135,7 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 		Services:       informerCollection.Services.GetStore(),
 		TrafficSplit:   informerCollection.TrafficSplit.GetStore(),
 		HTTPRouteGroup: informerCollection.HTTPRouteGroup.GetStore(),
+		TCPRoute:       informerCollection.TCPRoute.GetStore(),
 		TrafficTarget:  informerCollection.TrafficTarget.GetStore(),
 	}
 
@@ -133,12 +145,24 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 		cacheCollection.Backpressure = informerCollection.Backpressure.GetStore()
 	}
 
+	if featureflags.IsResiliencePolicyEnabled() {
+		resiliencePolicyInformerFactory := backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval)
+		informerCollection.ResiliencePolicy = resiliencePolicyInformerFactory.Policy().V1alpha1().ResiliencePolicies().Informer()
+		cacheCollection.ResiliencePolicy = informerCollection.ResiliencePolicy.GetStore()
+	}
+
+	if featureflags.IsEgressPolicyEnabled() {
+		egressInformerFactory := backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval)
+		informerCollection.Egress = egressInformerFactory.Policy().V1alpha1().Egresses().Informer()
+		cacheCollection.Egress = informerCollection.Egress.GetStore()
+	}
+
 	client := Client{
 		providerIdent:       providerIdent,
 		informers:           &informerCollection,
 		caches:              &cacheCollection,
 		cacheSynced:         make(chan interface{}),
-		announcements:       make(chan interface{}),
+		announcements:       make(chan announcements.Announcement),
 		osmNamespace:        osmNamespace,
 		namespaceController: namespaceController,
 	}
@@ -147,13 +171,54 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		return namespaceController.IsMonitoredNamespace(ns)
 	}
-	informerCollection.Services.AddEventHandler(k8s.GetKubernetesEventHandlers("Services", "SMI", client.announcements, shouldObserve))
-	informerCollection.TrafficSplit.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficSplit", "SMI", client.announcements, shouldObserve))
-	informerCollection.HTTPRouteGroup.AddEventHandler(k8s.GetKubernetesEventHandlers("HTTPRouteGroup", "SMI", client.announcements, shouldObserve))
-	informerCollection.TrafficTarget.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficTarget", "SMI", client.announcements, shouldObserve))
+	informerCollection.Services.AddEventHandler(k8s.GetKubernetesEventHandlers("Services", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.ServiceAdded,
+		Update: announcements.ServiceUpdated,
+		Delete: announcements.ServiceDeleted,
+	}))
+	informerCollection.TrafficSplit.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficSplit", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.TrafficSplitAdded,
+		Update: announcements.TrafficSplitUpdated,
+		Delete: announcements.TrafficSplitDeleted,
+	}))
+	informerCollection.HTTPRouteGroup.AddEventHandler(k8s.GetKubernetesEventHandlers("HTTPRouteGroup", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.RouteGroupAdded,
+		Update: announcements.RouteGroupUpdated,
+		Delete: announcements.RouteGroupDeleted,
+	}))
+	informerCollection.TCPRoute.AddEventHandler(k8s.GetKubernetesEventHandlers("TCPRoute", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.TCPRouteAdded,
+		Update: announcements.TCPRouteUpdated,
+		Delete: announcements.TCPRouteDeleted,
+	}))
+	informerCollection.TrafficTarget.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficTarget", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+		Add:    announcements.TrafficTargetAdded,
+		Update: announcements.TrafficTargetUpdated,
+		Delete: announcements.TrafficTargetDeleted,
+	}))
 
 	if featureflags.IsBackpressureEnabled() {
-		informerCollection.Backpressure.AddEventHandler(k8s.GetKubernetesEventHandlers("Backpressure", "SMI", client.announcements, shouldObserve))
+		informerCollection.Backpressure.AddEventHandler(k8s.GetKubernetesEventHandlers("Backpressure", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+			Add:    announcements.BackpressureAdded,
+			Update: announcements.BackpressureUpdated,
+			Delete: announcements.BackpressureDeleted,
+		}))
+	}
+
+	if featureflags.IsResiliencePolicyEnabled() {
+		informerCollection.ResiliencePolicy.AddEventHandler(k8s.GetKubernetesEventHandlers("ResiliencePolicy", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+			Add:    announcements.ResiliencePolicyAdded,
+			Update: announcements.ResiliencePolicyUpdated,
+			Delete: announcements.ResiliencePolicyDeleted,
+		}))
+	}
+
+	if featureflags.IsEgressPolicyEnabled() {
+		informerCollection.Egress.AddEventHandler(k8s.GetKubernetesEventHandlers("Egress", "SMI", client.announcements, shouldObserve, k8s.EventTypes{
+			Add:    announcements.EgressAdded,
+			Update: announcements.EgressUpdated,
+			Delete: announcements.EgressDeleted,
+		}))
 	}
 
 	err := client.run(stop)
@@ -192,6 +257,20 @@ func (c *Client) ListHTTPTrafficSpecs() []*smiSpecs.HTTPRouteGroup {
 	return httpTrafficSpec
 }
 
+// ListTCPTrafficSpecs lists SMI TCPRoute resources
+func (c *Client) ListTCPTrafficSpecs() []*smiSpecs.TCPRoute {
+	var tcpTrafficSpec []*smiSpecs.TCPRoute
+	for _, specIface := range c.caches.TCPRoute.List() {
+		tcpRoute := specIface.(*smiSpecs.TCPRoute)
+
+		if !c.namespaceController.IsMonitoredNamespace(tcpRoute.Namespace) {
+			continue
+		}
+		tcpTrafficSpec = append(tcpTrafficSpec, tcpRoute)
+	}
+	return tcpTrafficSpec
+}
+
 // ListTrafficTargets implements mesh.Topology by returning the list of traffic targets.
 func (c *Client) ListTrafficTargets() []*smiAccess.TrafficTarget {
 	var trafficTargets []*smiAccess.TrafficTarget
@@ -225,14 +304,85 @@ func (c *Client) GetBackpressurePolicy(svc service.MeshService) *osmPolicy.Backp
 			continue
 		}
 
-		if svc.Namespace == backpressure.Namespace && svc.Name == app {
-			return backpressure
+		if svc.Namespace != backpressure.Namespace || svc.Name != app {
+			continue
+		}
+
+		if err := validateBackpressure(backpressure); err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid Backpressure policy %s/%s", backpressure.Namespace, backpressure.Name)
+			continue
+		}
+
+		return backpressure
+	}
+
+	return nil
+}
+
+// GetResiliencePolicy gets the ResiliencePolicy whose destination is the MeshService
+func (c *Client) GetResiliencePolicy(svc service.MeshService) *osmPolicy.ResiliencePolicy {
+	if !featureflags.IsResiliencePolicyEnabled() {
+		return nil
+	}
+
+	for _, iface := range c.caches.ResiliencePolicy.List() {
+		resiliencePolicy := iface.(*osmPolicy.ResiliencePolicy)
+
+		if !c.namespaceController.IsMonitoredNamespace(resiliencePolicy.Namespace) {
+			continue
+		}
+
+		if svc.Namespace == resiliencePolicy.Namespace && svc.Name == resiliencePolicy.Spec.Destination {
+			return resiliencePolicy
 		}
 	}
 
 	return nil
 }
 
+// ListEgressPolicies lists the valid Egress policies whose sources include the given service account
+func (c *Client) ListEgressPolicies(serviceAccount service.K8sServiceAccount) []*osmPolicy.Egress {
+	if !featureflags.IsEgressPolicyEnabled() {
+		return nil
+	}
+
+	var egressPolicies []*osmPolicy.Egress
+	for _, iface := range c.caches.Egress.List() {
+		egress := iface.(*osmPolicy.Egress)
+
+		if !c.namespaceController.IsMonitoredNamespace(egress.Namespace) {
+			continue
+		}
+
+		if !isEgressSource(egress, serviceAccount) {
+			continue
+		}
+
+		if err := validateEgress(egress); err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid Egress policy %s/%s", egress.Namespace, egress.Name)
+			continue
+		}
+
+		egressPolicies = append(egressPolicies, egress)
+	}
+
+	return egressPolicies
+}
+
+// isEgressSource returns true when the sources of the given Egress policy include the given service account
+func isEgressSource(egress *osmPolicy.Egress, serviceAccount service.K8sServiceAccount) bool {
+	for _, source := range egress.Spec.Sources {
+		namespace := source.Namespace
+		if namespace == "" {
+			namespace = egress.Namespace
+		}
+		if namespace == serviceAccount.Namespace && source.Name == serviceAccount.Name {
+			return true
+		}
+	}
+	return false
+}
+
 // ListTrafficSplitServices implements mesh.MeshSpec by returning the services observed from the given compute provider
 func (c *Client) ListTrafficSplitServices() []service.WeightedService {
 	var services []service.WeightedService
@@ -243,11 +393,8 @@ func (c *Client) ListTrafficSplitServices() []service.WeightedService {
 		for _, backend := range trafficSplit.Spec.Backends {
 			// The TrafficSplit SMI Spec does not allow providing a namespace for the backends,
 			// so we assume that the top level namespace for the TrafficSplit is the namespace
-			// the backends belong to.
-			meshService := service.MeshService{
-				Namespace: trafficSplit.Namespace,
-				Name:      backend.Service,
-			}
+			// the backends belong to. A backend may be a service exported by a remote cluster.
+			meshService := service.NewMeshService(trafficSplit.Namespace, backend.Service)
 			services = append(services, service.WeightedService{Service: meshService, Weight: backend.Weight, RootService: rootService})
 		}
 	}
@@ -468,6 +468,48 @@ var _ = Describe("When listing ListHTTPTrafficSpecs", func() {
 	})
 })
 
+var _ = Describe("When listing ListTCPTrafficSpecs", func() {
+	var (
+		meshSpec      MeshSpec
+		fakeClientSet *fakeKubeClientSet
+		err           error
+	)
+	BeforeEach(func() {
+		meshSpec, fakeClientSet, err = bootstrapClient()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Returns an empty list when no TCPRoute are found", func() {
+		tcpRoutes := meshSpec.ListTCPTrafficSpecs()
+		Expect(len(tcpRoutes)).To(Equal(0))
+	})
+
+	It("should return a list of TCPRoute resources", func() {
+		tcpRoute := &smiSpecs.TCPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "specs.smi-spec.io/v1alpha3",
+				Kind:       "TCPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespaceName,
+				Name:      "test-ListTCPTrafficSpecs",
+			},
+		}
+
+		_, err := fakeClientSet.smiTrafficSpecClientSet.SpecsV1alpha3().TCPRoutes(testNamespaceName).Create(context.TODO(), tcpRoute, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+
+		tcpRoutes := meshSpec.ListTCPTrafficSpecs()
+		Expect(len(tcpRoutes)).To(Equal(1))
+		Expect(tcpRoutes[0].Name).To(Equal(tcpRoute.Name))
+
+		err = fakeClientSet.smiTrafficSpecClientSet.SpecsV1alpha3().TCPRoutes(testNamespaceName).Delete(context.TODO(), tcpRoute.Name, metav1.DeleteOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+	})
+})
+
 var _ = Describe("When fetching BackpressurePolicy for the given MeshService", func() {
 	var (
 		meshSpec      MeshSpec
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -14,8 +15,10 @@ import (
 type fakeMeshSpec struct {
 	trafficSplits    []*split.TrafficSplit
 	routeGroups      []*spec.HTTPRouteGroup
+	tcpRoutes        []*spec.TCPRoute
 	trafficTargets   []*target.TrafficTarget
 	backpressures    []*backpressure.Backpressure
+	egresses         []*backpressure.Egress
 	weightedServices []service.WeightedService
 	serviceAccounts  []service.K8sServiceAccount
 	services         []*corev1.Service
@@ -26,7 +29,8 @@ func NewFakeMeshSpecClient() MeshSpec {
 	return fakeMeshSpec{
 		trafficSplits:    []*split.TrafficSplit{&tests.TrafficSplit},
 		routeGroups:      []*spec.HTTPRouteGroup{&tests.HTTPRouteGroup},
-		trafficTargets:   []*target.TrafficTarget{&tests.TrafficTarget},
+		tcpRoutes:        []*spec.TCPRoute{&tests.TCPRoute},
+		trafficTargets:   []*target.TrafficTarget{&tests.TrafficTarget, &tests.TCPTrafficTarget},
 		weightedServices: []service.WeightedService{tests.WeightedService},
 		serviceAccounts: []service.K8sServiceAccount{
 			tests.BookstoreServiceAccount,
@@ -39,6 +43,7 @@ func NewFakeMeshSpecClient() MeshSpec {
 		},
 
 		backpressures: []*backpressure.Backpressure{&tests.Backpressure},
+		egresses:      []*backpressure.Egress{&tests.Egress},
 	}
 }
 
@@ -72,6 +77,11 @@ func (f fakeMeshSpec) ListHTTPTrafficSpecs() []*spec.HTTPRouteGroup {
 	return f.routeGroups
 }
 
+// ListTCPTrafficSpecs lists SMI TCPRoute resources
+func (f fakeMeshSpec) ListTCPTrafficSpecs() []*spec.TCPRoute {
+	return f.tcpRoutes
+}
+
 // ListTrafficTargets lists TrafficTarget SMI resources for the fake Mesh Spec.
 func (f fakeMeshSpec) ListTrafficTargets() []*target.TrafficTarget {
 	return f.trafficTargets
@@ -81,9 +91,25 @@ func (f fakeMeshSpec) GetBackpressurePolicy(svc service.MeshService) *backpressu
 	return nil
 }
 
+// GetResiliencePolicy fetches the ResiliencePolicy for the MeshService for the fake Mesh Spec.
+func (f fakeMeshSpec) GetResiliencePolicy(svc service.MeshService) *backpressure.ResiliencePolicy {
+	return nil
+}
+
+// ListEgressPolicies lists the Egress policies applying to the given service account for the fake Mesh Spec.
+func (f fakeMeshSpec) ListEgressPolicies(serviceAccount service.K8sServiceAccount) []*backpressure.Egress {
+	var egressPolicies []*backpressure.Egress
+	for _, egress := range f.egresses {
+		if isEgressSource(egress, serviceAccount) {
+			egressPolicies = append(egressPolicies, egress)
+		}
+	}
+	return egressPolicies
+}
+
 // GetAnnouncementsChannel returns the channel on which SMI makes announcements for the fake Mesh Spec.
-func (f fakeMeshSpec) GetAnnouncementsChannel() <-chan interface{} {
-	return make(chan interface{})
+func (f fakeMeshSpec) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return make(chan announcements.Announcement)
 }
 
 // ListServices returns a list of services that are part of monitored namespaces
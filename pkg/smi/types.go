@@ -9,6 +9,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -20,20 +21,26 @@ var (
 
 // InformerCollection is a struct of the Kubernetes informers used in OSM
 type InformerCollection struct {
-	Services       cache.SharedIndexInformer
-	TrafficSplit   cache.SharedIndexInformer
-	HTTPRouteGroup cache.SharedIndexInformer
-	TrafficTarget  cache.SharedIndexInformer
-	Backpressure   cache.SharedIndexInformer
+	Services         cache.SharedIndexInformer
+	TrafficSplit     cache.SharedIndexInformer
+	HTTPRouteGroup   cache.SharedIndexInformer
+	TCPRoute         cache.SharedIndexInformer
+	TrafficTarget    cache.SharedIndexInformer
+	Backpressure     cache.SharedIndexInformer
+	ResiliencePolicy cache.SharedIndexInformer
+	Egress           cache.SharedIndexInformer
 }
 
 // CacheCollection is a struct of the Kubernetes caches used in OSM
 type CacheCollection struct {
-	Services       cache.Store
-	TrafficSplit   cache.Store
-	HTTPRouteGroup cache.Store
-	TrafficTarget  cache.Store
-	Backpressure   cache.Store
+	Services         cache.Store
+	TrafficSplit     cache.Store
+	HTTPRouteGroup   cache.Store
+	TCPRoute         cache.Store
+	TrafficTarget    cache.Store
+	Backpressure     cache.Store
+	ResiliencePolicy cache.Store
+	Egress           cache.Store
 }
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
@@ -42,7 +49,7 @@ type Client struct {
 	cacheSynced         chan interface{}
 	providerIdent       string
 	informers           *InformerCollection
-	announcements       chan interface{}
+	announcements       chan announcements.Announcement
 	osmNamespace        string
 	namespaceController namespace.Controller
 }
@@ -67,12 +74,21 @@ type MeshSpec interface {
 	// ListHTTPTrafficSpecs lists SMI HTTPRouteGroup resources
 	ListHTTPTrafficSpecs() []*spec.HTTPRouteGroup
 
+	// ListTCPTrafficSpecs lists SMI TCPRoute resources
+	ListTCPTrafficSpecs() []*spec.TCPRoute
+
 	// ListTrafficTargets lists SMI TrafficTarget resources
 	ListTrafficTargets() []*target.TrafficTarget
 
 	// GetBackpressurePolicy fetches the Backpressure policy for the MeshService
 	GetBackpressurePolicy(service.MeshService) *backpressure.Backpressure
 
+	// GetResiliencePolicy fetches the ResiliencePolicy for the MeshService
+	GetResiliencePolicy(service.MeshService) *backpressure.ResiliencePolicy
+
+	// ListEgressPolicies lists the Egress policies applying to the given service account
+	ListEgressPolicies(service.K8sServiceAccount) []*backpressure.Egress
+
 	// GetAnnouncementsChannel returns the channel on which SMI client makes announcements
-	GetAnnouncementsChannel() <-chan interface{}
+	GetAnnouncementsChannel() <-chan announcements.Announcement
 }
@@ -0,0 +1,95 @@
+package smi
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+)
+
+// validateBackpressure returns an error when the given Backpressure policy can not be enforced by the proxies
+func validateBackpressure(backpressure *osmPolicy.Backpressure) error {
+	rateLimit := backpressure.Spec.RateLimit
+	if rateLimit == nil {
+		return nil
+	}
+
+	if rateLimit.RequestsPerSecond == 0 && rateLimit.Burst > 0 {
+		return errors.Errorf("rate limit sets a burst of %d requests without requests per second", rateLimit.Burst)
+	}
+
+	// The local rate limit filter of the proxies of the service can not tell the sources apart,
+	// while the proxies of a source service would each accept the limit, multiplying it by the number of replicas.
+	if len(rateLimit.Sources) > 0 {
+		return errors.New("source rate limits are not supported")
+	}
+
+	routes := make(map[string]interface{})
+	for _, route := range rateLimit.Routes {
+		if route.HTTPRouteGroup == "" || route.Match == "" {
+			return errors.New("route rate limit does not name its HTTPRouteGroup and match")
+		}
+		if route.RequestsPerSecond == 0 {
+			return errors.Errorf("rate limit of route %s/%s does not set requests per second", route.HTTPRouteGroup, route.Match)
+		}
+		key := route.HTTPRouteGroup + "/" + route.Match
+		if _, found := routes[key]; found {
+			return errors.Errorf("route %s/%s has more than one rate limit", route.HTTPRouteGroup, route.Match)
+		}
+		routes[key] = nil
+	}
+
+	return nil
+}
+
+// validateEgress returns an error when the given Egress policy can not be enforced by the proxies
+func validateEgress(egress *osmPolicy.Egress) error {
+	if len(egress.Spec.Sources) == 0 {
+		return errors.New("egress policy does not list any source service account")
+	}
+	for _, source := range egress.Spec.Sources {
+		if source.Name == "" {
+			return errors.New("egress source does not name its service account")
+		}
+	}
+
+	if len(egress.Spec.Hosts) == 0 && len(egress.Spec.IPAddresses) == 0 {
+		return errors.New("egress policy does not list any host or IP address")
+	}
+	for _, host := range egress.Spec.Hosts {
+		if host == "" || strings.Contains(strings.TrimPrefix(host, "*."), "*") || strings.Contains(host, ":") {
+			return errors.Errorf("invalid egress host %q, hosts must be hostnames without port, optionally starting with a *. wildcard", host)
+		}
+	}
+	for _, ipAddress := range egress.Spec.IPAddresses {
+		if net.ParseIP(ipAddress) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(ipAddress); err != nil {
+			return errors.Errorf("invalid egress IP address %q, IP addresses must be IPs or CIDR ranges", ipAddress)
+		}
+	}
+
+	if len(egress.Spec.Ports) == 0 {
+		return errors.New("egress policy does not list any port")
+	}
+	for _, port := range egress.Spec.Ports {
+		if port.Number == 0 || port.Number > 65535 {
+			return errors.Errorf("invalid egress port number %d", port.Number)
+		}
+		switch port.Protocol {
+		case osmPolicy.EgressProtocolHTTP, osmPolicy.EgressProtocolHTTPS:
+		case osmPolicy.EgressProtocolTCP:
+			// The hostnames of the destinations can not be matched on TCP traffic
+			if len(egress.Spec.IPAddresses) == 0 {
+				return errors.Errorf("egress port %d carrying TCP traffic requires IP addresses", port.Number)
+			}
+		default:
+			return errors.Errorf("invalid protocol %q of egress port %d, protocols must be http, https or tcp", port.Protocol, port.Number)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package smi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+)
+
+var _ = Describe("When validating a Backpressure policy", func() {
+	newBackpressure := func(rateLimit *osmPolicy.RateLimit) *osmPolicy.Backpressure {
+		return &osmPolicy.Backpressure{
+			Spec: osmPolicy.BackpressureSpec{
+				MaxConnections: 10,
+				RateLimit:      rateLimit,
+			},
+		}
+	}
+
+	It("accepts a policy without rate limit", func() {
+		Expect(validateBackpressure(newBackpressure(nil))).To(Succeed())
+	})
+
+	It("accepts a policy with valid rate limits", func() {
+		Expect(validateBackpressure(newBackpressure(&osmPolicy.RateLimit{
+			RequestsPerSecond: 100,
+			Burst:             10,
+			Routes:            []osmPolicy.RouteRateLimit{{HTTPRouteGroup: "bookstore-service-routes", Match: "buy-a-book", RequestsPerSecond: 5}},
+		}))).To(Succeed())
+	})
+
+	It("rejects a burst without requests per second", func() {
+		Expect(validateBackpressure(newBackpressure(&osmPolicy.RateLimit{Burst: 10}))).ToNot(Succeed())
+	})
+
+	It("rejects source rate limits", func() {
+		Expect(validateBackpressure(newBackpressure(&osmPolicy.RateLimit{
+			Sources: []osmPolicy.SourceRateLimit{{Name: "bookbuyer", RequestsPerSecond: 10}},
+		}))).ToNot(Succeed())
+	})
+
+	It("rejects duplicate route rate limits", func() {
+		routeRateLimit := osmPolicy.RouteRateLimit{HTTPRouteGroup: "bookstore-service-routes", Match: "buy-a-book", RequestsPerSecond: 5}
+		Expect(validateBackpressure(newBackpressure(&osmPolicy.RateLimit{
+			Routes: []osmPolicy.RouteRateLimit{routeRateLimit, routeRateLimit},
+		}))).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("When validating an Egress policy", func() {
+	newEgress := func(hosts []string, ipAddresses []string, ports ...osmPolicy.EgressPort) *osmPolicy.Egress {
+		return &osmPolicy.Egress{
+			Spec: osmPolicy.EgressSpec{
+				Sources:     []osmPolicy.EgressSource{{Name: "bookbuyer"}},
+				Hosts:       hosts,
+				IPAddresses: ipAddresses,
+				Ports:       ports,
+			},
+		}
+	}
+	httpsPort := osmPolicy.EgressPort{Number: 443, Protocol: osmPolicy.EgressProtocolHTTPS}
+	tcpPort := osmPolicy.EgressPort{Number: 5432, Protocol: osmPolicy.EgressProtocolTCP}
+
+	It("accepts a policy allowing hosts and IP addresses", func() {
+		Expect(validateEgress(newEgress([]string{"httpbin.org", "*.github.com"}, []string{"10.0.0.1", "10.10.0.0/16"}, httpsPort, tcpPort))).To(Succeed())
+	})
+
+	It("rejects a policy without source, destination or port", func() {
+		egress := newEgress([]string{"httpbin.org"}, nil, httpsPort)
+		egress.Spec.Sources = nil
+		Expect(validateEgress(egress)).ToNot(Succeed())
+		Expect(validateEgress(newEgress(nil, nil, httpsPort))).ToNot(Succeed())
+		Expect(validateEgress(newEgress([]string{"httpbin.org"}, nil))).ToNot(Succeed())
+	})
+
+	It("rejects invalid hosts and IP addresses", func() {
+		Expect(validateEgress(newEgress([]string{"*"}, nil, httpsPort))).ToNot(Succeed())
+		Expect(validateEgress(newEgress([]string{"httpbin.org:443"}, nil, httpsPort))).ToNot(Succeed())
+		Expect(validateEgress(newEgress(nil, []string{"10.0.0.0/33"}, httpsPort))).ToNot(Succeed())
+	})
+
+	It("rejects invalid ports and TCP ports without IP addresses", func() {
+		Expect(validateEgress(newEgress([]string{"httpbin.org"}, nil, osmPolicy.EgressPort{Number: 443, Protocol: "udp"}))).ToNot(Succeed())
+		Expect(validateEgress(newEgress([]string{"httpbin.org"}, nil, osmPolicy.EgressPort{Number: 70000, Protocol: osmPolicy.EgressProtocolHTTPS}))).ToNot(Succeed())
+		Expect(validateEgress(newEgress([]string{"httpbin.org"}, nil, tcpPort))).ToNot(Succeed())
+	})
+})
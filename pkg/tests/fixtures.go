@@ -45,6 +45,9 @@ const (
 	// TrafficTargetName is the name of the traffic target SMI object.
 	TrafficTargetName = "bookbuyer-access-bookstore"
 
+	// TCPTrafficTargetName is the name of the traffic target SMI object referencing a TCP route.
+	TCPTrafficTargetName = "bookbuyer-access-bookstore-tcp"
+
 	// BuyBooksMatchName is the name of the match object.
 	BuyBooksMatchName = "buy-books"
 
@@ -60,6 +63,9 @@ const (
 	// RouteGroupName is the name of the route group SMI object.
 	RouteGroupName = "bookstore-service-routes"
 
+	// TCPRouteName is the name of the TCP route SMI object.
+	TCPRouteName = "bookstore-tcp-route"
+
 	// BookstoreBuyPath is the path to the bookstore.
 	BookstoreBuyPath = "/buy"
 
@@ -184,6 +190,34 @@ var (
 		},
 	}
 
+	// TCPTrafficTarget is a traffic target SMI object referencing a TCP route.
+	TCPTrafficTarget = target.TrafficTarget{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: "access.smi-spec.io/v1alpha2",
+			Kind:       "TrafficTarget",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      TCPTrafficTargetName,
+			Namespace: "default",
+		},
+		Spec: target.TrafficTargetSpec{
+			Destination: target.IdentityBindingSubject{
+				Kind:      "Name",
+				Name:      BookstoreServiceAccountName,
+				Namespace: "default",
+			},
+			Sources: []target.IdentityBindingSubject{{
+				Kind:      "Name",
+				Name:      BookbuyerServiceAccountName,
+				Namespace: "default",
+			}},
+			Rules: []target.TrafficTargetRule{{
+				Kind: "TCPRoute",
+				Name: TCPRouteName,
+			}},
+		},
+	}
+
 	// RoutePolicyMap is a map of a key to a route policy SMI object.
 	RoutePolicyMap = map[trafficpolicy.TrafficSpecName]map[trafficpolicy.TrafficSpecMatchName]trafficpolicy.HTTPRoute{
 		trafficpolicy.TrafficSpecName(fmt.Sprintf("HTTPRouteGroup/%s/%s", Namespace, RouteGroupName)): {
@@ -247,6 +281,18 @@ var (
 		},
 	}
 
+	// TCPRoute is the TCP route SMI object.
+	TCPRoute = spec.TCPRoute{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: "specs.smi-spec.io/v1alpha3",
+			Kind:       "TCPRoute",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+			Name:      TCPRouteName,
+		},
+	}
+
 	// Backpressure is an experimental Backpressure policy.
 	// This will be replaced by an SMI Spec when it is ready.
 	Backpressure = backpressure.Backpressure{
@@ -254,6 +300,23 @@ var (
 			MaxConnections: 123,
 		},
 	}
+
+	// Egress is an experimental Egress policy allowing the bookbuyer service account to send traffic to external destinations.
+	Egress = backpressure.Egress{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: Namespace,
+			Name:      "bookbuyer-egress",
+		},
+		Spec: backpressure.EgressSpec{
+			Sources:     []backpressure.EgressSource{{Name: BookbuyerServiceAccountName}},
+			Hosts:       []string{"httpbin.org", "*.github.com"},
+			IPAddresses: []string{"10.10.0.0/16"},
+			Ports: []backpressure.EgressPort{
+				{Number: 80, Protocol: backpressure.EgressProtocolHTTP},
+				{Number: 443, Protocol: backpressure.EgressProtocolHTTPS},
+			},
+		},
+	}
 )
 
 // NewPodTestFixture creates a new Pod struct for testing.
@@ -106,3 +106,61 @@ func (s *XDSServer) SendMsg(m interface{}) error {
 func (s *XDSServer) RecvMsg(m interface{}) error {
 	return nil
 }
+
+// DeltaXDSServer implements AggregatedDiscoveryService_DeltaAggregatedResourcesServer
+type DeltaXDSServer struct {
+	ctx       context.Context
+	responses []*xds_discovery.DeltaDiscoveryResponse
+}
+
+// NewFakeDeltaXDSServer returns a new DeltaXDSServer and implements AggregatedDiscoveryService_DeltaAggregatedResourcesServer
+func NewFakeDeltaXDSServer(cert *x509.Certificate) (xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, *[]*xds_discovery.DeltaDiscoveryResponse) {
+	peerKey := peer.Peer{
+		Addr:     NewMockAddress("9.8.7.6"),
+		AuthInfo: NewMockAuthInfo(cert),
+	}
+	server := DeltaXDSServer{
+		ctx: peer.NewContext(context.TODO(), &peerKey),
+	}
+	return &server, &server.responses
+}
+
+// Send implements AggregatedDiscoveryService_DeltaAggregatedResourcesServer
+func (s *DeltaXDSServer) Send(r *xds_discovery.DeltaDiscoveryResponse) error {
+	s.responses = append(s.responses, r)
+	return nil
+}
+
+// Recv implements AggregatedDiscoveryService_DeltaAggregatedResourcesServer
+func (s *DeltaXDSServer) Recv() (*xds_discovery.DeltaDiscoveryRequest, error) {
+	return &xds_discovery.DeltaDiscoveryRequest{}, nil
+}
+
+// SetHeader sets the header metadata.
+func (s *DeltaXDSServer) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// SendHeader sends the header metadata.
+func (s *DeltaXDSServer) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer sets the trailer metadata which will be sent with the RPC status.
+func (s *DeltaXDSServer) SetTrailer(metadata.MD) {
+}
+
+// Context returns the context for this stream.
+func (s *DeltaXDSServer) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends a message.
+func (s *DeltaXDSServer) SendMsg(m interface{}) error {
+	return nil
+}
+
+// RecvMsg blocks until it receives a message into m or the stream is done.
+func (s *DeltaXDSServer) RecvMsg(m interface{}) error {
+	return nil
+}
@@ -1,6 +1,8 @@
 package trafficpolicy
 
 import (
+	"time"
+
 	set "github.com/deckarep/golang-set"
 
 	"github.com/openservicemesh/osm/pkg/service"
@@ -12,11 +14,14 @@ type TrafficSpecName string
 // TrafficSpecMatchName is the  name of a match in SMI TrafficSpec
 type TrafficSpecMatchName string
 
-// HTTPRoute is a struct to represent an HTTP route comprised of a path regex, methods, and headers
+// HTTPRoute is a struct to represent an HTTP route comprised of a path regex, methods, headers and query parameters
 type HTTPRoute struct {
 	PathRegex string            `json:"path_regex:omitempty"`
 	Methods   []string          `json:"methods:omitempty"`
 	Headers   map[string]string `json:"headers:omitempty"`
+
+	// QueryParams maps the names of the query parameters matched by the route to the regexes their values match
+	QueryParams map[string]string `json:"query_params:omitempty"`
 }
 
 // TrafficTarget is a struct to represent a traffic policy between a source and destination along with its routes
@@ -27,8 +32,101 @@ type TrafficTarget struct {
 	HTTPRoute   HTTPRoute           `json:"http_route:omitempty"`
 }
 
+// TCPTrafficTarget is a struct to represent a TCP traffic policy between a source and destination on a port of the destination
+type TCPTrafficTarget struct {
+	Name        string              `json:"name:omitempty"`
+	Destination service.MeshService `json:"destination:omitempty"`
+	Source      service.MeshService `json:"source:omitempty"`
+
+	// Port is the port of the destination service
+	Port uint32 `json:"port:omitempty"`
+
+	// TargetPort is the port the pods backing the destination service listen on
+	TargetPort uint32 `json:"target_port:omitempty"`
+}
+
 // RouteWeightedClusters is a struct of an HTTPRoute and associated weighted clusters
 type RouteWeightedClusters struct {
 	HTTPRoute        HTTPRoute `json:"http_route:omitempty"`
 	WeightedClusters set.Set   `json:"weighted_clusters:omitempty"`
+
+	// AppProtocol is the application protocol of the traffic matched by the route
+	AppProtocol service.AppProtocol `json:"app_protocol:omitempty"`
+
+	// Timeout is the timeout of the requests matched by the route, including all their retries; zero when not set
+	Timeout time.Duration `json:"timeout:omitempty"`
+
+	// RetryPolicy is the retry policy of the requests matched by the route; nil when not set
+	RetryPolicy *RetryPolicy `json:"retry_policy:omitempty"`
+
+	// RateLimit is the local rate limit of the requests matched by the route; nil when not set
+	RateLimit *RateLimit `json:"rate_limit:omitempty"`
+
+	// RequestHeaders is the modification of the headers of the requests matched by the route; nil when not set
+	RequestHeaders *HeaderModifier `json:"request_headers:omitempty"`
+
+	// ResponseHeaders is the modification of the headers of the responses to the requests matched by the route; nil when not set
+	ResponseHeaders *HeaderModifier `json:"response_headers:omitempty"`
+}
+
+// HeaderModifier is a struct to represent the modification of the headers of requests or responses
+type HeaderModifier struct {
+	// Set maps the names of the headers set to their values, replacing the existing values
+	Set map[string]string `json:"set:omitempty"`
+
+	// Add maps the names of the headers added to their values, appended to the existing values
+	Add map[string]string `json:"add:omitempty"`
+
+	// Remove is the list of the names of the headers removed
+	Remove []string `json:"remove:omitempty"`
+}
+
+// GatewayHTTPRoute is a struct to represent an HTTP route of a Gateway API HTTPRoute to a backend service
+type GatewayHTTPRoute struct {
+	// Name uniquely identifies the route among the routes of the backend service
+	Name string `json:"name:omitempty"`
+
+	HTTPRoute HTTPRoute `json:"http_route:omitempty"`
+
+	// RequestHeaders is the modification of the headers of the requests matched by the route; nil when not set
+	RequestHeaders *HeaderModifier `json:"request_headers:omitempty"`
+
+	// ResponseHeaders is the modification of the headers of the responses to the requests matched by the route; nil when not set
+	ResponseHeaders *HeaderModifier `json:"response_headers:omitempty"`
+}
+
+// RetryPolicy is a struct to represent the retry policy of the requests matched by a route
+type RetryPolicy struct {
+	// NumRetries is the max number of times a failed request is retried
+	NumRetries uint32 `json:"num_retries:omitempty"`
+
+	// PerTryTimeout is the timeout of each attempt of a request; zero when not set
+	PerTryTimeout time.Duration `json:"per_try_timeout:omitempty"`
+
+	// RetryOn is the list of conditions under which a request is retried
+	RetryOn []string `json:"retry_on:omitempty"`
+}
+
+// RateLimit is a struct to represent the local rate limit of the requests matched by a route
+type RateLimit struct {
+	// RequestsPerSecond is the number of requests per second accepted by the proxy
+	RequestsPerSecond uint32 `json:"requests_per_second:omitempty"`
+
+	// Burst is the number of requests accepted above RequestsPerSecond during a burst
+	Burst uint32 `json:"burst:omitempty"`
+}
+
+// EgressTrafficPolicy is a struct to represent the external destinations allowed on a port of the egress traffic
+type EgressTrafficPolicy struct {
+	// Port is the destination port of the egress traffic
+	Port uint32 `json:"port:omitempty"`
+
+	// HTTPHosts is the list of the hostnames allowed for the HTTP traffic sent to the port, matched on its Host header
+	HTTPHosts []string `json:"http_hosts:omitempty"`
+
+	// TLSHosts is the list of the hostnames allowed for the TLS traffic sent to the port, matched on its SNI
+	TLSHosts []string `json:"tls_hosts:omitempty"`
+
+	// IPRanges is the list of the CIDR ranges allowed for the traffic sent to the port, whatever its protocol
+	IPRanges []string `json:"ip_ranges:omitempty"`
 }
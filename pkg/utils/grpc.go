@@ -8,6 +8,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
 const (
@@ -15,8 +17,9 @@ const (
 	streamKeepAliveDuration = 60 * time.Second
 )
 
-// NewGrpc creates a new gRPC server
-func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (*grpc.Server, net.Listener) {
+// NewGrpc creates a new gRPC server, which presents the certificate with the given CN issued by the given certificate manager,
+// and verifies the certificates of its clients with the root certificates trusted by the given certificate manager.
+func NewGrpc(serverType string, port int, certManager certificate.Manager, cn certificate.CommonName, validityPeriod time.Duration) (*grpc.Server, net.Listener) {
 	log.Info().Msgf("Setting up %s gRPC server...", serverType)
 	addr := fmt.Sprintf(":%d", port)
 	lis, err := net.Listen("tcp", addr)
@@ -33,7 +36,7 @@ func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (
 		}),
 	}
 
-	mutualTLS, err := setupMutualTLS(false, serverType, certPem, keyPem, rootCertPem)
+	mutualTLS, err := setupMutualTLS(false, serverType, certManager, cn, validityPeriod)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to setup mutual tls for GRPC server")
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -15,27 +16,54 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
-func setupMutualTLS(insecure bool, serverName string, certPem []byte, keyPem []byte, ca []byte) (grpc.ServerOption, error) {
-	certif, err := tls.X509KeyPair(certPem, keyPem)
+func setupMutualTLS(insecure bool, serverName string, certManager certificate.Manager, cn certificate.CommonName, validityPeriod time.Duration) (grpc.ServerOption, error) {
+	// Fail early when the server's certificate cannot be issued
+	if _, err := getTLSConfig(insecure, serverName, certManager, cn, validityPeriod); err != nil {
+		return nil, err
+	}
+
+	// The certificate and the root certificates are looked up for every connection, so the server follows the rotations of its
+	// certificate and of the root certificate without being restarted.
+	tlsConfig := tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return getTLSConfig(insecure, serverName, certManager, cn, validityPeriod)
+		},
+	}
+	return grpc.Creds(credentials.NewTLS(&tlsConfig)), nil
+}
+
+func getTLSConfig(insecure bool, serverName string, certManager certificate.Manager, cn certificate.CommonName, validityPeriod time.Duration) (*tls.Config, error) {
+	cert, err := certManager.IssueCertificate(cn, &validityPeriod, "")
+	if err != nil {
+		return nil, errors.Errorf("[grpc][mTLS][%s] Failed issuing certificate with CN=%s: %+v", serverName, cn, err)
+	}
+
+	certif, err := tls.X509KeyPair(cert.GetCertificateChain(), cert.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Errorf("[grpc][mTLS][%s] Failed loading Certificate (%+v) and Key (%+v) PEM files", serverName, cert.GetCertificateChain(), cert.GetPrivateKey())
+	}
+
+	trustBundle, err := certManager.GetTrustBundle()
 	if err != nil {
-		return nil, errors.Errorf("[grpc][mTLS][%s] Failed loading Certificate (%+v) and Key (%+v) PEM files", serverName, certPem, keyPem)
+		return nil, errors.Errorf("[grpc][mTLS][%s] Failed getting the root certificates: %+v", serverName, err)
 	}
 
 	certPool := x509.NewCertPool()
 
 	// Load the set of Root CAs
-	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+	if ok := certPool.AppendCertsFromPEM(trustBundle); !ok {
 		return nil, errors.Errorf("[grpc][mTLS][%s] Filed to append client certs", serverName)
 	}
 
-	tlsConfig := tls.Config{
+	return &tls.Config{
 		InsecureSkipVerify: insecure,
 		ServerName:         serverName,
 		ClientAuth:         tls.RequireAndVerifyClientCert,
 		Certificates:       []tls.Certificate{certif},
 		ClientCAs:          certPool,
-	}
-	return grpc.Creds(credentials.NewTLS(&tlsConfig)), nil
+		// gRPC runs over HTTP/2
+		NextProtos: []string{"h2"},
+	}, nil
 }
 
 // ValidateClient ensures that the connected client is authorized to connect to the gRPC server.